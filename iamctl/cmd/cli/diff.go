@@ -0,0 +1,111 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/cmd"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare resources with the target environment",
+	Long:  `You can compare the resources in a local directory with the resources available in the target environment, or compare the resources of two environments`,
+	Run: func(cmd *cobra.Command, args []string) {
+		inputDirPath, _ := cmd.Flags().GetString("inputDir")
+		format, _ := cmd.Flags().GetString("format")
+		configFile, _ := cmd.Flags().GetString("config")
+		fromConfigFile, _ := cmd.Flags().GetString("from")
+		toConfigFile, _ := cmd.Flags().GetString("to")
+		output, _ := cmd.Flags().GetString("output")
+		if err := utils.ValidateOutputFormat(output); err != nil {
+			utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, "ERROR:", err)
+		}
+		if err := utils.ValidateDiffSources(configFile, inputDirPath, fromConfigFile, toConfigFile); err != nil {
+			utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, "ERROR:", err)
+		}
+
+		var report utils.DiffReport
+		if fromConfigFile != "" {
+			report = diffEnvironments(fromConfigFile, toConfigFile, format)
+		} else {
+			report = diffLocalResources(configFile, inputDirPath, format)
+		}
+
+		if err := utils.PrintDiff(report, output); err != nil {
+			utils.ExitWithCode(utils.EXIT_CODE_TOTAL_FAILURE, "ERROR:", err)
+		}
+	},
+}
+
+func diffLocalResources(configFile, inputDirPath, format string) utils.DiffReport {
+
+	session := utils.LoadSession(configFile)
+	if inputDirPath == "" {
+		inputDirPath = session.BaseDir
+	}
+
+	// Export to memory with ALLOW_DELETE enabled so that local resources which are not deployed are collected.
+	session.ToolConfigs.AllowDelete = true
+	utils.StartInMemoryExport()
+	exportResources(session, utils.ResourceTypes, inputDirPath, format)
+
+	return utils.DiffReport{
+		Source:    "local",
+		Target:    session.Name,
+		Resources: session.DiffExportedFiles(inputDirPath),
+	}
+}
+
+func diffEnvironments(fromConfigFile, toConfigFile, format string) utils.DiffReport {
+
+	fromSession := utils.LoadSession(fromConfigFile)
+	toSession := utils.LoadSessionKeepingLogs(toConfigFile)
+
+	fromFiles := exportEnvironment(fromSession, format)
+	toFiles := exportEnvironment(toSession, format)
+
+	return utils.DiffReport{
+		Source:    fromSession.Name,
+		Target:    toSession.Name,
+		Resources: toSession.DiffExports(fromFiles, toFiles),
+	}
+}
+
+func exportEnvironment(session *utils.Session, format string) map[string]utils.ExportedFile {
+
+	utils.PrintLog(utils.LogLevelInfo, utils.UtilsResourceWrapper, "", "Exporting resources from environment: "+session.Name)
+	session.ToolConfigs.AllowDelete = false
+	utils.ResetResourceIdentifierMap()
+	utils.StartEnvironmentExport()
+	exportResources(session, utils.ResourceTypes, "", format)
+	return utils.ExportedFiles
+}
+
+func init() {
+
+	cmd.RootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringP("inputDir", "i", "", "Path to the local resource directory")
+	diffCmd.Flags().StringP("format", "f", "yaml", "Format of the resource files")
+	diffCmd.Flags().StringP("config", "c", "", "Path to the environment specific config folder")
+	diffCmd.Flags().String("from", "", "Path to the config folder of the source environment to compare")
+	diffCmd.Flags().String("to", "", "Path to the config folder of the target environment to compare")
+	diffCmd.Flags().String("output", "text", "Output format of the diff: text or json")
+}
@@ -0,0 +1,72 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cli
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/cmd"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <resource type> [resource name...]",
+	Short: "Export resources of a resource type",
+	Long:  `You can export all resources of a resource type, or only the resources with the given names, available in the target environment`,
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		outputDirPath, _ := cmd.Flags().GetString("outputDir")
+		format, _ := cmd.Flags().GetString("format")
+		configFile, _ := cmd.Flags().GetString("config")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		runOptions := getRunOptions(cmd)
+
+		resourceType, includedTypes, err := utils.ResolveResourceType(args[0])
+		if err != nil {
+			utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, "ERROR:", err)
+		}
+
+		session := utils.LoadSession(configFile)
+		if concurrency > 0 {
+			session.ToolConfigs.Concurrency = concurrency
+		}
+		if outputDirPath == "" {
+			outputDirPath = session.BaseDir
+		}
+		session.ToolConfigs = session.ToolConfigs.IncludeOnlyResources(includedTypes, args[1:])
+
+		utils.StartTime = time.Now()
+		session.WarnExcludedDependencies(includedTypes)
+		exportResources(session, []utils.ResourceType{resourceType}, outputDirPath, format)
+
+		utils.PrintSummary(utils.EXPORT)
+		finishRun(runOptions, utils.EXPORT)
+	},
+}
+
+func init() {
+
+	cmd.RootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringP("outputDir", "o", "", "Path to the output directory")
+	exportCmd.Flags().StringP("format", "f", "yaml", "Format of the exported files")
+	exportCmd.Flags().StringP("config", "c", "", "Path to the environment specific config folder")
+	exportCmd.Flags().Int("concurrency", 0, "Number of resources to export in parallel. Overrides the CONCURRENCY tool config")
+	addRunFlags(exportCmd)
+}
@@ -54,57 +54,78 @@ var exportAllCmd = &cobra.Command{
 		outputDirPath, _ := cmd.Flags().GetString("outputDir")
 		format, _ := cmd.Flags().GetString("format")
 		configFile, _ := cmd.Flags().GetString("config")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		runOptions := getRunOptions(cmd)
 
-		baseDir := utils.LoadConfigs(configFile)
-		if outputDirPath == "" {
-			outputDirPath = baseDir
+		session := utils.LoadSession(configFile)
+		if concurrency > 0 {
+			session.ToolConfigs.Concurrency = concurrency
 		}
-
-		exportFunctions := map[utils.ResourceType]func(string, string){
-			utils.CLAIMS:                claims.ExportAll,
-			utils.IDENTITY_PROVIDERS:    identityproviders.ExportAll,
-			utils.APPLICATIONS:          applications.ExportAll,
-			utils.USERSTORES:            userstores.ExportAll,
-			utils.OIDC_SCOPES:           oidcScopes.ExportAll,
-			utils.ROLES:                 roles.ExportAll,
-			utils.CHALLENGE_QUESTIONS:   challengeQuestions.ExportAll,
-			utils.EMAIL_TEMPLATES:       emailTemplates.ExportAll,
-			utils.SCRIPT_LIBRARIES:      scriptLibraries.ExportAll,
-			utils.GOVERNANCE_CONNECTORS: governanceConnectors.ExportAll,
-			utils.CERTIFICATES:          certificates.ExportAll,
-			utils.WORKFLOWS:             workflows.ExportAll,
-			utils.API_RESOURCES:         apiResources.ExportAll,
-			utils.VALIDATION_RULES:      validationRules.ExportAll,
-			utils.EMAIL_PROVIDERS:       notificationProviders.ExportAllEmailProviders,
-			utils.SMS_PROVIDERS:         notificationProviders.ExportAllSmsProviders,
-			utils.SMS_TEMPLATES:         notificationTemplates.ExportAllSmsTemplates,
-			utils.ACTIONS:               actions.ExportAll,
-			utils.ORGANIZATIONS:         organizations.ExportAll,
-			utils.BRANDING:              branding.ExportAll,
-			utils.FLOWS:                 flows.ExportAll,
+		applyToolConfigFlags(cmd, session)
+		if outputDirPath == "" {
+			outputDirPath = session.BaseDir
 		}
 
 		utils.StartTime = time.Now()
-		for _, resourceType := range utils.ResourceOrder {
-			if exportFunc, exists := exportFunctions[resourceType]; exists {
-				if resourceType != utils.BRANDING {
-					utils.MarkResTypeStart(resourceType)
-				}
-				exportFunc(outputDirPath, format)
-				if resourceType != utils.BRANDING {
-					utils.MarkResTypeEnd(resourceType)
-				}
-			}
-		}
+		session.WarnExcludedDependencies(utils.ResourceTypes)
+		exportResources(session, utils.ResourceTypes, outputDirPath, format)
 
 		utils.PrintSummary(utils.EXPORT)
+		finishRun(runOptions, utils.EXPORT)
 	},
 }
 
+// exportFunctions maps each resource type to the function exporting its resources.
+var exportFunctions = map[utils.ResourceType]func(*utils.Session, string, string){
+	utils.CLAIMS:                claims.ExportAll,
+	utils.IDENTITY_PROVIDERS:    identityproviders.ExportAll,
+	utils.APPLICATIONS:          applications.ExportAll,
+	utils.USERSTORES:            userstores.ExportAll,
+	utils.OIDC_SCOPES:           oidcScopes.ExportAll,
+	utils.ROLES:                 roles.ExportAll,
+	utils.CHALLENGE_QUESTIONS:   challengeQuestions.ExportAll,
+	utils.EMAIL_TEMPLATES:       emailTemplates.ExportAll,
+	utils.SCRIPT_LIBRARIES:      scriptLibraries.ExportAll,
+	utils.GOVERNANCE_CONNECTORS: governanceConnectors.ExportAll,
+	utils.CERTIFICATES:          certificates.ExportAll,
+	utils.WORKFLOWS:             workflows.ExportAll,
+	utils.API_RESOURCES:         apiResources.ExportAll,
+	utils.VALIDATION_RULES:      validationRules.ExportAll,
+	utils.EMAIL_PROVIDERS:       notificationProviders.ExportAllEmailProviders,
+	utils.SMS_PROVIDERS:         notificationProviders.ExportAllSmsProviders,
+	utils.SMS_TEMPLATES:         notificationTemplates.ExportAllSmsTemplates,
+	utils.ACTIONS:               actions.ExportAll,
+	utils.ORGANIZATIONS:         organizations.ExportAll,
+	utils.BRANDING:              branding.ExportAll,
+	utils.FLOWS:                 flows.ExportAll,
+}
+
+// exportResources exports the given resource types in the resource order to the given directory.
+func exportResources(session *utils.Session, resourceTypes []utils.ResourceType, outputDirPath string, format string) {
+
+	err := session.ProcessResourceTypes(resourceTypes, func(resourceType utils.ResourceType) {
+		if exportFunc, exists := exportFunctions[resourceType]; exists {
+			if resourceType != utils.BRANDING {
+				utils.MarkResTypeStart(resourceType)
+			}
+			exportFunc(session, outputDirPath, format)
+			if resourceType != utils.BRANDING {
+				utils.MarkResTypeEnd(resourceType)
+			}
+		}
+	})
+	if err != nil {
+		utils.ExitWithCode(utils.EXIT_CODE_TOTAL_FAILURE, "ERROR:", err)
+	}
+}
+
 func init() {
 
 	cmd.RootCmd.AddCommand(exportAllCmd)
 	exportAllCmd.Flags().StringP("outputDir", "o", "", "Path to the output directory")
 	exportAllCmd.Flags().StringP("format", "f", "yaml", "Format of the exported files")
 	exportAllCmd.Flags().StringP("config", "c", "", "Path to the environment specific config folder")
+	exportAllCmd.Flags().Int("concurrency", 0, "Number of resources of a resource type to export in parallel. Overrides the CONCURRENCY tool config")
+	addToolConfigFlags(exportAllCmd)
+	addRunFlags(exportAllCmd)
 }
@@ -0,0 +1,117 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/cmd"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <resource type> [resource file...]",
+	Short: "Import resources of a resource type",
+	Long: `You can import all resources of a resource type from the input directory, or only the given resource files, ` +
+		`to the target environment`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		inputDirPath, _ := cmd.Flags().GetString("inputDir")
+		configFile, _ := cmd.Flags().GetString("config")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		snapshotsDirPath, _ := cmd.Flags().GetString("snapshotDir")
+		noSnapshot, _ := cmd.Flags().GetBool("no-snapshot")
+		snapshotSecrets, _ := cmd.Flags().GetBool("snapshot-secrets")
+		runOptions := getRunOptions(cmd)
+
+		resourceType, includedTypes, err := utils.ResolveResourceType(args[0])
+		if err != nil {
+			utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, "ERROR:", err)
+		}
+		var resourceNames []string
+		if len(args) > 1 {
+			if inputDirPath != "" {
+				utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, "ERROR: The inputDir flag cannot be used with resource files.")
+			}
+			inputDirPath, resourceNames, err = utils.GetResourceFilesDir(includedTypes, args[1:])
+			if err != nil {
+				utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, "ERROR:", err)
+			}
+		}
+
+		session := utils.LoadSession(configFile)
+		if concurrency > 0 {
+			session.ToolConfigs.Concurrency = concurrency
+		}
+		if inputDirPath == "" {
+			inputDirPath = session.BaseDir
+		}
+		session.ToolConfigs = session.ToolConfigs.IncludeOnlyResources(includedTypes, resourceNames)
+		resourceTypes := []utils.ResourceType{resourceType}
+
+		if runOptions.strict {
+			checkResourceKeywords(session, resourceTypes, inputDirPath)
+		}
+		utils.StartTime = time.Now()
+		if !dryRun && !noSnapshot {
+			if snapshotsDirPath == "" {
+				defaultDirPath, err := utils.GetDefaultSnapshotsDir()
+				if err != nil {
+					utils.ExitWithCode(utils.EXIT_CODE_SNAPSHOT_FAILURE, "ERROR: Import aborted.", err)
+				}
+				snapshotsDirPath = defaultDirPath
+			}
+			snapshotDir, err := takeSnapshot(session, resourceTypes, inputDirPath, snapshotsDirPath, snapshotSecrets)
+			if err != nil {
+				utils.ExitWithCode(utils.EXIT_CODE_SNAPSHOT_FAILURE, "ERROR: Import aborted. Error while taking the snapshot of the resources to be modified:", err)
+			}
+			utils.PrintLog(utils.LogLevelInfo, utils.UtilsResourceWrapper, "", fmt.Sprintf("Snapshot of the resources to be modified saved to: %s", snapshotDir))
+		}
+
+		if dryRun {
+			findUnchangedResources(session, resourceTypes, inputDirPath)
+		}
+		utils.DRY_RUN = dryRun
+		session.WarnExcludedDependencies(includedTypes)
+		importResources(session, resourceTypes, inputDirPath)
+
+		if utils.DRY_RUN {
+			utils.PrintPlan()
+		}
+		utils.PrintSummary(utils.IMPORT)
+		finishRun(runOptions, utils.IMPORT)
+	},
+}
+
+func init() {
+
+	cmd.RootCmd.AddCommand(importCmd)
+	importCmd.Flags().StringP("inputDir", "i", "", "Path to the input directory. Not used when resource files are given")
+	importCmd.Flags().StringP("config", "c", "", "Path to the environment specific config folder")
+	importCmd.Flags().Bool("dry-run", false, "Show the planned create, update and delete actions without modifying the target environment")
+	importCmd.Flags().Int("concurrency", 0, "Number of resources to import in parallel. Overrides the CONCURRENCY tool config")
+	importCmd.Flags().String("snapshotDir", "", "Path to the directory to save the snapshot of the resources to be modified. Defaults to the user cache directory")
+	importCmd.Flags().Bool("no-snapshot", false, "Import without taking a snapshot of the resources to be modified")
+	importCmd.Flags().Bool("snapshot-secrets", false, "Include the secrets of the resources to be modified in the snapshot, so that a rollback can restore them")
+	addRunFlags(importCmd)
+	importCmd.MarkFlagRequired("config")
+}
@@ -19,6 +19,9 @@
 package cli
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -53,60 +56,182 @@ var importAllCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		inputDirPath, _ := cmd.Flags().GetString("inputDir")
 		configFile, _ := cmd.Flags().GetString("config")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		snapshotsDirPath, _ := cmd.Flags().GetString("snapshotDir")
+		noSnapshot, _ := cmd.Flags().GetBool("no-snapshot")
+		snapshotSecrets, _ := cmd.Flags().GetBool("snapshot-secrets")
+		runOptions := getRunOptions(cmd)
 
-		baseDir := utils.LoadConfigs(configFile)
+		session := utils.LoadSession(configFile)
+		if concurrency > 0 {
+			session.ToolConfigs.Concurrency = concurrency
+		}
+		applyToolConfigFlags(cmd, session)
 		if inputDirPath == "" {
-			inputDirPath = baseDir
+			inputDirPath = session.BaseDir
 		}
 
-		importFunctions := map[utils.ResourceType]func(string){
-			utils.CLAIMS:                claims.ImportAll,
-			utils.IDENTITY_PROVIDERS:    identityproviders.ImportAll,
-			utils.APPLICATIONS:          applications.ImportAll,
-			utils.USERSTORES:            userstores.ImportAll,
-			utils.OIDC_SCOPES:           oidcScopes.ImportAll,
-			utils.ROLES:                 roles.ImportAll,
-			utils.CHALLENGE_QUESTIONS:   challengeQuestions.ImportAll,
-			utils.EMAIL_TEMPLATES:       emailTemplates.ImportAll,
-			utils.SCRIPT_LIBRARIES:      scriptLibraries.ImportAll,
-			utils.GOVERNANCE_CONNECTORS: governanceConnectors.ImportAll,
-			utils.CERTIFICATES:          certificates.ImportAll,
-			utils.WORKFLOWS:             workflows.ImportAll,
-			utils.API_RESOURCES:         apiResources.ImportAll,
-			utils.VALIDATION_RULES:      validationRules.ImportAll,
-			utils.EMAIL_PROVIDERS:       notificationProviders.ImportAllEmailProviders,
-			utils.SMS_PROVIDERS:         notificationProviders.ImportAllSmsProviders,
-			utils.SMS_TEMPLATES:         notificationTemplates.ImportAllSmsTemplates,
-			utils.ACTIONS:               actions.ImportAll,
-			utils.ORGANIZATIONS:         organizations.ImportAll,
-			utils.BRANDING:              branding.ImportAll,
-			utils.FLOWS:                 flows.ImportAll,
+		if runOptions.strict {
+			checkResourceKeywords(session, utils.ResourceTypes, inputDirPath)
 		}
-
 		utils.StartTime = time.Now()
-		for _, resourceType := range utils.ResourceOrder {
-			if importFunc, exists := importFunctions[resourceType]; exists {
-				if resourceType != utils.BRANDING {
-					utils.MarkResTypeStart(resourceType)
-				}
-				importFunc(inputDirPath)
-				if resourceType != utils.BRANDING {
-					utils.MarkResTypeEnd(resourceType)
+		if !dryRun && !noSnapshot {
+			if snapshotsDirPath == "" {
+				defaultDirPath, err := utils.GetDefaultSnapshotsDir()
+				if err != nil {
+					utils.ExitWithCode(utils.EXIT_CODE_SNAPSHOT_FAILURE, "ERROR: Import aborted.", err)
 				}
+				snapshotsDirPath = defaultDirPath
+			}
+			snapshotDir, err := takeSnapshot(session, utils.ResourceTypes, inputDirPath, snapshotsDirPath, snapshotSecrets)
+			if err != nil {
+				utils.ExitWithCode(utils.EXIT_CODE_SNAPSHOT_FAILURE, "ERROR: Import aborted. Error while taking the snapshot of the resources to be modified:", err)
 			}
+			utils.PrintLog(utils.LogLevelInfo, utils.UtilsResourceWrapper, "", fmt.Sprintf("Snapshot of the resources to be modified saved to: %s", snapshotDir))
 		}
 
-		// Delete identity providers after deleting associated applications
-		identityproviders.RemoveDeletedDeployedIdps(inputDirPath)
+		if dryRun {
+			findUnchangedResources(session, utils.ResourceTypes, inputDirPath)
+		}
+		utils.DRY_RUN = dryRun
+		session.WarnExcludedDependencies(utils.ResourceTypes)
+		importResources(session, utils.ResourceTypes, inputDirPath)
 
+		if utils.DRY_RUN {
+			utils.PrintPlan()
+		}
 		utils.PrintSummary(utils.IMPORT)
+		finishRun(runOptions, utils.IMPORT)
 	},
 }
 
+// importFunctions maps each resource type to the function importing its resources.
+var importFunctions = map[utils.ResourceType]func(*utils.Session, string){
+	utils.CLAIMS:                claims.ImportAll,
+	utils.IDENTITY_PROVIDERS:    identityproviders.ImportAll,
+	utils.APPLICATIONS:          applications.ImportAll,
+	utils.USERSTORES:            userstores.ImportAll,
+	utils.OIDC_SCOPES:           oidcScopes.ImportAll,
+	utils.ROLES:                 roles.ImportAll,
+	utils.CHALLENGE_QUESTIONS:   challengeQuestions.ImportAll,
+	utils.EMAIL_TEMPLATES:       emailTemplates.ImportAll,
+	utils.SCRIPT_LIBRARIES:      scriptLibraries.ImportAll,
+	utils.GOVERNANCE_CONNECTORS: governanceConnectors.ImportAll,
+	utils.CERTIFICATES:          certificates.ImportAll,
+	utils.WORKFLOWS:             workflows.ImportAll,
+	utils.API_RESOURCES:         apiResources.ImportAll,
+	utils.VALIDATION_RULES:      validationRules.ImportAll,
+	utils.EMAIL_PROVIDERS:       notificationProviders.ImportAllEmailProviders,
+	utils.SMS_PROVIDERS:         notificationProviders.ImportAllSmsProviders,
+	utils.SMS_TEMPLATES:         notificationTemplates.ImportAllSmsTemplates,
+	utils.ACTIONS:               actions.ImportAll,
+	utils.ORGANIZATIONS:         organizations.ImportAll,
+	utils.BRANDING:              branding.ImportAll,
+	utils.FLOWS:                 flows.ImportAll,
+}
+
+// importResources imports the given resource types from the given directory in the resource order.
+func importResources(session *utils.Session, resourceTypes []utils.ResourceType, inputDirPath string) {
+
+	err := session.ProcessResourceTypes(resourceTypes, func(resourceType utils.ResourceType) {
+		if importFunc, exists := importFunctions[resourceType]; exists {
+			if resourceType != utils.BRANDING {
+				utils.MarkResTypeStart(resourceType)
+			}
+			importFunc(session, inputDirPath)
+			if resourceType != utils.BRANDING {
+				utils.MarkResTypeEnd(resourceType)
+			}
+		}
+	})
+	if err != nil {
+		utils.ExitWithCode(utils.EXIT_CODE_TOTAL_FAILURE, "ERROR:", err)
+	}
+
+	// Delete identity providers after deleting associated applications
+	for _, resourceType := range resourceTypes {
+		if resourceType == utils.IDENTITY_PROVIDERS {
+			identityproviders.RemoveDeletedDeployedIdps(session, inputDirPath)
+		}
+	}
+}
+
+// findUnchangedResources exports the deployed resources of the given resource types in memory and compares them with
+// the local resources in the given directory, so that the resources that are not changed are planned as no-op.
+func findUnchangedResources(session *utils.Session, resourceTypes []utils.ResourceType, inputDirPath string) {
+
+	utils.PrintLog(utils.LogLevelInfo, utils.UtilsResourceWrapper, "", "Comparing the local resources with the deployed resources...")
+	logLevel := utils.CURRENT_LOG_LEVEL
+	utils.CURRENT_LOG_LEVEL = utils.LogLevelError
+	allowDelete := session.ToolConfigs.AllowDelete
+	defer func() {
+		utils.CURRENT_LOG_LEVEL = logLevel
+		session.ToolConfigs.AllowDelete = allowDelete
+		utils.StopInMemoryExport()
+		utils.ResetSummary()
+		utils.ResetResourceIdentifierMap()
+	}()
+
+	// Local resources that are not deployed are planned as creates, so they are not collected.
+	session.ToolConfigs.AllowDelete = false
+	utils.StartDeployedExport()
+	exportResources(session, resourceTypes, inputDirPath, string(utils.FormatYAML))
+	session.FindUnchangedResources(resourceTypes, inputDirPath)
+}
+
+// takeSnapshot plans the import of the given resource types and exports the resources that it updates or deletes to a new directory
+// under the given directory, along with a manifest of the resources that it creates, updates or deletes.
+func takeSnapshot(session *utils.Session, resourceTypes []utils.ResourceType, inputDirPath, snapshotsDirPath string, includeSecrets bool) (string, error) {
+
+	utils.PrintLog(utils.LogLevelInfo, utils.UtilsResourceWrapper, "", "Taking a snapshot of the resources to be modified...")
+	logLevel := utils.CURRENT_LOG_LEVEL
+	utils.CURRENT_LOG_LEVEL = utils.LogLevelError
+	defer func() {
+		utils.CURRENT_LOG_LEVEL = logLevel
+		utils.DRY_RUN = false
+		utils.ImportPlan = nil
+		utils.ResetSummary()
+		utils.ResetResourceIdentifierMap()
+	}()
+
+	utils.DRY_RUN = true
+	importResources(session, resourceTypes, inputDirPath)
+	utils.DRY_RUN = false
+	manifest := session.NewSnapshotManifest(utils.ImportPlan, includeSecrets)
+	utils.ResetSummary()
+	utils.ResetResourceIdentifierMap()
+
+	snapshotDir := filepath.Join(snapshotsDirPath, manifest.CreatedAt.Format("20060102-150405"))
+	if err := os.MkdirAll(snapshotDir, 0700); err != nil {
+		return "", fmt.Errorf("error creating snapshot directory: %w", err)
+	}
+	if snapshotConfigs, exists := manifest.SnapshotToolConfigs(session.ToolConfigs); exists {
+		toolConfigs := session.ToolConfigs
+		session.ToolConfigs = snapshotConfigs
+		exportResources(session, resourceTypes, snapshotDir, string(utils.FormatYAML))
+		session.ToolConfigs = toolConfigs
+		if utils.HasFailures() {
+			return "", fmt.Errorf("failed to export some of the resources to be modified")
+		}
+	}
+	if err := utils.WriteSnapshotManifest(snapshotDir, manifest); err != nil {
+		return "", err
+	}
+	return snapshotDir, nil
+}
+
 func init() {
 
 	cmd.RootCmd.AddCommand(importAllCmd)
 	importAllCmd.Flags().StringP("inputDir", "i", "", "Path to the input directory")
 	importAllCmd.Flags().StringP("config", "c", "", "Path to the environment specific config folder")
+	importAllCmd.Flags().Bool("dry-run", false, "Show the planned create, update and delete actions without modifying the target environment")
+	importAllCmd.Flags().Int("concurrency", 0, "Number of resources of a resource type to import in parallel. Overrides the CONCURRENCY tool config")
+	importAllCmd.Flags().String("snapshotDir", "", "Path to the directory to save the snapshot of the resources to be modified. Defaults to the user cache directory")
+	importAllCmd.Flags().Bool("no-snapshot", false, "Import without taking a snapshot of the resources to be modified")
+	importAllCmd.Flags().Bool("snapshot-secrets", false, "Include the secrets of the resources to be modified in the snapshot, so that a rollback can restore them")
+	addToolConfigFlags(importAllCmd)
+	addRunFlags(importAllCmd)
 	importAllCmd.MarkFlagRequired("config")
 }
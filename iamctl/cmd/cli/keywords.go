@@ -0,0 +1,117 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cli
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/cmd"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+var keywordsCmd = &cobra.Command{
+	Use:   "keywords",
+	Short: "Manage keywords of environment specific values",
+	Long:  `You can manage the keywords used for the values that differ between environments`,
+}
+
+var keywordsSuggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Short: "Suggest keywords for the values that differ between two environments",
+	Long:  `You can compare the resources of two environments and get keywords and keyword mappings suggested for the values that differ`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fromConfigFile, _ := cmd.Flags().GetString("from")
+		toConfigFile, _ := cmd.Flags().GetString("to")
+		inputDirPath, _ := cmd.Flags().GetString("inputDir")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		write, _ := cmd.Flags().GetBool("write")
+		if err := utils.ValidateOutputFormat(output); err != nil {
+			utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, "ERROR:", err)
+		}
+
+		fromSession := utils.LoadSession(fromConfigFile)
+		toSession := utils.LoadSessionKeepingLogs(toConfigFile)
+		fromFiles := exportEnvironment(fromSession, format)
+		toFiles := exportEnvironment(toSession, format)
+
+		var reservedKeywords []string
+		for keyword := range fromSession.KeywordConfigs.KeywordMappings {
+			reservedKeywords = append(reservedKeywords, keyword)
+		}
+		for keyword := range toSession.KeywordConfigs.KeywordMappings {
+			reservedKeywords = append(reservedKeywords, keyword)
+		}
+		report := utils.KeywordSuggestionReport{
+			Source:      fromSession.Name,
+			Target:      toSession.Name,
+			Suggestions: toSession.SuggestKeywords(fromFiles, toFiles, reservedKeywords),
+		}
+		if err := utils.PrintKeywordSuggestions(report, output); err != nil {
+			utils.ExitWithCode(utils.EXIT_CODE_TOTAL_FAILURE, "ERROR:", err)
+		}
+
+		if write && len(report.Suggestions) > 0 {
+			if inputDirPath == "" {
+				inputDirPath = fromSession.BaseDir
+			}
+			writeSuggestedKeywords(report.Suggestions, inputDirPath, fromSession, toSession)
+		}
+	},
+}
+
+// Writes the placeholders of the suggested keywords to the local files, which are expected to hold the resources
+// of the source environment, and the values to the keyword configs of each environment.
+func writeSuggestedKeywords(suggestions []utils.KeywordSuggestion, inputDirPath string, fromSession, toSession *utils.Session) {
+
+	updatedFiles, err := utils.WriteKeywordPlaceholders(inputDirPath, suggestions)
+	for _, filePath := range updatedFiles {
+		log.Println("Keyword placeholders written to: " + filePath)
+	}
+	if err != nil {
+		utils.ExitWithCode(utils.GetWriteExitCode(len(updatedFiles) > 0), "ERROR:", err)
+	}
+
+	fromMappings, toMappings := utils.GetSuggestedKeywordMappings(suggestions)
+	if err := utils.AddKeywordMappings(fromSession.KeywordConfigPath, fromMappings); err != nil {
+		utils.ExitWithCode(utils.GetWriteExitCode(len(updatedFiles) > 0),
+			"ERROR: Error when writing the keyword mappings of "+fromSession.Name+": "+err.Error())
+	}
+	log.Println("Keyword mappings written to: " + fromSession.KeywordConfigPath)
+	if err := utils.AddKeywordMappings(toSession.KeywordConfigPath, toMappings); err != nil {
+		utils.ExitWithCode(utils.GetWriteExitCode(true),
+			"ERROR: Error when writing the keyword mappings of "+toSession.Name+": "+err.Error())
+	}
+	log.Println("Keyword mappings written to: " + toSession.KeywordConfigPath)
+}
+
+func init() {
+
+	cmd.RootCmd.AddCommand(keywordsCmd)
+	keywordsCmd.AddCommand(keywordsSuggestCmd)
+	keywordsSuggestCmd.Flags().String("from", "", "Path to the config folder of the source environment")
+	keywordsSuggestCmd.Flags().String("to", "", "Path to the config folder of the target environment")
+	keywordsSuggestCmd.Flags().StringP("inputDir", "i", "", "Path to the local resource directory of the source environment to write the keywords to")
+	keywordsSuggestCmd.Flags().StringP("format", "f", "yaml", "Format of the resource files")
+	keywordsSuggestCmd.Flags().String("output", "text", "Output format of the suggestions: text or json")
+	keywordsSuggestCmd.Flags().Bool("write", false, "Write the keyword placeholders to the local files and the keyword mappings to the keyword config of each environment")
+	keywordsSuggestCmd.MarkFlagRequired("from")
+	keywordsSuggestCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,85 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/cmd"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render resources for an environment",
+	Long:  `You can write the resources in a local directory with the keywords replaced as they would be imported to the target environment, without connecting to it`,
+	Run: func(cmd *cobra.Command, args []string) {
+		inputDirPath, _ := cmd.Flags().GetString("inputDir")
+		outputDirPath, _ := cmd.Flags().GetString("outputDir")
+		configFile, _ := cmd.Flags().GetString("config")
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		session := utils.LoadOfflineSession(configFile)
+		applyToolConfigFlags(cmd, session)
+		if inputDirPath == "" {
+			inputDirPath = session.BaseDir
+		}
+		if sameDir(inputDirPath, outputDirPath) {
+			utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, "ERROR: The output directory must be different from the input directory.")
+		}
+
+		if strict {
+			checkResourceKeywords(session, utils.ResourceTypes, inputDirPath)
+		}
+		session.RenderResources(utils.ResourceTypes, inputDirPath, outputDirPath)
+		utils.PrintLog(utils.LogLevelInfo, utils.UtilsResourceWrapper, "", fmt.Sprintf("Rendered resources written to: %s", outputDirPath))
+		utils.PrintSummary(utils.RENDER)
+		if exitCode := utils.GetExitCode(false); exitCode != utils.EXIT_CODE_SUCCESS {
+			os.Exit(exitCode)
+		}
+	},
+}
+
+func sameDir(first, second string) bool {
+
+	firstAbs, err := filepath.Abs(first)
+	if err != nil {
+		return false
+	}
+	secondAbs, err := filepath.Abs(second)
+	if err != nil {
+		return false
+	}
+	return firstAbs == secondAbs
+}
+
+func init() {
+
+	cmd.RootCmd.AddCommand(renderCmd)
+	renderCmd.Flags().StringP("inputDir", "i", "", "Path to the input directory")
+	renderCmd.Flags().StringP("outputDir", "o", "", "Path to the directory to write the rendered resources")
+	renderCmd.Flags().StringP("config", "c", "", "Path to the environment specific config folder")
+	renderCmd.Flags().Bool("strict", false, "Exit without rendering any resource if keywords cannot be resolved in the local resources")
+	addToolConfigFlags(renderCmd)
+	renderCmd.MarkFlagRequired("config")
+	renderCmd.MarkFlagRequired("outputDir")
+}
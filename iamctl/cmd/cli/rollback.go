@@ -0,0 +1,75 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cli
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/cmd"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Roll back an import",
+	Long:  `You can restore the resources modified by an import from the snapshot taken before the import, and delete the resources created by the import`,
+	Run: func(cmd *cobra.Command, args []string) {
+		snapshotDir, _ := cmd.Flags().GetString("snapshot")
+		configFile, _ := cmd.Flags().GetString("config")
+		runOptions := getRunOptions(cmd)
+
+		manifest, err := utils.ReadSnapshotManifest(snapshotDir)
+		if err != nil {
+			utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, "ERROR:", err)
+		}
+		session := utils.LoadSession(configFile)
+		if err := manifest.CheckEnvironment(session); err != nil {
+			utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, "ERROR:", err)
+		}
+
+		rollbackConfigs, exists := manifest.RollbackToolConfigs(session.ToolConfigs)
+		if !exists {
+			utils.PrintLog(utils.LogLevelInfo, utils.UtilsResourceWrapper, "", "No resources were modified by the import. Nothing to roll back.")
+			return
+		}
+		if err := manifest.PrepareRollbackDir(snapshotDir); err != nil {
+			utils.ExitWithCode(utils.EXIT_CODE_SNAPSHOT_FAILURE, "ERROR:", err)
+		}
+		session.ToolConfigs = rollbackConfigs
+		if !manifest.SecretsIncluded {
+			utils.PrintLog(utils.LogLevelWarn, utils.UtilsResourceWrapper, "", "The snapshot does not include secrets. The secrets of the restored resources are not restored.")
+		}
+
+		utils.StartTime = time.Now()
+		importResources(session, utils.ResourceTypes, snapshotDir)
+		utils.PrintSummary(utils.IMPORT)
+		finishRun(runOptions, utils.IMPORT)
+	},
+}
+
+func init() {
+
+	cmd.RootCmd.AddCommand(rollbackCmd)
+	rollbackCmd.Flags().String("snapshot", "", "Path to the snapshot directory created by the import")
+	rollbackCmd.Flags().StringP("config", "c", "", "Path to the environment specific config folder")
+	addRunFlags(rollbackCmd)
+	rollbackCmd.MarkFlagRequired("snapshot")
+	rollbackCmd.MarkFlagRequired("config")
+}
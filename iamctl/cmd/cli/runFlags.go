@@ -0,0 +1,96 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+const strictFlagUsage = "Exit with an error if resource types are skipped as they are not supported in the server version. " +
+	"Imports are not started if keywords cannot be resolved in the local resources"
+
+type runOptions struct {
+	reportFile   string
+	reportFormat utils.ReportFormat
+	strict       bool
+}
+
+// addRunFlags adds the flags to write a machine readable report of the run, to decide its exit code and to trace the requests.
+func addRunFlags(command *cobra.Command) {
+
+	command.Flags().String("report-file", "", "Path to the file to write the report of the run")
+	command.Flags().String("report-format", string(utils.ReportFormatJSON), "Format of the report file: json or junit")
+	command.Flags().Bool("strict", false, strictFlagUsage)
+	command.Flags().Bool("trace", false, "Log every request sent to the server and print the time taken by the requests of each resource type")
+}
+
+// getRunOptions reads the run flags and enables request tracing if requested. Exits if the report format is not supported.
+func getRunOptions(command *cobra.Command) runOptions {
+
+	reportFile, _ := command.Flags().GetString("report-file")
+	reportFormat, _ := command.Flags().GetString("report-format")
+	strict, _ := command.Flags().GetBool("strict")
+	utils.TRACE_REQUESTS, _ = command.Flags().GetBool("trace")
+	format, err := utils.ParseReportFormat(reportFormat)
+	if err != nil {
+		utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, "ERROR:", err)
+	}
+	return runOptions{reportFile: reportFile, reportFormat: format, strict: strict}
+}
+
+// finishRun writes the report of the run if a report file is given, and exits with a non-zero status
+// if any operation or resource type has failed, if unsupported resource types were skipped in strict mode,
+// or if the report could not be written.
+func finishRun(options runOptions, operation string) {
+
+	var reportErr error
+	if options.reportFile != "" {
+		if reportErr = utils.WriteReport(options.reportFile, options.reportFormat, operation); reportErr != nil {
+			utils.PrintLog(utils.LogLevelError, utils.UtilsResourceWrapper, "", reportErr.Error())
+		} else {
+			utils.PrintLog(utils.LogLevelInfo, utils.UtilsResourceWrapper, "", fmt.Sprintf("Report written to: %s", options.reportFile))
+		}
+	}
+	if exitCode := utils.GetRunExitCode(options.strict, reportErr); exitCode != utils.EXIT_CODE_SUCCESS {
+		os.Exit(exitCode)
+	}
+}
+
+// checkResourceKeywords exits before any resource is modified if keywords cannot be resolved in the local resource
+// files of the given resource types. Only used in strict mode, as each resource fails on its own otherwise.
+func checkResourceKeywords(session *utils.Session, resourceTypes []utils.ResourceType, inputDirPath string) {
+
+	logLevel := utils.CURRENT_LOG_LEVEL
+	utils.CURRENT_LOG_LEVEL = utils.LogLevelError
+	errs := session.CheckResourceKeywords(resourceTypes, inputDirPath)
+	utils.CURRENT_LOG_LEVEL = logLevel
+	utils.ResetSummary()
+
+	if len(errs) == 0 {
+		return
+	}
+	for _, err := range errs {
+		utils.PrintLog(utils.LogLevelError, utils.UtilsResourceWrapper, "", err.Error())
+	}
+	utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, fmt.Sprintf("ERROR: Keywords could not be resolved in %d resource files.", len(errs)))
+}
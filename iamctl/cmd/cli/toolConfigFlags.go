@@ -0,0 +1,53 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+// addToolConfigFlags adds the flags that override the resource selection and deletion tool configs.
+func addToolConfigFlags(command *cobra.Command) {
+
+	command.Flags().StringSlice("include", nil, "Resource types to include. Overrides the INCLUDE_ONLY tool config")
+	command.Flags().StringSlice("exclude", nil, "Resource types to exclude, in addition to the EXCLUDE tool config")
+	command.Flags().StringArray("include-resource", nil, "Resource to include, as <resource type>:<resource name>. Overrides the INCLUDE_ONLY config of the resource type")
+	command.Flags().StringArray("exclude-resource", nil, "Resource to exclude, as <resource type>:<resource name>. Added to the EXCLUDE config of the resource type")
+	command.Flags().Bool("allow-delete", false, "Delete resources that are not available in the source. Overrides the ALLOW_DELETE tool config")
+	command.Flags().Bool("no-delete", false, "Do not delete any resources. Overrides the ALLOW_DELETE tool config")
+}
+
+// applyToolConfigFlags merges the tool config flags on top of the tool configs of the session.
+func applyToolConfigFlags(command *cobra.Command, session *utils.Session) {
+
+	var overrides utils.ToolConfigOverrides
+	overrides.Include, _ = command.Flags().GetStringSlice("include")
+	overrides.Exclude, _ = command.Flags().GetStringSlice("exclude")
+	overrides.IncludeResources, _ = command.Flags().GetStringArray("include-resource")
+	overrides.ExcludeResources, _ = command.Flags().GetStringArray("exclude-resource")
+	overrides.AllowDelete, _ = command.Flags().GetBool("allow-delete")
+	overrides.NoDelete, _ = command.Flags().GetBool("no-delete")
+
+	toolConfigs, err := session.ToolConfigs.ApplyOverrides(overrides)
+	if err != nil {
+		utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, "ERROR:", err)
+	}
+	session.ToolConfigs = toolConfigs
+}
@@ -20,7 +20,6 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 	"os"
 
 	"github.com/mitchellh/go-homedir"
@@ -42,8 +41,7 @@ var RootCmd = &cobra.Command{
 func Execute() {
 
 	if err := RootCmd.Execute(); err != nil {
-		log.Fatalln(err)
-		os.Exit(1)
+		utils.ExitWithCode(utils.EXIT_CODE_CONFIG_ERROR, err)
 	}
 }
 
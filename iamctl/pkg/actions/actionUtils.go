@@ -46,9 +46,9 @@ type action struct {
 	} `json:"passwordSharing"`
 }
 
-func getActionTypesList() ([]actionType, error) {
+func getActionTypesList(session *utils.Session) ([]actionType, error) {
 
-	body, err := utils.SendGetRequest(utils.ACTIONS, "types")
+	body, err := session.SendGetRequest(utils.ACTIONS, "types")
 	if err != nil {
 		return nil, fmt.Errorf("error when getting the list: %w", err)
 	}
@@ -62,9 +62,9 @@ func getActionTypesList() ([]actionType, error) {
 	return types, nil
 }
 
-func getActionsList(actionType string) ([]action, error) {
+func getActionsList(session *utils.Session, actionType string) ([]action, error) {
 
-	body, err := utils.SendGetRequest(utils.ACTIONS, actionType)
+	body, err := session.SendGetRequest(utils.ACTIONS, actionType)
 	if err != nil {
 		return nil, err
 	}
@@ -84,12 +84,12 @@ func getDeployedActionNames(summaries []action) []string {
 	return names
 }
 
-func getActionsKeywordMapping(typeName string) map[string]interface{} {
+func getActionsKeywordMapping(session *utils.Session, typeName string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.ActionConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(typeName, utils.KEYWORD_CONFIGS.ActionConfigs)
+	if session.KeywordConfigs.ActionConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(typeName, session.KeywordConfigs.ActionConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func getActionId(name string, existingActionList []action) string {
@@ -133,27 +133,22 @@ func processAuthProperties(actionMap map[string]interface{}) error {
 		}
 	}
 
+	secretProperties, ok := utils.AUTH_SECRET_PROPERTIES[authType]
+	if !ok {
+		return fmt.Errorf("unknown authentication type %s", authType)
+	}
 	switch authType {
-	case "NONE":
 	case "BASIC":
 		if _, exists := props["username"]; !exists {
 			props["username"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
 		}
-		props["password"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
-	case "BEARER":
-		props["accessToken"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
 	case "API_KEY":
 		if _, exists := props["header"]; !exists {
 			props["header"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
 		}
-		props["value"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
-	case "CLIENT_CREDENTIAL":
-		props["clientSecret"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
-	case "PASSWORD_CREDENTIAL":
-		props["clientSecret"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
-		props["password"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
-	default:
-		return fmt.Errorf("unknown authentication type %s", authType)
+	}
+	for _, property := range secretProperties {
+		props[property] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
 	}
 
 	auth["properties"] = props
@@ -214,7 +209,7 @@ func replaceRuleReferences(actionMap map[string]interface{}) error {
 	return nil
 }
 
-func addMissingFields(localMap map[string]interface{}, typeName, actionId string) error {
+func addMissingFields(session *utils.Session, localMap map[string]interface{}, typeName, actionId string) error {
 
 	if _, inLocal := localMap["rule"]; !inLocal {
 		localMap["rule"] = map[string]interface{}{}
@@ -226,7 +221,7 @@ func addMissingFields(localMap map[string]interface{}, typeName, actionId string
 			localMap["attributes"] = []interface{}{}
 		}
 		if typeName == actionTypePreUpdatePassword {
-			if err := addCertificateIfDeployed(localMap, typeName, actionId); err != nil {
+			if err := addCertificateIfDeployed(session, localMap, typeName, actionId); err != nil {
 				return err
 			}
 		}
@@ -245,7 +240,7 @@ func addMissingFields(localMap map[string]interface{}, typeName, actionId string
 	return nil
 }
 
-func addCertificateIfDeployed(localMap map[string]interface{}, typeName, actionId string) error {
+func addCertificateIfDeployed(session *utils.Session, localMap map[string]interface{}, typeName, actionId string) error {
 
 	psRaw, exists := localMap["passwordSharing"]
 	if !exists {
@@ -259,7 +254,7 @@ func addCertificateIfDeployed(localMap map[string]interface{}, typeName, actionI
 		return nil
 	}
 
-	body, err := utils.SendGetRequest(utils.ACTIONS, typeName+"/"+actionId)
+	body, err := session.SendGetRequest(utils.ACTIONS, typeName+"/"+actionId)
 	if err != nil {
 		return fmt.Errorf("error getting deployed action data: %w", err)
 	}
@@ -274,7 +269,7 @@ func addCertificateIfDeployed(localMap map[string]interface{}, typeName, actionI
 	return nil
 }
 
-func setActionStatus(typePath, actionId, status string) error {
+func setActionStatus(session *utils.Session, typePath, actionId, status string) error {
 
 	endpoint := typePath + "/" + actionId + "/"
 	switch status {
@@ -286,7 +281,7 @@ func setActionStatus(typePath, actionId, status string) error {
 		return fmt.Errorf("unexpected value for status: %s", status)
 	}
 
-	resp, err := utils.SendPostRequest(utils.ACTIONS, nil, utils.WithPathSuffix(endpoint))
+	resp, err := session.SendPostRequest(utils.ACTIONS, nil, utils.WithPathSuffix(endpoint))
 	if err != nil {
 		return err
 	}
@@ -20,34 +20,34 @@ package actions
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(outputDirPath, format string) {
+func ExportAll(session *utils.Session, outputDirPath, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.ACTIONS, "", "Exporting actions...")
 	actionsDir := filepath.Join(outputDirPath, utils.ACTIONS.String())
 
-	if utils.ShouldSkip(utils.ACTIONS) {
+	if session.ShouldSkip(utils.ACTIONS) {
 		return
 	}
-	types, err := getActionTypesList()
+	types, err := getActionTypesList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.ACTIONS, "", fmt.Sprintf("Error retrieving action types list: %s", err))
 		utils.MarkResTypeFailure(utils.ACTIONS)
 		return
 	}
 
-	if !utils.AreSecretsExcluded(utils.TOOL_CONFIGS.ActionConfigs) {
+	if !session.AreSecretsExcluded(session.ToolConfigs.ActionConfigs) {
 		utils.PrintLog(utils.LogLevelWarn, utils.ACTIONS, "", "Secrets exclusion cannot be disabled for actions. All secrets will be masked.")
 	}
 
 	if _, err := os.Stat(actionsDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(actionsDir, 0700); err != nil {
+		if err := utils.CreateExportDir(actionsDir); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.ACTIONS, "", fmt.Sprintf("Error creating actions directory: %s", err))
 			utils.MarkResTypeFailure(utils.ACTIONS)
 			return
@@ -55,32 +55,35 @@ func ExportAll(outputDirPath, format string) {
 	}
 
 	var typesWithActions []string
-	for _, at := range types {
-		if utils.IsResourceExcluded(at.ID, utils.TOOL_CONFIGS.ActionConfigs) {
-			continue
+	var typesLock sync.Mutex
+	session.ProcessConcurrently(len(types), func(i int) {
+		at := types[i]
+		if utils.IsResourceExcluded(at.ID, session.ToolConfigs.ActionConfigs) {
+			return
 		}
 
-		hadActions, err := exportActionType(at, actionsDir, format)
+		utils.MarkResourceStart(utils.ACTIONS, at.ID)
+		hadActions, err := exportActionType(session, at, actionsDir, format)
 		if err != nil {
-			utils.UpdateFailureSummary(utils.ACTIONS, at.ID)
-			utils.PrintLog(utils.LogLevelError, utils.ACTIONS, at.ID, fmt.Sprintf("Error exporting action type: %s", err))
+			utils.UpdateFailureSummaryAndLog(utils.ACTIONS, at.ID, utils.EXPORT, err, fmt.Sprintf("Error exporting action type: %s", err))
 		} else {
 			if hadActions {
+				typesLock.Lock()
 				typesWithActions = append(typesWithActions, at.ID)
-				utils.UpdateSuccessSummary(utils.ACTIONS, utils.EXPORT)
-				utils.PrintLog(utils.LogLevelInfo, utils.ACTIONS, at.ID, "Exported successfully")
+				typesLock.Unlock()
+				utils.UpdateSuccessSummaryAndLog(utils.ACTIONS, at.ID, utils.EXPORT, "Exported successfully")
 			}
 		}
-	}
+	})
 
-	if utils.TOOL_CONFIGS.AllowDelete {
+	if session.ToolConfigs.AllowDelete {
 		utils.RemoveDeletedLocalDirectories(actionsDir, typesWithActions)
 	}
 }
 
-func exportActionType(actionType actionType, parentDir, format string) (bool, error) {
+func exportActionType(session *utils.Session, actionType actionType, parentDir, format string) (bool, error) {
 
-	actions, err := getActionsList(actionType.ID)
+	actions, err := getActionsList(session, actionType.ID)
 	if err != nil {
 		return false, fmt.Errorf("error retrieving actions list: %w", err)
 	}
@@ -91,24 +94,24 @@ func exportActionType(actionType actionType, parentDir, format string) (bool, er
 	utils.PrintLog(utils.LogLevelInfo, utils.ACTIONS, actionType.ID, "Exporting action type")
 	typeDir := filepath.Join(parentDir, actionType.ID)
 	if _, err := os.Stat(typeDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(typeDir, 0700); err != nil {
+		if err := utils.CreateExportDir(typeDir); err != nil {
 			return false, fmt.Errorf("error creating action type directory: %w", err)
 		}
-	} else if utils.TOOL_CONFIGS.AllowDelete {
+	} else if session.ToolConfigs.AllowDelete {
 		utils.RemoveDeletedLocalResources(typeDir, getDeployedActionNames(actions))
 	}
 
 	for _, action := range actions {
-		if err := exportAction(actionType.ID, action.ID, action.Name, typeDir, format); err != nil {
+		if err := exportAction(session, actionType.ID, action.ID, action.Name, typeDir, format); err != nil {
 			return false, fmt.Errorf("error exporting action %s: %w", action.Name, err)
 		}
 	}
 	return true, nil
 }
 
-func exportAction(typeId, actionId, actionName, outputDir, formatStr string) error {
+func exportAction(session *utils.Session, typeId, actionId, actionName, outputDir, formatStr string) error {
 
-	actionData, err := utils.GetResourceData(utils.ACTIONS, typeId+"/"+actionId)
+	actionData, err := session.GetResourceData(utils.ACTIONS, typeId+"/"+actionId)
 	if err != nil {
 		return fmt.Errorf("error getting action data: %w", err)
 	}
@@ -127,8 +130,8 @@ func exportAction(typeId, actionId, actionName, outputDir, formatStr string) err
 	format := utils.FormatFromString(formatStr)
 	exportedFileName := utils.GetExportedFilePath(outputDir, actionName, format)
 
-	keywordMapping := getActionsKeywordMapping(typeId)
-	modifiedData, err := utils.ProcessExportedData(actionMap, exportedFileName, format, keywordMapping, utils.ACTIONS)
+	keywordMapping := getActionsKeywordMapping(session, typeId)
+	modifiedData, err := session.ProcessExportedData(actionMap, exportedFileName, format, keywordMapping, utils.ACTIONS)
 	if err != nil {
 		return fmt.Errorf("error processing exported data: %w", err)
 	}
@@ -138,7 +141,7 @@ func exportAction(typeId, actionId, actionName, outputDir, formatStr string) err
 		return fmt.Errorf("error serializing action: %w", err)
 	}
 
-	if err := ioutil.WriteFile(exportedFileName, modifiedFile, 0644); err != nil {
+	if err := utils.WriteExportedFile(exportedFileName, modifiedFile, utils.ACTIONS); err != nil {
 		return fmt.Errorf("error writing exported content to file: %w", err)
 	}
 
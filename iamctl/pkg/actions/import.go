@@ -28,12 +28,12 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.ACTIONS, "", "Importing actions...")
 	importFilePath := filepath.Join(inputDirPath, utils.ACTIONS.String())
 
-	if utils.ShouldSkip(utils.ACTIONS) {
+	if session.ShouldSkip(utils.ACTIONS) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -41,7 +41,7 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	deployedTypes, err := getActionTypesList()
+	deployedTypes, err := getActionTypesList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.ACTIONS, "", fmt.Sprintf("Error retrieving action types list: %s", err))
 		utils.MarkResTypeFailure(utils.ACTIONS)
@@ -54,31 +54,32 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedActionTypes(typeFolders, deployedTypes)
+	if session.ToolConfigs.AllowDelete {
+		removeDeletedDeployedActionTypes(session, typeFolders, deployedTypes)
 	}
 
-	for _, typeFolder := range typeFolders {
+	session.ProcessConcurrently(len(typeFolders), func(i int) {
+		typeFolder := typeFolders[i]
 		if !typeFolder.IsDir() {
-			continue
+			return
 		}
 		typeName := typeFolder.Name()
 
-		if !utils.IsResourceExcluded(typeName, utils.TOOL_CONFIGS.ActionConfigs) {
-			err := importActionType(importFilePath, typeName)
+		if !utils.IsResourceExcluded(typeName, session.ToolConfigs.ActionConfigs) {
+			utils.MarkResourceStart(utils.ACTIONS, typeName)
+			err := importActionType(session, importFilePath, typeName)
 			if err != nil {
-				utils.UpdateFailureSummary(utils.ACTIONS, typeName)
-				utils.PrintLog(utils.LogLevelError, utils.ACTIONS, typeName, fmt.Sprintf("Error importing action type: %s", err))
+				utils.UpdateFailureSummaryAndLog(utils.ACTIONS, typeName, utils.IMPORT, err, fmt.Sprintf("Error importing action type: %s", err))
 			}
 		}
-	}
+	})
 }
 
-func importActionType(importFilePath, typeName string) error {
+func importActionType(session *utils.Session, importFilePath, typeName string) error {
 
 	typeDir := filepath.Join(importFilePath, typeName)
 
-	deployed, err := getActionsList(typeName)
+	deployed, err := getActionsList(session, typeName)
 	if err != nil {
 		return fmt.Errorf("error retrieving deployed action list: %w", err)
 	}
@@ -87,8 +88,8 @@ func importActionType(importFilePath, typeName string) error {
 		return fmt.Errorf("error reading action type directory: %w", err)
 	}
 
-	if utils.TOOL_CONFIGS.AllowDelete {
-		if err := removeDeletedDeployedActions(typeName, localFiles, deployed); err != nil {
+	if session.ToolConfigs.AllowDelete {
+		if err := removeDeletedDeployedActions(session, typeName, localFiles, deployed); err != nil {
 			return fmt.Errorf("error removing deleted deployed actions: %w", err)
 		}
 	}
@@ -99,7 +100,7 @@ func importActionType(importFilePath, typeName string) error {
 		actionName := fileInfo.ResourceName
 
 		actionId := getActionId(actionName, deployed)
-		err := importAction(typeName, actionId, actionName, actionFilePath)
+		err := importAction(session, typeName, actionId, actionName, actionFilePath)
 		if err != nil {
 			return fmt.Errorf("error importing action %s: %w", actionName, err)
 		}
@@ -107,7 +108,7 @@ func importActionType(importFilePath, typeName string) error {
 	return nil
 }
 
-func importAction(typeName, actionId, actionName, filePath string) error {
+func importAction(session *utils.Session, typeName, actionId, actionName, filePath string) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(filePath))
 	if err != nil {
@@ -118,13 +119,24 @@ func importAction(typeName, actionId, actionName, filePath string) error {
 		return fmt.Errorf("error when reading the file: %w", err)
 	}
 
-	keywordMapping := getActionsKeywordMapping(typeName)
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	keywordMapping := getActionsKeywordMapping(session, typeName)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.ACTIONS, filePath, modifiedFileData, keywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.ACTIONS, filePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
 
 	actionMap, err := utils.DeserializeToMap([]byte(modifiedFileData), format, utils.ACTIONS, "id", "type", "createdAt", "updatedAt")
 	if err != nil {
 		return fmt.Errorf("error when deserializing action data: %w", err)
 	}
+	if utils.DRY_RUN {
+		utils.AddSubResourceToPlan(utils.ACTIONS, typeName, actionName, utils.GetImportAction(actionId != ""), "Action type "+typeName)
+		return nil
+	}
 	if err := replaceRuleReferences(actionMap); err != nil {
 		return fmt.Errorf("error replacing rule references: %w", err)
 	}
@@ -136,12 +148,12 @@ func importAction(typeName, actionId, actionName, filePath string) error {
 	delete(actionMap, "status")
 
 	if actionId == "" {
-		return createAction(typeName, actionName, status, actionMap)
+		return createAction(session, typeName, actionName, status, actionMap)
 	}
-	return updateAction(typeName, actionId, actionName, status, actionMap)
+	return updateAction(session, typeName, actionId, actionName, status, actionMap)
 }
 
-func createAction(typeName, actionName, status string, actionMap map[string]interface{}) error {
+func createAction(session *utils.Session, typeName, actionName, status string, actionMap map[string]interface{}) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.ACTIONS, actionName, fmt.Sprintf("Creating new action of type %s", typeName))
 
@@ -151,7 +163,7 @@ func createAction(typeName, actionName, status string, actionMap map[string]inte
 		return fmt.Errorf("error when serializing action data: %w", err)
 	}
 
-	resp, err := utils.SendPostRequest(utils.ACTIONS, jsonBody, utils.WithPathSuffix(typeName))
+	resp, err := session.SendPostRequest(utils.ACTIONS, jsonBody, utils.WithPathSuffix(typeName))
 	if err != nil {
 		return fmt.Errorf("error when importing action: %w", err)
 	}
@@ -166,20 +178,19 @@ func createAction(typeName, actionName, status string, actionMap map[string]inte
 		return fmt.Errorf("error parsing create response: %w", err)
 	}
 
-	if err := setActionStatus(typeName, created.ID, status); err != nil {
+	if err := setActionStatus(session, typeName, created.ID, status); err != nil {
 		return fmt.Errorf("error setting action status: %w", err)
 	}
 
-	utils.UpdateSuccessSummary(utils.ACTIONS, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.ACTIONS, actionName, "Imported successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.ACTIONS, typeName, utils.IMPORT, fmt.Sprintf("Imported %s successfully", actionName))
 	return nil
 }
 
-func updateAction(typeName, actionId, actionName, status string, actionMap map[string]interface{}) error {
+func updateAction(session *utils.Session, typeName, actionId, actionName, status string, actionMap map[string]interface{}) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.ACTIONS, actionName, fmt.Sprintf("Updating action of type %s", typeName))
 
-	if err := addMissingFields(actionMap, typeName, actionId); err != nil {
+	if err := addMissingFields(session, actionMap, typeName, actionId); err != nil {
 		return fmt.Errorf("error adding missing fields: %w", err)
 	}
 	jsonBody, err := utils.Serialize(actionMap, utils.FormatJSON, utils.ACTIONS)
@@ -187,22 +198,21 @@ func updateAction(typeName, actionId, actionName, status string, actionMap map[s
 		return fmt.Errorf("error when serializing action data: %w", err)
 	}
 
-	resp, err := utils.SendPatchRequest(utils.ACTIONS, typeName+"/"+actionId, jsonBody)
+	resp, err := session.SendPatchRequest(utils.ACTIONS, typeName+"/"+actionId, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when updating action: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if err := setActionStatus(typeName, actionId, status); err != nil {
+	if err := setActionStatus(session, typeName, actionId, status); err != nil {
 		return fmt.Errorf("error setting action status: %w", err)
 	}
 
-	utils.UpdateSuccessSummary(utils.ACTIONS, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.ACTIONS, actionName, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.ACTIONS, typeName, utils.UPDATE, fmt.Sprintf("Updated %s successfully", actionName))
 	return nil
 }
 
-func removeDeletedDeployedActionTypes(localDirs []os.FileInfo, deployedTypes []actionType) {
+func removeDeletedDeployedActionTypes(session *utils.Session, localDirs []os.FileInfo, deployedTypes []actionType) {
 
 	localDirNames := make(map[string]struct{})
 	for _, dir := range localDirs {
@@ -215,23 +225,22 @@ func removeDeletedDeployedActionTypes(localDirs []os.FileInfo, deployedTypes []a
 		if _, existsLocally := localDirNames[deployedType.ID]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(deployedType.ID, utils.TOOL_CONFIGS.ActionConfigs) {
+		if utils.IsResourceExcluded(deployedType.ID, session.ToolConfigs.ActionConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.ACTIONS, deployedType.ID, "Excluded from deletion.")
 			continue
 		}
-		actions, err := getActionsList(deployedType.ID)
+		actions, err := getActionsList(session, deployedType.ID)
 		if err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.ACTIONS, deployedType.ID, fmt.Sprintf("Error retrieving deployed actions: %s", err))
 			continue
 		}
-		if err := removeDeletedDeployedActions(deployedType.ID, nil, actions); err != nil {
-			utils.UpdateFailureSummary(utils.ACTIONS, deployedType.ID)
-			utils.PrintLog(utils.LogLevelError, utils.ACTIONS, deployedType.ID, fmt.Sprintf("Error deleting actions: %s", err))
+		if err := removeDeletedDeployedActions(session, deployedType.ID, nil, actions); err != nil {
+			utils.UpdateFailureSummaryAndLog(utils.ACTIONS, deployedType.ID, utils.DELETE, err, fmt.Sprintf("Error deleting actions: %s", err))
 		}
 	}
 }
 
-func removeDeletedDeployedActions(typeName string, localFiles []os.FileInfo, deployed []action) error {
+func removeDeletedDeployedActions(session *utils.Session, typeName string, localFiles []os.FileInfo, deployed []action) error {
 
 	if len(deployed) == 0 {
 		return nil
@@ -247,11 +256,15 @@ func removeDeletedDeployedActions(typeName string, localFiles []os.FileInfo, dep
 		if _, existsLocally := localResourceNames[action.Name]; existsLocally {
 			continue
 		}
+		if utils.DRY_RUN {
+			utils.AddSubResourceToPlan(utils.ACTIONS, typeName, action.Name, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.ACTIONS, action.Name, fmt.Sprintf("Not found locally. Deleting action of type %s.", typeName))
-		if err := utils.SendDeleteRequest(typeName+"/"+action.ID, utils.ACTIONS); err != nil {
+		if err := session.SendDeleteRequest(typeName+"/"+action.ID, utils.ACTIONS); err != nil {
 			return fmt.Errorf("error deleting action: %s. %w", action.Name, err)
 		} else {
-			utils.UpdateSuccessSummary(utils.ACTIONS, utils.DELETE)
+			utils.UpdateSuccessSummary(utils.ACTIONS, action.Name, utils.DELETE)
 		}
 	}
 	return nil
@@ -24,6 +24,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"strconv"
+	"sync"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
@@ -44,14 +45,15 @@ type apiResourceListResponse struct {
 }
 
 var exportedScopesMap map[string]string
+var exportedScopesLock sync.Mutex
 
-func GetApiResourceList(limitToBusinessApis bool) ([]ApiResource, error) {
+func GetApiResourceList(session *utils.Session, limitToBusinessApis bool) ([]ApiResource, error) {
 
 	queryParams := make(map[string]string)
 	if limitToBusinessApis {
 		queryParams["filter"] = "type eq BUSINESS"
 	}
-	totalResults, err := getApiResourceCount(queryParams)
+	totalResults, err := getApiResourceCount(session, queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +63,7 @@ func GetApiResourceList(limitToBusinessApis bool) ([]ApiResource, error) {
 
 	queryParams["limit"] = strconv.Itoa(totalResults)
 	var listResponse apiResourceListResponse
-	body, err := utils.SendGetListRequest(utils.API_RESOURCES,
+	body, err := session.SendGetListRequest(utils.API_RESOURCES,
 		utils.WithQueryParams(queryParams))
 	if err != nil {
 		return nil, fmt.Errorf("error while retrieving API resource list. %w", err)
@@ -72,10 +74,10 @@ func GetApiResourceList(limitToBusinessApis bool) ([]ApiResource, error) {
 	return listResponse.APIResources, nil
 }
 
-func getApiResourceCount(queryParams map[string]string) (int, error) {
+func getApiResourceCount(session *utils.Session, queryParams map[string]string) (int, error) {
 
 	queryParams["limit"] = "1"
-	body, err := utils.SendGetListRequest(utils.API_RESOURCES,
+	body, err := session.SendGetListRequest(utils.API_RESOURCES,
 		utils.WithQueryParams(queryParams))
 	if err != nil {
 		return 0, fmt.Errorf("error while retrieving API resource count. %w", err)
@@ -106,12 +108,12 @@ func getApiResourceId(identifier string, list []ApiResource) string {
 	return ""
 }
 
-func getApiResourceKeywordMapping(resourceIdentifier string) map[string]interface{} {
+func getApiResourceKeywordMapping(session *utils.Session, resourceIdentifier string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.ApiResourceConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(resourceIdentifier, utils.KEYWORD_CONFIGS.ApiResourceConfigs)
+	if session.KeywordConfigs.ApiResourceConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(resourceIdentifier, session.KeywordConfigs.ApiResourceConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func processScopes(resourceMap map[string]interface{}) (scopeNames []string, err error) {
@@ -138,10 +140,10 @@ func processScopes(resourceMap map[string]interface{}) (scopeNames []string, err
 	return scopeNames, nil
 }
 
-func getApiResourceScopes(resourceId string) ([]apiScope, error) {
+func getApiResourceScopes(session *utils.Session, resourceId string) ([]apiScope, error) {
 
 	var scopes []apiScope
-	body, err := utils.SendGetRequest(utils.API_RESOURCES, resourceId+"/scopes")
+	body, err := session.SendGetRequest(utils.API_RESOURCES, resourceId+"/scopes")
 	if err != nil {
 		return nil, err
 	}
@@ -187,19 +189,20 @@ func writeScopesMap(outputDirPath string, scopeMap map[string]string, formatStri
 	if err != nil {
 		return fmt.Errorf("error serializing scope name map: %w", err)
 	}
-	if err := ioutil.WriteFile(exportedFileName, data, 0644); err != nil {
+	if err := utils.WriteExportedFile(exportedFileName, data, utils.API_RESOURCES); err != nil {
 		return fmt.Errorf("error writing scope name map: %w", err)
 	}
 	return nil
 }
 
-func updateApiResourceExportSummary(success bool, successCount int) {
+func updateApiResourceExportSummary(scopesMapErr error, exportedIdentifiers []string) {
 
-	if !success {
-		utils.UpdateFailureSummary(utils.API_RESOURCES, utils.API_RESOURCE_SCOPES.String())
+	if scopesMapErr != nil {
+		utils.UpdateFailureSummaryAndLog(utils.API_RESOURCES, utils.API_RESOURCE_SCOPES.String(), utils.EXPORT, scopesMapErr,
+			fmt.Sprintf("Error writing scope name map: %s", scopesMapErr))
 		return
 	}
-	for i := 0; i < successCount; i++ {
-		utils.UpdateSuccessSummary(utils.API_RESOURCES, utils.EXPORT)
+	for _, identifier := range exportedIdentifiers {
+		utils.UpdateSuccessSummaryAndLog(utils.API_RESOURCES, identifier, utils.EXPORT, "Exported successfully")
 	}
 }
@@ -19,23 +19,24 @@
 package apiResources
 
 import (
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.API_RESOURCES, "", "Exporting API resources...")
 	exportFilePath = filepath.Join(exportFilePath, utils.API_RESOURCES.String())
 
-	if utils.ShouldSkip(utils.API_RESOURCES) {
+	if session.ShouldSkip(utils.API_RESOURCES) {
 		return
 	}
-	resources, err := GetApiResourceList(true)
+	resources, err := GetApiResourceList(session, true)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.API_RESOURCES, "", fmt.Sprintf("Error when retrieving API resource list: %s", err))
 		utils.MarkResTypeFailure(utils.API_RESOURCES)
@@ -43,46 +44,49 @@ func ExportAll(exportFilePath string, format string) {
 	}
 
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.API_RESOURCES, "", fmt.Sprintf("Error creating API resources directory: %s", err))
 			utils.MarkResTypeFailure(utils.API_RESOURCES)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
+		if session.ToolConfigs.AllowDelete {
 			deployedIdentifiers := getDeployedApiResourceIdentifiers(resources)
 			utils.RemoveDeletedLocalResources(exportFilePath, deployedIdentifiers)
 		}
 	}
 
 	exportedScopesMap = map[string]string{}
-	successCount := 0
+	var exportedIdentifiers []string
+	var exportedLock sync.Mutex
 
-	for _, resource := range resources {
-		if !utils.IsResourceExcluded(resource.Identifier, utils.TOOL_CONFIGS.ApiResourceConfigs) {
+	session.ProcessConcurrently(len(resources), func(i int) {
+		resource := resources[i]
+		if !utils.IsResourceExcluded(resource.Identifier, session.ToolConfigs.ApiResourceConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.API_RESOURCES, resource.Identifier, "Exporting")
-			err := exportApiResource(resource.ID, resource.Identifier, exportFilePath, format)
+			utils.MarkResourceStart(utils.API_RESOURCES, resource.Identifier)
+			err := exportApiResource(session, resource.ID, resource.Identifier, exportFilePath, format)
+			if errors.Is(err, utils.ErrFilteredOut) {
+				return
+			}
 			if err != nil {
-				utils.UpdateFailureSummary(utils.API_RESOURCES, resource.Identifier)
-				utils.PrintLog(utils.LogLevelError, utils.API_RESOURCES, resource.Identifier, fmt.Sprintf("Error while exporting: %s", err))
+				utils.UpdateFailureSummaryAndLog(utils.API_RESOURCES, resource.Identifier, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 			} else {
-				successCount++
+				exportedLock.Lock()
+				exportedIdentifiers = append(exportedIdentifiers, resource.Identifier)
+				exportedLock.Unlock()
 				utils.AddToIdentifierMap(utils.API_RESOURCES, resource.ID, resource.Identifier, utils.EXPORT)
-				utils.PrintLog(utils.LogLevelInfo, utils.API_RESOURCES, resource.Identifier, "Exported successfully")
 			}
 		}
-	}
+	})
 
 	err = writeScopesMap(exportFilePath, exportedScopesMap, format)
-	updateApiResourceExportSummary(err == nil, successCount)
-	if err != nil {
-		utils.PrintLog(utils.LogLevelError, utils.API_RESOURCES, "", fmt.Sprintf("Error writing scope name map: %s", err))
-	}
+	updateApiResourceExportSummary(err, exportedIdentifiers)
 }
 
-func exportApiResource(resourceId string, resourceIdentifier string, outputDirPath string, formatString string) error {
+func exportApiResource(session *utils.Session, resourceId string, resourceIdentifier string, outputDirPath string, formatString string) error {
 
-	resourceData, err := utils.GetResourceData(utils.API_RESOURCES, resourceId)
+	resourceData, err := session.GetResourceData(utils.API_RESOURCES, resourceId)
 	if err != nil {
 		return fmt.Errorf("error while getting API resource: %w", err)
 	}
@@ -99,8 +103,8 @@ func exportApiResource(resourceId string, resourceIdentifier string, outputDirPa
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, resourceIdentifier, format)
 
-	keywordMapping := getApiResourceKeywordMapping(resourceIdentifier)
-	modifiedResource, err := utils.ProcessExportedData(resourceMap, exportedFileName, format, keywordMapping, utils.API_RESOURCES)
+	keywordMapping := getApiResourceKeywordMapping(session, resourceIdentifier)
+	modifiedResource, err := session.ProcessExportedData(resourceMap, exportedFileName, format, keywordMapping, utils.API_RESOURCES)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -110,13 +114,15 @@ func exportApiResource(resourceId string, resourceIdentifier string, outputDirPa
 		return fmt.Errorf("error while serializing API resource: %w", err)
 	}
 
-	if err := ioutil.WriteFile(exportedFileName, modifiedFile, 0644); err != nil {
+	if err := utils.WriteExportedFile(exportedFileName, modifiedFile, utils.API_RESOURCES); err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
 
+	exportedScopesLock.Lock()
 	for _, scopeName := range scopeNames {
 		exportedScopesMap[scopeName] = resourceIdentifier
 	}
+	exportedScopesLock.Unlock()
 
 	return nil
 }
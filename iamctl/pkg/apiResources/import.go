@@ -28,12 +28,12 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.API_RESOURCES, "", "Importing API resources...")
 	importFilePath := filepath.Join(inputDirPath, utils.API_RESOURCES.String())
 
-	if utils.ShouldSkip(utils.API_RESOURCES) {
+	if session.ShouldSkip(utils.API_RESOURCES) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -41,7 +41,7 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	deployedResources, err := GetApiResourceList(true)
+	deployedResources, err := GetApiResourceList(session, true)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.API_RESOURCES, "", fmt.Sprintf("Error retrieving the deployed API resource list: %s", err))
 		utils.MarkResTypeFailure(utils.API_RESOURCES)
@@ -53,43 +53,44 @@ func ImportAll(inputDirPath string) {
 		utils.MarkResTypeFailure(utils.API_RESOURCES)
 		return
 	}
-	if utils.TOOL_CONFIGS.AllowDelete {
-		deployedResources = removeDeletedDeployedApiResources(files, deployedResources)
+	if session.IsDeleteAllowed(utils.API_RESOURCES) {
+		deployedResources = removeDeletedDeployedApiResources(session, files, deployedResources)
 	}
 
 	localScopeMap, err := readLocalScopesMap(importFilePath)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.API_RESOURCES, "", fmt.Sprintf("Error reading local scope name map: %s", err))
 		utils.MarkResTypeFailure(utils.API_RESOURCES)
-		utils.UpdateFailureSummary(utils.API_RESOURCES, utils.API_RESOURCE_SCOPES.String())
+		utils.UpdateFailureSummary(utils.API_RESOURCES, utils.API_RESOURCE_SCOPES.String(), utils.IMPORT, err)
 		return
 	}
-	failedResources := removeDeletedDeployedScopes(localScopeMap, deployedResources)
+	failedResources := removeDeletedDeployedScopes(session, localScopeMap, deployedResources)
 
-	for _, file := range files {
+	session.ProcessConcurrently(len(files), func(i int) {
+		file := files[i]
 		apiResFilePath := filepath.Join(importFilePath, file.Name())
 		fileInfo := utils.GetFileInfo(apiResFilePath)
 		resourceName := fileInfo.ResourceName
 
 		if resourceName == utils.API_RESOURCE_SCOPES.String() {
-			continue
+			return
 		}
 		if _, failed := failedResources[resourceName]; failed {
-			utils.PrintLog(utils.LogLevelInfo, utils.API_RESOURCES, resourceName, "Skipping: deleting stale scopes failed")
-			utils.UpdateFailureSummary(utils.API_RESOURCES, resourceName)
-			continue
+			err := fmt.Errorf("deleting stale scopes failed")
+			utils.UpdateFailureSummaryAndLog(utils.API_RESOURCES, resourceName, utils.IMPORT, err, fmt.Sprintf("Skipping: %s", err))
+			return
 		}
-		if !utils.IsResourceExcluded(resourceName, utils.TOOL_CONFIGS.ApiResourceConfigs) {
+		if !utils.IsResourceExcluded(resourceName, session.ToolConfigs.ApiResourceConfigs) && session.MatchesFileFilter(utils.API_RESOURCES, apiResFilePath, getApiResourceKeywordMapping(session, resourceName), utils.IMPORT) {
 			resourceId := getApiResourceId(resourceName, deployedResources)
-			if err := importApiResource(resourceId, resourceName, apiResFilePath); err != nil {
-				utils.PrintLog(utils.LogLevelError, utils.API_RESOURCES, resourceName, fmt.Sprintf("Error importing API resource: %s", err))
-				utils.UpdateFailureSummary(utils.API_RESOURCES, resourceName)
+			utils.MarkResourceStart(utils.API_RESOURCES, resourceName)
+			if err := importApiResource(session, resourceId, resourceName, apiResFilePath); err != nil {
+				utils.UpdateFailureSummaryAndLog(utils.API_RESOURCES, resourceName, utils.IMPORT, err, fmt.Sprintf("Error importing API resource: %s", err))
 			}
 		}
-	}
+	})
 }
 
-func importApiResource(resourceId, resourceIdentifier, importFilePath string) error {
+func importApiResource(session *utils.Session, resourceId, resourceIdentifier, importFilePath string) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(importFilePath))
 	if err != nil {
@@ -101,16 +102,28 @@ func importApiResource(resourceId, resourceIdentifier, importFilePath string) er
 		return fmt.Errorf("error when reading the file for API resource: %w", err)
 	}
 
-	keywordMapping := getApiResourceKeywordMapping(resourceIdentifier)
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	keywordMapping := getApiResourceKeywordMapping(session, resourceIdentifier)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.API_RESOURCES, importFilePath, modifiedFileData, keywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.API_RESOURCES, importFilePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
+
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.API_RESOURCES, resourceIdentifier, utils.GetImportAction(resourceId != ""), "")
+		return nil
+	}
 
 	if resourceId == "" {
-		return createApiResource(resourceIdentifier, []byte(modifiedFileData), format)
+		return createApiResource(session, resourceIdentifier, []byte(modifiedFileData), format)
 	}
-	return updateApiResource(resourceId, resourceIdentifier, []byte(modifiedFileData), format)
+	return updateApiResource(session, resourceId, resourceIdentifier, []byte(modifiedFileData), format)
 }
 
-func createApiResource(resourceIdentifier string, requestBody []byte, format utils.Format) error {
+func createApiResource(session *utils.Session, resourceIdentifier string, requestBody []byte, format utils.Format) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.API_RESOURCES, resourceIdentifier, "Creating new API resource")
 
@@ -120,7 +133,7 @@ func createApiResource(resourceIdentifier string, requestBody []byte, format uti
 		return err
 	}
 
-	resp, err := utils.SendPostRequest(utils.API_RESOURCES, jsonBody)
+	resp, err := session.SendPostRequest(utils.API_RESOURCES, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when creating API resource: %w", err)
 	}
@@ -136,12 +149,11 @@ func createApiResource(resourceIdentifier string, requestBody []byte, format uti
 	}
 	utils.AddToIdentifierMap(utils.API_RESOURCES, created.ID, resourceIdentifier, utils.IMPORT)
 
-	utils.UpdateSuccessSummary(utils.API_RESOURCES, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.API_RESOURCES, resourceIdentifier, "Created successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.API_RESOURCES, resourceIdentifier, utils.IMPORT, "Created successfully")
 	return nil
 }
 
-func updateApiResource(resourceId, resourceIdentifier string, requestBody []byte, format utils.Format) error {
+func updateApiResource(session *utils.Session, resourceId, resourceIdentifier string, requestBody []byte, format utils.Format) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.API_RESOURCES, resourceIdentifier, "Updating API resource")
 
@@ -159,19 +171,18 @@ func updateApiResource(resourceId, resourceIdentifier string, requestBody []byte
 		return fmt.Errorf("error serializing update scopes request body: %w", err)
 	}
 
-	resp, err := utils.SendPutRequest(utils.API_RESOURCES, resourceId+"/scopes", jsonBody)
+	resp, err := session.SendPutRequest(utils.API_RESOURCES, resourceId+"/scopes", jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when updating API resource scopes: %w", err)
 	}
 	defer resp.Body.Close()
 
 	utils.AddToIdentifierMap(utils.API_RESOURCES, resourceId, resourceIdentifier, utils.IMPORT)
-	utils.UpdateSuccessSummary(utils.API_RESOURCES, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.API_RESOURCES, resourceIdentifier, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.API_RESOURCES, resourceIdentifier, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func removeDeletedDeployedApiResources(localFiles []os.FileInfo, deployedResources []ApiResource) (remainingResources []ApiResource) {
+func removeDeletedDeployedApiResources(session *utils.Session, localFiles []os.FileInfo, deployedResources []ApiResource) (remainingResources []ApiResource) {
 
 	if len(deployedResources) == 0 {
 		return deployedResources
@@ -188,33 +199,36 @@ func removeDeletedDeployedApiResources(localFiles []os.FileInfo, deployedResourc
 			remainingResources = append(remainingResources, resource)
 			continue
 		}
-		if utils.IsResourceExcluded(resource.Identifier, utils.TOOL_CONFIGS.ApiResourceConfigs) {
+		if utils.IsResourceExcluded(resource.Identifier, session.ToolConfigs.ApiResourceConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.API_RESOURCES, resource.Identifier, "Excluded from deletion")
 			remainingResources = append(remainingResources, resource)
 			continue
 		}
 
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.API_RESOURCES, resource.Identifier, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.API_RESOURCES, resource.Identifier, "Not found locally. Deleting.")
-		if err := utils.SendDeleteRequest(resource.ID, utils.API_RESOURCES); err != nil {
-			utils.UpdateFailureSummary(utils.API_RESOURCES, resource.Identifier)
-			utils.PrintLog(utils.LogLevelError, utils.API_RESOURCES, resource.Identifier, fmt.Sprintf("Error deleting API resource: %s", err))
+		if err := session.SendDeleteRequest(resource.ID, utils.API_RESOURCES); err != nil {
+			utils.UpdateFailureSummaryAndLog(utils.API_RESOURCES, resource.Identifier, utils.DELETE, err, fmt.Sprintf("Error deleting API resource: %s", err))
 			remainingResources = append(remainingResources, resource)
 		} else {
-			utils.UpdateSuccessSummary(utils.API_RESOURCES, utils.DELETE)
+			utils.UpdateSuccessSummary(utils.API_RESOURCES, resource.Identifier, utils.DELETE)
 		}
 	}
 	return remainingResources
 }
 
-func removeDeletedDeployedScopes(localScopeMap map[string]string, deployedResources []ApiResource) (failedResources map[string]struct{}) {
+func removeDeletedDeployedScopes(session *utils.Session, localScopeMap map[string]string, deployedResources []ApiResource) (failedResources map[string]struct{}) {
 
 	failedResources = make(map[string]struct{})
 
 	for _, resource := range deployedResources {
-		if utils.IsResourceExcluded(resource.Identifier, utils.TOOL_CONFIGS.ApiResourceConfigs) {
+		if utils.IsResourceExcluded(resource.Identifier, session.ToolConfigs.ApiResourceConfigs) {
 			continue
 		}
-		scopes, err := getApiResourceScopes(resource.ID)
+		scopes, err := getApiResourceScopes(session, resource.ID)
 		if err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.API_RESOURCES, resource.Identifier, fmt.Sprintf("Error retrieving scopes: %s", err))
 			failedResources[resource.Identifier] = struct{}{}
@@ -225,7 +239,7 @@ func removeDeletedDeployedScopes(localScopeMap map[string]string, deployedResour
 			localApiResName, scopeInLocalMap := localScopeMap[scope.Name]
 
 			shouldDelete := false
-			if !scopeInLocalMap && utils.TOOL_CONFIGS.AllowDelete {
+			if !scopeInLocalMap && session.ToolConfigs.AllowDelete {
 				shouldDelete = true
 			} else if scopeInLocalMap && localApiResName != resource.Identifier {
 				shouldDelete = true
@@ -233,8 +247,12 @@ func removeDeletedDeployedScopes(localScopeMap map[string]string, deployedResour
 			if !shouldDelete {
 				continue
 			}
+			if utils.DRY_RUN {
+				utils.AddSubResourceToPlan(utils.API_RESOURCES, resource.Identifier, resource.Identifier+"/scopes/"+scope.Name, utils.PLAN_DELETE, "Stale scope")
+				continue
+			}
 
-			if err := utils.SendDeleteRequest(resource.ID+"/scopes/id/"+scope.ID, utils.API_RESOURCES); err != nil {
+			if err := session.SendDeleteRequest(resource.ID+"/scopes/id/"+scope.ID, utils.API_RESOURCES); err != nil {
 				utils.PrintLog(utils.LogLevelError, utils.API_RESOURCES, resource.Identifier, fmt.Sprintf("Error deleting scope %s: %s", scope.Name, err))
 				failedResources[resource.Identifier] = struct{}{}
 			}
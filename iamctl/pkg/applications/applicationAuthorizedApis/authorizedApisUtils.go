@@ -41,14 +41,14 @@ type AuthorizedScope struct {
 var IsSupported bool
 var apiResourcesMap map[string]string
 
-func InitIsSupported() {
+func InitIsSupported(session *utils.Session) {
 
-	IsSupported = utils.IsEntitySupportedInVersion(utils.APPLICATION_AUTHORIZED_APIS) && utils.IsEntitySupportedInOrg(utils.APPLICATION_AUTHORIZED_APIS)
+	IsSupported = session.IsEntitySupportedInVersion(utils.APPLICATION_AUTHORIZED_APIS) && session.IsEntitySupportedInOrg(utils.APPLICATION_AUTHORIZED_APIS)
 }
 
-func GetAPIResources() error {
+func GetAPIResources(session *utils.Session) error {
 
-	list, err := apiResources.GetApiResourceList(false)
+	list, err := apiResources.GetApiResourceList(session, false)
 	if err != nil {
 		return fmt.Errorf("error while retrieving API resource list: %w", err)
 	}
@@ -64,9 +64,9 @@ func GetOutputDirPath(appsOutputDirPath string) string {
 	return filepath.Join(appsOutputDirPath, utils.APPLICATION_AUTHORIZED_APIS.String())
 }
 
-func getAuthorizedAPIList(appId string) ([]AuthorizedAPI, error) {
+func getAuthorizedAPIList(session *utils.Session, appId string) ([]AuthorizedAPI, error) {
 
-	body, err := utils.SendGetRequest(utils.APPLICATIONS, appId+"/authorized-apis")
+	body, err := session.SendGetRequest(utils.APPLICATIONS, appId+"/authorized-apis")
 	if err != nil {
 		return nil, err
 	}
@@ -77,12 +77,12 @@ func getAuthorizedAPIList(appId string) ([]AuthorizedAPI, error) {
 	return apis, nil
 }
 
-func getAuthorizedApisKeywordMapping(appName string) map[string]interface{} {
+func getAuthorizedApisKeywordMapping(session *utils.Session, appName string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.ApplicationConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(appName, utils.KEYWORD_CONFIGS.ApplicationConfigs)
+	if session.KeywordConfigs.ApplicationConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(appName, session.KeywordConfigs.ApplicationConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func getAPIByIdentifier(identifier string, deployedApis []AuthorizedAPI) *AuthorizedAPI {
@@ -20,19 +20,18 @@ package applicationAuthorizedApis
 
 import (
 	"fmt"
-	"io/ioutil"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAPIs(appId, appName, appsOutputDirPath, formatString string) error {
+func ExportAPIs(session *utils.Session, appId, appName, appsOutputDirPath, formatString string) error {
 
 	if !IsSupported {
 		return nil
 	}
 	outputDirPath := GetOutputDirPath(appsOutputDirPath)
 
-	apiData, err := utils.GetResourceData(utils.APPLICATIONS, appId+"/authorized-apis")
+	apiData, err := session.GetResourceData(utils.APPLICATIONS, appId+"/authorized-apis")
 	if err != nil {
 		return fmt.Errorf("error fetching authorized APIs: %w", err)
 	}
@@ -43,8 +42,8 @@ func ExportAPIs(appId, appName, appsOutputDirPath, formatString string) error {
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, appName, format)
 
-	keywordMapping := getAuthorizedApisKeywordMapping(appName)
-	modifiedData, err := utils.ProcessExportedData(apiData, exportedFileName, format, keywordMapping, utils.APPLICATION_AUTHORIZED_APIS)
+	keywordMapping := getAuthorizedApisKeywordMapping(session, appName)
+	modifiedData, err := session.ProcessExportedData(apiData, exportedFileName, format, keywordMapping, utils.APPLICATION_AUTHORIZED_APIS)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -54,7 +53,7 @@ func ExportAPIs(appId, appName, appsOutputDirPath, formatString string) error {
 		return fmt.Errorf("error serializing authorized APIs: %w", err)
 	}
 
-	if err := ioutil.WriteFile(exportedFileName, fileContent, 0644); err != nil {
+	if err := utils.WriteExportedFile(exportedFileName, fileContent, utils.APPLICATION_AUTHORIZED_APIS); err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
 
@@ -26,7 +26,7 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAPIs(appId, appName, appsImportDirPath string) error {
+func ImportAPIs(session *utils.Session, appId, appName, appsImportDirPath string) error {
 
 	if !IsSupported {
 		return nil
@@ -41,8 +41,15 @@ func ImportAPIs(appId, appName, appsImportDirPath string) error {
 		return fmt.Errorf("error reading authorized APIs file: %w", err)
 	}
 
-	keywordMapping := getAuthorizedApisKeywordMapping(appName)
-	fileContent := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	keywordMapping := getAuthorizedApisKeywordMapping(session, appName)
+	fileContent := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.APPLICATION_AUTHORIZED_APIS, filePath, fileContent, keywordMapping); err != nil {
+		return err
+	}
+	fileContent, err = session.ApplyOverlay(utils.APPLICATION_AUTHORIZED_APIS, filePath, fileContent)
+	if err != nil {
+		return err
+	}
 
 	format, err := utils.FormatFromExtension(filepath.Ext(filePath))
 	if err != nil {
@@ -58,25 +65,25 @@ func ImportAPIs(appId, appName, appsImportDirPath string) error {
 		return fmt.Errorf("unexpected format for authorized APIs file content")
 	}
 
-	deployedAPIs, err := getAuthorizedAPIList(appId)
+	deployedAPIs, err := getAuthorizedAPIList(session, appId)
 	if err != nil {
 		return fmt.Errorf("error fetching deployed authorized APIs: %w", err)
 	}
-	if utils.TOOL_CONFIGS.AllowDelete {
-		if err := removeDeletedAuthorizedAPIs(appId, apiList, deployedAPIs); err != nil {
+	if session.ToolConfigs.AllowDelete {
+		if err := removeDeletedAuthorizedAPIs(session, appId, apiList, deployedAPIs); err != nil {
 			return fmt.Errorf("error removing deleted APIs: %w", err)
 		}
 	}
 
 	for _, api := range apiList {
-		if err := importAuthorizedAPI(appId, api, deployedAPIs); err != nil {
+		if err := importAuthorizedAPI(session, appId, api, deployedAPIs); err != nil {
 			return fmt.Errorf("error importing API: %w", err)
 		}
 	}
 	return nil
 }
 
-func importAuthorizedAPI(appId string, api interface{}, deployedAPIs []AuthorizedAPI) error {
+func importAuthorizedAPI(session *utils.Session, appId string, api interface{}, deployedAPIs []AuthorizedAPI) error {
 
 	apiMap, ok := api.(map[string]interface{})
 	if !ok {
@@ -98,12 +105,12 @@ func importAuthorizedAPI(appId string, api interface{}, deployedAPIs []Authorize
 	deployedApi := getAPIByIdentifier(identifier, deployedAPIs)
 
 	if deployedApi != nil {
-		err := updateAuthorizedAPI(appId, apiMap, *deployedApi)
+		err := updateAuthorizedAPI(session, appId, apiMap, *deployedApi)
 		if err != nil {
 			return fmt.Errorf("error updating API %q: %w", identifier, err)
 		}
 	} else {
-		err := createAuthorizedAPI(appId, identifier, apiMap)
+		err := createAuthorizedAPI(session, appId, identifier, apiMap)
 		if err != nil {
 			return fmt.Errorf("error creating API %q: %w", identifier, err)
 		}
@@ -111,7 +118,7 @@ func importAuthorizedAPI(appId string, api interface{}, deployedAPIs []Authorize
 	return nil
 }
 
-func createAuthorizedAPI(appId, identifier string, apiMap map[string]interface{}) error {
+func createAuthorizedAPI(session *utils.Session, appId, identifier string, apiMap map[string]interface{}) error {
 
 	apiId, err := getApiIdByIdentifier(identifier)
 	if err != nil {
@@ -127,7 +134,7 @@ func createAuthorizedAPI(appId, identifier string, apiMap map[string]interface{}
 		return err
 	}
 
-	resp, err := utils.SendPostRequest(utils.APPLICATIONS, body,
+	resp, err := session.SendPostRequest(utils.APPLICATIONS, body,
 		utils.WithPathSuffix(appId+"/authorized-apis"))
 	if err != nil {
 		return err
@@ -136,7 +143,7 @@ func createAuthorizedAPI(appId, identifier string, apiMap map[string]interface{}
 	return nil
 }
 
-func updateAuthorizedAPI(appId string, apiMap map[string]interface{}, deployedApi AuthorizedAPI) error {
+func updateAuthorizedAPI(session *utils.Session, appId string, apiMap map[string]interface{}, deployedApi AuthorizedAPI) error {
 
 	localScopeNames, err := extractScopeNames(apiMap)
 	if err != nil {
@@ -160,7 +167,7 @@ func updateAuthorizedAPI(appId string, apiMap map[string]interface{}, deployedAp
 		return nil
 	}
 
-	resp, err := utils.SendPatchRequest(utils.APPLICATIONS, appId+"/authorized-apis/"+deployedApi.ID, reqbody)
+	resp, err := session.SendPatchRequest(utils.APPLICATIONS, appId+"/authorized-apis/"+deployedApi.ID, reqbody)
 	if err != nil {
 		return err
 	}
@@ -168,7 +175,7 @@ func updateAuthorizedAPI(appId string, apiMap map[string]interface{}, deployedAp
 	return nil
 }
 
-func removeDeletedAuthorizedAPIs(appId string, localApis []interface{}, deployedApis []AuthorizedAPI) error {
+func removeDeletedAuthorizedAPIs(session *utils.Session, appId string, localApis []interface{}, deployedApis []AuthorizedAPI) error {
 
 	localIdents := make(map[string]struct{})
 	for _, api := range localApis {
@@ -187,7 +194,7 @@ func removeDeletedAuthorizedAPIs(appId string, localApis []interface{}, deployed
 		if _, exists := localIdents[dep.Identifier]; exists {
 			continue
 		}
-		if err := utils.SendDeleteRequest(appId+"/authorized-apis/"+dep.ID, utils.APPLICATIONS); err != nil {
+		if err := session.SendDeleteRequest(appId+"/authorized-apis/"+dep.ID, utils.APPLICATIONS); err != nil {
 			return fmt.Errorf("error deleting API %q: %w", dep.Identifier, err)
 		}
 	}
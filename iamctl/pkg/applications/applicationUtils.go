@@ -75,9 +75,9 @@ func getDeployedAppNames(apps []Application) []string {
 	return appNames
 }
 
-func getAppList() ([]Application, error) {
+func getAppList(session *utils.Session) ([]Application, error) {
 
-	data, err := utils.SendPaginatedGetListRequest(
+	data, err := session.SendPaginatedGetListRequest(
 		utils.APPLICATIONS,
 		"totalResults",
 		"count",
@@ -96,12 +96,12 @@ func getAppList() ([]Application, error) {
 	return apps, nil
 }
 
-func getAppKeywordMapping(appName string) map[string]interface{} {
+func getAppKeywordMapping(session *utils.Session, appName string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.ApplicationConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(appName, utils.KEYWORD_CONFIGS.ApplicationConfigs)
+	if session.KeywordConfigs.ApplicationConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(appName, session.KeywordConfigs.ApplicationConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func getAppId(appName string, appList []Application) string {
@@ -161,9 +161,9 @@ func removeAssociatedRoles(fileContent []byte) []byte {
 	return []byte(result)
 }
 
-func injectDeployedOAuthCredentials(appId, fileData string, format utils.Format) (string, error) {
+func injectDeployedOAuthCredentials(session *utils.Session, appId, fileData string, format utils.Format) (string, error) {
 
-	oidcConfig, err := getDeployedInboundProtocolConfig(appId, "oidc")
+	oidcConfig, err := getDeployedInboundProtocolConfig(session, appId, "oidc")
 	if err != nil {
 		return fileData, err
 	}
@@ -199,9 +199,9 @@ func injectDeployedOAuthCredentials(appId, fileData string, format utils.Format)
 	return result, nil
 }
 
-func isToolMgtApp(appId string) (bool, error) {
+func isToolMgtApp(session *utils.Session, appId string) (bool, error) {
 
-	oidcConfig, err := getDeployedInboundProtocolConfig(appId, "oidc")
+	oidcConfig, err := getDeployedInboundProtocolConfig(session, appId, "oidc")
 	if err != nil {
 		return false, err
 	}
@@ -213,7 +213,7 @@ func isToolMgtApp(appId string) (bool, error) {
 	if !ok {
 		return false, fmt.Errorf("clientId not found in deployed oidc config")
 	}
-	return clientId == utils.SERVER_CONFIGS.ClientId, nil
+	return clientId == session.ServerConfigs.ClientId, nil
 }
 
 func isOauthSecretGiven(modifiedFileData string, format utils.Format) (bool, error) {
@@ -233,7 +233,7 @@ func isOauthSecretGiven(modifiedFileData string, format utils.Format) (bool, err
 	return false, nil
 }
 
-func processInboundProtocolConfigs(appId string, inboundProtocols []inboundProtocolRef, appMap map[string]interface{}, excludeSecrets bool) error {
+func processInboundProtocolConfigs(session *utils.Session, appId string, inboundProtocols []inboundProtocolRef, appMap map[string]interface{}, excludeSecrets bool) error {
 
 	result := make(map[string]interface{})
 	var customProtocols []interface{}
@@ -248,7 +248,7 @@ func processInboundProtocolConfigs(appId string, inboundProtocols []inboundProto
 			continue
 		}
 
-		body, err := utils.SendGetRequest(utils.APPLICATIONS, appId+"/inbound-protocols/"+protocolPath)
+		body, err := session.SendGetRequest(utils.APPLICATIONS, appId+"/inbound-protocols/"+protocolPath)
 		if err != nil {
 			return fmt.Errorf("error retrieving inbound protocol %s: %w", protocolPath, err)
 		}
@@ -330,9 +330,9 @@ func processInboundProtocolsForPost(appMap map[string]interface{}) (newSecretCre
 	return newSecretCreated, nil
 }
 
-func getDeployedInboundProtocols(appId string) ([]inboundProtocolRef, error) {
+func getDeployedInboundProtocols(session *utils.Session, appId string) ([]inboundProtocolRef, error) {
 
-	body, err := utils.SendGetRequest(utils.APPLICATIONS, appId+"/inbound-protocols")
+	body, err := session.SendGetRequest(utils.APPLICATIONS, appId+"/inbound-protocols")
 	if err != nil {
 		return nil, err
 	}
@@ -370,7 +370,7 @@ func flattenInboundProtocols(localProtocolConfig map[string]interface{}) ([]map[
 	return result, nil
 }
 
-func processInboundProtocolForUpdate(appId string, protocolMap map[string]interface{}) (protocolPath string, err error) {
+func processInboundProtocolForUpdate(session *utils.Session, appId string, protocolMap map[string]interface{}) (protocolPath string, err error) {
 
 	protocolPath, ok := protocolMap["_type"].(string)
 	if !ok {
@@ -379,16 +379,16 @@ func processInboundProtocolForUpdate(appId string, protocolMap map[string]interf
 	delete(protocolMap, "_type")
 
 	if protocolPath == "oidc" || protocolPath == "passive-sts" {
-		if err := injectDeployedReadOnlyFields(appId, protocolPath, protocolMap); err != nil {
+		if err := injectDeployedReadOnlyFields(session, appId, protocolPath, protocolMap); err != nil {
 			return "", err
 		}
 	}
 	return protocolPath, nil
 }
 
-func getDeployedInboundProtocolConfig(appId, protocolPath string) (map[string]interface{}, error) {
+func getDeployedInboundProtocolConfig(session *utils.Session, appId, protocolPath string) (map[string]interface{}, error) {
 
-	body, err := utils.SendGetRequest(utils.APPLICATIONS, appId+"/inbound-protocols/"+protocolPath)
+	body, err := session.SendGetRequest(utils.APPLICATIONS, appId+"/inbound-protocols/"+protocolPath)
 	if err != nil {
 		if strings.Contains(err.Error(), "Resource not found") {
 			return nil, nil
@@ -402,9 +402,9 @@ func getDeployedInboundProtocolConfig(appId, protocolPath string) (map[string]in
 	return deployedConfig, nil
 }
 
-func injectDeployedReadOnlyFields(appId, protocolPath string, localConfig map[string]interface{}) error {
+func injectDeployedReadOnlyFields(session *utils.Session, appId, protocolPath string, localConfig map[string]interface{}) error {
 
-	deployedConfig, err := getDeployedInboundProtocolConfig(appId, protocolPath)
+	deployedConfig, err := getDeployedInboundProtocolConfig(session, appId, protocolPath)
 	if err != nil {
 		return err
 	}
@@ -436,9 +436,9 @@ func injectDeployedReadOnlyFields(appId, protocolPath string, localConfig map[st
 	return nil
 }
 
-func removeRoleClaimUri(appMap map[string]interface{}) error {
+func removeRoleClaimUri(session *utils.Session, appMap map[string]interface{}) error {
 
-	if !claims.RoleClaimUnsupported() {
+	if !claims.RoleClaimUnsupported(session) {
 		return nil
 	}
 	claimConfMap, ok := appMap["claimConfiguration"].(map[string]interface{})
@@ -527,9 +527,9 @@ func removeAdditionalSpProperties(appMap map[string]interface{}) error {
 	return nil
 }
 
-func InitDeployedRoleIds() error {
+func InitDeployedRoleIds(session *utils.Session) error {
 
-	roleList, err := roles.GetRoleList()
+	roleList, err := roles.GetRoleList(session)
 	if err != nil {
 		return fmt.Errorf("error retrieving role list: %w", err)
 	}
@@ -20,6 +20,7 @@ package applications
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"mime"
@@ -30,20 +31,20 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	// Export all applications to the Applications folder.
 	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, "", "Exporting applications...")
 	exportFilePath = filepath.Join(exportFilePath, utils.APPLICATIONS.String())
 	authAPIsOutputDir := applicationAuthorizedApis.GetOutputDirPath(exportFilePath)
 
-	if utils.IsResourceTypeExcluded(utils.APPLICATIONS) {
+	if session.IsResourceTypeExcluded(utils.APPLICATIONS) {
 		return
 	}
-	exportAPIExists := utils.ExportAPIExists(utils.APPLICATIONS)
-	applicationAuthorizedApis.InitIsSupported()
+	exportAPIExists := session.ExportAPIExists(utils.APPLICATIONS)
+	applicationAuthorizedApis.InitIsSupported(session)
 
-	apps, err := getAppList()
+	apps, err := getAppList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.APPLICATIONS, "", fmt.Sprintf("Error retrieving applications list: %s", err))
 		utils.MarkResTypeFailure(utils.APPLICATIONS)
@@ -52,67 +53,69 @@ func ExportAll(exportFilePath string, format string) {
 	deployedAppNames := getDeployedAppNames(apps)
 
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.APPLICATIONS, "", fmt.Sprintf("Error creating applications directory: %s", err))
 			utils.MarkResTypeFailure(utils.APPLICATIONS)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
+		if session.ToolConfigs.AllowDelete {
 			utils.RemoveDeletedLocalResources(exportFilePath, append(deployedAppNames, utils.RESIDENT_APP))
 		}
 	}
 
 	if applicationAuthorizedApis.IsSupported {
 		if _, err := os.Stat(authAPIsOutputDir); os.IsNotExist(err) {
-			if err := os.MkdirAll(authAPIsOutputDir, 0700); err != nil {
+			if err := utils.CreateExportDir(authAPIsOutputDir); err != nil {
 				utils.PrintLog(utils.LogLevelError, utils.APPLICATIONS, "", fmt.Sprintf("Error creating authorized APIs directory: %s", err))
 				utils.MarkResTypeFailure(utils.APPLICATIONS)
 				return
 			}
 		} else {
-			if utils.TOOL_CONFIGS.AllowDelete {
+			if session.ToolConfigs.AllowDelete {
 				utils.RemoveDeletedLocalResources(authAPIsOutputDir, deployedAppNames)
 			}
 		}
 	}
-	excludeSecrets := utils.AreSecretsExcluded(utils.TOOL_CONFIGS.ApplicationConfigs)
-	for _, app := range apps {
-		if !utils.IsResourceExcluded(app.Name, utils.TOOL_CONFIGS.ApplicationConfigs) {
+	excludeSecrets := session.AreSecretsExcluded(session.ToolConfigs.ApplicationConfigs)
+	session.ProcessConcurrently(len(apps), func(i int) {
+		app := apps[i]
+		if !utils.IsResourceExcluded(app.Name, session.ToolConfigs.ApplicationConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, app.Name, "Exporting")
+			utils.MarkResourceStart(utils.APPLICATIONS, app.Name)
 			var err error
 			if exportAPIExists {
-				err = exportApp(app.Id, exportFilePath, format, excludeSecrets)
+				err = exportApp(session, app.Id, exportFilePath, format, excludeSecrets)
 			} else {
-				err = exportAppWithCRUD(app.Id, app.Name, exportFilePath, format, excludeSecrets)
+				err = exportAppWithCRUD(session, app.Id, app.Name, exportFilePath, format, excludeSecrets)
+			}
+			if errors.Is(err, utils.ErrFilteredOut) {
+				return
 			}
 			if err != nil {
-				utils.UpdateFailureSummary(utils.APPLICATIONS, app.Name)
-				utils.PrintLog(utils.LogLevelError, utils.APPLICATIONS, app.Name, fmt.Sprintf("Error while exporting: %s", err))
+				utils.UpdateFailureSummaryAndLog(utils.APPLICATIONS, app.Name, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 			} else {
 				utils.AddToIdentifierMap(utils.APPLICATIONS, app.Id, app.Name, utils.EXPORT)
-				utils.UpdateSuccessSummary(utils.APPLICATIONS, utils.EXPORT)
-				utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, app.Name, "Exported successfully")
+				utils.UpdateSuccessSummaryAndLog(utils.APPLICATIONS, app.Name, utils.EXPORT, "Exported successfully")
 			}
 		}
-	}
-
-	if !utils.IsResourceExcluded(utils.RESIDENT_APP, utils.TOOL_CONFIGS.ApplicationConfigs) {
-		if err := exportResidentApp(exportFilePath, format); err != nil {
-			utils.UpdateFailureSummary(utils.APPLICATIONS, utils.RESIDENT_APP)
-			utils.PrintLog(utils.LogLevelError, utils.APPLICATIONS, utils.RESIDENT_APP, fmt.Sprintf("Error while exporting resident application: %s", err))
-		} else {
-			utils.UpdateSuccessSummary(utils.APPLICATIONS, utils.EXPORT)
-			utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, utils.RESIDENT_APP, "Exported successfully")
+	})
+
+	if !utils.IsResourceExcluded(utils.RESIDENT_APP, session.ToolConfigs.ApplicationConfigs) {
+		utils.MarkResourceStart(utils.APPLICATIONS, utils.RESIDENT_APP)
+		if err := exportResidentApp(session, exportFilePath, format); err != nil && !errors.Is(err, utils.ErrFilteredOut) {
+			utils.UpdateFailureSummaryAndLog(utils.APPLICATIONS, utils.RESIDENT_APP, utils.EXPORT, err, fmt.Sprintf("Error while exporting resident application: %s", err))
+		} else if err == nil {
+			utils.UpdateSuccessSummaryAndLog(utils.APPLICATIONS, utils.RESIDENT_APP, utils.EXPORT, "Exported successfully")
 		}
 	}
 
-	if utils.IsResourceTypeExcluded(utils.ROLES) {
+	if session.IsResourceTypeExcluded(utils.ROLES) {
 		utils.PrintLog(utils.LogLevelWarn, utils.APPLICATIONS, "", "Roles are excluded from export. Export roles to propagate new application roles.")
 	}
 }
 
-func exportApp(appId string, outputDirPath string, format string, excludeSecrets bool) error {
+func exportApp(session *utils.Session, appId string, outputDirPath string, format string, excludeSecrets bool) error {
 
 	var fileType string
 	// TODO: Extend support for json and xml formats.
@@ -125,7 +128,7 @@ func exportApp(appId string, outputDirPath string, format string, excludeSecrets
 		fileType = utils.MEDIA_TYPE_YAML
 	}
 
-	resp, err := utils.SendExportRequest(appId, fileType, utils.APPLICATIONS, excludeSecrets)
+	resp, err := session.SendExportRequest(appId, fileType, utils.APPLICATIONS, excludeSecrets)
 	if err != nil {
 		return fmt.Errorf("error while exporting the application: %s", err)
 	}
@@ -147,25 +150,25 @@ func exportApp(appId string, outputDirPath string, format string, excludeSecrets
 	if excludeSecrets {
 		body = maskOAuthConsumerSecret(body)
 	}
-	appKeywordMapping := getAppKeywordMapping(fileInfo.ResourceName)
-	modifiedFile, err := utils.ProcessExportedContent(exportedFileName, body, appKeywordMapping, utils.APPLICATIONS)
+	appKeywordMapping := getAppKeywordMapping(session, fileInfo.ResourceName)
+	modifiedFile, err := session.ProcessExportedContent(exportedFileName, body, appKeywordMapping, utils.APPLICATIONS)
 	if err != nil {
-		return fmt.Errorf("error while processing exported data: %s", err)
+		return fmt.Errorf("error while processing exported data: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.APPLICATIONS)
 	if err != nil {
 		return fmt.Errorf("error when writing the exported content to file: %w", err)
 	}
-	if err := applicationAuthorizedApis.ExportAPIs(appId, fileInfo.ResourceName, outputDirPath, format); err != nil {
+	if err := applicationAuthorizedApis.ExportAPIs(session, appId, fileInfo.ResourceName, outputDirPath, format); err != nil {
 		return fmt.Errorf("error exporting authorized APIs: %w", err)
 	}
 	return nil
 }
 
-func exportAppWithCRUD(appId, appName, outputDirPath, formatString string, excludeSecrets bool) error {
+func exportAppWithCRUD(session *utils.Session, appId, appName, outputDirPath, formatString string, excludeSecrets bool) error {
 
-	appMap, err := getApp(appId, excludeSecrets)
+	appMap, err := getApp(session, appId, excludeSecrets)
 	if err != nil {
 		return fmt.Errorf("error while getting application: %w", err)
 	}
@@ -173,8 +176,8 @@ func exportAppWithCRUD(appId, appName, outputDirPath, formatString string, exclu
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, appName, format)
 
-	appKeywordMapping := getAppKeywordMapping(appName)
-	modifiedApp, err := utils.ProcessExportedData(appMap, exportedFileName, format, appKeywordMapping, utils.APPLICATIONS)
+	appKeywordMapping := getAppKeywordMapping(session, appName)
+	modifiedApp, err := session.ProcessExportedData(appMap, exportedFileName, format, appKeywordMapping, utils.APPLICATIONS)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -184,22 +187,22 @@ func exportAppWithCRUD(appId, appName, outputDirPath, formatString string, exclu
 		return fmt.Errorf("error while serializing application: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.APPLICATIONS)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
 
-	if err := applicationAuthorizedApis.ExportAPIs(appId, appName, outputDirPath, formatString); err != nil {
+	if err := applicationAuthorizedApis.ExportAPIs(session, appId, appName, outputDirPath, formatString); err != nil {
 		return fmt.Errorf("error exporting authorized APIs: %w", err)
 	}
 	return nil
 }
 
-func exportResidentApp(outputDirPath, formatString string) error {
+func exportResidentApp(session *utils.Session, outputDirPath, formatString string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, utils.RESIDENT_APP, "Exporting Resident application...")
 
-	appData, err := utils.GetResourceData(utils.APPLICATIONS, "resident")
+	appData, err := session.GetResourceData(utils.APPLICATIONS, "resident")
 	if err != nil {
 		return fmt.Errorf("error retrieving application: %w", err)
 	}
@@ -207,8 +210,8 @@ func exportResidentApp(outputDirPath, formatString string) error {
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, utils.RESIDENT_APP, format)
 
-	appKeywordMapping := getAppKeywordMapping(utils.RESIDENT_APP)
-	modifiedApp, err := utils.ProcessExportedData(appData, exportedFileName, format, appKeywordMapping, utils.APPLICATIONS)
+	appKeywordMapping := getAppKeywordMapping(session, utils.RESIDENT_APP)
+	modifiedApp, err := session.ProcessExportedData(appData, exportedFileName, format, appKeywordMapping, utils.APPLICATIONS)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -218,16 +221,16 @@ func exportResidentApp(outputDirPath, formatString string) error {
 		return fmt.Errorf("error while serializing application: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.APPLICATIONS)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
 	return nil
 }
 
-func getApp(appId string, excludeSecrets bool) (map[string]interface{}, error) {
+func getApp(session *utils.Session, appId string, excludeSecrets bool) (map[string]interface{}, error) {
 
-	body, err := utils.SendGetRequest(utils.APPLICATIONS, appId)
+	body, err := session.SendGetRequest(utils.APPLICATIONS, appId)
 	if err != nil {
 		return nil, fmt.Errorf("error while retrieving application: %w", err)
 	}
@@ -241,7 +244,7 @@ func getApp(appId string, excludeSecrets bool) (map[string]interface{}, error) {
 		return nil, fmt.Errorf("error unmarshalling application response to map: %w", err)
 	}
 
-	if err := processInboundProtocolConfigs(appId, appStruct.InboundProtocols, appMap, excludeSecrets); err != nil {
+	if err := processInboundProtocolConfigs(session, appId, appStruct.InboundProtocols, appMap, excludeSecrets); err != nil {
 		return nil, fmt.Errorf("error while processing inbound protocol configs: %w", err)
 	}
 
@@ -30,14 +30,14 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, "", "Importing applications...")
 	importFilePath := filepath.Join(inputDirPath, utils.APPLICATIONS.String())
-	exportAPIExists := utils.ExportAPIExists(utils.APPLICATIONS)
-	applicationAuthorizedApis.InitIsSupported()
+	exportAPIExists := session.ExportAPIExists(utils.APPLICATIONS)
+	applicationAuthorizedApis.InitIsSupported(session)
 
-	if utils.IsResourceTypeExcluded(utils.APPLICATIONS) {
+	if session.IsResourceTypeExcluded(utils.APPLICATIONS) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -46,20 +46,20 @@ func ImportAll(inputDirPath string) {
 	}
 
 	if applicationAuthorizedApis.IsSupported {
-		err := applicationAuthorizedApis.GetAPIResources()
+		err := applicationAuthorizedApis.GetAPIResources(session)
 		if err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.APPLICATIONS, "", fmt.Sprintf("Error retrieving API resource list: %s", err))
 			utils.MarkResTypeFailure(utils.APPLICATIONS)
 			return
 		}
 	}
-	if err := InitDeployedRoleIds(); err != nil {
+	if err := InitDeployedRoleIds(session); err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.APPLICATIONS, "", fmt.Sprintf("Error retrieving roles list: %s", err))
 		utils.MarkResTypeFailure(utils.APPLICATIONS)
 		return
 	}
 
-	deployedApps, err := getAppList()
+	deployedApps, err := getAppList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.APPLICATIONS, "", fmt.Sprintf("Error retrieving applications list: %s", err))
 		utils.MarkResTypeFailure(utils.APPLICATIONS)
@@ -71,37 +71,39 @@ func ImportAll(inputDirPath string) {
 		utils.MarkResTypeFailure(utils.APPLICATIONS)
 		return
 	}
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedApps(files, deployedApps)
+	if session.IsDeleteAllowed(utils.APPLICATIONS) {
+		removeDeletedDeployedApps(session, files, deployedApps)
 	}
 
-	for _, file := range files {
+	session.ProcessConcurrently(len(files), func(i int) {
+		file := files[i]
 		if file.IsDir() {
-			continue
+			return
 		}
 		appFilePath := filepath.Join(importFilePath, file.Name())
 		fileInfo := utils.GetFileInfo(appFilePath)
 		appName := fileInfo.ResourceName
 
-		if !utils.IsResourceExcluded(appName, utils.TOOL_CONFIGS.ApplicationConfigs) {
+		if !utils.IsResourceExcluded(appName, session.ToolConfigs.ApplicationConfigs) && session.MatchesFileFilter(utils.APPLICATIONS, appFilePath, getAppKeywordMapping(session, appName), utils.IMPORT) {
 			appId := getAppId(appName, deployedApps)
-			err := importApp(appId, appName, appFilePath, exportAPIExists)
+			utils.MarkResourceStart(utils.APPLICATIONS, appName)
+			err := importApp(session, appId, appName, appFilePath, exportAPIExists)
 			if err != nil {
-				utils.PrintLog(utils.LogLevelError, utils.APPLICATIONS, appName, fmt.Sprintf("Error importing application: %s", err))
-				utils.UpdateFailureSummary(utils.APPLICATIONS, appName)
+				utils.UpdateFailureSummaryAndLog(utils.APPLICATIONS, appName, utils.IMPORT, err, fmt.Sprintf("Error importing application: %s", err))
 			}
 		}
-	}
+	})
 
-	if utils.IsResourceTypeExcluded(utils.ROLES) {
+	if session.IsResourceTypeExcluded(utils.ROLES) {
 		utils.PrintLog(utils.LogLevelWarn, utils.APPLICATIONS, "", "Roles are excluded from import. Import Roles to propagate new application roles.")
 	}
 }
 
-func importApp(appId, appName, importFilePath string, exportAPIExists bool) error {
+func importApp(session *utils.Session, appId, appName, importFilePath string, exportAPIExists bool) error {
 
 	if appName == utils.CONSOLE || appName == utils.MY_ACCOUNT || appName == utils.CARBON_SP {
 		utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, appName, "System application. Skipping import.")
+		utils.AddToPlan(utils.APPLICATIONS, appName, utils.PLAN_NO_OP, "System application")
 		return nil
 	}
 
@@ -110,23 +112,38 @@ func importApp(appId, appName, importFilePath string, exportAPIExists bool) erro
 		return fmt.Errorf("error when reading the file for application: %s", err)
 	}
 
-	appKeywordMapping := getAppKeywordMapping(appName)
-	fileDataWithReplacedKeywords := utils.ReplaceKeywords(string(fileBytes), appKeywordMapping)
+	appKeywordMapping := getAppKeywordMapping(session, appName)
+	fileDataWithReplacedKeywords := session.ReplaceKeywords(string(fileBytes), appKeywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.APPLICATIONS, importFilePath, fileDataWithReplacedKeywords, appKeywordMapping); err != nil {
+		return err
+	}
 	modifiedFileData := utils.RemoveSecretMasks(fileDataWithReplacedKeywords)
+	modifiedFileData, err = session.ApplyOverlay(utils.APPLICATIONS, importFilePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
 
 	format, err := utils.FormatFromExtension(filepath.Ext(importFilePath))
 	if err != nil {
 		return fmt.Errorf("unsupported file format for application: %w", err)
 	}
 
+	if utils.DRY_RUN {
+		if appId != "" {
+			utils.AddToIdentifierMap(utils.APPLICATIONS, appId, appName, utils.IMPORT)
+		}
+		utils.AddToPlan(utils.APPLICATIONS, appName, utils.GetImportAction(appId != "" || appName == utils.RESIDENT_APP), "")
+		return nil
+	}
+
 	var finalAppId string
 
 	if exportAPIExists && appName != utils.RESIDENT_APP {
 		modifiedFileData = string(removeAssociatedRoles([]byte(modifiedFileData)))
 		if appId == "" {
-			finalAppId, err = importApplication(appName, importFilePath, modifiedFileData, format)
+			finalAppId, err = importApplication(session, appName, importFilePath, modifiedFileData, format)
 		} else {
-			err = updateApplication(appId, appName, importFilePath, modifiedFileData, format)
+			err = updateApplication(session, appId, appName, importFilePath, modifiedFileData, format)
 			finalAppId = appId
 		}
 	} else {
@@ -137,14 +154,14 @@ func importApp(appId, appName, importFilePath string, exportAPIExists bool) erro
 		}
 
 		if appName == utils.RESIDENT_APP {
-			return updateResidentApp(appMap)
+			return updateResidentApp(session, appMap)
 		}
 
 		delete(appMap, "id")
 		if appId == "" {
-			finalAppId, err = importAppWithCRUD(appName, appMap)
+			finalAppId, err = importAppWithCRUD(session, appName, appMap)
 		} else {
-			err = updateAppWithCRUD(appId, appName, appMap)
+			err = updateAppWithCRUD(session, appId, appName, appMap)
 			finalAppId = appId
 		}
 	}
@@ -152,16 +169,16 @@ func importApp(appId, appName, importFilePath string, exportAPIExists bool) erro
 	if err != nil {
 		return err
 	}
-	if err := applicationAuthorizedApis.ImportAPIs(finalAppId, appName, filepath.Dir(importFilePath)); err != nil {
+	if err := applicationAuthorizedApis.ImportAPIs(session, finalAppId, appName, filepath.Dir(importFilePath)); err != nil {
 		return fmt.Errorf("error importing authorized APIs: %w", err)
 	}
 	return nil
 }
 
-func importApplication(appName, importFilePath, modifiedFileData string, format utils.Format) (appId string, err error) {
+func importApplication(session *utils.Session, appName, importFilePath, modifiedFileData string, format utils.Format) (appId string, err error) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, appName, "Creating new application")
-	resp, err := utils.SendImportRequest(importFilePath, modifiedFileData, utils.APPLICATIONS)
+	resp, err := session.SendImportRequest(importFilePath, modifiedFileData, utils.APPLICATIONS)
 	if err != nil {
 		return "", fmt.Errorf("error when importing application: %s", err)
 	}
@@ -183,32 +200,30 @@ func importApplication(appName, importFilePath, modifiedFileData string, format
 	appId = path.Base(location)
 	utils.AddToIdentifierMap(utils.APPLICATIONS, appId, appName, utils.IMPORT)
 
-	utils.UpdateSuccessSummary(utils.APPLICATIONS, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, appName, "Imported successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.APPLICATIONS, appName, utils.IMPORT, "Imported successfully")
 	return appId, nil
 }
 
-func updateApplication(appId, appName, importFilePath, modifiedFileData string, format utils.Format) error {
+func updateApplication(session *utils.Session, appId, appName, importFilePath, modifiedFileData string, format utils.Format) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, appName, "Updating application")
-	fileData, err := injectDeployedOAuthCredentials(appId, modifiedFileData, format)
+	fileData, err := injectDeployedOAuthCredentials(session, appId, modifiedFileData, format)
 	if err != nil {
 		return fmt.Errorf("error injecting deployed OAuth credentials: %w", err)
 	}
 
-	err = utils.SendUpdateRequest(appId, importFilePath, fileData, utils.APPLICATIONS)
+	err = session.SendUpdateRequest(appId, importFilePath, fileData, utils.APPLICATIONS)
 	if err != nil {
 		return fmt.Errorf("error when updating application: %s", err)
 	}
 
 	utils.AddToIdentifierMap(utils.APPLICATIONS, appId, appName, utils.IMPORT)
 
-	utils.UpdateSuccessSummary(utils.APPLICATIONS, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, appName, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.APPLICATIONS, appName, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func importAppWithCRUD(appName string, appMap map[string]interface{}) (string, error) {
+func importAppWithCRUD(session *utils.Session, appName string, appMap map[string]interface{}) (string, error) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, appName, "Creating new application")
 
@@ -221,7 +236,7 @@ func importAppWithCRUD(appName string, appMap map[string]interface{}) (string, e
 	if err := removeAdditionalSpProperties(appMap); err != nil {
 		return "", fmt.Errorf("error removing additional sp properties: %w", err)
 	}
-	if err := removeRoleClaimUri(appMap); err != nil {
+	if err := removeRoleClaimUri(session, appMap); err != nil {
 		return "", fmt.Errorf("error clearing role claim uri: %w", err)
 	}
 	if err := removeAssociatedApplicationRoles(appMap); err != nil {
@@ -233,7 +248,7 @@ func importAppWithCRUD(appName string, appMap map[string]interface{}) (string, e
 		return "", fmt.Errorf("error marshalling application: %w", err)
 	}
 
-	resp, err := utils.SendPostRequest(utils.APPLICATIONS, body)
+	resp, err := session.SendPostRequest(utils.APPLICATIONS, body)
 	if err != nil {
 		return "", fmt.Errorf("error creating application: %w", err)
 	}
@@ -250,12 +265,11 @@ func importAppWithCRUD(appName string, appMap map[string]interface{}) (string, e
 	appId := path.Base(location)
 	utils.AddToIdentifierMap(utils.APPLICATIONS, appId, appName, utils.IMPORT)
 
-	utils.UpdateSuccessSummary(utils.APPLICATIONS, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, appName, "Imported successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.APPLICATIONS, appName, utils.IMPORT, "Imported successfully")
 	return appId, nil
 }
 
-func updateAppWithCRUD(appId, appName string, appMap map[string]interface{}) error {
+func updateAppWithCRUD(session *utils.Session, appId, appName string, appMap map[string]interface{}) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, appName, "Updating application")
 
@@ -271,31 +285,30 @@ func updateAppWithCRUD(appId, appName string, appMap map[string]interface{}) err
 	if err := filterAssociatedApplicationRoles(appMap); err != nil {
 		return fmt.Errorf("error filtering associated application roles: %w", err)
 	}
-	if err := patchApplication(appId, appMap); err != nil {
+	if err := patchApplication(session, appId, appMap); err != nil {
 		return fmt.Errorf("error updating application: %w", err)
 	}
 
-	if utils.TOOL_CONFIGS.AllowDelete {
-		deployedRefs, err := getDeployedInboundProtocols(appId)
+	if session.ToolConfigs.AllowDelete {
+		deployedRefs, err := getDeployedInboundProtocols(session, appId)
 		if err != nil {
 			return fmt.Errorf("error retrieving deployed inbound protocols: %w", err)
 		}
-		if err := removeDeletedInboundProtocols(appId, deployedRefs, localProtocols); err != nil {
+		if err := removeDeletedInboundProtocols(session, appId, deployedRefs, localProtocols); err != nil {
 			return fmt.Errorf("error removing deleted inbound protocols: %w", err)
 		}
 	}
-	if err := updateInboundProtocols(appId, localProtocols); err != nil {
+	if err := updateInboundProtocols(session, appId, localProtocols); err != nil {
 		return fmt.Errorf("error updating inbound protocols: %w", err)
 	}
 
 	utils.AddToIdentifierMap(utils.APPLICATIONS, appId, appName, utils.IMPORT)
 
-	utils.UpdateSuccessSummary(utils.APPLICATIONS, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, appName, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.APPLICATIONS, appName, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func updateResidentApp(appMap map[string]interface{}) error {
+func updateResidentApp(session *utils.Session, appMap map[string]interface{}) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, utils.RESIDENT_APP, "Updating Resident application")
 
@@ -313,25 +326,24 @@ func updateResidentApp(appMap map[string]interface{}) error {
 	if err != nil {
 		return fmt.Errorf("error marshalling provisioning configurations: %w", err)
 	}
-	resp, err := utils.SendPutRequest(utils.APPLICATIONS, "resident", reqBody)
+	resp, err := session.SendPutRequest(utils.APPLICATIONS, "resident", reqBody)
 	if err != nil {
 		return fmt.Errorf("error updating application: %w", err)
 	}
 	resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.APPLICATIONS, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, utils.RESIDENT_APP, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.APPLICATIONS, utils.RESIDENT_APP, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func patchApplication(appId string, appMap map[string]interface{}) error {
+func patchApplication(session *utils.Session, appId string, appMap map[string]interface{}) error {
 
 	delete(appMap, "inboundProtocolConfiguration")
 	delete(appMap, "isManagementApp")
 	if err := removeAdditionalSpProperties(appMap); err != nil {
 		return fmt.Errorf("error removing additional sp properties: %w", err)
 	}
-	if err := removeRoleClaimUri(appMap); err != nil {
+	if err := removeRoleClaimUri(session, appMap); err != nil {
 		return fmt.Errorf("error clearing role claim uri: %w", err)
 	}
 
@@ -339,7 +351,7 @@ func patchApplication(appId string, appMap map[string]interface{}) error {
 	if err != nil {
 		return fmt.Errorf("error marshalling application: %w", err)
 	}
-	resp, err := utils.SendPatchRequest(utils.APPLICATIONS, appId, body)
+	resp, err := session.SendPatchRequest(utils.APPLICATIONS, appId, body)
 	if err != nil {
 		return err
 	}
@@ -348,10 +360,10 @@ func patchApplication(appId string, appMap map[string]interface{}) error {
 	return nil
 }
 
-func updateInboundProtocols(appId string, localProtocols []map[string]interface{}) error {
+func updateInboundProtocols(session *utils.Session, appId string, localProtocols []map[string]interface{}) error {
 
 	for _, protocolMap := range localProtocols {
-		protocolPath, err := processInboundProtocolForUpdate(appId, protocolMap)
+		protocolPath, err := processInboundProtocolForUpdate(session, appId, protocolMap)
 		if err != nil {
 			return fmt.Errorf("error processing protocol: %w", err)
 		}
@@ -360,7 +372,7 @@ func updateInboundProtocols(appId string, localProtocols []map[string]interface{
 			return fmt.Errorf("error marshalling protocol %s: %w", protocolPath, err)
 		}
 
-		resp, err := utils.SendPutRequest(utils.APPLICATIONS, appId+"/inbound-protocols/"+protocolPath, protocolBody)
+		resp, err := session.SendPutRequest(utils.APPLICATIONS, appId+"/inbound-protocols/"+protocolPath, protocolBody)
 		if err != nil {
 			return fmt.Errorf("error updating protocol %s: %w", protocolPath, err)
 		}
@@ -369,7 +381,7 @@ func updateInboundProtocols(appId string, localProtocols []map[string]interface{
 	return nil
 }
 
-func removeDeletedDeployedApps(localFiles []os.FileInfo, deployedApps []Application) {
+func removeDeletedDeployedApps(session *utils.Session, localFiles []os.FileInfo, deployedApps []Application) {
 
 	localAppNames := make(map[string]struct{})
 	for _, file := range localFiles {
@@ -381,12 +393,12 @@ func removeDeletedDeployedApps(localFiles []os.FileInfo, deployedApps []Applicat
 			continue
 		}
 
-		if utils.IsResourceExcluded(app.Name, utils.TOOL_CONFIGS.ApplicationConfigs) ||
+		if utils.IsResourceExcluded(app.Name, session.ToolConfigs.ApplicationConfigs) ||
 			app.Name == utils.CONSOLE || app.Name == utils.MY_ACCOUNT || app.Name == utils.CARBON_SP {
 			utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, app.Name, "Excluded from deletion.")
 			continue
 		}
-		if isToolMgt, err := isToolMgtApp(app.Id); err != nil {
+		if isToolMgt, err := isToolMgtApp(session, app.Id); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.APPLICATIONS, app.Name, fmt.Sprintf("Error checking if application is the tool management app: %s", err.Error()))
 			utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, app.Name, "Excluded from deletion.")
 			continue
@@ -395,17 +407,21 @@ func removeDeletedDeployedApps(localFiles []os.FileInfo, deployedApps []Applicat
 			continue
 		}
 
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.APPLICATIONS, app.Name, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, app.Name, "Not found locally. Deleting app.")
-		err := utils.SendDeleteRequest(app.Id, utils.APPLICATIONS)
+		err := session.SendDeleteRequest(app.Id, utils.APPLICATIONS)
 		if err != nil {
-			utils.UpdateFailureSummary(utils.APPLICATIONS, app.Name)
-			utils.PrintLog(utils.LogLevelError, utils.APPLICATIONS, app.Name, fmt.Sprintf("Error deleting application: %s", err))
+			utils.UpdateFailureSummaryAndLog(utils.APPLICATIONS, app.Name, utils.DELETE, err, fmt.Sprintf("Error deleting application: %s", err))
+		} else {
+			utils.UpdateSuccessSummary(utils.APPLICATIONS, app.Name, utils.DELETE)
 		}
-		utils.UpdateSuccessSummary(utils.APPLICATIONS, utils.DELETE)
 	}
 }
 
-func removeDeletedInboundProtocols(appId string, deployedRefs []inboundProtocolRef, localProtocols []map[string]interface{}) error {
+func removeDeletedInboundProtocols(session *utils.Session, appId string, deployedRefs []inboundProtocolRef, localProtocols []map[string]interface{}) error {
 
 	localTypes := make(map[string]struct{})
 	for _, p := range localProtocols {
@@ -424,7 +440,7 @@ func removeDeletedInboundProtocols(appId string, deployedRefs []inboundProtocolR
 		if _, unsupported := unsupportedInboundProtocols[protocolPath]; unsupported {
 			continue
 		}
-		if err := utils.SendDeleteRequest(appId+"/inbound-protocols/"+protocolPath, utils.APPLICATIONS); err != nil {
+		if err := session.SendDeleteRequest(appId+"/inbound-protocols/"+protocolPath, utils.APPLICATIONS); err != nil {
 			return fmt.Errorf("error deleting inbound protocol %s: %w", protocolPath, err)
 		}
 	}
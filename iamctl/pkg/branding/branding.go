@@ -26,30 +26,30 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.BRANDING, "", "Exporting branding...")
 	exportFilePath = filepath.Join(exportFilePath, utils.BRANDING.String())
 
 	utils.MarkResTypeStart(utils.BRANDING_PREFERENCES)
-	brandingPreferences.ExportAll(exportFilePath, format)
+	brandingPreferences.ExportAll(session, exportFilePath, format)
 	utils.MarkResTypeEnd(utils.BRANDING_PREFERENCES)
 
 	utils.MarkResTypeStart(utils.CUSTOM_TEXTS)
-	customTexts.ExportAll(exportFilePath, format)
+	customTexts.ExportAll(session, exportFilePath, format)
 	utils.MarkResTypeEnd(utils.CUSTOM_TEXTS)
 }
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.BRANDING, "", "Importing branding...")
 	inputDirPath = filepath.Join(inputDirPath, utils.BRANDING.String())
 
 	utils.MarkResTypeStart(utils.BRANDING_PREFERENCES)
-	brandingPreferences.ImportAll(inputDirPath)
+	brandingPreferences.ImportAll(session, inputDirPath)
 	utils.MarkResTypeEnd(utils.BRANDING_PREFERENCES)
 
 	utils.MarkResTypeStart(utils.CUSTOM_TEXTS)
-	customTexts.ImportAll(inputDirPath)
+	customTexts.ImportAll(session, inputDirPath)
 	utils.MarkResTypeEnd(utils.CUSTOM_TEXTS)
 }
@@ -27,18 +27,18 @@ import (
 
 const resourceFileName = "brandingPreferences"
 
-func getBrandingPreferencesKeywordMapping() map[string]interface{} {
+func getBrandingPreferencesKeywordMapping(session *utils.Session) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.BrandingPreferenceConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(resourceFileName,
-			utils.KEYWORD_CONFIGS.BrandingPreferenceConfigs)
+	if session.KeywordConfigs.BrandingPreferenceConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(resourceFileName,
+			session.KeywordConfigs.BrandingPreferenceConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
-func isBrandingPreferencesExist() (bool, error) {
+func isBrandingPreferencesExist(session *utils.Session) (bool, error) {
 
-	_, err := utils.SendGetRequest(utils.BRANDING_PREFERENCES, "")
+	_, err := session.SendGetRequest(utils.BRANDING_PREFERENCES, "")
 
 	if utils.IsResourceNotFound(err) {
 		return false, nil
@@ -20,58 +20,56 @@ package brandingPreferences
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(parentDir string, formatString string) {
+func ExportAll(session *utils.Session, parentDir string, formatString string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.BRANDING_PREFERENCES, "", "Exporting branding preferences...")
 	exportFilePath := filepath.Join(parentDir, utils.BRANDING_PREFERENCES.String())
 
-	if utils.ShouldSkip(utils.BRANDING_PREFERENCES) {
+	if session.ShouldSkip(utils.BRANDING_PREFERENCES) {
 		return
 	}
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.BRANDING_PREFERENCES, "", fmt.Sprintf("Error creating branding preferences directory: %s", err))
 			utils.MarkResTypeFailure(utils.BRANDING_PREFERENCES)
 			return
 		}
 	}
 
-	err := exportBrandingPreferences(exportFilePath, formatString)
+	utils.MarkResourceStart(utils.BRANDING_PREFERENCES, resourceFileName)
+	err := exportBrandingPreferences(session, exportFilePath, formatString)
 	if err != nil {
 		if utils.IsResourceNotFound(err) {
 			utils.PrintLog(utils.LogLevelInfo, utils.BRANDING_PREFERENCES, "", "No branding preferences configured.")
-			if utils.TOOL_CONFIGS.AllowDelete {
+			if session.ToolConfigs.AllowDelete {
 				utils.RemoveDeletedLocalResources(exportFilePath, []string{})
 			}
 			return
 		}
-		utils.UpdateFailureSummary(utils.BRANDING_PREFERENCES, resourceFileName)
-		utils.PrintLog(utils.LogLevelError, utils.BRANDING_PREFERENCES, "", fmt.Sprintf("Error while exporting branding preferences: %s", err))
+		utils.UpdateFailureSummaryAndLog(utils.BRANDING_PREFERENCES, resourceFileName, utils.EXPORT, err, fmt.Sprintf("Error while exporting branding preferences: %s", err))
 	} else {
-		utils.UpdateSuccessSummary(utils.BRANDING_PREFERENCES, utils.EXPORT)
-		utils.PrintLog(utils.LogLevelInfo, utils.BRANDING_PREFERENCES, "", "Exported successfully")
+		utils.UpdateSuccessSummaryAndLog(utils.BRANDING_PREFERENCES, resourceFileName, utils.EXPORT, "Exported successfully")
 	}
 }
 
-func exportBrandingPreferences(outputDirPath string, formatString string) error {
+func exportBrandingPreferences(session *utils.Session, outputDirPath string, formatString string) error {
 
-	data, err := utils.GetResourceData(utils.BRANDING_PREFERENCES, "")
+	data, err := session.GetResourceData(utils.BRANDING_PREFERENCES, "")
 	if err != nil {
 		return err
 	}
 
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, resourceFileName, format)
-	keywordMapping := getBrandingPreferencesKeywordMapping()
+	keywordMapping := getBrandingPreferencesKeywordMapping(session)
 
-	modifiedData, err := utils.ProcessExportedData(data, exportedFileName, format, keywordMapping, utils.BRANDING_PREFERENCES)
+	modifiedData, err := session.ProcessExportedData(data, exportedFileName, format, keywordMapping, utils.BRANDING_PREFERENCES)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -81,7 +79,7 @@ func exportBrandingPreferences(outputDirPath string, formatString string) error
 		return fmt.Errorf("error while serializing exported content: %w", err)
 	}
 
-	if err := ioutil.WriteFile(exportedFileName, modifiedFile, 0644); err != nil {
+	if err := utils.WriteExportedFile(exportedFileName, modifiedFile, utils.BRANDING_PREFERENCES); err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
 
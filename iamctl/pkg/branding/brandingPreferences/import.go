@@ -27,12 +27,12 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(parentDir string) {
+func ImportAll(session *utils.Session, parentDir string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.BRANDING_PREFERENCES, "", "Importing branding preferences...")
 	importFilePath := filepath.Join(parentDir, utils.BRANDING_PREFERENCES.String())
 
-	if utils.ShouldSkip(utils.BRANDING_PREFERENCES) {
+	if session.ShouldSkip(utils.BRANDING_PREFERENCES) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -40,7 +40,7 @@ func ImportAll(parentDir string) {
 		return
 	}
 
-	isDeployed, err := isBrandingPreferencesExist()
+	isDeployed, err := isBrandingPreferencesExist(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.BRANDING_PREFERENCES, "", fmt.Sprintf("Error retrieving deployed branding preferences: %s", err))
 		return
@@ -52,20 +52,20 @@ func ImportAll(parentDir string) {
 	}
 
 	if !fileExists {
-		if utils.TOOL_CONFIGS.AllowDelete && isDeployed {
-			removeDeletedDeployedBrandingPreferences()
+		if session.ToolConfigs.AllowDelete && isDeployed {
+			removeDeletedDeployedBrandingPreferences(session)
 		}
 		return
 	}
 
-	err = importBrandingPreferences(filePath, isDeployed)
+	utils.MarkResourceStart(utils.BRANDING_PREFERENCES, resourceFileName)
+	err = importBrandingPreferences(session, filePath, isDeployed)
 	if err != nil {
-		utils.UpdateFailureSummary(utils.BRANDING_PREFERENCES, resourceFileName)
-		utils.PrintLog(utils.LogLevelError, utils.BRANDING_PREFERENCES, "", fmt.Sprintf("Error while importing branding preferences: %s", err))
+		utils.UpdateFailureSummaryAndLog(utils.BRANDING_PREFERENCES, resourceFileName, utils.IMPORT, err, fmt.Sprintf("Error while importing branding preferences: %s", err))
 	}
 }
 
-func importBrandingPreferences(filePath string, exists bool) error {
+func importBrandingPreferences(session *utils.Session, filePath string, exists bool) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(filePath))
 	if err != nil {
@@ -76,59 +76,70 @@ func importBrandingPreferences(filePath string, exists bool) error {
 		return fmt.Errorf("error when reading branding preferences file: %w", err)
 	}
 
-	keywordMapping := getBrandingPreferencesKeywordMapping()
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	keywordMapping := getBrandingPreferencesKeywordMapping(session)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.BRANDING_PREFERENCES, filePath, modifiedFileData, keywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.BRANDING_PREFERENCES, filePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
 
 	jsonBody, err := utils.PrepareJSONRequestBody([]byte(modifiedFileData), format, utils.BRANDING_PREFERENCES)
 	if err != nil {
 		return err
 	}
 
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.BRANDING_PREFERENCES, resourceFileName, utils.GetImportAction(exists), "")
+		return nil
+	}
 	if !exists {
-		return createBrandingPreferences(jsonBody)
+		return createBrandingPreferences(session, jsonBody)
 	}
-	return updateBrandingPreferences(jsonBody)
+	return updateBrandingPreferences(session, jsonBody)
 }
 
-func createBrandingPreferences(jsonBody []byte) error {
+func createBrandingPreferences(session *utils.Session, jsonBody []byte) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.BRANDING_PREFERENCES, "", "Creating branding preferences")
 
-	resp, err := utils.SendPostRequest(utils.BRANDING_PREFERENCES, jsonBody)
+	resp, err := session.SendPostRequest(utils.BRANDING_PREFERENCES, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when creating branding preferences: %w", err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.BRANDING_PREFERENCES, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.BRANDING_PREFERENCES, "", "Created successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.BRANDING_PREFERENCES, resourceFileName, utils.IMPORT, "Created successfully")
 	return nil
 }
 
-func updateBrandingPreferences(jsonBody []byte) error {
+func updateBrandingPreferences(session *utils.Session, jsonBody []byte) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.BRANDING_PREFERENCES, "", "Updating branding preferences")
 
-	resp, err := utils.SendPutRequest(utils.BRANDING_PREFERENCES, "", jsonBody)
+	resp, err := session.SendPutRequest(utils.BRANDING_PREFERENCES, "", jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when updating branding preferences: %w", err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.BRANDING_PREFERENCES, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.BRANDING_PREFERENCES, "", "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.BRANDING_PREFERENCES, resourceFileName, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func removeDeletedDeployedBrandingPreferences() {
+func removeDeletedDeployedBrandingPreferences(session *utils.Session) {
 
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.BRANDING_PREFERENCES, resourceFileName, utils.PLAN_DELETE, "Not found locally")
+		return
+	}
 	utils.PrintLog(utils.LogLevelInfo, utils.BRANDING_PREFERENCES, "", "Not found locally. Deleting preferences.")
 
-	if err := utils.SendDeleteRequest("", utils.BRANDING_PREFERENCES); err != nil {
-		utils.UpdateFailureSummary(utils.BRANDING_PREFERENCES, resourceFileName)
-		utils.PrintLog(utils.LogLevelError, utils.BRANDING_PREFERENCES, "", fmt.Sprintf("Error while deleting branding preferences: %s", err))
+	if err := session.SendDeleteRequest("", utils.BRANDING_PREFERENCES); err != nil {
+		utils.UpdateFailureSummaryAndLog(utils.BRANDING_PREFERENCES, resourceFileName, utils.DELETE, err, fmt.Sprintf("Error while deleting branding preferences: %s", err))
 	} else {
-		utils.UpdateSuccessSummary(utils.BRANDING_PREFERENCES, utils.DELETE)
-		utils.PrintLog(utils.LogLevelInfo, utils.BRANDING_PREFERENCES, "", "Deleted successfully")
+		utils.UpdateSuccessSummaryAndLog(utils.BRANDING_PREFERENCES, resourceFileName, utils.DELETE, "Deleted successfully")
 	}
 }
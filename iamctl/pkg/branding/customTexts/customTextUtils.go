@@ -30,13 +30,13 @@ const dotPlaceholder = "__DOT__"
 var ScreenList = []string{"common", "login", "sms-otp", "email-otp", "totp", "push-auth", "sign-up", "password-recovery", "password-reset", "password-reset-success", "email-link-expiry", "username-recovery-claim", "username-recovery-channel-selection", "username-recovery-success"}
 var LocaleList = []string{"en-US", "de-DE", "es-ES", "fr-FR", "ja-JP", "pt-BR", "pt-PT", "zh-CN"}
 
-func getCustomTextList() (map[string]map[string]struct{}, error) {
+func getCustomTextList(session *utils.Session) (map[string]map[string]struct{}, error) {
 
 	deployedTexts := make(map[string]map[string]struct{})
 
 	for _, screen := range ScreenList {
 		for _, locale := range LocaleList {
-			_, err := getCustomText(screen, locale)
+			_, err := getCustomText(session, screen, locale)
 			if err == nil {
 				if deployedTexts[screen] == nil {
 					deployedTexts[screen] = make(map[string]struct{})
@@ -50,27 +50,27 @@ func getCustomTextList() (map[string]map[string]struct{}, error) {
 	return deployedTexts, nil
 }
 
-func getCustomTextsKeywordMapping(screen string) map[string]interface{} {
+func getCustomTextsKeywordMapping(session *utils.Session, screen string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.CustomTextConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(screen, utils.KEYWORD_CONFIGS.CustomTextConfigs)
+	if session.KeywordConfigs.CustomTextConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(screen, session.KeywordConfigs.CustomTextConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
-func getCustomText(screen, locale string) (interface{}, error) {
+func getCustomText(session *utils.Session, screen, locale string) (interface{}, error) {
 
-	return utils.GetResourceData(utils.CUSTOM_TEXTS, "",
+	return session.GetResourceData(utils.CUSTOM_TEXTS, "",
 		utils.WithQueryParams(map[string]string{"screen": screen, "locale": locale}))
 }
 
-func deleteCustomText(screen, locale string) error {
+func deleteCustomText(session *utils.Session, screen, locale string) error {
 
-	return utils.SendDeleteRequest("", utils.CUSTOM_TEXTS,
+	return session.SendDeleteRequest("", utils.CUSTOM_TEXTS,
 		utils.WithQueryParams(map[string]string{"screen": screen, "locale": locale}))
 }
 
-func preprocessCustomTextKeys(data interface{}) (interface{}, error) {
+func preprocessCustomTextKeys(session *utils.Session, data interface{}) (interface{}, error) {
 
 	data = utils.ConvertToStringKeyMap(data)
 
@@ -20,23 +20,23 @@ package customTexts
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(parentDir string, formatString string) {
+func ExportAll(session *utils.Session, parentDir string, formatString string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CUSTOM_TEXTS, "", "Exporting custom texts...")
 	exportFilePath := filepath.Join(parentDir, utils.CUSTOM_TEXTS.String())
 
-	if utils.ShouldSkip(utils.CUSTOM_TEXTS) {
+	if session.ShouldSkip(utils.CUSTOM_TEXTS) {
 		return
 	}
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.CUSTOM_TEXTS, "", fmt.Sprintf("Error creating custom texts directory: %s", err))
 			utils.MarkResTypeFailure(utils.CUSTOM_TEXTS)
 			return
@@ -44,43 +44,46 @@ func ExportAll(parentDir string, formatString string) {
 	}
 
 	var screensWithLocales []string
-	for _, screen := range ScreenList {
-		if utils.IsResourceExcluded(screen, utils.TOOL_CONFIGS.CustomTextConfigs) {
-			continue
+	var screensLock sync.Mutex
+	session.ProcessConcurrently(len(ScreenList), func(i int) {
+		screen := ScreenList[i]
+		if utils.IsResourceExcluded(screen, session.ToolConfigs.CustomTextConfigs) {
+			return
 		}
 		utils.PrintLog(utils.LogLevelInfo, utils.CUSTOM_TEXTS, screen, "Exporting")
-		hadLocales, err := exportCustomTextScreen(screen, exportFilePath, formatString)
+		utils.MarkResourceStart(utils.CUSTOM_TEXTS, screen)
+		hadLocales, err := exportCustomTextScreen(session, screen, exportFilePath, formatString)
 
 		if err != nil {
-			utils.UpdateFailureSummary(utils.CUSTOM_TEXTS, screen)
-			utils.PrintLog(utils.LogLevelError, utils.CUSTOM_TEXTS, screen, fmt.Sprintf("Error while exporting: %s", err))
+			utils.UpdateFailureSummaryAndLog(utils.CUSTOM_TEXTS, screen, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 		} else {
 			if hadLocales {
+				screensLock.Lock()
 				screensWithLocales = append(screensWithLocales, screen)
-				utils.UpdateSuccessSummary(utils.CUSTOM_TEXTS, utils.EXPORT)
-				utils.PrintLog(utils.LogLevelInfo, utils.CUSTOM_TEXTS, screen, "Exported successfully")
+				screensLock.Unlock()
+				utils.UpdateSuccessSummaryAndLog(utils.CUSTOM_TEXTS, screen, utils.EXPORT, "Exported successfully")
 			} else {
 				utils.PrintLog(utils.LogLevelInfo, utils.CUSTOM_TEXTS, screen, "No custom text to export")
 			}
 		}
-	}
+	})
 
-	if utils.TOOL_CONFIGS.AllowDelete {
+	if session.ToolConfigs.AllowDelete {
 		utils.RemoveDeletedLocalDirectories(exportFilePath, screensWithLocales)
 	}
 }
 
-func exportCustomTextScreen(screen, exportFilePath, formatString string) (hadLocales bool, err error) {
+func exportCustomTextScreen(session *utils.Session, screen, exportFilePath, formatString string) (hadLocales bool, err error) {
 
 	screenDir := filepath.Join(exportFilePath, screen)
 	screenDirCreated := false
 
 	format := utils.FormatFromString(formatString)
-	keywordMapping := getCustomTextsKeywordMapping(screen)
+	keywordMapping := getCustomTextsKeywordMapping(session, screen)
 
 	var exportedLocales []string
 	for _, locale := range LocaleList {
-		data, err := getCustomText(screen, locale)
+		data, err := getCustomText(session, screen, locale)
 		if err != nil {
 			if utils.IsResourceNotFound(err) {
 				continue
@@ -89,13 +92,13 @@ func exportCustomTextScreen(screen, exportFilePath, formatString string) (hadLoc
 		}
 
 		if !screenDirCreated {
-			if err := os.MkdirAll(screenDir, 0700); err != nil {
+			if err := utils.CreateExportDir(screenDir); err != nil {
 				return false, fmt.Errorf("error creating directory for screen: %w", err)
 			}
 			screenDirCreated = true
 		}
 
-		err = exportCustomTextLocale(data, screenDir, locale, format, keywordMapping)
+		err = exportCustomTextLocale(session, data, screenDir, locale, format, keywordMapping)
 		if err != nil {
 			return false, fmt.Errorf("error while exporting custom text locale: %s. %w", locale, err)
 		}
@@ -103,21 +106,21 @@ func exportCustomTextScreen(screen, exportFilePath, formatString string) (hadLoc
 	}
 
 	hadLocales = len(exportedLocales) > 0
-	if utils.TOOL_CONFIGS.AllowDelete && hadLocales {
+	if session.ToolConfigs.AllowDelete && hadLocales {
 		utils.RemoveDeletedLocalResources(screenDir, exportedLocales)
 	}
 	return hadLocales, nil
 }
 
-func exportCustomTextLocale(data interface{}, screenDir, locale string, format utils.Format, keywordMapping map[string]interface{}) error {
+func exportCustomTextLocale(session *utils.Session, data interface{}, screenDir, locale string, format utils.Format, keywordMapping map[string]interface{}) error {
 
 	exportedFileName := utils.GetExportedFilePath(screenDir, locale, format)
 
-	preprocessedData, err := preprocessCustomTextKeys(data)
+	preprocessedData, err := preprocessCustomTextKeys(session, data)
 	if err != nil {
 		return fmt.Errorf("error while preprocessing custom text keys: %w", err)
 	}
-	modifiedData, err := utils.ProcessExportedData(preprocessedData, exportedFileName, format, keywordMapping, utils.CUSTOM_TEXTS)
+	modifiedData, err := session.ProcessExportedData(preprocessedData, exportedFileName, format, keywordMapping, utils.CUSTOM_TEXTS)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -130,7 +133,7 @@ func exportCustomTextLocale(data interface{}, screenDir, locale string, format u
 	if err != nil {
 		return fmt.Errorf("error while serializing exported content: %w", err)
 	}
-	if err := ioutil.WriteFile(exportedFileName, modifiedFile, 0644); err != nil {
+	if err := utils.WriteExportedFile(exportedFileName, modifiedFile, utils.CUSTOM_TEXTS); err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
 	return nil
@@ -27,12 +27,12 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(parentDir string) {
+func ImportAll(session *utils.Session, parentDir string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CUSTOM_TEXTS, "", "Importing custom texts...")
 	importFilePath := filepath.Join(parentDir, utils.CUSTOM_TEXTS.String())
 
-	if utils.ShouldSkip(utils.CUSTOM_TEXTS) {
+	if session.ShouldSkip(utils.CUSTOM_TEXTS) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -40,7 +40,7 @@ func ImportAll(parentDir string) {
 		return
 	}
 
-	deployedTexts, err := getCustomTextList()
+	deployedTexts, err := getCustomTextList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.CUSTOM_TEXTS, "", fmt.Sprintf("Error while retrieving deployed custom text list: %s", err))
 		utils.MarkResTypeFailure(utils.CUSTOM_TEXTS)
@@ -53,27 +53,28 @@ func ImportAll(parentDir string) {
 		return
 	}
 
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedScreens(localScreenDirs, deployedTexts)
+	if session.ToolConfigs.AllowDelete {
+		removeDeletedDeployedScreens(session, localScreenDirs, deployedTexts)
 	}
 
-	for _, entry := range localScreenDirs {
+	session.ProcessConcurrently(len(localScreenDirs), func(i int) {
+		entry := localScreenDirs[i]
 		if !entry.IsDir() {
-			continue
+			return
 		}
 		screen := entry.Name()
 		screenDir := filepath.Join(importFilePath, screen)
 
-		if !utils.IsResourceExcluded(screen, utils.TOOL_CONFIGS.CustomTextConfigs) {
-			if err := importCustomTextScreen(screen, screenDir, deployedTexts[screen]); err != nil {
-				utils.UpdateFailureSummary(utils.CUSTOM_TEXTS, screen)
-				utils.PrintLog(utils.LogLevelError, utils.CUSTOM_TEXTS, screen, fmt.Sprintf("Error while importing: %s", err))
+		if !utils.IsResourceExcluded(screen, session.ToolConfigs.CustomTextConfigs) {
+			utils.MarkResourceStart(utils.CUSTOM_TEXTS, screen)
+			if err := importCustomTextScreen(session, screen, screenDir, deployedTexts[screen]); err != nil {
+				utils.UpdateFailureSummaryAndLog(utils.CUSTOM_TEXTS, screen, utils.IMPORT, err, fmt.Sprintf("Error while importing: %s", err))
 			}
 		}
-	}
+	})
 }
 
-func importCustomTextScreen(screen, screenDir string, deployedLocales map[string]struct{}) error {
+func importCustomTextScreen(session *utils.Session, screen, screenDir string, deployedLocales map[string]struct{}) error {
 
 	if len(deployedLocales) == 0 {
 		utils.PrintLog(utils.LogLevelInfo, utils.CUSTOM_TEXTS, screen, "Importing")
@@ -85,10 +86,10 @@ func importCustomTextScreen(screen, screenDir string, deployedLocales map[string
 	if err != nil {
 		return fmt.Errorf("error reading local custom text files: %w", err)
 	}
-	keywordMapping := getCustomTextsKeywordMapping(screen)
+	keywordMapping := getCustomTextsKeywordMapping(session, screen)
 
-	if utils.TOOL_CONFIGS.AllowDelete {
-		if err := removeDeletedDeployedLocales(screen, localFiles, deployedLocales); err != nil {
+	if session.ToolConfigs.AllowDelete {
+		if err := removeDeletedDeployedLocales(session, screen, localFiles, deployedLocales); err != nil {
 			return fmt.Errorf("error removing deleted deployed locales: %w", err)
 		}
 	}
@@ -98,22 +99,22 @@ func importCustomTextScreen(screen, screenDir string, deployedLocales map[string
 		locale := utils.GetFileInfo(file.Name()).ResourceName
 
 		_, srvExists := deployedLocales[locale]
-		if err := importCustomTextLocale(filePath, srvExists, keywordMapping); err != nil {
+		if err := importCustomTextLocale(session, filePath, srvExists, keywordMapping); err != nil {
 			return fmt.Errorf("error importing locale %s: %w", locale, err)
 		}
 	}
 
-	if len(deployedLocales) == 0 {
-		utils.UpdateSuccessSummary(utils.CUSTOM_TEXTS, utils.IMPORT)
-		utils.PrintLog(utils.LogLevelInfo, utils.CUSTOM_TEXTS, screen, "Imported successfully")
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.CUSTOM_TEXTS, screen, utils.GetImportAction(len(deployedLocales) > 0), "")
+	} else if len(deployedLocales) == 0 {
+		utils.UpdateSuccessSummaryAndLog(utils.CUSTOM_TEXTS, screen, utils.IMPORT, "Imported successfully")
 	} else {
-		utils.UpdateSuccessSummary(utils.CUSTOM_TEXTS, utils.UPDATE)
-		utils.PrintLog(utils.LogLevelInfo, utils.CUSTOM_TEXTS, screen, "Updated successfully")
+		utils.UpdateSuccessSummaryAndLog(utils.CUSTOM_TEXTS, screen, utils.UPDATE, "Updated successfully")
 	}
 	return nil
 }
 
-func importCustomTextLocale(filePath string, srvExists bool, keywordMapping map[string]interface{}) error {
+func importCustomTextLocale(session *utils.Session, filePath string, srvExists bool, keywordMapping map[string]interface{}) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(filePath))
 	if err != nil {
@@ -124,22 +125,32 @@ func importCustomTextLocale(filePath string, srvExists bool, keywordMapping map[
 		return fmt.Errorf("error when reading the file for custom text: %w", err)
 	}
 
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.CUSTOM_TEXTS, filePath, modifiedFileData, keywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.CUSTOM_TEXTS, filePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
 
+	if utils.DRY_RUN {
+		return nil
+	}
 	if !srvExists {
-		return createLocale([]byte(modifiedFileData), format)
+		return createLocale(session, []byte(modifiedFileData), format)
 	}
-	return updateLocale([]byte(modifiedFileData), format)
+	return updateLocale(session, []byte(modifiedFileData), format)
 }
 
-func createLocale(requestBody []byte, format utils.Format) error {
+func createLocale(session *utils.Session, requestBody []byte, format utils.Format) error {
 
 	jsonBody, err := utils.PrepareJSONRequestBody(requestBody, format, utils.CUSTOM_TEXTS)
 	if err != nil {
 		return err
 	}
 
-	resp, err := utils.SendPostRequest(utils.CUSTOM_TEXTS, jsonBody)
+	resp, err := session.SendPostRequest(utils.CUSTOM_TEXTS, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when creating locale: %w", err)
 	}
@@ -148,14 +159,14 @@ func createLocale(requestBody []byte, format utils.Format) error {
 	return nil
 }
 
-func updateLocale(requestBody []byte, format utils.Format) error {
+func updateLocale(session *utils.Session, requestBody []byte, format utils.Format) error {
 
 	jsonBody, err := utils.PrepareJSONRequestBody(requestBody, format, utils.CUSTOM_TEXTS)
 	if err != nil {
 		return err
 	}
 
-	resp, err := utils.SendPutRequest(utils.CUSTOM_TEXTS, "", jsonBody)
+	resp, err := session.SendPutRequest(utils.CUSTOM_TEXTS, "", jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when updating locale: %w", err)
 	}
@@ -164,7 +175,7 @@ func updateLocale(requestBody []byte, format utils.Format) error {
 	return nil
 }
 
-func removeDeletedDeployedScreens(localScreenDirs []os.FileInfo, deployedTexts map[string]map[string]struct{}) {
+func removeDeletedDeployedScreens(session *utils.Session, localScreenDirs []os.FileInfo, deployedTexts map[string]map[string]struct{}) {
 
 	localScreenNames := make(map[string]struct{})
 	for _, dir := range localScreenDirs {
@@ -177,25 +188,28 @@ func removeDeletedDeployedScreens(localScreenDirs []os.FileInfo, deployedTexts m
 		if _, existsLocally := localScreenNames[screen]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(screen, utils.TOOL_CONFIGS.CustomTextConfigs) {
+		if utils.IsResourceExcluded(screen, session.ToolConfigs.CustomTextConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.CUSTOM_TEXTS, screen, "Excluded from deletion.")
 			continue
 		}
 
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.CUSTOM_TEXTS, screen, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.CUSTOM_TEXTS, screen, "Not found locally. Deleting all locales.")
 		for locale := range locales {
-			if err := deleteCustomText(screen, locale); err != nil {
-				utils.PrintLog(utils.LogLevelError, utils.CUSTOM_TEXTS, screen, fmt.Sprintf("Error deleting locale %s: %s", locale, err))
-				utils.UpdateFailureSummary(utils.CUSTOM_TEXTS, screen+"/"+locale)
+			if err := deleteCustomText(session, screen, locale); err != nil {
+				utils.UpdateFailureSummaryAndLog(utils.CUSTOM_TEXTS, screen+"/"+locale, utils.DELETE, err, fmt.Sprintf("Error deleting locale: %s", err))
 				continue
 			} else {
-				utils.UpdateSuccessSummary(utils.CUSTOM_TEXTS, utils.DELETE)
+				utils.UpdateSuccessSummary(utils.CUSTOM_TEXTS, screen+"/"+locale, utils.DELETE)
 			}
 		}
 	}
 }
 
-func removeDeletedDeployedLocales(screen string, localFiles []os.FileInfo, deployedLocales map[string]struct{}) error {
+func removeDeletedDeployedLocales(session *utils.Session, screen string, localFiles []os.FileInfo, deployedLocales map[string]struct{}) error {
 
 	localLocales := make(map[string]struct{})
 	for _, file := range localFiles {
@@ -207,8 +221,12 @@ func removeDeletedDeployedLocales(screen string, localFiles []os.FileInfo, deplo
 		if _, existsLocally := localLocales[locale]; existsLocally {
 			continue
 		}
+		if utils.DRY_RUN {
+			utils.AddSubResourceToPlan(utils.CUSTOM_TEXTS, screen, screen+"/"+locale, utils.PLAN_DELETE, "Locale not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.CUSTOM_TEXTS, screen, fmt.Sprintf("Locale %s not found locally. Deleting.", locale))
-		if err := deleteCustomText(screen, locale); err != nil {
+		if err := deleteCustomText(session, screen, locale); err != nil {
 			return fmt.Errorf("error deleting locale: %s. %w", locale, err)
 		}
 	}
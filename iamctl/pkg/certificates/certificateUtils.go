@@ -29,10 +29,10 @@ type certificate struct {
 	Alias string `json:"alias"`
 }
 
-func getCertificateList() ([]certificate, error) {
+func getCertificateList(session *utils.Session) ([]certificate, error) {
 
 	var list []certificate
-	body, err := utils.SendGetListRequest(utils.CERTIFICATES)
+	body, err := session.SendGetListRequest(utils.CERTIFICATES)
 	if err != nil {
 		return nil, fmt.Errorf("error while retrieving certificate list. %w", err)
 	}
@@ -42,9 +42,9 @@ func getCertificateList() ([]certificate, error) {
 	return list, nil
 }
 
-func getDeployedCertificateAliases() []string {
+func getDeployedCertificateAliases(session *utils.Session) []string {
 
-	certs, err := getCertificateList()
+	certs, err := getCertificateList(session)
 	if err != nil {
 		return []string{}
 	}
@@ -56,12 +56,12 @@ func getDeployedCertificateAliases() []string {
 	return aliases
 }
 
-func getCertificateKeywordMapping(alias string) map[string]interface{} {
+func getCertificateKeywordMapping(session *utils.Session, alias string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.CertificateConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(alias, utils.KEYWORD_CONFIGS.CertificateConfigs)
+	if session.KeywordConfigs.CertificateConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(alias, session.KeywordConfigs.CertificateConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func isCertificateExists(alias string, existingCertList []certificate) bool {
@@ -73,9 +73,9 @@ func isCertificateExists(alias string, existingCertList []certificate) bool {
 	return false
 }
 
-func getEncodedCertificate(alias string) (map[string]interface{}, error) {
+func getEncodedCertificate(session *utils.Session, alias string) (map[string]interface{}, error) {
 
-	body, err := utils.SendGetRequest(utils.CERTIFICATES, alias, utils.WithContentType(utils.MEDIA_TYPE_PKIX_CERT))
+	body, err := session.SendGetRequest(utils.CERTIFICATES, alias, utils.WithContentType(utils.MEDIA_TYPE_PKIX_CERT))
 	if err != nil {
 		return nil, err
 	}
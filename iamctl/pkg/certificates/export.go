@@ -19,60 +19,63 @@
 package certificates
 
 import (
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CERTIFICATES, "", "Exporting certificates...")
 	exportFilePath = filepath.Join(exportFilePath, utils.CERTIFICATES.String())
 
-	if utils.ShouldSkip(utils.CERTIFICATES) {
+	if session.ShouldSkip(utils.CERTIFICATES) {
 		return
 	}
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.CERTIFICATES, "", fmt.Sprintf("Error creating certificates directory: %s", err))
 			utils.MarkResTypeFailure(utils.CERTIFICATES)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
-			deployedAliases := getDeployedCertificateAliases()
+		if session.ToolConfigs.AllowDelete {
+			deployedAliases := getDeployedCertificateAliases(session)
 			utils.RemoveDeletedLocalResources(exportFilePath, deployedAliases)
 		}
 	}
 
-	certs, err := getCertificateList()
+	certs, err := getCertificateList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.CERTIFICATES, "", fmt.Sprintf("Error retrieving the deployed certificates list: %s", err))
 		utils.MarkResTypeFailure(utils.CERTIFICATES)
 	} else {
-		for _, cert := range certs {
-			if !utils.IsResourceExcluded(cert.Alias, utils.TOOL_CONFIGS.CertificateConfigs) {
+		session.ProcessConcurrently(len(certs), func(i int) {
+			cert := certs[i]
+			if !utils.IsResourceExcluded(cert.Alias, session.ToolConfigs.CertificateConfigs) {
 				utils.PrintLog(utils.LogLevelInfo, utils.CERTIFICATES, cert.Alias, "Exporting")
 
-				err := exportCertificate(cert.Alias, exportFilePath, format)
+				utils.MarkResourceStart(utils.CERTIFICATES, cert.Alias)
+				err := exportCertificate(session, cert.Alias, exportFilePath, format)
+				if errors.Is(err, utils.ErrFilteredOut) {
+					return
+				}
 				if err != nil {
-					utils.UpdateFailureSummary(utils.CERTIFICATES, cert.Alias)
-					utils.PrintLog(utils.LogLevelError, utils.CERTIFICATES, cert.Alias, fmt.Sprintf("Error while exporting: %s", err))
+					utils.UpdateFailureSummaryAndLog(utils.CERTIFICATES, cert.Alias, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 				} else {
-					utils.UpdateSuccessSummary(utils.CERTIFICATES, utils.EXPORT)
-					utils.PrintLog(utils.LogLevelInfo, utils.CERTIFICATES, cert.Alias, "Exported successfully")
+					utils.UpdateSuccessSummaryAndLog(utils.CERTIFICATES, cert.Alias, utils.EXPORT, "Exported successfully")
 				}
 			}
-		}
+		})
 	}
 }
 
-func exportCertificate(alias string, outputDirPath string, formatString string) error {
+func exportCertificate(session *utils.Session, alias string, outputDirPath string, formatString string) error {
 
-	certData, err := getEncodedCertificate(alias)
+	certData, err := getEncodedCertificate(session, alias)
 	if err != nil {
 		return fmt.Errorf("error while getting certificate data: %w", err)
 	}
@@ -80,8 +83,8 @@ func exportCertificate(alias string, outputDirPath string, formatString string)
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, alias, format)
 
-	certKeywordMapping := getCertificateKeywordMapping(alias)
-	modifiedCert, err := utils.ProcessExportedData(certData, exportedFileName, format, certKeywordMapping, utils.CERTIFICATES)
+	certKeywordMapping := getCertificateKeywordMapping(session, alias)
+	modifiedCert, err := session.ProcessExportedData(certData, exportedFileName, format, certKeywordMapping, utils.CERTIFICATES)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -91,7 +94,7 @@ func exportCertificate(alias string, outputDirPath string, formatString string)
 		return fmt.Errorf("error while serializing certificate: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.CERTIFICATES)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
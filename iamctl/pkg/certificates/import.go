@@ -27,16 +27,16 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CERTIFICATES, "", "Importing certificates...")
-	if utils.ShouldSkip(utils.CERTIFICATES) {
+	if session.ShouldSkip(utils.CERTIFICATES) {
 		return
 	}
 
-	if utils.SERVER_CONFIGS.TenantDomain == utils.DEFAULT_TENANT_DOMAIN {
+	if session.ServerConfigs.TenantDomain == utils.DEFAULT_TENANT_DOMAIN {
 		utils.PrintLog(utils.LogLevelInfo, utils.CERTIFICATES, "", "Importing certificates for super tenant not supported.")
-		utils.UpdateSkipSummary(utils.CERTIFICATES, "Not supported in super tenant")
+		utils.UpdateSkipSummary(utils.CERTIFICATES, utils.SKIP_REASON_SUPER_TENANT)
 		return
 	}
 
@@ -47,7 +47,7 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	existingCertList, err := getCertificateList()
+	existingCertList, err := getCertificateList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.CERTIFICATES, "", fmt.Sprintf("Error retrieving the deployed certificate list: %s", err))
 		utils.MarkResTypeFailure(utils.CERTIFICATES)
@@ -60,27 +60,28 @@ func ImportAll(inputDirPath string) {
 		utils.MarkResTypeFailure(utils.CERTIFICATES)
 		return
 	}
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedCertificates(files, existingCertList)
+	if session.IsDeleteAllowed(utils.CERTIFICATES) {
+		removeDeletedDeployedCertificates(session, files, existingCertList)
 	}
 
-	for _, file := range files {
+	session.ProcessConcurrently(len(files), func(i int) {
+		file := files[i]
 		certFilePath := filepath.Join(importFilePath, file.Name())
 		fileInfo := utils.GetFileInfo(certFilePath)
 		alias := fileInfo.ResourceName
 
-		if !utils.IsResourceExcluded(alias, utils.TOOL_CONFIGS.CertificateConfigs) {
+		if !utils.IsResourceExcluded(alias, session.ToolConfigs.CertificateConfigs) && session.MatchesFileFilter(utils.CERTIFICATES, certFilePath, getCertificateKeywordMapping(session, alias), utils.IMPORT) {
 			certExists := isCertificateExists(alias, existingCertList)
-			err := importCertificate(alias, certExists, certFilePath)
+			utils.MarkResourceStart(utils.CERTIFICATES, alias)
+			err := importCertificate(session, alias, certExists, certFilePath)
 			if err != nil {
-				utils.PrintLog(utils.LogLevelError, utils.CERTIFICATES, alias, fmt.Sprintf("Error importing certificate: %s", err))
-				utils.UpdateFailureSummary(utils.CERTIFICATES, alias)
+				utils.UpdateFailureSummaryAndLog(utils.CERTIFICATES, alias, utils.IMPORT, err, fmt.Sprintf("Error importing certificate: %s", err))
 			}
 		}
-	}
+	})
 }
 
-func importCertificate(alias string, certExists bool, importFilePath string) error {
+func importCertificate(session *utils.Session, alias string, certExists bool, importFilePath string) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(importFilePath))
 	if err != nil {
@@ -92,18 +93,33 @@ func importCertificate(alias string, certExists bool, importFilePath string) err
 		return fmt.Errorf("error when reading the file for certificate: %w", err)
 	}
 
-	certKeywordMapping := getCertificateKeywordMapping(alias)
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), certKeywordMapping)
+	certKeywordMapping := getCertificateKeywordMapping(session, alias)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), certKeywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.CERTIFICATES, importFilePath, modifiedFileData, certKeywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.CERTIFICATES, importFilePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
 
+	if utils.DRY_RUN {
+		if certExists {
+			utils.AddToPlan(utils.CERTIFICATES, alias, utils.PLAN_NO_OP, "Already exists")
+		} else {
+			utils.AddToPlan(utils.CERTIFICATES, alias, utils.PLAN_CREATE, "")
+		}
+		return nil
+	}
 	if !certExists {
-		return createCertificate([]byte(modifiedFileData), format, alias)
+		return createCertificate(session, []byte(modifiedFileData), format, alias)
 	}
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CERTIFICATES, alias, "Already exists. Skipping.")
 	return nil
 }
 
-func createCertificate(requestBody []byte, format utils.Format, alias string) error {
+func createCertificate(session *utils.Session, requestBody []byte, format utils.Format, alias string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CERTIFICATES, alias, "Creating new certificate")
 
@@ -112,18 +128,17 @@ func createCertificate(requestBody []byte, format utils.Format, alias string) er
 		return err
 	}
 
-	resp, err := utils.SendPostRequest(utils.CERTIFICATES, jsonBody)
+	resp, err := session.SendPostRequest(utils.CERTIFICATES, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when creating certificate: %w", err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.CERTIFICATES, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.CERTIFICATES, alias, "Imported successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.CERTIFICATES, alias, utils.IMPORT, "Imported successfully")
 	return nil
 }
 
-func removeDeletedDeployedCertificates(localFiles []os.FileInfo, deployedCerts []certificate) {
+func removeDeletedDeployedCertificates(session *utils.Session, localFiles []os.FileInfo, deployedCerts []certificate) {
 
 	if len(deployedCerts) == 0 {
 		return
@@ -139,17 +154,20 @@ func removeDeletedDeployedCertificates(localFiles []os.FileInfo, deployedCerts [
 		if _, existsLocally := localResourceNames[cert.Alias]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(cert.Alias, utils.TOOL_CONFIGS.CertificateConfigs) || cert.Alias == utils.SERVER_CONFIGS.TenantDomain {
+		if utils.IsResourceExcluded(cert.Alias, session.ToolConfigs.CertificateConfigs) || cert.Alias == session.ServerConfigs.TenantDomain {
 			utils.PrintLog(utils.LogLevelInfo, utils.CERTIFICATES, cert.Alias, "Excluded from deletion.")
 			continue
 		}
 
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.CERTIFICATES, cert.Alias, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.CERTIFICATES, cert.Alias, "Not found locally. Deleting.")
-		if err := utils.SendDeleteRequest(cert.Alias, utils.CERTIFICATES); err != nil {
-			utils.UpdateFailureSummary(utils.CERTIFICATES, cert.Alias)
-			utils.PrintLog(utils.LogLevelError, utils.CERTIFICATES, cert.Alias, fmt.Sprintf("Error deleting certificate: %s", err))
+		if err := session.SendDeleteRequest(cert.Alias, utils.CERTIFICATES); err != nil {
+			utils.UpdateFailureSummaryAndLog(utils.CERTIFICATES, cert.Alias, utils.DELETE, err, fmt.Sprintf("Error deleting certificate: %s", err))
 		} else {
-			utils.UpdateSuccessSummary(utils.CERTIFICATES, utils.DELETE)
+			utils.UpdateSuccessSummary(utils.CERTIFICATES, cert.Alias, utils.DELETE)
 		}
 	}
 }
@@ -29,10 +29,10 @@ type challengeSet struct {
 	QuestionSetId string `json:"questionSetId"`
 }
 
-func getChallengeSetList() ([]challengeSet, error) {
+func getChallengeSetList(session *utils.Session) ([]challengeSet, error) {
 
 	var list []challengeSet
-	body, err := utils.SendGetListRequest(utils.CHALLENGE_QUESTIONS)
+	body, err := session.SendGetListRequest(utils.CHALLENGE_QUESTIONS)
 	if err != nil {
 		return nil, fmt.Errorf("error while retrieving challenge question set list. %w", err)
 	}
@@ -42,9 +42,9 @@ func getChallengeSetList() ([]challengeSet, error) {
 	return list, nil
 }
 
-func getDeployedChallengeSetIds() []string {
+func getDeployedChallengeSetIds(session *utils.Session) []string {
 
-	sets, err := getChallengeSetList()
+	sets, err := getChallengeSetList(session)
 	if err != nil {
 		return []string{}
 	}
@@ -56,12 +56,12 @@ func getDeployedChallengeSetIds() []string {
 	return ids
 }
 
-func getChallengeQuestionKeywordMapping(setId string) map[string]interface{} {
+func getChallengeQuestionKeywordMapping(session *utils.Session, setId string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.ChallengeQuestionConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(setId, utils.KEYWORD_CONFIGS.ChallengeQuestionConfigs)
+	if session.KeywordConfigs.ChallengeQuestionConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(setId, session.KeywordConfigs.ChallengeQuestionConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func isChallengeSetExists(setId string, existingSets []challengeSet) bool {
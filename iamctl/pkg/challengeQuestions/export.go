@@ -19,60 +19,63 @@
 package challengeQuestions
 
 import (
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CHALLENGE_QUESTIONS, "", "Exporting challenge question sets...")
 	exportFilePath = filepath.Join(exportFilePath, utils.CHALLENGE_QUESTIONS.String())
 
-	if utils.ShouldSkip(utils.CHALLENGE_QUESTIONS) {
+	if session.ShouldSkip(utils.CHALLENGE_QUESTIONS) {
 		return
 	}
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.CHALLENGE_QUESTIONS, "", fmt.Sprintf("Error creating challenge questions directory: %s", err))
 			utils.MarkResTypeFailure(utils.CHALLENGE_QUESTIONS)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
-			deployedSetIds := getDeployedChallengeSetIds()
+		if session.ToolConfigs.AllowDelete {
+			deployedSetIds := getDeployedChallengeSetIds(session)
 			utils.RemoveDeletedLocalResources(exportFilePath, deployedSetIds)
 		}
 	}
 
-	sets, err := getChallengeSetList()
+	sets, err := getChallengeSetList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.CHALLENGE_QUESTIONS, "", fmt.Sprintf("Error retrieving the deployed challenge question sets list: %s", err))
 		utils.MarkResTypeFailure(utils.CHALLENGE_QUESTIONS)
 		return
 	}
 
-	for _, set := range sets {
-		if !utils.IsResourceExcluded(set.QuestionSetId, utils.TOOL_CONFIGS.ChallengeQuestionConfigs) {
+	session.ProcessConcurrently(len(sets), func(i int) {
+		set := sets[i]
+		if !utils.IsResourceExcluded(set.QuestionSetId, session.ToolConfigs.ChallengeQuestionConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.CHALLENGE_QUESTIONS, set.QuestionSetId, "Exporting")
-			err := exportChallengeSet(set.QuestionSetId, exportFilePath, format)
+			utils.MarkResourceStart(utils.CHALLENGE_QUESTIONS, set.QuestionSetId)
+			err := exportChallengeSet(session, set.QuestionSetId, exportFilePath, format)
+			if errors.Is(err, utils.ErrFilteredOut) {
+				return
+			}
 			if err != nil {
-				utils.UpdateFailureSummary(utils.CHALLENGE_QUESTIONS, set.QuestionSetId)
-				utils.PrintLog(utils.LogLevelError, utils.CHALLENGE_QUESTIONS, set.QuestionSetId, fmt.Sprintf("Error while exporting: %s", err))
+				utils.UpdateFailureSummaryAndLog(utils.CHALLENGE_QUESTIONS, set.QuestionSetId, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 			} else {
-				utils.UpdateSuccessSummary(utils.CHALLENGE_QUESTIONS, utils.EXPORT)
-				utils.PrintLog(utils.LogLevelInfo, utils.CHALLENGE_QUESTIONS, set.QuestionSetId, "Exported successfully")
+				utils.UpdateSuccessSummaryAndLog(utils.CHALLENGE_QUESTIONS, set.QuestionSetId, utils.EXPORT, "Exported successfully")
 			}
 		}
-	}
+	})
 }
 
-func exportChallengeSet(setId string, outputDirPath string, formatString string) error {
+func exportChallengeSet(session *utils.Session, setId string, outputDirPath string, formatString string) error {
 
-	set, err := utils.GetResourceData(utils.CHALLENGE_QUESTIONS, setId)
+	set, err := session.GetResourceData(utils.CHALLENGE_QUESTIONS, setId)
 	if err != nil {
 		return fmt.Errorf("error while getting challenge question set: %w", err)
 	}
@@ -80,8 +83,8 @@ func exportChallengeSet(setId string, outputDirPath string, formatString string)
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, setId, format)
 
-	keywordMapping := getChallengeQuestionKeywordMapping(setId)
-	modifiedSet, err := utils.ProcessExportedData(set, exportedFileName, format, keywordMapping, utils.CHALLENGE_QUESTIONS)
+	keywordMapping := getChallengeQuestionKeywordMapping(session, setId)
+	modifiedSet, err := session.ProcessExportedData(set, exportedFileName, format, keywordMapping, utils.CHALLENGE_QUESTIONS)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -91,7 +94,7 @@ func exportChallengeSet(setId string, outputDirPath string, formatString string)
 		return fmt.Errorf("error while serializing challenge question set: %w", err)
 	}
 
-	if err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644); err != nil {
+	if err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.CHALLENGE_QUESTIONS); err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
 
@@ -27,12 +27,12 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CHALLENGE_QUESTIONS, "", "Importing challenge question sets...")
 	importFilePath := filepath.Join(inputDirPath, utils.CHALLENGE_QUESTIONS.String())
 
-	if utils.ShouldSkip(utils.CHALLENGE_QUESTIONS) {
+	if session.ShouldSkip(utils.CHALLENGE_QUESTIONS) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -40,7 +40,7 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	existingSets, err := getChallengeSetList()
+	existingSets, err := getChallengeSetList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.CHALLENGE_QUESTIONS, "", fmt.Sprintf("Error retrieving the deployed challenge question set list: %s", err))
 		utils.MarkResTypeFailure(utils.CHALLENGE_QUESTIONS)
@@ -53,27 +53,28 @@ func ImportAll(inputDirPath string) {
 		utils.MarkResTypeFailure(utils.CHALLENGE_QUESTIONS)
 		return
 	}
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedChallengeSets(files, existingSets)
+	if session.IsDeleteAllowed(utils.CHALLENGE_QUESTIONS) {
+		removeDeletedDeployedChallengeSets(session, files, existingSets)
 	}
 
-	for _, file := range files {
+	session.ProcessConcurrently(len(files), func(i int) {
+		file := files[i]
 		setFilePath := filepath.Join(importFilePath, file.Name())
 		fileInfo := utils.GetFileInfo(setFilePath)
 		setId := fileInfo.ResourceName
 
-		if !utils.IsResourceExcluded(setId, utils.TOOL_CONFIGS.ChallengeQuestionConfigs) {
+		if !utils.IsResourceExcluded(setId, session.ToolConfigs.ChallengeQuestionConfigs) && session.MatchesFileFilter(utils.CHALLENGE_QUESTIONS, setFilePath, getChallengeQuestionKeywordMapping(session, setId), utils.IMPORT) {
 			setExists := isChallengeSetExists(setId, existingSets)
-			err := importChallengeSet(setId, setExists, setFilePath)
+			utils.MarkResourceStart(utils.CHALLENGE_QUESTIONS, setId)
+			err := importChallengeSet(session, setId, setExists, setFilePath)
 			if err != nil {
-				utils.PrintLog(utils.LogLevelError, utils.CHALLENGE_QUESTIONS, setId, fmt.Sprintf("Error importing challenge question set: %s", err))
-				utils.UpdateFailureSummary(utils.CHALLENGE_QUESTIONS, setId)
+				utils.UpdateFailureSummaryAndLog(utils.CHALLENGE_QUESTIONS, setId, utils.IMPORT, err, fmt.Sprintf("Error importing challenge question set: %s", err))
 			}
 		}
-	}
+	})
 }
 
-func importChallengeSet(setId string, setExists bool, importFilePath string) error {
+func importChallengeSet(session *utils.Session, setId string, setExists bool, importFilePath string) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(importFilePath))
 	if err != nil {
@@ -85,16 +86,28 @@ func importChallengeSet(setId string, setExists bool, importFilePath string) err
 		return fmt.Errorf("error when reading the file for challenge question set: %w", err)
 	}
 
-	keywordMapping := getChallengeQuestionKeywordMapping(setId)
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	keywordMapping := getChallengeQuestionKeywordMapping(session, setId)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.CHALLENGE_QUESTIONS, importFilePath, modifiedFileData, keywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.CHALLENGE_QUESTIONS, importFilePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
+
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.CHALLENGE_QUESTIONS, setId, utils.GetImportAction(setExists), "")
+		return nil
+	}
 
 	if !setExists {
-		return createChallengeSet([]byte(modifiedFileData), format, setId)
+		return createChallengeSet(session, []byte(modifiedFileData), format, setId)
 	}
-	return updateChallengeSet(setId, []byte(modifiedFileData), format)
+	return updateChallengeSet(session, setId, []byte(modifiedFileData), format)
 }
 
-func createChallengeSet(requestBody []byte, format utils.Format, setId string) error {
+func createChallengeSet(session *utils.Session, requestBody []byte, format utils.Format, setId string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CHALLENGE_QUESTIONS, setId, "Creating new challenge question set")
 
@@ -107,18 +120,17 @@ func createChallengeSet(requestBody []byte, format utils.Format, setId string) e
 		return fmt.Errorf("error serializing to JSON: %w", err)
 	}
 
-	resp, err := utils.SendPostRequest(utils.CHALLENGE_QUESTIONS, wrappedBody)
+	resp, err := session.SendPostRequest(utils.CHALLENGE_QUESTIONS, wrappedBody)
 	if err != nil {
 		return fmt.Errorf("error when creating challenge question set: %w", err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.CHALLENGE_QUESTIONS, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.CHALLENGE_QUESTIONS, setId, "Created successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.CHALLENGE_QUESTIONS, setId, utils.IMPORT, "Created successfully")
 	return nil
 }
 
-func updateChallengeSet(setId string, requestBody []byte, format utils.Format) error {
+func updateChallengeSet(session *utils.Session, setId string, requestBody []byte, format utils.Format) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CHALLENGE_QUESTIONS, setId, "Updating challenge question set")
 
@@ -127,18 +139,17 @@ func updateChallengeSet(setId string, requestBody []byte, format utils.Format) e
 		return err
 	}
 
-	resp, err := utils.SendPutRequest(utils.CHALLENGE_QUESTIONS, setId, questionsBytes)
+	resp, err := session.SendPutRequest(utils.CHALLENGE_QUESTIONS, setId, questionsBytes)
 	if err != nil {
 		return fmt.Errorf("error when updating challenge question set: %w", err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.CHALLENGE_QUESTIONS, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.CHALLENGE_QUESTIONS, setId, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.CHALLENGE_QUESTIONS, setId, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func removeDeletedDeployedChallengeSets(localFiles []os.FileInfo, deployedSets []challengeSet) {
+func removeDeletedDeployedChallengeSets(session *utils.Session, localFiles []os.FileInfo, deployedSets []challengeSet) {
 
 	if len(deployedSets) == 0 {
 		return
@@ -154,17 +165,20 @@ func removeDeletedDeployedChallengeSets(localFiles []os.FileInfo, deployedSets [
 		if _, existsLocally := localResourceNames[set.QuestionSetId]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(set.QuestionSetId, utils.TOOL_CONFIGS.ChallengeQuestionConfigs) {
+		if utils.IsResourceExcluded(set.QuestionSetId, session.ToolConfigs.ChallengeQuestionConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.CHALLENGE_QUESTIONS, set.QuestionSetId, "Excluded from deletion")
 			continue
 		}
 
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.CHALLENGE_QUESTIONS, set.QuestionSetId, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.CHALLENGE_QUESTIONS, set.QuestionSetId, "Not found locally. Deleting.")
-		if err := utils.SendDeleteRequest(set.QuestionSetId, utils.CHALLENGE_QUESTIONS); err != nil {
-			utils.UpdateFailureSummary(utils.CHALLENGE_QUESTIONS, set.QuestionSetId)
-			utils.PrintLog(utils.LogLevelError, utils.CHALLENGE_QUESTIONS, set.QuestionSetId, fmt.Sprintf("Error deleting challenge question set: %s", err))
+		if err := session.SendDeleteRequest(set.QuestionSetId, utils.CHALLENGE_QUESTIONS); err != nil {
+			utils.UpdateFailureSummaryAndLog(utils.CHALLENGE_QUESTIONS, set.QuestionSetId, utils.DELETE, err, fmt.Sprintf("Error deleting challenge question set: %s", err))
 		} else {
-			utils.UpdateSuccessSummary(utils.CHALLENGE_QUESTIONS, utils.DELETE)
+			utils.UpdateSuccessSummary(utils.CHALLENGE_QUESTIONS, set.QuestionSetId, utils.DELETE)
 		}
 	}
 }
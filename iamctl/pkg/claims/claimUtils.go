@@ -47,10 +47,10 @@ type LocalClaimDialectSummary struct {
 
 var localClaimDialectSummary LocalClaimDialectSummary
 
-func getClaimDialectsList() ([]claimDialect, error) {
+func getClaimDialectsList(session *utils.Session) ([]claimDialect, error) {
 
 	var list []claimDialect
-	body, err := utils.SendGetListRequest(utils.CLAIMS)
+	body, err := session.SendGetListRequest(utils.CLAIMS)
 	if err != nil {
 		return nil, fmt.Errorf("error while retrieving claim dialect list. %w", err)
 	}
@@ -60,9 +60,9 @@ func getClaimDialectsList() ([]claimDialect, error) {
 	return list, nil
 }
 
-func getClaimsList(dialectId string) ([]map[string]interface{}, error) {
+func getClaimsList(session *utils.Session, dialectId string) ([]map[string]interface{}, error) {
 
-	body, err := utils.SendGetRequest(utils.CLAIMS, dialectId+"/claims")
+	body, err := session.SendGetRequest(utils.CLAIMS, dialectId+"/claims")
 	if err != nil {
 		return nil, fmt.Errorf("error while getting claims for dialect. %w", err)
 	}
@@ -74,12 +74,12 @@ func getClaimsList(dialectId string) ([]map[string]interface{}, error) {
 	return list, nil
 }
 
-func getClaimKeywordMapping(claimDialectName string) map[string]interface{} {
+func getClaimKeywordMapping(session *utils.Session, claimDialectName string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.ClaimConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(claimDialectName, utils.KEYWORD_CONFIGS.ClaimConfigs)
+	if session.KeywordConfigs.ClaimConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(claimDialectName, session.KeywordConfigs.ClaimConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func getDeployedDialectFileNames(claimDialects []claimDialect) []string {
@@ -157,9 +157,9 @@ func getClaimDialectId(dialectURI string, existingClaimDialectList []claimDialec
 	return ""
 }
 
-func getClaimDialect(dialectId string) (interface{}, error) {
+func getClaimDialect(session *utils.Session, dialectId string) (interface{}, error) {
 
-	dialectData, err := utils.GetResourceData(utils.CLAIMS, dialectId)
+	dialectData, err := session.GetResourceData(utils.CLAIMS, dialectId)
 	if err != nil {
 		return nil, fmt.Errorf("error while retrieving claim dialect. %w", err)
 	}
@@ -168,7 +168,7 @@ func getClaimDialect(dialectId string) (interface{}, error) {
 		return nil, fmt.Errorf("unexpected format for claim dialect response")
 	}
 
-	claims, err := utils.GetResourceData(utils.CLAIMS, dialectId+"/claims")
+	claims, err := session.GetResourceData(utils.CLAIMS, dialectId+"/claims")
 	if err != nil {
 		return nil, fmt.Errorf("error while retrieving claims for dialect. %w", err)
 	}
@@ -208,27 +208,27 @@ func claimChanged(dialectId string, local, deployed map[string]interface{}) bool
 	return string(localJSON) != string(deployedJSON)
 }
 
-func removeStaleClaimsFromLocalDialect() {
+func removeStaleClaimsFromLocalDialect(session *utils.Session) {
 
 	if !localClaimDialectSummary.Success {
 		return
 	}
 	utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, utils.LOCAL_CLAIM_DIALECT, "Removing deleted claims from local claim dialect")
 
-	if err := removeDeletedDeployedClaims(utils.LOCAL_CLAIM_DIALECT, localClaimDialectSummary.DeployedClaims, localClaimDialectSummary.LocalClaims); err != nil {
-		utils.PrintLog(utils.LogLevelError, utils.CLAIMS, utils.LOCAL_CLAIM_DIALECT, fmt.Sprintf("Error removing deleted local claims: %s", err))
-		utils.UpdateFailureSummary(utils.CLAIMS, localClaimDialectSummary.DialectURI)
+	if err := removeDeletedDeployedClaims(session, utils.LOCAL_CLAIM_DIALECT, localClaimDialectSummary.DeployedClaims, localClaimDialectSummary.LocalClaims); err != nil {
+		utils.UpdateFailureSummaryAndLog(utils.CLAIMS, localClaimDialectSummary.DialectURI, utils.UPDATE, err,
+			fmt.Sprintf("Error removing deleted local claims: %s", err))
 		return
 	}
-	utils.UpdateSuccessSummary(utils.CLAIMS, utils.UPDATE)
+	utils.UpdateSuccessSummaryAndLog(utils.CLAIMS, localClaimDialectSummary.DialectURI, utils.UPDATE, "Updated successfully")
 }
 
-func RoleClaimUnsupported() bool {
+func RoleClaimUnsupported(session *utils.Session) bool {
 
-	if utils.SERVER_CONFIGS.ServerVersion == "" {
+	if session.ServerConfigs.ServerVersion == "" {
 		return true
 	}
-	cmp, err := utils.CompareVersions(utils.SERVER_CONFIGS.ServerVersion, utils.MIN_VERSION_ROLE_CLAIM_REMOVED)
+	cmp, err := utils.CompareVersions(session.ServerConfigs.ServerVersion, utils.MIN_VERSION_ROLE_CLAIM_REMOVED)
 	// Consider role claim unsupported when the server version is ""
 	return err != nil || cmp >= 0
 }
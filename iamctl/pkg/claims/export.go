@@ -19,6 +19,7 @@
 package claims
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"mime"
@@ -28,58 +29,61 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	// Export all claim dialects with related claims.
 	utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, "", "Exporting claims...")
 	exportFilePath = filepath.Join(exportFilePath, utils.CLAIMS.String())
 
-	if utils.ShouldSkip(utils.CLAIMS) {
+	if session.ShouldSkip(utils.CLAIMS) {
 		return
 	}
 
-	claimDialects, err := getClaimDialectsList()
+	claimDialects, err := getClaimDialectsList(session)
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.CLAIMS, "", fmt.Sprintf("Error creating claims directory: %s", err))
 			utils.MarkResTypeFailure(utils.CLAIMS)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
+		if session.ToolConfigs.AllowDelete {
 			utils.RemoveDeletedLocalResources(exportFilePath, getDeployedDialectFileNames(claimDialects))
 		}
 	}
 
 	// Min version requirement for claims export api is removed. CRUD apis used for all versions
-	exportAPIExists := utils.ExportAPIExists(utils.CLAIMS)
+	exportAPIExists := session.ExportAPIExists(utils.CLAIMS)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.CLAIMS, "", fmt.Sprintf("Error while retrieving Claim Dialect list: %s", err))
 	} else {
-		for _, dialect := range claimDialects {
-			if !utils.IsResourceExcluded(dialect.DialectURI, utils.TOOL_CONFIGS.ClaimConfigs) {
+		session.ProcessConcurrently(len(claimDialects), func(i int) {
+			dialect := claimDialects[i]
+			if !utils.IsResourceExcluded(dialect.DialectURI, session.ToolConfigs.ClaimConfigs) {
 				utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, dialect.DialectURI, "Exporting")
 
+				utils.MarkResourceStart(utils.CLAIMS, dialect.DialectURI)
 				var err error
 				if exportAPIExists {
-					err = exportClaimDialect(dialect.Id, dialect.DialectURI, exportFilePath, format)
+					err = exportClaimDialect(session, dialect.Id, dialect.DialectURI, exportFilePath, format)
 				} else {
-					err = exportClaimDialectWithCRUD(dialect.Id, dialect.DialectURI, exportFilePath, format)
+					err = exportClaimDialectWithCRUD(session, dialect.Id, dialect.DialectURI, exportFilePath, format)
 				}
 
+				if errors.Is(err, utils.ErrFilteredOut) {
+					return
+				}
 				if err != nil {
-					utils.UpdateFailureSummary(utils.CLAIMS, dialect.DialectURI)
-					utils.PrintLog(utils.LogLevelError, utils.CLAIMS, dialect.DialectURI, fmt.Sprintf("Error while exporting: %s", err))
+					utils.UpdateFailureSummaryAndLog(utils.CLAIMS, dialect.DialectURI, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 				} else {
-					utils.UpdateSuccessSummary(utils.CLAIMS, utils.EXPORT)
-					utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, dialect.DialectURI, "Exported successfully")
+					utils.UpdateSuccessSummaryAndLog(utils.CLAIMS, dialect.DialectURI, utils.EXPORT, "Exported successfully")
 				}
 			}
-		}
+		})
 	}
 }
 
-func exportClaimDialect(dialectId, dialectUri, outputDirPath, format string) error {
+func exportClaimDialect(session *utils.Session, dialectId, dialectUri, outputDirPath, format string) error {
 
 	var fileType string
 	// TODO: Extend support for json and xml formats.
@@ -92,7 +96,7 @@ func exportClaimDialect(dialectId, dialectUri, outputDirPath, format string) err
 		fileType = utils.MEDIA_TYPE_YAML
 	}
 
-	resp, err := utils.SendExportRequest(dialectId, fileType, utils.CLAIMS, true)
+	resp, err := session.SendExportRequest(dialectId, fileType, utils.CLAIMS, true)
 	if err != nil {
 		return fmt.Errorf("error while exporting the claim dialect: %s", err)
 	}
@@ -111,22 +115,22 @@ func exportClaimDialect(dialectId, dialectUri, outputDirPath, format string) err
 		return fmt.Errorf("error while reading the response body when exporting claim dialect: %s. %s", fileName, err)
 	}
 
-	claimDialectKeywordMapping := getClaimKeywordMapping(dialectUri)
-	modifiedFile, err := utils.ProcessExportedContent(exportedFileName, body, claimDialectKeywordMapping, utils.CLAIMS)
+	claimDialectKeywordMapping := getClaimKeywordMapping(session, dialectUri)
+	modifiedFile, err := session.ProcessExportedContent(exportedFileName, body, claimDialectKeywordMapping, utils.CLAIMS)
 	if err != nil {
-		return fmt.Errorf("error while processing the exported content: %s", err)
+		return fmt.Errorf("error while processing the exported content: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.CLAIMS)
 	if err != nil {
 		return fmt.Errorf("error when writing the exported content to file: %w", err)
 	}
 	return nil
 }
 
-func exportClaimDialectWithCRUD(dialectId, dialectUri, outputDirPath, formatString string) error {
+func exportClaimDialectWithCRUD(session *utils.Session, dialectId, dialectUri, outputDirPath, formatString string) error {
 
-	claimDialect, err := getClaimDialect(dialectId)
+	claimDialect, err := getClaimDialect(session, dialectId)
 	if err != nil {
 		return fmt.Errorf("error while getting claim dialect: %w", err)
 	}
@@ -135,8 +139,8 @@ func exportClaimDialectWithCRUD(dialectId, dialectUri, outputDirPath, formatStri
 	fileName := formatFileName(dialectUri)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, fileName, format)
 
-	dialectKeywordMapping := getClaimKeywordMapping(dialectUri)
-	modifiedDialect, err := utils.ProcessExportedData(claimDialect, exportedFileName, format, dialectKeywordMapping, utils.CLAIMS)
+	dialectKeywordMapping := getClaimKeywordMapping(session, dialectUri)
+	modifiedDialect, err := session.ProcessExportedData(claimDialect, exportedFileName, format, dialectKeywordMapping, utils.CLAIMS)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -146,7 +150,7 @@ func exportClaimDialectWithCRUD(dialectId, dialectUri, outputDirPath, formatStri
 		return fmt.Errorf("error while serializing claim dialect: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.CLAIMS)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
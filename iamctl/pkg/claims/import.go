@@ -30,12 +30,12 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, "", "Importing claims...")
 	importFilePath := filepath.Join(inputDirPath, utils.CLAIMS.String())
 
-	if utils.ShouldSkip(utils.CLAIMS) {
+	if session.ShouldSkip(utils.CLAIMS) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -43,7 +43,7 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	existingClaimDialectList, err := getClaimDialectsList()
+	existingClaimDialectList, err := getClaimDialectsList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.CLAIMS, "", fmt.Sprintf("Error when retrieving the deployed claim dialect list: %s", err))
 		utils.MarkResTypeFailure(utils.CLAIMS)
@@ -56,8 +56,8 @@ func ImportAll(inputDirPath string) {
 		utils.MarkResTypeFailure(utils.CLAIMS)
 		return
 	}
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedClaimdialect(files, existingClaimDialectList)
+	if session.IsDeleteAllowed(utils.CLAIMS) {
+		removeDeletedDeployedClaimdialect(session, files, existingClaimDialectList)
 	}
 
 	// Move the local claims file to the front of the array to import it first
@@ -79,18 +79,18 @@ func ImportAll(inputDirPath string) {
 		}
 		dialectId := getClaimDialectId(dialectUri, existingClaimDialectList)
 
-		if !utils.IsResourceExcluded(dialectUri, utils.TOOL_CONFIGS.ClaimConfigs) {
-			err = importClaimDialect(dialectId, dialectUri, claimFilePath)
+		if !utils.IsResourceExcluded(dialectUri, session.ToolConfigs.ClaimConfigs) && session.MatchesFileFilter(utils.CLAIMS, claimFilePath, getClaimKeywordMapping(session, dialectUri), utils.IMPORT) {
+			utils.MarkResourceStart(utils.CLAIMS, dialectUri)
+			err = importClaimDialect(session, dialectId, dialectUri, claimFilePath)
 			if err != nil {
-				utils.PrintLog(utils.LogLevelError, utils.CLAIMS, dialectUri, fmt.Sprintf("Error importing claim dialect: %s", err))
-				utils.UpdateFailureSummary(utils.CLAIMS, dialectUri)
+				utils.UpdateFailureSummaryAndLog(utils.CLAIMS, dialectUri, utils.IMPORT, err, fmt.Sprintf("Error importing claim dialect: %s", err))
 			}
 		}
 	}
-	removeStaleClaimsFromLocalDialect()
+	removeStaleClaimsFromLocalDialect(session)
 }
 
-func importClaimDialect(dialectId, dialectUri, importFilePath string) error {
+func importClaimDialect(session *utils.Session, dialectId, dialectUri, importFilePath string) error {
 
 	fileBytes, err := ioutil.ReadFile(importFilePath)
 	if err != nil {
@@ -98,15 +98,27 @@ func importClaimDialect(dialectId, dialectUri, importFilePath string) error {
 	}
 
 	// Replace keyword placeholders in the local file according to the keyword mappings added in configs.
-	claimKeywordMapping := getClaimKeywordMapping(dialectUri)
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), claimKeywordMapping)
+	claimKeywordMapping := getClaimKeywordMapping(session, dialectUri)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), claimKeywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.CLAIMS, importFilePath, modifiedFileData, claimKeywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.CLAIMS, importFilePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
+
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.CLAIMS, dialectUri, utils.GetImportAction(dialectId != ""), "")
+		return nil
+	}
 
 	// Min version requirement for claims export api is removed. CRUD apis used for all versions
-	if utils.ExportAPIExists(utils.CLAIMS) {
+	if session.ExportAPIExists(utils.CLAIMS) {
 		if dialectId == "" {
-			return importDialect(dialectUri, importFilePath, modifiedFileData)
+			return importDialect(session, dialectUri, importFilePath, modifiedFileData)
 		}
-		return updateDialect(dialectId, dialectUri, importFilePath, modifiedFileData)
+		return updateDialect(session, dialectId, dialectUri, importFilePath, modifiedFileData)
 	}
 
 	format, err := utils.FormatFromExtension(filepath.Ext(importFilePath))
@@ -120,97 +132,93 @@ func importClaimDialect(dialectId, dialectUri, importFilePath string) error {
 	}
 
 	if dialectId == "" {
-		return importClaimDialectWithCRUD(dialectUri, claims)
+		return importClaimDialectWithCRUD(session, dialectUri, claims)
 	}
-	return updateClaimDialectWithCRUD(dialectId, dialectUri, claims)
+	return updateClaimDialectWithCRUD(session, dialectId, dialectUri, claims)
 }
 
-func importDialect(dialectUri, importFilePath, modifiedFileData string) error {
+func importDialect(session *utils.Session, dialectUri, importFilePath, modifiedFileData string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, dialectUri, "Creating new claim dialect")
-	resp, err := utils.SendImportRequest(importFilePath, modifiedFileData, utils.CLAIMS)
+	resp, err := session.SendImportRequest(importFilePath, modifiedFileData, utils.CLAIMS)
 	if err != nil {
 		return fmt.Errorf("error when importing claim dialect: %s", err)
 	}
 	defer resp.Body.Close()
-	utils.UpdateSuccessSummary(utils.CLAIMS, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, dialectUri, "Imported successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.CLAIMS, dialectUri, utils.IMPORT, "Imported successfully")
 	return nil
 }
 
-func updateDialect(dialectId, dialectUri, importFilePath, modifiedFileData string) error {
+func updateDialect(session *utils.Session, dialectId, dialectUri, importFilePath, modifiedFileData string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, dialectUri, "Updating claim dialect")
-	err := utils.SendUpdateRequest(dialectId, importFilePath, modifiedFileData, utils.CLAIMS)
+	err := session.SendUpdateRequest(dialectId, importFilePath, modifiedFileData, utils.CLAIMS)
 	if err != nil {
 		return fmt.Errorf("error when updating claim dialect: %s", err)
 	}
-	utils.UpdateSuccessSummary(utils.CLAIMS, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, dialectUri, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.CLAIMS, dialectUri, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func importClaimDialectWithCRUD(dialectURI string, claims []map[string]interface{}) error {
+func importClaimDialectWithCRUD(session *utils.Session, dialectURI string, claims []map[string]interface{}) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, dialectURI, "Creating new claim dialect")
 
-	newDialectId, err := createDialect(dialectURI)
+	newDialectId, err := createDialect(session, dialectURI)
 	if err != nil {
 		return fmt.Errorf("error when creating claim dialect: %w", err)
 	}
 	for _, claim := range claims {
-		if err := createClaim(newDialectId, claim); err != nil {
+		if err := createClaim(session, newDialectId, claim); err != nil {
 			return fmt.Errorf("error when creating claims of dialect %s: %w", dialectURI, err)
 		}
 	}
 
-	utils.UpdateSuccessSummary(utils.CLAIMS, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, dialectURI, "Imported successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.CLAIMS, dialectURI, utils.IMPORT, "Imported successfully")
 	return nil
 }
 
-func updateClaimDialectWithCRUD(dialectId, dialectURI string, localClaims []map[string]interface{}) error {
+func updateClaimDialectWithCRUD(session *utils.Session, dialectId, dialectURI string, localClaims []map[string]interface{}) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, dialectURI, "Updating claim dialect")
 
-	deployedClaims, err := getClaimsList(dialectId)
+	deployedClaims, err := getClaimsList(session, dialectId)
 	if err != nil {
 		return fmt.Errorf("error retrieving deployed claims for dialect: %w", err)
 	}
-	if utils.TOOL_CONFIGS.AllowDelete {
+	if session.ToolConfigs.AllowDelete {
 		if dialectId == utils.LOCAL_CLAIM_DIALECT {
 			localClaimDialectSummary.DialectURI = dialectURI
 			localClaimDialectSummary.LocalClaims = localClaims
 			localClaimDialectSummary.DeployedClaims = deployedClaims
 		} else {
-			err := removeDeletedDeployedClaims(dialectId, deployedClaims, localClaims)
+			err := removeDeletedDeployedClaims(session, dialectId, deployedClaims, localClaims)
 			if err != nil {
 				return fmt.Errorf("error removing deleted claims of dialect: %w", err)
 			}
 		}
 	}
 
-	if err := updateChangedClaims(dialectId, localClaims, deployedClaims); err != nil {
+	if err := updateChangedClaims(session, dialectId, localClaims, deployedClaims); err != nil {
 		return fmt.Errorf("error updating changed claims of dialect: %w", err)
 	}
 
-	if dialectId == utils.LOCAL_CLAIM_DIALECT && utils.TOOL_CONFIGS.AllowDelete {
+	if dialectId == utils.LOCAL_CLAIM_DIALECT && session.ToolConfigs.AllowDelete {
 		localClaimDialectSummary.Success = true
 	} else {
-		utils.UpdateSuccessSummary(utils.CLAIMS, utils.UPDATE)
+		utils.UpdateSuccessSummaryAndLog(utils.CLAIMS, dialectURI, utils.UPDATE, "Updated successfully")
 	}
-	utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, dialectURI, "Updated successfully")
 	return nil
 }
 
-func createDialect(dialectURI string) (dialectId string, err error) {
+func createDialect(session *utils.Session, dialectURI string) (dialectId string, err error) {
 
 	dialectJSON, err := json.Marshal(map[string]string{"dialectURI": dialectURI})
 	if err != nil {
 		return "", fmt.Errorf("error serializing claim dialect: %w", err)
 	}
 
-	resp, err := utils.SendPostRequest(utils.CLAIMS, dialectJSON)
+	resp, err := session.SendPostRequest(utils.CLAIMS, dialectJSON)
 	if err != nil {
 		return "", err
 	}
@@ -223,7 +231,7 @@ func createDialect(dialectURI string) (dialectId string, err error) {
 	return path.Base(location), nil
 }
 
-func updateChangedClaims(dialectId string, localClaims, deployedClaims []map[string]interface{}) error {
+func updateChangedClaims(session *utils.Session, dialectId string, localClaims, deployedClaims []map[string]interface{}) error {
 
 	deployedByURI := make(map[string]map[string]interface{})
 	for _, c := range deployedClaims {
@@ -236,11 +244,11 @@ func updateChangedClaims(dialectId string, localClaims, deployedClaims []map[str
 			if !claimChanged(dialectId, claim, deployed) {
 				continue
 			}
-			if err := updateClaim(dialectId, getClaimID(deployed), claim); err != nil {
+			if err := updateClaim(session, dialectId, getClaimID(deployed), claim); err != nil {
 				return err
 			}
 		} else {
-			if err := createClaim(dialectId, claim); err != nil {
+			if err := createClaim(session, dialectId, claim); err != nil {
 				return err
 			}
 		}
@@ -248,14 +256,14 @@ func updateChangedClaims(dialectId string, localClaims, deployedClaims []map[str
 	return nil
 }
 
-func createClaim(dialectId string, claim map[string]interface{}) error {
+func createClaim(session *utils.Session, dialectId string, claim map[string]interface{}) error {
 
 	claimJSON, err := createClaimReqBody(dialectId, claim)
 	if err != nil {
 		return fmt.Errorf("error when marshalling claim %s: %w", getClaimURI(claim), err)
 	}
 
-	resp, err := utils.SendPostRequest(utils.CLAIMS, claimJSON, utils.WithPathSuffix(dialectId+"/claims"))
+	resp, err := session.SendPostRequest(utils.CLAIMS, claimJSON, utils.WithPathSuffix(dialectId+"/claims"))
 	if err != nil {
 		return fmt.Errorf("error when creating claim %s: %w", getClaimURI(claim), err)
 	}
@@ -263,14 +271,14 @@ func createClaim(dialectId string, claim map[string]interface{}) error {
 	return nil
 }
 
-func updateClaim(dialectId, claimId string, claim map[string]interface{}) error {
+func updateClaim(session *utils.Session, dialectId, claimId string, claim map[string]interface{}) error {
 
 	claimJSON, err := createClaimReqBody(dialectId, claim)
 	if err != nil {
 		return fmt.Errorf("error when marshalling claim %s: %w", getClaimURI(claim), err)
 	}
 
-	resp, err := utils.SendPutRequest(utils.CLAIMS, dialectId+"/claims/"+claimId, claimJSON)
+	resp, err := session.SendPutRequest(utils.CLAIMS, dialectId+"/claims/"+claimId, claimJSON)
 	if err != nil {
 		return fmt.Errorf("error when updating claim %s: %w", getClaimURI(claim), err)
 	}
@@ -278,7 +286,7 @@ func updateClaim(dialectId, claimId string, claim map[string]interface{}) error
 	return nil
 }
 
-func removeDeletedDeployedClaimdialect(localFiles []os.FileInfo, deployedClaimDialects []claimDialect) {
+func removeDeletedDeployedClaimdialect(session *utils.Session, localFiles []os.FileInfo, deployedClaimDialects []claimDialect) {
 
 	if len(deployedClaimDialects) == 0 {
 		return
@@ -293,21 +301,24 @@ func removeDeletedDeployedClaimdialect(localFiles []os.FileInfo, deployedClaimDi
 		if _, existsLocally := localDialectNames[formatFileName(claimDialect.DialectURI)]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(claimDialect.DialectURI, utils.TOOL_CONFIGS.ClaimConfigs) {
+		if utils.IsResourceExcluded(claimDialect.DialectURI, session.ToolConfigs.ClaimConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, claimDialect.DialectURI, "Excluded from deletion.")
 			continue
 		}
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.CLAIMS, claimDialect.DialectURI, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.CLAIMS, claimDialect.DialectURI, "Not found locally. Deleting.")
-		if err := utils.SendDeleteRequest(claimDialect.Id, utils.CLAIMS); err != nil {
-			utils.UpdateFailureSummary(utils.CLAIMS, claimDialect.DialectURI)
-			utils.PrintLog(utils.LogLevelError, utils.CLAIMS, claimDialect.DialectURI, fmt.Sprintf("Error deleting claim dialect: %s", err))
+		if err := session.SendDeleteRequest(claimDialect.Id, utils.CLAIMS); err != nil {
+			utils.UpdateFailureSummaryAndLog(utils.CLAIMS, claimDialect.DialectURI, utils.DELETE, err, fmt.Sprintf("Error deleting claim dialect: %s", err))
 		} else {
-			utils.UpdateSuccessSummary(utils.CLAIMS, utils.DELETE)
+			utils.UpdateSuccessSummary(utils.CLAIMS, claimDialect.DialectURI, utils.DELETE)
 		}
 	}
 }
 
-func removeDeletedDeployedClaims(dialectId string, deployedClaims, localClaims []map[string]interface{}) error {
+func removeDeletedDeployedClaims(session *utils.Session, dialectId string, deployedClaims, localClaims []map[string]interface{}) error {
 
 	if len(deployedClaims) == 0 {
 		return nil
@@ -322,7 +333,7 @@ func removeDeletedDeployedClaims(dialectId string, deployedClaims, localClaims [
 		if _, existsLocally := localByURI[getClaimURI(deployed)]; existsLocally {
 			continue
 		}
-		if err := utils.SendDeleteRequest(dialectId+"/claims/"+getClaimID(deployed), utils.CLAIMS); err != nil {
+		if err := session.SendDeleteRequest(dialectId+"/claims/"+getClaimID(deployed), utils.CLAIMS); err != nil {
 			return fmt.Errorf("error deleting claim %s of dialect: %w", getClaimURI(deployed), err)
 		}
 	}
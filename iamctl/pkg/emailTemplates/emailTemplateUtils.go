@@ -36,10 +36,10 @@ type emailTemplate struct {
 	ID string `json:"id"`
 }
 
-func getEmailTemplateTypeList() ([]emailTemplateType, error) {
+func getEmailTemplateTypeList(session *utils.Session) ([]emailTemplateType, error) {
 
 	var list []emailTemplateType
-	body, err := utils.SendGetListRequest(utils.EMAIL_TEMPLATES)
+	body, err := session.SendGetListRequest(utils.EMAIL_TEMPLATES)
 	if err != nil {
 		return nil, fmt.Errorf("error while retrieving email template type list. %w", err)
 	}
@@ -49,9 +49,9 @@ func getEmailTemplateTypeList() ([]emailTemplateType, error) {
 	return list, nil
 }
 
-func getDeployedEmailTemplateTypeNames() []string {
+func getDeployedEmailTemplateTypeNames(session *utils.Session) []string {
 
-	types, err := getEmailTemplateTypeList()
+	types, err := getEmailTemplateTypeList(session)
 	if err != nil {
 		return []string{}
 	}
@@ -72,9 +72,9 @@ func getDeployedEmailTemplatesList(templateType emailTemplateType) []string {
 	return templateIds
 }
 
-func getEmailTemplateTypeDetails(typeId string) (*emailTemplateType, error) {
+func getEmailTemplateTypeDetails(session *utils.Session, typeId string) (*emailTemplateType, error) {
 
-	jsonBytes, err := utils.SendGetRequest(utils.EMAIL_TEMPLATES, typeId)
+	jsonBytes, err := session.SendGetRequest(utils.EMAIL_TEMPLATES, typeId)
 	if err != nil {
 		return nil, fmt.Errorf("error getting email template type details: %w", err)
 	}
@@ -86,7 +86,7 @@ func getEmailTemplateTypeDetails(typeId string) (*emailTemplateType, error) {
 	return &templateType, nil
 }
 
-func createEmailTemplateType(displayName string) (*emailTemplateType, error) {
+func createEmailTemplateType(session *utils.Session, displayName string) (*emailTemplateType, error) {
 
 	body := map[string]string{"displayName": displayName}
 	jsonBody, err := json.Marshal(body)
@@ -94,7 +94,7 @@ func createEmailTemplateType(displayName string) (*emailTemplateType, error) {
 		return nil, fmt.Errorf("error marshaling create type request: %w", err)
 	}
 
-	resp, err := utils.SendPostRequest(utils.EMAIL_TEMPLATES, jsonBody)
+	resp, err := session.SendPostRequest(utils.EMAIL_TEMPLATES, jsonBody)
 	if err != nil {
 		return nil, err
 	}
@@ -133,26 +133,26 @@ func isTemplateExists(templateId string, deployedTemplates []emailTemplate) bool
 	return false
 }
 
-func getEmailTemplateKeywordMapping(typeName string) map[string]interface{} {
+func getEmailTemplateKeywordMapping(session *utils.Session, typeName string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.EmailTemplateConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(typeName, utils.KEYWORD_CONFIGS.EmailTemplateConfigs)
+	if session.KeywordConfigs.EmailTemplateConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(typeName, session.KeywordConfigs.EmailTemplateConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
-func setNotificationTemplatesApiExists() {
+func setNotificationTemplatesApiExists(session *utils.Session) {
 
-	if utils.SERVER_CONFIGS.ServerVersion == "" {
-		utils.NotificationTemplatesApiExists = true
+	if session.ServerConfigs.ServerVersion == "" {
+		session.NotificationTemplatesApiExists = true
 		return
 	}
 
-	res, err := utils.CompareVersions(utils.SERVER_CONFIGS.ServerVersion, utils.MIN_VERSION_NOTIFICATION_TEMPLATES_API)
+	res, err := utils.CompareVersions(session.ServerConfigs.ServerVersion, utils.MIN_VERSION_NOTIFICATION_TEMPLATES_API)
 	// Use the Notification Templates API when the server version is ""
 	if err != nil || res >= 0 {
-		utils.NotificationTemplatesApiExists = true
+		session.NotificationTemplatesApiExists = true
 	} else {
-		utils.NotificationTemplatesApiExists = false
+		session.NotificationTemplatesApiExists = false
 	}
 }
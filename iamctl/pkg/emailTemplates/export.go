@@ -20,7 +20,6 @@ package emailTemplates
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -28,62 +27,62 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
-	setNotificationTemplatesApiExists()
-	if utils.NotificationTemplatesApiExists {
-		notificationTemplates.ExportAll(utils.EMAIL_TEMPLATES, exportFilePath, format)
+	setNotificationTemplatesApiExists(session)
+	if session.NotificationTemplatesApiExists {
+		notificationTemplates.ExportAll(session, utils.EMAIL_TEMPLATES, exportFilePath, format)
 		return
 	}
-	ExportAllLegacyApi(exportFilePath, format)
+	ExportAllLegacyApi(session, exportFilePath, format)
 }
 
-func ExportAllLegacyApi(exportFilePath string, format string) {
+func ExportAllLegacyApi(session *utils.Session, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.EMAIL_TEMPLATES, "", "Exporting email templates...")
 	exportFilePath = filepath.Join(exportFilePath, utils.EMAIL_TEMPLATES.String())
 
-	if utils.ShouldSkip(utils.EMAIL_TEMPLATES) {
+	if session.ShouldSkip(utils.EMAIL_TEMPLATES) {
 		return
 	}
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.EMAIL_TEMPLATES, "", fmt.Sprintf("Error creating email templates directory: %s", err))
 			utils.MarkResTypeFailure(utils.EMAIL_TEMPLATES)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
-			deployedTypeNames := getDeployedEmailTemplateTypeNames()
+		if session.ToolConfigs.AllowDelete {
+			deployedTypeNames := getDeployedEmailTemplateTypeNames(session)
 			utils.RemoveDeletedLocalDirectories(exportFilePath, deployedTypeNames)
 		}
 	}
 
-	types, err := getEmailTemplateTypeList()
+	types, err := getEmailTemplateTypeList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.EMAIL_TEMPLATES, "", fmt.Sprintf("Error retrieving the deployed email templates list: %s", err))
 		utils.MarkResTypeFailure(utils.EMAIL_TEMPLATES)
 	} else {
-		for _, emailType := range types {
-			if !utils.IsResourceExcluded(emailType.DisplayName, utils.TOOL_CONFIGS.EmailTemplateConfigs) {
+		session.ProcessConcurrently(len(types), func(i int) {
+			emailType := types[i]
+			if !utils.IsResourceExcluded(emailType.DisplayName, session.ToolConfigs.EmailTemplateConfigs) {
 				utils.PrintLog(utils.LogLevelInfo, utils.EMAIL_TEMPLATES, emailType.DisplayName, "Exporting")
-				err := exportEmailTemplateType(emailType.ID, emailType.DisplayName, exportFilePath, format)
+				utils.MarkResourceStart(utils.EMAIL_TEMPLATES, emailType.DisplayName)
+				err := exportEmailTemplateType(session, emailType.ID, emailType.DisplayName, exportFilePath, format)
 				if err != nil {
-					utils.UpdateFailureSummary(utils.EMAIL_TEMPLATES, emailType.DisplayName)
-					utils.PrintLog(utils.LogLevelError, utils.EMAIL_TEMPLATES, emailType.DisplayName, fmt.Sprintf("Error while exporting: %s", err))
+					utils.UpdateFailureSummaryAndLog(utils.EMAIL_TEMPLATES, emailType.DisplayName, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 				} else {
-					utils.UpdateSuccessSummary(utils.EMAIL_TEMPLATES, utils.EXPORT)
-					utils.PrintLog(utils.LogLevelInfo, utils.EMAIL_TEMPLATES, emailType.DisplayName, "Exported successfully")
+					utils.UpdateSuccessSummaryAndLog(utils.EMAIL_TEMPLATES, emailType.DisplayName, utils.EXPORT, "Exported successfully")
 				}
 			}
-		}
+		})
 	}
 
 }
 
-func exportEmailTemplateType(typeId, displayName, parentDir, formatString string) error {
+func exportEmailTemplateType(session *utils.Session, typeId, displayName, parentDir, formatString string) error {
 
-	typeDetails, err := getEmailTemplateTypeDetails(typeId)
+	typeDetails, err := getEmailTemplateTypeDetails(session, typeId)
 	if err != nil {
 		return fmt.Errorf("error getting template type details: %w", err)
 	}
@@ -92,19 +91,19 @@ func exportEmailTemplateType(typeId, displayName, parentDir, formatString string
 	typeDir := filepath.Join(parentDir, displayName)
 
 	if _, err := os.Stat(typeDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(typeDir, 0700); err != nil {
+		if err := utils.CreateExportDir(typeDir); err != nil {
 			return fmt.Errorf("error creating template type directory: %w", err)
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
+		if session.ToolConfigs.AllowDelete {
 			deployedTemplateIds := getDeployedEmailTemplatesList(*typeDetails)
 			utils.RemoveDeletedLocalResources(typeDir, deployedTemplateIds)
 		}
 	}
 
-	keywordMapping := getEmailTemplateKeywordMapping(displayName)
+	keywordMapping := getEmailTemplateKeywordMapping(session, displayName)
 	for _, template := range typeDetails.Templates {
-		err := exportEmailTemplate(typeId, template.ID, typeDir, format, keywordMapping)
+		err := exportEmailTemplate(session, typeId, template.ID, typeDir, format, keywordMapping)
 		if err != nil {
 			return fmt.Errorf("error while exporting email template: %s. %w", template.ID, err)
 		}
@@ -113,16 +112,16 @@ func exportEmailTemplateType(typeId, displayName, parentDir, formatString string
 	return nil
 }
 
-func exportEmailTemplate(typeId, templateId, typeDir string, format utils.Format, keywordMapping map[string]interface{}) error {
+func exportEmailTemplate(session *utils.Session, typeId, templateId, typeDir string, format utils.Format, keywordMapping map[string]interface{}) error {
 
-	templateData, err := utils.GetResourceData(utils.EMAIL_TEMPLATES, typeId+"/templates/"+templateId)
+	templateData, err := session.GetResourceData(utils.EMAIL_TEMPLATES, typeId+"/templates/"+templateId)
 	if err != nil {
 		return fmt.Errorf("error while getting email template: %w", err)
 	}
 
 	exportedFileName := utils.GetExportedFilePath(typeDir, templateId, format)
 
-	modifiedData, err := utils.ProcessExportedData(templateData, exportedFileName, format, keywordMapping, utils.EMAIL_TEMPLATES)
+	modifiedData, err := session.ProcessExportedData(templateData, exportedFileName, format, keywordMapping, utils.EMAIL_TEMPLATES)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -132,7 +131,7 @@ func exportEmailTemplate(typeId, templateId, typeDir string, format utils.Format
 		return fmt.Errorf("error while serializing email template: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.EMAIL_TEMPLATES)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
@@ -28,22 +28,22 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
-	setNotificationTemplatesApiExists()
-	if utils.NotificationTemplatesApiExists {
-		notificationTemplates.ImportAll(utils.EMAIL_TEMPLATES, inputDirPath)
+	setNotificationTemplatesApiExists(session)
+	if session.NotificationTemplatesApiExists {
+		notificationTemplates.ImportAll(session, utils.EMAIL_TEMPLATES, inputDirPath)
 		return
 	}
-	ImportAllLegacyApi(inputDirPath)
+	ImportAllLegacyApi(session, inputDirPath)
 }
 
-func ImportAllLegacyApi(inputDirPath string) {
+func ImportAllLegacyApi(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.EMAIL_TEMPLATES, "", "Importing email templates...")
 	importFilePath := filepath.Join(inputDirPath, utils.EMAIL_TEMPLATES.String())
 
-	if utils.ShouldSkip(utils.EMAIL_TEMPLATES) {
+	if session.ShouldSkip(utils.EMAIL_TEMPLATES) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -51,7 +51,7 @@ func ImportAllLegacyApi(inputDirPath string) {
 		return
 	}
 
-	deployedTypes, err := getEmailTemplateTypeList()
+	deployedTypes, err := getEmailTemplateTypeList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.EMAIL_TEMPLATES, "", fmt.Sprintf("Error retrieving deployed email template types: %s", err))
 		utils.MarkResTypeFailure(utils.EMAIL_TEMPLATES)
@@ -64,34 +64,39 @@ func ImportAllLegacyApi(inputDirPath string) {
 		utils.MarkResTypeFailure(utils.EMAIL_TEMPLATES)
 		return
 	}
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedTypes(localTypeDirs, deployedTypes)
+	if session.ToolConfigs.AllowDelete {
+		removeDeletedDeployedTypes(session, localTypeDirs, deployedTypes)
 	}
 
-	for _, entry := range localTypeDirs {
+	session.ProcessConcurrently(len(localTypeDirs), func(i int) {
+		entry := localTypeDirs[i]
 		if !entry.IsDir() {
-			continue
+			return
 		}
 		displayName := entry.Name()
 		localTypePath := filepath.Join(importFilePath, displayName)
 
-		if !utils.IsResourceExcluded(displayName, utils.TOOL_CONFIGS.EmailTemplateConfigs) {
-			err := importEmailTemplateType(localTypePath, displayName, deployedTypes)
+		if !utils.IsResourceExcluded(displayName, session.ToolConfigs.EmailTemplateConfigs) {
+			utils.MarkResourceStart(utils.EMAIL_TEMPLATES, displayName)
+			err := importEmailTemplateType(session, localTypePath, displayName, deployedTypes)
 			if err != nil {
-				utils.UpdateFailureSummary(utils.EMAIL_TEMPLATES, displayName)
-				utils.PrintLog(utils.LogLevelError, utils.EMAIL_TEMPLATES, displayName, fmt.Sprintf("Error importing: %s", err))
+				utils.UpdateFailureSummaryAndLog(utils.EMAIL_TEMPLATES, displayName, utils.IMPORT, err, fmt.Sprintf("Error importing: %s", err))
 			}
 		}
-	}
+	})
 }
 
-func importEmailTemplateType(localTypePath, displayName string, deployedTypes []emailTemplateType) error {
+func importEmailTemplateType(session *utils.Session, localTypePath, displayName string, deployedTypes []emailTemplateType) error {
 
 	var typeId string
 	existingType := isEmailTemplateTypeExists(displayName, deployedTypes)
 	if existingType == nil {
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.EMAIL_TEMPLATES, displayName, utils.PLAN_CREATE, "")
+			return nil
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.EMAIL_TEMPLATES, displayName, "Creating new email template type")
-		created, err := createEmailTemplateType(displayName)
+		created, err := createEmailTemplateType(session, displayName)
 		if err != nil {
 			return fmt.Errorf("error creating email template type: %w", err)
 		}
@@ -101,7 +106,7 @@ func importEmailTemplateType(localTypePath, displayName string, deployedTypes []
 		typeId = existingType.ID
 	}
 
-	typeDetails, err := getEmailTemplateTypeDetails(typeId)
+	typeDetails, err := getEmailTemplateTypeDetails(session, typeId)
 	if err != nil {
 		return fmt.Errorf("error getting deployed templates: %w", err)
 	}
@@ -110,14 +115,14 @@ func importEmailTemplateType(localTypePath, displayName string, deployedTypes []
 	if err != nil {
 		return fmt.Errorf("error reading local template files: %w", err)
 	}
-	if utils.TOOL_CONFIGS.AllowDelete {
-		err := removeDeletedDeployedTemplates(typeId, localFiles, typeDetails.Templates)
+	if session.ToolConfigs.AllowDelete {
+		err := removeDeletedDeployedTemplates(session, typeId, displayName, localFiles, typeDetails.Templates)
 		if err != nil {
 			return fmt.Errorf("error removing deleted deployed templates: %w", err)
 		}
 	}
 
-	keywordMapping := getEmailTemplateKeywordMapping(displayName)
+	keywordMapping := getEmailTemplateKeywordMapping(session, displayName)
 
 	for _, file := range localFiles {
 		filePath := filepath.Join(localTypePath, file.Name())
@@ -126,24 +131,24 @@ func importEmailTemplateType(localTypePath, displayName string, deployedTypes []
 
 		templateExists := isTemplateExists(templateId, typeDetails.Templates)
 
-		err := importEmailTemplate(typeId, templateId, filePath, keywordMapping, templateExists)
+		err := importEmailTemplate(session, typeId, templateId, filePath, keywordMapping, templateExists)
 		if err != nil {
 			return fmt.Errorf("error importing template: %s. %w", templateId, err)
 		}
 	}
 
-	if existingType != nil {
-		utils.UpdateSuccessSummary(utils.EMAIL_TEMPLATES, utils.UPDATE)
-		utils.PrintLog(utils.LogLevelInfo, utils.EMAIL_TEMPLATES, displayName, "Updated successfully")
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.EMAIL_TEMPLATES, displayName, utils.PLAN_UPDATE, "")
+	} else if existingType != nil {
+		utils.UpdateSuccessSummaryAndLog(utils.EMAIL_TEMPLATES, displayName, utils.UPDATE, "Updated successfully")
 	} else {
-		utils.UpdateSuccessSummary(utils.EMAIL_TEMPLATES, utils.IMPORT)
-		utils.PrintLog(utils.LogLevelInfo, utils.EMAIL_TEMPLATES, displayName, "Imported successfully")
+		utils.UpdateSuccessSummaryAndLog(utils.EMAIL_TEMPLATES, displayName, utils.IMPORT, "Imported successfully")
 	}
 
 	return nil
 }
 
-func importEmailTemplate(typeId, templateId, filePath string, keywordMapping map[string]interface{}, templateExists bool) error {
+func importEmailTemplate(session *utils.Session, typeId, templateId, filePath string, keywordMapping map[string]interface{}, templateExists bool) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(filePath))
 	if err != nil {
@@ -155,22 +160,32 @@ func importEmailTemplate(typeId, templateId, filePath string, keywordMapping map
 		return fmt.Errorf("error when reading the file for email template: %w", err)
 	}
 
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.EMAIL_TEMPLATES, filePath, modifiedFileData, keywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.EMAIL_TEMPLATES, filePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
 
+	if utils.DRY_RUN {
+		return nil
+	}
 	if !templateExists {
-		return createTemplate(typeId, []byte(modifiedFileData), format)
+		return createTemplate(session, typeId, []byte(modifiedFileData), format)
 	}
-	return updateTemplate(typeId, templateId, []byte(modifiedFileData), format)
+	return updateTemplate(session, typeId, templateId, []byte(modifiedFileData), format)
 }
 
-func createTemplate(typeId string, requestBody []byte, format utils.Format) error {
+func createTemplate(session *utils.Session, typeId string, requestBody []byte, format utils.Format) error {
 
 	jsonBody, err := utils.PrepareJSONRequestBody(requestBody, format, utils.EMAIL_TEMPLATES)
 	if err != nil {
 		return err
 	}
 
-	resp, err := utils.SendPostRequest(utils.EMAIL_TEMPLATES, jsonBody, utils.WithPathSuffix(typeId))
+	resp, err := session.SendPostRequest(utils.EMAIL_TEMPLATES, jsonBody, utils.WithPathSuffix(typeId))
 	if err != nil {
 		return fmt.Errorf("error when creating email template: %w", err)
 	}
@@ -179,14 +194,14 @@ func createTemplate(typeId string, requestBody []byte, format utils.Format) erro
 	return nil
 }
 
-func updateTemplate(typeId, templateId string, requestBody []byte, format utils.Format) error {
+func updateTemplate(session *utils.Session, typeId, templateId string, requestBody []byte, format utils.Format) error {
 
 	jsonBody, err := utils.PrepareJSONRequestBody(requestBody, format, utils.EMAIL_TEMPLATES)
 	if err != nil {
 		return err
 	}
 
-	resp, err := utils.SendPutRequest(utils.EMAIL_TEMPLATES, typeId+"/templates/"+templateId, jsonBody)
+	resp, err := session.SendPutRequest(utils.EMAIL_TEMPLATES, typeId+"/templates/"+templateId, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when updating email template: %w", err)
 	}
@@ -195,7 +210,7 @@ func updateTemplate(typeId, templateId string, requestBody []byte, format utils.
 	return nil
 }
 
-func removeDeletedDeployedTypes(localDirs []os.FileInfo, deployedTypes []emailTemplateType) {
+func removeDeletedDeployedTypes(session *utils.Session, localDirs []os.FileInfo, deployedTypes []emailTemplateType) {
 
 	if len(deployedTypes) == 0 {
 		return
@@ -212,21 +227,24 @@ func removeDeletedDeployedTypes(localDirs []os.FileInfo, deployedTypes []emailTe
 		if _, existsLocally := localNames[deployedType.DisplayName]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(deployedType.DisplayName, utils.TOOL_CONFIGS.EmailTemplateConfigs) {
+		if utils.IsResourceExcluded(deployedType.DisplayName, session.ToolConfigs.EmailTemplateConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.EMAIL_TEMPLATES, deployedType.DisplayName, "Excluded from deletion.")
 			continue
 		}
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.EMAIL_TEMPLATES, deployedType.DisplayName, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.EMAIL_TEMPLATES, deployedType.DisplayName, "Not found locally. Deleting template type.")
-		if err := utils.SendDeleteRequest(deployedType.ID, utils.EMAIL_TEMPLATES); err != nil {
-			utils.UpdateFailureSummary(utils.EMAIL_TEMPLATES, deployedType.DisplayName)
-			utils.PrintLog(utils.LogLevelError, utils.EMAIL_TEMPLATES, deployedType.DisplayName, fmt.Sprintf("Error deleting email template type: %s", err))
+		if err := session.SendDeleteRequest(deployedType.ID, utils.EMAIL_TEMPLATES); err != nil {
+			utils.UpdateFailureSummaryAndLog(utils.EMAIL_TEMPLATES, deployedType.DisplayName, utils.DELETE, err, fmt.Sprintf("Error deleting email template type: %s", err))
 		} else {
-			utils.UpdateSuccessSummary(utils.EMAIL_TEMPLATES, utils.DELETE)
+			utils.UpdateSuccessSummary(utils.EMAIL_TEMPLATES, deployedType.DisplayName, utils.DELETE)
 		}
 	}
 }
 
-func removeDeletedDeployedTemplates(typeId string, localFiles []os.FileInfo, deployedTemplates []emailTemplate) error {
+func removeDeletedDeployedTemplates(session *utils.Session, typeId, displayName string, localFiles []os.FileInfo, deployedTemplates []emailTemplate) error {
 
 	if len(deployedTemplates) == 0 {
 		return nil
@@ -240,8 +258,12 @@ func removeDeletedDeployedTemplates(typeId string, localFiles []os.FileInfo, dep
 
 	for _, template := range deployedTemplates {
 		if _, existsLocally := localIds[template.ID]; !existsLocally {
+			if utils.DRY_RUN {
+				utils.AddSubResourceToPlan(utils.EMAIL_TEMPLATES, displayName, template.ID, utils.PLAN_DELETE, "Not found locally")
+				continue
+			}
 			utils.PrintLog(utils.LogLevelInfo, utils.EMAIL_TEMPLATES, template.ID, "Not found locally. Deleting template.")
-			if err := utils.SendDeleteRequest(typeId+"/templates/"+template.ID, utils.EMAIL_TEMPLATES); err != nil {
+			if err := session.SendDeleteRequest(typeId+"/templates/"+template.ID, utils.EMAIL_TEMPLATES); err != nil {
 				return fmt.Errorf("error deleting email template: %w", err)
 			}
 		}
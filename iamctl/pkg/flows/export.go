@@ -19,24 +19,24 @@
 package flows
 
 import (
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.FLOWS, "", "Exporting flows...")
 	exportFilePath = filepath.Join(exportFilePath, utils.FLOWS.String())
 
-	if utils.ShouldSkip(utils.FLOWS) {
+	if session.ShouldSkip(utils.FLOWS) {
 		return
 	}
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.FLOWS, "", fmt.Sprintf("Error creating flows directory: %s", err))
 			utils.MarkResTypeFailure(utils.FLOWS)
 			return
@@ -45,18 +45,22 @@ func ExportAll(exportFilePath string, format string) {
 
 	var exportedFlowNames []string
 	for name, id := range flowTypes {
-		if !utils.IsResourceExcluded(name, utils.TOOL_CONFIGS.FlowConfigs) {
+		if !utils.IsResourceExcluded(name, session.ToolConfigs.FlowConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.FLOWS, name, "Exporting")
 
-			exists, err := exportFlow(name, id, exportFilePath, format)
+			utils.MarkResourceStart(utils.FLOWS, name)
+			exists, err := exportFlow(session, name, id, exportFilePath, format)
+			if errors.Is(err, utils.ErrFilteredOut) {
+				// Keep the local file of the flow
+				exportedFlowNames = append(exportedFlowNames, name)
+				continue
+			}
 			if err != nil {
-				utils.UpdateFailureSummary(utils.FLOWS, name)
-				utils.PrintLog(utils.LogLevelError, utils.FLOWS, name, fmt.Sprintf("Error while exporting: %s", err))
+				utils.UpdateFailureSummaryAndLog(utils.FLOWS, name, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 			} else {
 				if exists {
 					exportedFlowNames = append(exportedFlowNames, name)
-					utils.UpdateSuccessSummary(utils.FLOWS, utils.EXPORT)
-					utils.PrintLog(utils.LogLevelInfo, utils.FLOWS, name, "Exported successfully")
+					utils.UpdateSuccessSummaryAndLog(utils.FLOWS, name, utils.EXPORT, "Exported successfully")
 				} else {
 					utils.PrintLog(utils.LogLevelInfo, utils.FLOWS, name, "Not configured")
 				}
@@ -64,12 +68,12 @@ func ExportAll(exportFilePath string, format string) {
 		}
 	}
 
-	if utils.TOOL_CONFIGS.AllowDelete {
+	if session.ToolConfigs.AllowDelete {
 		utils.RemoveDeletedLocalResources(exportFilePath, exportedFlowNames)
 	}
 }
 
-func exportFlow(name, id string, outputDirPath string, formatString string) (exists bool, err error) {
+func exportFlow(session *utils.Session, name, id string, outputDirPath string, formatString string) (exists bool, err error) {
 
 	if name == invitedUserRegistrationFlowName {
 		if _, exists := utils.GetResourceIdentifierMap(utils.GOVERNANCE_CONNECTORS)[utils.USER_ONBOARDING_GOVERNANCE_CATEGORY_ID]; !exists {
@@ -77,7 +81,7 @@ func exportFlow(name, id string, outputDirPath string, formatString string) (exi
 		}
 	}
 
-	flowData, exists, err := getFlowData(id)
+	flowData, exists, err := getFlowData(session, id)
 	if err != nil {
 		return false, fmt.Errorf("error while getting flow data: %w", err)
 	}
@@ -87,9 +91,9 @@ func exportFlow(name, id string, outputDirPath string, formatString string) (exi
 
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, name, format)
-	keywordMapping := getFlowKeywordMapping(name)
+	keywordMapping := getFlowKeywordMapping(session, name)
 
-	modifiedData, err := utils.ProcessExportedData(flowData, exportedFileName, format, keywordMapping, utils.FLOWS)
+	modifiedData, err := session.ProcessExportedData(flowData, exportedFileName, format, keywordMapping, utils.FLOWS)
 	if err != nil {
 		return false, fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -99,16 +103,16 @@ func exportFlow(name, id string, outputDirPath string, formatString string) (exi
 		return false, fmt.Errorf("error while serializing flow: %w", err)
 	}
 
-	if err := ioutil.WriteFile(exportedFileName, modifiedFile, 0644); err != nil {
+	if err := utils.WriteExportedFile(exportedFileName, modifiedFile, utils.FLOWS); err != nil {
 		return false, fmt.Errorf("error when writing exported content to file: %w", err)
 	}
 
 	return true, nil
 }
 
-func getFlowData(id string) (flow map[string]interface{}, exists bool, err error) {
+func getFlowData(session *utils.Session, id string) (flow map[string]interface{}, exists bool, err error) {
 
-	flowData, err := utils.GetResourceData(utils.FLOWS, "", utils.WithQueryParams(map[string]string{"flowType": id}))
+	flowData, err := session.GetResourceData(utils.FLOWS, "", utils.WithQueryParams(map[string]string{"flowType": id}))
 	if err != nil {
 		return nil, false, fmt.Errorf("error while retrieving flow: %w", err)
 	}
@@ -121,7 +125,7 @@ func getFlowData(id string) (flow map[string]interface{}, exists bool, err error
 		return nil, false, nil
 	}
 
-	configData, err := utils.GetResourceData(utils.FLOWS, "config", utils.WithQueryParams(map[string]string{"flowType": id}))
+	configData, err := session.GetResourceData(utils.FLOWS, "config", utils.WithQueryParams(map[string]string{"flowType": id}))
 	if err != nil {
 		return nil, false, fmt.Errorf("error while retrieving flow config: %w", err)
 	}
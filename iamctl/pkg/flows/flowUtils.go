@@ -30,10 +30,10 @@ var flowTypes = map[string]string{
 	invitedUserRegistrationFlowName: "INVITED_USER_REGISTRATION",
 }
 
-func getFlowKeywordMapping(flowType string) map[string]interface{} {
+func getFlowKeywordMapping(session *utils.Session, flowType string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.FlowConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(flowType, utils.KEYWORD_CONFIGS.FlowConfigs)
+	if session.KeywordConfigs.FlowConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(flowType, session.KeywordConfigs.FlowConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
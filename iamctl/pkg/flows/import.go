@@ -28,12 +28,12 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.FLOWS, "", "Importing flows...")
 	importFilePath := filepath.Join(inputDirPath, utils.FLOWS.String())
 
-	if utils.ShouldSkip(utils.FLOWS) {
+	if session.ShouldSkip(utils.FLOWS) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -53,24 +53,23 @@ func ImportAll(inputDirPath string) {
 		fileInfo := utils.GetFileInfo(flowFilePath)
 		name := fileInfo.ResourceName
 
-		if !utils.IsResourceExcluded(name, utils.TOOL_CONFIGS.FlowConfigs) {
+		if !utils.IsResourceExcluded(name, session.ToolConfigs.FlowConfigs) && session.MatchesFileFilter(utils.FLOWS, flowFilePath, getFlowKeywordMapping(session, name), utils.IMPORT) {
+			utils.MarkResourceStart(utils.FLOWS, name)
 			id, ok := flowTypes[name]
 			if !ok {
-				utils.PrintLog(utils.LogLevelError, utils.FLOWS, name, "Error importing flow: unknown flow type")
-				utils.UpdateFailureSummary(utils.FLOWS, name)
+				utils.UpdateFailureSummaryAndLog(utils.FLOWS, name, utils.IMPORT, fmt.Errorf("unknown flow type"), "Error importing flow: unknown flow type")
 				continue
 			}
 
-			err := importFlow(name, id, flowFilePath)
+			err := importFlow(session, name, id, flowFilePath)
 			if err != nil {
-				utils.UpdateFailureSummary(utils.FLOWS, name)
-				utils.PrintLog(utils.LogLevelError, utils.FLOWS, name, fmt.Sprintf("Error importing flow: %s", err))
+				utils.UpdateFailureSummaryAndLog(utils.FLOWS, name, utils.IMPORT, err, fmt.Sprintf("Error importing flow: %s", err))
 			}
 		}
 	}
 }
 
-func importFlow(name, id, importFilePath string) error {
+func importFlow(session *utils.Session, name, id, importFilePath string) error {
 
 	if name == invitedUserRegistrationFlowName {
 		if _, exists := utils.GetResourceIdentifierMap(utils.GOVERNANCE_CONNECTORS)[utils.USER_ONBOARDING_GOVERNANCE_CATEGORY_NAME]; !exists {
@@ -87,13 +86,25 @@ func importFlow(name, id, importFilePath string) error {
 		return fmt.Errorf("error when reading the file: %w", err)
 	}
 
-	keywordMapping := getFlowKeywordMapping(name)
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	keywordMapping := getFlowKeywordMapping(session, name)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.FLOWS, importFilePath, modifiedFileData, keywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.FLOWS, importFilePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
+
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.FLOWS, name, utils.PLAN_UPDATE, "")
+		return nil
+	}
 
-	return updateFlow(name, id, []byte(modifiedFileData), format)
+	return updateFlow(session, name, id, []byte(modifiedFileData), format)
 }
 
-func updateFlow(name, id string, data []byte, format utils.Format) error {
+func updateFlow(session *utils.Session, name, id string, data []byte, format utils.Format) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.FLOWS, name, "Updating flow")
 
@@ -114,30 +125,29 @@ func updateFlow(name, id string, data []byte, format utils.Format) error {
 		return fmt.Errorf("error when marshalling flow request body: %w", err)
 	}
 
-	resp, err := utils.SendPutRequest(utils.FLOWS, "", flowJSON)
+	resp, err := session.SendPutRequest(utils.FLOWS, "", flowJSON)
 	if err != nil {
 		return fmt.Errorf("error when updating flow: %w", err)
 	}
 	resp.Body.Close()
 
 	delete(dataMap, "steps")
-	if err := updateFlowConfig(dataMap); err != nil {
+	if err := updateFlowConfig(session, dataMap); err != nil {
 		return err
 	}
 
-	utils.UpdateSuccessSummary(utils.FLOWS, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.FLOWS, name, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.FLOWS, name, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func updateFlowConfig(dataMap map[string]interface{}) error {
+func updateFlowConfig(session *utils.Session, dataMap map[string]interface{}) error {
 
 	configJSON, err := json.Marshal(dataMap)
 	if err != nil {
 		return fmt.Errorf("error when marshalling flow config request body: %w", err)
 	}
 
-	resp, err := utils.SendPatchRequest(utils.FLOWS, "config", configJSON)
+	resp, err := session.SendPatchRequest(utils.FLOWS, "config", configJSON)
 	if err != nil {
 		return fmt.Errorf("error when updating flow config: %w", err)
 	}
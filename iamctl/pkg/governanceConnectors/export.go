@@ -20,51 +20,50 @@ package governanceConnectors
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.GOVERNANCE_CONNECTORS, "", "Exporting governance connectors...")
 	exportFilePath = filepath.Join(exportFilePath, utils.GOVERNANCE_CONNECTORS.String())
 
-	if utils.ShouldSkip(utils.GOVERNANCE_CONNECTORS) {
+	if session.ShouldSkip(utils.GOVERNANCE_CONNECTORS) {
 		return
 	}
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.GOVERNANCE_CONNECTORS, "", fmt.Sprintf("Error creating governance connectors directory: %s", err))
 			utils.MarkResTypeFailure(utils.GOVERNANCE_CONNECTORS)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
-			deployedCategoryNames := getDeployedCategoryNames()
+		if session.ToolConfigs.AllowDelete {
+			deployedCategoryNames := getDeployedCategoryNames(session)
 			utils.RemoveDeletedLocalDirectories(exportFilePath, deployedCategoryNames)
 		}
 	}
 
-	categories, err := getCategoryList()
+	categories, err := getCategoryList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.GOVERNANCE_CONNECTORS, "", fmt.Sprintf("Error retrieving governance connector categories: %s", err))
 		utils.MarkResTypeFailure(utils.GOVERNANCE_CONNECTORS)
 		return
 	}
-	for _, catInfo := range categories {
-		if !utils.IsResourceExcluded(catInfo.Name, utils.TOOL_CONFIGS.GovernanceConnectorConfigs) {
+	session.ProcessConcurrently(len(categories), func(i int) {
+		catInfo := categories[i]
+		if !utils.IsResourceExcluded(catInfo.Name, session.ToolConfigs.GovernanceConnectorConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.GOVERNANCE_CONNECTORS, catInfo.Name, "Exporting")
 
-			err := exportCategory(catInfo.Id, catInfo.Name, exportFilePath, format)
+			utils.MarkResourceStart(utils.GOVERNANCE_CONNECTORS, catInfo.Name)
+			err := exportCategory(session, catInfo.Id, catInfo.Name, exportFilePath, format)
 			if err != nil {
-				utils.UpdateFailureSummary(utils.GOVERNANCE_CONNECTORS, catInfo.Name)
-				utils.PrintLog(utils.LogLevelError, utils.GOVERNANCE_CONNECTORS, catInfo.Name, fmt.Sprintf("Error while exporting: %s", err))
+				utils.UpdateFailureSummaryAndLog(utils.GOVERNANCE_CONNECTORS, catInfo.Name, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 			} else {
-				utils.UpdateSuccessSummary(utils.GOVERNANCE_CONNECTORS, utils.EXPORT)
-				utils.PrintLog(utils.LogLevelInfo, utils.GOVERNANCE_CONNECTORS, catInfo.Name, "Exported successfully")
+				utils.UpdateSuccessSummaryAndLog(utils.GOVERNANCE_CONNECTORS, catInfo.Name, utils.EXPORT, "Exported successfully")
 
 				if catInfo.Name == utils.USER_ONBOARDING_GOVERNANCE_CATEGORY_NAME {
 					utils.AddToIdentifierMap(utils.GOVERNANCE_CONNECTORS, catInfo.Id, catInfo.Name, utils.EXPORT)
@@ -72,12 +71,12 @@ func ExportAll(exportFilePath string, format string) {
 			}
 		}
 
-	}
+	})
 }
 
-func exportCategory(catId, catName, parentDir, formatString string) error {
+func exportCategory(session *utils.Session, catId, catName, parentDir, formatString string) error {
 
-	connectors, err := getConnectorListForCategory(catId)
+	connectors, err := getConnectorListForCategory(session, catId)
 	if err != nil {
 		return fmt.Errorf("error retrieving connectors: %w", err)
 	}
@@ -86,19 +85,19 @@ func exportCategory(catId, catName, parentDir, formatString string) error {
 	categoryDir := filepath.Join(parentDir, catName)
 
 	if _, err := os.Stat(categoryDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(categoryDir, 0700); err != nil {
+		if err := utils.CreateExportDir(categoryDir); err != nil {
 			return fmt.Errorf("error creating connector category directory: %w", err)
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
+		if session.ToolConfigs.AllowDelete {
 			getDeployedConnectorNames := getDeployedConnectorNames(connectors)
 			utils.RemoveDeletedLocalResources(categoryDir, getDeployedConnectorNames)
 		}
 	}
 
-	keywordMapping := getGovernanceCategoryKeywordMapping(catName)
+	keywordMapping := getGovernanceCategoryKeywordMapping(session, catName)
 	for _, c := range connectors {
-		err := exportConnector(c.Id, c.FriendlyName, catId, categoryDir, format, keywordMapping)
+		err := exportConnector(session, c.Id, c.FriendlyName, catId, categoryDir, format, keywordMapping)
 		if err != nil {
 			return fmt.Errorf("error while exporting connector: %s. %w", c.FriendlyName, err)
 		}
@@ -107,9 +106,9 @@ func exportCategory(catId, catName, parentDir, formatString string) error {
 	return nil
 }
 
-func exportConnector(connectorId, connectorName, categoryId, categoryDir string, format utils.Format, keywordMapping map[string]interface{}) error {
+func exportConnector(session *utils.Session, connectorId, connectorName, categoryId, categoryDir string, format utils.Format, keywordMapping map[string]interface{}) error {
 
-	connectorData, err := utils.GetResourceData(utils.GOVERNANCE_CONNECTORS, categoryId+"/connectors/"+connectorId)
+	connectorData, err := session.GetResourceData(utils.GOVERNANCE_CONNECTORS, categoryId+"/connectors/"+connectorId)
 	if err != nil {
 		return fmt.Errorf("error while getting connector: %w", err)
 	}
@@ -123,7 +122,7 @@ func exportConnector(connectorId, connectorName, categoryId, categoryDir string,
 		utils.PrintLog(utils.LogLevelWarn, utils.GOVERNANCE_CONNECTORS, connectorName, "Group-based password expiry rules are not exported")
 	}
 
-	modifiedData, err := utils.ProcessExportedData(connectorData, exportedFileName, format, keywordMapping, utils.GOVERNANCE_CONNECTORS)
+	modifiedData, err := session.ProcessExportedData(connectorData, exportedFileName, format, keywordMapping, utils.GOVERNANCE_CONNECTORS)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -133,7 +132,7 @@ func exportConnector(connectorId, connectorName, categoryId, categoryDir string,
 		return fmt.Errorf("error while serializing connector: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.GOVERNANCE_CONNECTORS)
 	if err != nil {
 		return fmt.Errorf("error writing exported content to file: %w", err)
 	}
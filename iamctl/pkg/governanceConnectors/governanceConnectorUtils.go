@@ -46,10 +46,10 @@ type connector struct {
 	FriendlyName string `json:"friendlyName"`
 }
 
-func getCategoryList() ([]connectorCategory, error) {
+func getCategoryList(session *utils.Session) ([]connectorCategory, error) {
 
 	var categories []connectorCategory
-	body, err := utils.SendGetListRequest(utils.GOVERNANCE_CONNECTORS)
+	body, err := session.SendGetListRequest(utils.GOVERNANCE_CONNECTORS)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving governance connector category list: %w", err)
 	}
@@ -59,9 +59,9 @@ func getCategoryList() ([]connectorCategory, error) {
 	return categories, nil
 }
 
-func getConnectorListForCategory(categoryId string) ([]connector, error) {
+func getConnectorListForCategory(session *utils.Session, categoryId string) ([]connector, error) {
 
-	body, err := utils.SendGetRequest(utils.GOVERNANCE_CONNECTORS, categoryId+"/connectors")
+	body, err := session.SendGetRequest(utils.GOVERNANCE_CONNECTORS, categoryId+"/connectors")
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving connectors list: %w", err)
 	}
@@ -102,9 +102,9 @@ func getDeployedConnectorNames(connectors []connector) []string {
 	return names
 }
 
-func getDeployedCategoryNames() []string {
+func getDeployedCategoryNames(session *utils.Session) []string {
 
-	categories, err := getCategoryList()
+	categories, err := getCategoryList(session)
 	if err != nil {
 		return []string{}
 	}
@@ -116,12 +116,12 @@ func getDeployedCategoryNames() []string {
 	return catNames
 }
 
-func getGovernanceCategoryKeywordMapping(categoryName string) map[string]interface{} {
+func getGovernanceCategoryKeywordMapping(session *utils.Session, categoryName string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.GovernanceConnectorConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(categoryName, utils.KEYWORD_CONFIGS.GovernanceConnectorConfigs)
+	if session.KeywordConfigs.GovernanceConnectorConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(categoryName, session.KeywordConfigs.GovernanceConnectorConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func processPasswordExpiryConnector(data interface{}, deployedRuleNames []string) error {
@@ -198,9 +198,9 @@ func processPasswordExpiryConnector(data interface{}, deployedRuleNames []string
 	return nil
 }
 
-func getDeployedPasswordExpiryRuleNames(categoryId, connectorId string) ([]string, error) {
+func getDeployedPasswordExpiryRuleNames(session *utils.Session, categoryId, connectorId string) ([]string, error) {
 
-	body, err := utils.SendGetRequest(utils.GOVERNANCE_CONNECTORS, categoryId+"/connectors/"+connectorId)
+	body, err := session.SendGetRequest(utils.GOVERNANCE_CONNECTORS, categoryId+"/connectors/"+connectorId)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving connector: %w", err)
 	}
@@ -218,7 +218,7 @@ func getDeployedPasswordExpiryRuleNames(categoryId, connectorId string) ([]strin
 	return ruleNames, nil
 }
 
-func buildPatchRequestBody(requestBody []byte, format utils.Format, connectorId, categoryId string) ([]byte, error) {
+func buildPatchRequestBody(session *utils.Session, requestBody []byte, format utils.Format, connectorId, categoryId string) ([]byte, error) {
 
 	connectorMap, err := utils.DeserializeToMap(requestBody, format, utils.GOVERNANCE_CONNECTORS)
 	if err != nil {
@@ -227,8 +227,8 @@ func buildPatchRequestBody(requestBody []byte, format utils.Format, connectorId,
 
 	if connectorId == passwordExpiryConnectorId {
 		var deployedRuleNames []string
-		if utils.TOOL_CONFIGS.AllowDelete {
-			deployedRuleNames, err = getDeployedPasswordExpiryRuleNames(categoryId, connectorId)
+		if session.ToolConfigs.AllowDelete {
+			deployedRuleNames, err = getDeployedPasswordExpiryRuleNames(session, categoryId, connectorId)
 			if err != nil {
 				return nil, fmt.Errorf("error retrieving deployed rules of password expiry connector: %w", err)
 			}
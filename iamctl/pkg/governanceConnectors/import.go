@@ -27,12 +27,12 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.GOVERNANCE_CONNECTORS, "", "Importing governance connectors...")
 	importFilePath := filepath.Join(inputDirPath, utils.GOVERNANCE_CONNECTORS.String())
 
-	if utils.ShouldSkip(utils.GOVERNANCE_CONNECTORS) {
+	if session.ShouldSkip(utils.GOVERNANCE_CONNECTORS) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -40,7 +40,7 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	deployedCategories, err := getCategoryList()
+	deployedCategories, err := getCategoryList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.GOVERNANCE_CONNECTORS, "", fmt.Sprintf("Error retrieving governance connector categories: %s", err))
 		utils.MarkResTypeFailure(utils.GOVERNANCE_CONNECTORS)
@@ -54,32 +54,34 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	for _, entry := range localCategoryDirs {
+	session.ProcessConcurrently(len(localCategoryDirs), func(i int) {
+		entry := localCategoryDirs[i]
 		if !entry.IsDir() {
-			continue
+			return
 		}
 		catName := entry.Name()
 		localCategoryPath := filepath.Join(importFilePath, catName)
 
-		if !utils.IsResourceExcluded(catName, utils.TOOL_CONFIGS.GovernanceConnectorConfigs) {
-			err := importCategory(localCategoryPath, catName, deployedCategories)
+		if !utils.IsResourceExcluded(catName, session.ToolConfigs.GovernanceConnectorConfigs) {
+			utils.MarkResourceStart(utils.GOVERNANCE_CONNECTORS, catName)
+			err := importCategory(session, localCategoryPath, catName, deployedCategories)
 			if err != nil {
-				utils.UpdateFailureSummary(utils.GOVERNANCE_CONNECTORS, catName)
-				utils.PrintLog(utils.LogLevelError, utils.GOVERNANCE_CONNECTORS, catName, fmt.Sprintf("Error importing: %s", err))
+				utils.UpdateFailureSummaryAndLog(utils.GOVERNANCE_CONNECTORS, catName, utils.IMPORT, err, fmt.Sprintf("Error importing: %s", err))
 			}
 		}
-	}
+	})
 }
 
-func importCategory(localCategoryPath, catName string, deployedCategories []connectorCategory) error {
+func importCategory(session *utils.Session, localCategoryPath, catName string, deployedCategories []connectorCategory) error {
 
 	catInfo := isCategoryExists(catName, deployedCategories)
 	if catInfo == nil {
 		utils.PrintLog(utils.LogLevelInfo, utils.GOVERNANCE_CONNECTORS, catName, "Not found on server, skipping.")
+		utils.AddToPlan(utils.GOVERNANCE_CONNECTORS, catName, utils.PLAN_NO_OP, "Not found on server")
 		return nil
 	}
 
-	deployedConnectors, err := getConnectorListForCategory(catInfo.Id)
+	deployedConnectors, err := getConnectorListForCategory(session, catInfo.Id)
 	if err != nil {
 		return fmt.Errorf("error retrieving connector list: %w", err)
 	}
@@ -89,7 +91,7 @@ func importCategory(localCategoryPath, catName string, deployedCategories []conn
 		return fmt.Errorf("error reading local connector files: %w", err)
 	}
 
-	keywordMapping := getGovernanceCategoryKeywordMapping(catName)
+	keywordMapping := getGovernanceCategoryKeywordMapping(session, catName)
 
 	for _, file := range localFiles {
 		filePath := filepath.Join(localCategoryPath, file.Name())
@@ -102,14 +104,17 @@ func importCategory(localCategoryPath, catName string, deployedCategories []conn
 			continue
 		}
 
-		err := importConnector(conId, catInfo.Id, filePath, keywordMapping)
+		err := importConnector(session, conId, catInfo.Id, filePath, keywordMapping)
 		if err != nil {
 			return fmt.Errorf("error importing connector: %s. %w", connectorName, err)
 		}
 	}
 
-	utils.UpdateSuccessSummary(utils.GOVERNANCE_CONNECTORS, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.GOVERNANCE_CONNECTORS, catName, "Imported successfully")
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.GOVERNANCE_CONNECTORS, catName, utils.PLAN_UPDATE, "")
+	} else {
+		utils.UpdateSuccessSummaryAndLog(utils.GOVERNANCE_CONNECTORS, catName, utils.UPDATE, "Imported successfully")
+	}
 
 	if catName == utils.USER_ONBOARDING_GOVERNANCE_CATEGORY_NAME {
 		utils.AddToIdentifierMap(utils.GOVERNANCE_CONNECTORS, catInfo.Id, catName, utils.IMPORT)
@@ -117,7 +122,7 @@ func importCategory(localCategoryPath, catName string, deployedCategories []conn
 	return nil
 }
 
-func importConnector(connectorId, categoryId, filePath string, keywordMapping map[string]interface{}) error {
+func importConnector(session *utils.Session, connectorId, categoryId, filePath string, keywordMapping map[string]interface{}) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(filePath))
 	if err != nil {
@@ -129,14 +134,25 @@ func importConnector(connectorId, categoryId, filePath string, keywordMapping ma
 		return fmt.Errorf("error when reading the file for connector: %w", err)
 	}
 
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.GOVERNANCE_CONNECTORS, filePath, modifiedFileData, keywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.GOVERNANCE_CONNECTORS, filePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
+
+	if utils.DRY_RUN {
+		return nil
+	}
 
-	patchBody, err := buildPatchRequestBody([]byte(modifiedFileData), format, connectorId, categoryId)
+	patchBody, err := buildPatchRequestBody(session, []byte(modifiedFileData), format, connectorId, categoryId)
 	if err != nil {
 		return err
 	}
 
-	resp, err := utils.SendPatchRequest(utils.GOVERNANCE_CONNECTORS, categoryId+"/connectors/"+connectorId, patchBody)
+	resp, err := session.SendPatchRequest(utils.GOVERNANCE_CONNECTORS, categoryId+"/connectors/"+connectorId, patchBody)
 	if err != nil {
 		return fmt.Errorf("error when updating connector: %w", err)
 	}
@@ -20,6 +20,7 @@ package identityproviders
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"mime"
@@ -29,25 +30,25 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	// Export all identity providers to the IdentityProviders folder.
 	utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, "", "Exporting identity providers...")
 	exportFilePath = filepath.Join(exportFilePath, utils.IDENTITY_PROVIDERS.String())
-	exportAPIExists := utils.ExportAPIExists(utils.IDENTITY_PROVIDERS)
+	exportAPIExists := session.ExportAPIExists(utils.IDENTITY_PROVIDERS)
 
-	if utils.IsResourceTypeExcluded(utils.IDENTITY_PROVIDERS) {
+	if session.IsResourceTypeExcluded(utils.IDENTITY_PROVIDERS) {
 		return
 	}
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.IDENTITY_PROVIDERS, "", fmt.Sprintf("Error creating identity providers directory: %s", err))
 			utils.MarkResTypeFailure(utils.IDENTITY_PROVIDERS)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
-			deployedIdpNames := getDeployedIdpNames()
+		if session.ToolConfigs.AllowDelete {
+			deployedIdpNames := getDeployedIdpNames(session)
 			if exportAPIExists {
 				deployedIdpNames = append(deployedIdpNames, utils.RESIDENT_IDP_NAME)
 			}
@@ -55,44 +56,46 @@ func ExportAll(exportFilePath string, format string) {
 		}
 	}
 
-	excludeSecerts := utils.AreSecretsExcluded(utils.TOOL_CONFIGS.IdpConfigs)
-	idps, err := getIdpList()
+	excludeSecerts := session.AreSecretsExcluded(session.ToolConfigs.IdpConfigs)
+	idps, err := getIdpList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.IDENTITY_PROVIDERS, "", fmt.Sprintf("Error retrieving the deployed identity providers list: %s", err))
 		utils.MarkResTypeFailure(utils.IDENTITY_PROVIDERS)
 	} else {
-		for _, idp := range idps {
-			if !utils.IsResourceExcluded(idp.Name, utils.TOOL_CONFIGS.IdpConfigs) {
+		session.ProcessConcurrently(len(idps), func(i int) {
+			idp := idps[i]
+			if !utils.IsResourceExcluded(idp.Name, session.ToolConfigs.IdpConfigs) {
 				utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, idp.Name, "Exporting")
 
-				err := exportIdpWithCRUD(idp.Id, idp.Name, exportFilePath, format, excludeSecerts)
+				utils.MarkResourceStart(utils.IDENTITY_PROVIDERS, idp.Name)
+				err := exportIdpWithCRUD(session, idp.Id, idp.Name, exportFilePath, format, excludeSecerts)
+				if errors.Is(err, utils.ErrFilteredOut) {
+					return
+				}
 				if err != nil {
-					utils.UpdateFailureSummary(utils.IDENTITY_PROVIDERS, idp.Name)
-					utils.PrintLog(utils.LogLevelError, utils.IDENTITY_PROVIDERS, idp.Name, fmt.Sprintf("Error while exporting: %s", err))
+					utils.UpdateFailureSummaryAndLog(utils.IDENTITY_PROVIDERS, idp.Name, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 				} else {
-					utils.UpdateSuccessSummary(utils.IDENTITY_PROVIDERS, utils.EXPORT)
-					utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, idp.Name, "Exported successfully")
+					utils.UpdateSuccessSummaryAndLog(utils.IDENTITY_PROVIDERS, idp.Name, utils.EXPORT, "Exported successfully")
 				}
 			}
-		}
+		})
 	}
-	if !utils.IsResourceExcluded(utils.RESIDENT_IDP_NAME, utils.TOOL_CONFIGS.IdpConfigs) && exportAPIExists {
+	if !utils.IsResourceExcluded(utils.RESIDENT_IDP_NAME, session.ToolConfigs.IdpConfigs) && exportAPIExists {
 		utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, utils.RESIDENT_IDP_NAME, "Exporting Resident identity provider")
-		err := exportIdp(utils.RESIDENT_IDP_NAME, exportFilePath, format, excludeSecerts)
-		if err != nil {
-			utils.UpdateFailureSummary(utils.IDENTITY_PROVIDERS, utils.RESIDENT_IDP_NAME)
-			utils.PrintLog(utils.LogLevelError, utils.IDENTITY_PROVIDERS, utils.RESIDENT_IDP_NAME, fmt.Sprintf("Error while exporting resident identity provider: %s", err))
-		} else {
-			utils.UpdateSuccessSummary(utils.IDENTITY_PROVIDERS, utils.EXPORT)
-			utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, utils.RESIDENT_IDP_NAME, "Exported successfully")
+		utils.MarkResourceStart(utils.IDENTITY_PROVIDERS, utils.RESIDENT_IDP_NAME)
+		err := exportIdp(session, utils.RESIDENT_IDP_NAME, exportFilePath, format, excludeSecerts)
+		if err != nil && !errors.Is(err, utils.ErrFilteredOut) {
+			utils.UpdateFailureSummaryAndLog(utils.IDENTITY_PROVIDERS, utils.RESIDENT_IDP_NAME, utils.EXPORT, err, fmt.Sprintf("Error while exporting resident identity provider: %s", err))
+		} else if err == nil {
+			utils.UpdateSuccessSummaryAndLog(utils.IDENTITY_PROVIDERS, utils.RESIDENT_IDP_NAME, utils.EXPORT, "Exported successfully")
 		}
 	}
-	if shouldRemoveOutboundProvisioningRoles() {
+	if shouldRemoveOutboundProvisioningRoles(session) {
 		utils.PrintLog(utils.LogLevelWarn, utils.IDENTITY_PROVIDERS, "", "Outbound provisioning groups of identity providers are not exported")
 	}
 }
 
-func exportIdp(idpId string, outputDirPath string, format string, excludeSecrets bool) error {
+func exportIdp(session *utils.Session, idpId string, outputDirPath string, format string, excludeSecrets bool) error {
 
 	var fileType string
 	// TODO: Extend support for json and xml formats.
@@ -105,7 +108,7 @@ func exportIdp(idpId string, outputDirPath string, format string, excludeSecrets
 		fileType = utils.MEDIA_TYPE_YAML
 	}
 
-	resp, err := utils.SendExportRequest(idpId, fileType, utils.IDENTITY_PROVIDERS, excludeSecrets)
+	resp, err := session.SendExportRequest(idpId, fileType, utils.IDENTITY_PROVIDERS, excludeSecrets)
 	if err != nil {
 		return fmt.Errorf("error while exporting the identity provider: %s", err)
 	}
@@ -125,25 +128,25 @@ func exportIdp(idpId string, outputDirPath string, format string, excludeSecrets
 	if err != nil {
 		return fmt.Errorf("error while reading the response body when exporting IDP: %s. %s", fileName, err)
 	}
-	body = removeProvisioningRole(body)
+	body = removeProvisioningRole(session, body)
 
-	idpKeywordMapping := getIdpKeywordMapping(fileInfo.ResourceName)
-	modifiedFile, err := utils.ProcessExportedContent(exportedFileName, body, idpKeywordMapping, utils.IDENTITY_PROVIDERS_EXPORT_API)
+	idpKeywordMapping := getIdpKeywordMapping(session, fileInfo.ResourceName)
+	modifiedFile, err := session.ProcessExportedContent(exportedFileName, body, idpKeywordMapping, utils.IDENTITY_PROVIDERS_EXPORT_API)
 	if err != nil {
-		return fmt.Errorf("error while processing the exported content: %s", err)
+		return fmt.Errorf("error while processing the exported content: %w", err)
 	}
 	modifiedFile = processIdpGroupFields(modifiedFile)
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.IDENTITY_PROVIDERS_EXPORT_API)
 	if err != nil {
 		return fmt.Errorf("error when writing the exported content to file: %w", err)
 	}
 	return nil
 }
 
-func exportIdpWithCRUD(idpId, idpName, outputDirPath, formatString string, excludeSecrets bool) error {
+func exportIdpWithCRUD(session *utils.Session, idpId, idpName, outputDirPath, formatString string, excludeSecrets bool) error {
 
-	idpMap, err := getIdp(idpId, excludeSecrets)
+	idpMap, err := getIdp(session, idpId, excludeSecrets)
 	if err != nil {
 		return fmt.Errorf("error while getting IDP: %w", err)
 	}
@@ -151,12 +154,12 @@ func exportIdpWithCRUD(idpId, idpName, outputDirPath, formatString string, exclu
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, idpName, format)
 
-	idpKeywordMapping := getIdpKeywordMapping(idpName)
-	preproccessedIdp, err := preprocessIdpKeys(idpMap)
+	idpKeywordMapping := getIdpKeywordMapping(session, idpName)
+	preproccessedIdp, err := preprocessIdpKeys(session, idpMap)
 	if err != nil {
 		return fmt.Errorf("error while preprocessing IDP keys: %w", err)
 	}
-	modifiedIdp, err := utils.ProcessExportedData(preproccessedIdp, exportedFileName, format, idpKeywordMapping, utils.IDENTITY_PROVIDERS)
+	modifiedIdp, err := session.ProcessExportedData(preproccessedIdp, exportedFileName, format, idpKeywordMapping, utils.IDENTITY_PROVIDERS)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -170,7 +173,7 @@ func exportIdpWithCRUD(idpId, idpName, outputDirPath, formatString string, exclu
 		return fmt.Errorf("error while serializing IDP: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.IDENTITY_PROVIDERS)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
@@ -178,9 +181,9 @@ func exportIdpWithCRUD(idpId, idpName, outputDirPath, formatString string, exclu
 	return nil
 }
 
-func getIdp(idpId string, excludeSecrets bool) (map[string]interface{}, error) {
+func getIdp(session *utils.Session, idpId string, excludeSecrets bool) (map[string]interface{}, error) {
 
-	body, err := utils.SendGetRequest(utils.IDENTITY_PROVIDERS, idpId)
+	body, err := session.SendGetRequest(utils.IDENTITY_PROVIDERS, idpId)
 	if err != nil {
 		return nil, fmt.Errorf("error while retrieving IDP: %w", err)
 	}
@@ -194,13 +197,13 @@ func getIdp(idpId string, excludeSecrets bool) (map[string]interface{}, error) {
 		return nil, fmt.Errorf("error unmarshalling IDP response to map: %w", err)
 	}
 
-	if err := processFederatedAuthenticators(idpId, idpStruct, idpMap, excludeSecrets); err != nil {
+	if err := processFederatedAuthenticators(session, idpId, idpStruct, idpMap, excludeSecrets); err != nil {
 		return nil, fmt.Errorf("error while processing federated authenticators of IDP: %w", err)
 	}
-	if err := processOutboundConnectors(idpId, idpStruct, idpMap, excludeSecrets); err != nil {
+	if err := processOutboundConnectors(session, idpId, idpStruct, idpMap, excludeSecrets); err != nil {
 		return nil, fmt.Errorf("error while processing outbound connectors of IDP: %w", err)
 	}
-	if err := processClaims(idpMap); err != nil {
+	if err := processClaims(session, idpMap); err != nil {
 		return nil, fmt.Errorf("error while processing claims of IDP: %w", err)
 	}
 	if err := processGroups(idpMap); err != nil {
@@ -209,7 +212,7 @@ func getIdp(idpId string, excludeSecrets bool) (map[string]interface{}, error) {
 	if err := processImplicitAssociation(idpMap); err != nil {
 		return nil, fmt.Errorf("error while processing implicit association of IDP: %w", err)
 	}
-	if err := removeOutboundProvisioningRoles(idpMap); err != nil {
+	if err := removeOutboundProvisioningRoles(session, idpMap); err != nil {
 		return nil, fmt.Errorf("error while processing outbound provisioning roles: %w", err)
 	}
 
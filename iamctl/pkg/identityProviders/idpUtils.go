@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"sync"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/claims"
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
@@ -76,11 +77,11 @@ var idpPatchSkipKeys = map[string]bool{
 	"templateId":              true,
 }
 
-var customAuthSecretsWarningLogged bool
+var customAuthSecretsWarning sync.Once
 
-func getIdpList() ([]identityProvider, error) {
+func getIdpList(session *utils.Session) ([]identityProvider, error) {
 
-	data, err := utils.SendPaginatedGetListRequest(
+	data, err := session.SendPaginatedGetListRequest(
 		utils.IDENTITY_PROVIDERS,
 		"totalResults",
 		"count",
@@ -99,9 +100,9 @@ func getIdpList() ([]identityProvider, error) {
 	return idps, nil
 }
 
-func getDeployedIdpNames() []string {
+func getDeployedIdpNames(session *utils.Session) []string {
 
-	idps, err := getIdpList()
+	idps, err := getIdpList(session)
 	if err != nil {
 		return []string{}
 	}
@@ -113,12 +114,12 @@ func getDeployedIdpNames() []string {
 	return idpNames
 }
 
-func getIdpKeywordMapping(idpName string) map[string]interface{} {
+func getIdpKeywordMapping(session *utils.Session, idpName string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.IdpConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(idpName, utils.KEYWORD_CONFIGS.IdpConfigs)
+	if session.KeywordConfigs.IdpConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(idpName, session.KeywordConfigs.IdpConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func getIdpId(idpName string, existingIdpList []identityProvider) string {
@@ -131,7 +132,7 @@ func getIdpId(idpName string, existingIdpList []identityProvider) string {
 	return ""
 }
 
-func processFederatedAuthenticators(idpId string, idpStruct idpConfig, idpMap map[string]interface{}, excludeSecrets bool) error {
+func processFederatedAuthenticators(session *utils.Session, idpId string, idpStruct idpConfig, idpMap map[string]interface{}, excludeSecrets bool) error {
 
 	fedAuths, ok := idpMap["federatedAuthenticators"].(map[string]interface{})
 	if !ok || idpStruct.FederatedAuthenticators == nil {
@@ -156,7 +157,7 @@ func processFederatedAuthenticators(idpId string, idpStruct idpConfig, idpMap ma
 			continue
 		}
 
-		fullAuth, err := utils.GetResourceData(utils.IDENTITY_PROVIDERS, idpId+"/federated-authenticators/"+authId)
+		fullAuth, err := session.GetResourceData(utils.IDENTITY_PROVIDERS, idpId+"/federated-authenticators/"+authId)
 		if err != nil {
 			return fmt.Errorf("error while retrieving federated authenticator %s: %w", authId, err)
 		}
@@ -171,15 +172,16 @@ func processFederatedAuthenticators(idpId string, idpStruct idpConfig, idpMap ma
 			return fmt.Errorf("unexpected format for definedBy field of federated authenticator: %s", authId)
 		}
 		if definedBy == "USER" {
-			if !excludeSecrets && !customAuthSecretsWarningLogged {
-				utils.PrintLog(utils.LogLevelWarn, utils.IDENTITY_PROVIDERS, "", "Secrets exclusion cannot be disabled for custom authenticators(service-based). All secrets will be masked.")
-				customAuthSecretsWarningLogged = true
+			if !excludeSecrets {
+				customAuthSecretsWarning.Do(func() {
+					utils.PrintLog(utils.LogLevelWarn, utils.IDENTITY_PROVIDERS, "", "Secrets exclusion cannot be disabled for custom authenticators(service-based). All secrets will be masked.")
+				})
 			}
 			if err := processEndpointAuthProperties(fullAuthMap); err != nil {
 				return fmt.Errorf("error processing endpoint auth properties for authenticator %s: %v", authId, err)
 			}
 		} else if excludeSecrets {
-			if err := maskSecretProperties(fullAuthMap, "meta/federated-authenticators/"+authId); err != nil {
+			if err := maskSecretProperties(session, fullAuthMap, "meta/federated-authenticators/"+authId); err != nil {
 				return fmt.Errorf("error masking secrets for authenticator %s: %v", authId, err)
 			}
 		}
@@ -194,7 +196,7 @@ func processFederatedAuthenticators(idpId string, idpStruct idpConfig, idpMap ma
 	return nil
 }
 
-func processOutboundConnectors(idpId string, idpStruct idpConfig, idpMap map[string]interface{}, excludeSecrets bool) error {
+func processOutboundConnectors(session *utils.Session, idpId string, idpStruct idpConfig, idpMap map[string]interface{}, excludeSecrets bool) error {
 
 	provisioning, ok := idpMap["provisioning"].(map[string]interface{})
 	if !ok || idpStruct.Provisioning == nil || idpStruct.Provisioning.OutboundConnectors == nil {
@@ -223,7 +225,7 @@ func processOutboundConnectors(idpId string, idpStruct idpConfig, idpMap map[str
 			continue
 		}
 
-		fullConn, err := utils.GetResourceData(utils.IDENTITY_PROVIDERS, idpId+"/provisioning/outbound-connectors/"+connId)
+		fullConn, err := session.GetResourceData(utils.IDENTITY_PROVIDERS, idpId+"/provisioning/outbound-connectors/"+connId)
 		if err != nil {
 			return fmt.Errorf("error while retrieving outbound connector %s: %w", connId, err)
 		}
@@ -232,7 +234,7 @@ func processOutboundConnectors(idpId string, idpStruct idpConfig, idpMap map[str
 			if !ok {
 				return fmt.Errorf("unexpected format for retrieved outbound connector: %s", connId)
 			}
-			if err := maskSecretProperties(fullConnMap, "meta/outbound-provisioning-connectors/"+connId); err != nil {
+			if err := maskSecretProperties(session, fullConnMap, "meta/outbound-provisioning-connectors/"+connId); err != nil {
 				return fmt.Errorf("error masking secrets for connector %s: %v", connId, err)
 			}
 		}
@@ -247,7 +249,7 @@ func processOutboundConnectors(idpId string, idpStruct idpConfig, idpMap map[str
 	return nil
 }
 
-func processClaims(idpMap map[string]interface{}) error {
+func processClaims(session *utils.Session, idpMap map[string]interface{}) error {
 
 	claimMap, ok := idpMap["claims"].(map[string]interface{})
 	if !ok {
@@ -269,7 +271,7 @@ func processClaims(idpMap map[string]interface{}) error {
 			if !ok {
 				return fmt.Errorf("invalid format for roleClaim uri")
 			}
-			if claims.RoleClaimUnsupported() && roleClaimUri == "http://wso2.org/claims/role" {
+			if claims.RoleClaimUnsupported(session) && roleClaimUri == "http://wso2.org/claims/role" {
 				claim["uri"] = ""
 			}
 		}
@@ -343,27 +345,22 @@ func processEndpointAuthProperties(authenticatorMap map[string]interface{}) erro
 		}
 	}
 
+	secretProperties, ok := utils.AUTH_SECRET_PROPERTIES[authType]
+	if !ok {
+		return fmt.Errorf("unknown endpoint authentication type: %s", authType)
+	}
 	switch authType {
-	case "NONE":
 	case "BASIC":
 		if _, exists := props["username"]; !exists {
 			props["username"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
 		}
-		props["password"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
-	case "BEARER":
-		props["accessToken"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
 	case "API_KEY":
 		if _, exists := props["header"]; !exists {
 			props["header"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
 		}
-		props["value"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
-	case "CLIENT_CREDENTIAL":
-		props["clientSecret"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
-	case "PASSWORD_CREDENTIAL":
-		props["clientSecret"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
-		props["password"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
-	default:
-		return fmt.Errorf("unknown endpoint authentication type: %s", authType)
+	}
+	for _, property := range secretProperties {
+		props[property] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
 	}
 
 	auth["properties"] = props
@@ -377,9 +374,9 @@ func processEndpointAuthProperties(authenticatorMap map[string]interface{}) erro
 	return nil
 }
 
-func maskSecretProperties(resourceMap map[string]interface{}, metaPath string) error {
+func maskSecretProperties(session *utils.Session, resourceMap map[string]interface{}, metaPath string) error {
 
-	body, err := utils.SendGetRequest(utils.IDENTITY_PROVIDERS, metaPath)
+	body, err := session.SendGetRequest(utils.IDENTITY_PROVIDERS, metaPath)
 	if err != nil {
 		return fmt.Errorf("error fetching metadata from %s: %w", metaPath, err)
 	}
@@ -392,6 +389,7 @@ func maskSecretProperties(resourceMap map[string]interface{}, metaPath string) e
 	for _, prop := range meta.Properties {
 		if prop.IsConfidential {
 			confidentialKeys[prop.Key] = true
+			utils.AddSecretPaths(utils.IDENTITY_PROVIDERS, fmt.Sprintf("**.properties.[key=%s].value", prop.Key))
 		}
 	}
 	properties, ok := resourceMap["properties"].([]interface{})
@@ -415,7 +413,7 @@ func maskSecretProperties(resourceMap map[string]interface{}, metaPath string) e
 	return nil
 }
 
-func preprocessIdpKeys(data interface{}) (interface{}, error) {
+func preprocessIdpKeys(session *utils.Session, data interface{}) (interface{}, error) {
 
 	data = utils.ConvertToStringKeyMap(data)
 	d, ok := data.(map[string]interface{})
@@ -489,9 +487,9 @@ func buildIdpPatchOps(idpMap map[string]interface{}) []map[string]interface{} {
 	return patchOps
 }
 
-func buildCertificatePatchOps(idpId string, localIdpStruct idpConfig) ([]map[string]interface{}, error) {
+func buildCertificatePatchOps(session *utils.Session, idpId string, localIdpStruct idpConfig) ([]map[string]interface{}, error) {
 
-	body, err := utils.SendGetRequest(utils.IDENTITY_PROVIDERS, idpId)
+	body, err := session.SendGetRequest(utils.IDENTITY_PROVIDERS, idpId)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching deployed identity provider: %w", err)
 	}
@@ -581,14 +579,14 @@ func buildCertificatePatchOps(idpId string, localIdpStruct idpConfig) ([]map[str
 	return patchOps, nil
 }
 
-func patchIdp(idpId string, patchOps []map[string]interface{}) error {
+func patchIdp(session *utils.Session, idpId string, patchOps []map[string]interface{}) error {
 
 	body, err := json.Marshal(patchOps)
 	if err != nil {
 		return fmt.Errorf("error marshalling patch operations for identity provider: %w", err)
 	}
 
-	resp, err := utils.SendPatchRequest(utils.IDENTITY_PROVIDERS, idpId, body)
+	resp, err := session.SendPatchRequest(utils.IDENTITY_PROVIDERS, idpId, body)
 	if err != nil {
 		return err
 	}
@@ -596,9 +594,9 @@ func patchIdp(idpId string, patchOps []map[string]interface{}) error {
 	return nil
 }
 
-func removeOutboundProvisioningRoles(idpMap map[string]interface{}) error {
+func removeOutboundProvisioningRoles(session *utils.Session, idpMap map[string]interface{}) error {
 
-	if shouldRemoveOutboundProvisioningRoles() {
+	if shouldRemoveOutboundProvisioningRoles(session) {
 		roles, ok := idpMap["roles"].(map[string]interface{})
 		if !ok {
 			return fmt.Errorf("unexpected format for roles")
@@ -608,9 +606,9 @@ func removeOutboundProvisioningRoles(idpMap map[string]interface{}) error {
 	return nil
 }
 
-func removeProvisioningRole(fileContent []byte) []byte {
+func removeProvisioningRole(session *utils.Session, fileContent []byte) []byte {
 
-	if !shouldRemoveOutboundProvisioningRoles() {
+	if !shouldRemoveOutboundProvisioningRoles(session) {
 		return fileContent
 	}
 	yamlPattern := regexp.MustCompile(`(?m)(^\s*provisioningRole:)[^\n]*`)
@@ -641,12 +639,12 @@ func init() {
 	utils.DataPreprocessFuncs[utils.IDENTITY_PROVIDERS] = preprocessIdpKeys
 }
 
-func shouldRemoveOutboundProvisioningRoles() bool {
+func shouldRemoveOutboundProvisioningRoles(session *utils.Session) bool {
 
-	if utils.SERVER_CONFIGS.ServerVersion == "" {
+	if session.ServerConfigs.ServerVersion == "" {
 		return true
 	}
-	cmp, err := utils.CompareVersions(utils.SERVER_CONFIGS.ServerVersion, utils.MIN_VERSION_OUTBOUND_PROV_GROUPS)
+	cmp, err := utils.CompareVersions(session.ServerConfigs.ServerVersion, utils.MIN_VERSION_OUTBOUND_PROV_GROUPS)
 	// Consider outbound provisioning groups exist when the server version is ""
 	return err != nil || cmp >= 0
 }
@@ -29,13 +29,13 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, "", "Importing identity providers...")
 	importFilePath := filepath.Join(inputDirPath, utils.IDENTITY_PROVIDERS.String())
-	exportAPIExists := utils.ExportAPIExists(utils.IDENTITY_PROVIDERS)
+	exportAPIExists := session.ExportAPIExists(utils.IDENTITY_PROVIDERS)
 
-	if utils.IsResourceTypeExcluded(utils.IDENTITY_PROVIDERS) {
+	if session.IsResourceTypeExcluded(utils.IDENTITY_PROVIDERS) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -43,7 +43,7 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	existingIdpList, err := getIdpList()
+	existingIdpList, err := getIdpList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.IDENTITY_PROVIDERS, "", fmt.Sprintf("Error when retrieving the deployed identity provider list. %s", err))
 		utils.MarkResTypeFailure(utils.IDENTITY_PROVIDERS)
@@ -57,46 +57,58 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	for _, file := range files {
+	session.ProcessConcurrently(len(files), func(i int) {
+		file := files[i]
 		idpFilePath := filepath.Join(importFilePath, file.Name())
 		fileInfo := utils.GetFileInfo(idpFilePath)
 		idpName := fileInfo.ResourceName
 
-		if !utils.IsResourceExcluded(idpName, utils.TOOL_CONFIGS.IdpConfigs) {
+		if !utils.IsResourceExcluded(idpName, session.ToolConfigs.IdpConfigs) && session.MatchesFileFilter(utils.IDENTITY_PROVIDERS, idpFilePath, getIdpKeywordMapping(session, idpName), utils.IMPORT) {
 			var idpId string
 			if idpName == utils.RESIDENT_IDP_NAME {
 				if !exportAPIExists {
-					continue
+					return
 				}
 				idpId = utils.RESIDENT_IDP_NAME
 			} else {
 				idpId = getIdpId(idpName, existingIdpList)
 			}
 
-			err := importIdp(idpId, idpName, idpFilePath, exportAPIExists)
+			utils.MarkResourceStart(utils.IDENTITY_PROVIDERS, idpName)
+			err := importIdp(session, idpId, idpName, idpFilePath, exportAPIExists)
 			if err != nil {
-				utils.PrintLog(utils.LogLevelError, utils.IDENTITY_PROVIDERS, idpName, fmt.Sprintf("Error importing identity provider: %s", err))
-				utils.UpdateFailureSummary(utils.IDENTITY_PROVIDERS, idpName)
+				utils.UpdateFailureSummaryAndLog(utils.IDENTITY_PROVIDERS, idpName, utils.IMPORT, err, fmt.Sprintf("Error importing identity provider: %s", err))
 			}
 		}
-	}
-	if shouldRemoveOutboundProvisioningRoles() {
+	})
+	if shouldRemoveOutboundProvisioningRoles(session) {
 		utils.PrintLog(utils.LogLevelWarn, utils.IDENTITY_PROVIDERS, "", "Outbound provisioning groups of identity providers are removed during import")
 	}
 }
 
-func importIdp(idpId string, idpName string, importFilePath string, exportAPIExists bool) error {
+func importIdp(session *utils.Session, idpId string, idpName string, importFilePath string, exportAPIExists bool) error {
 
 	fileBytes, err := ioutil.ReadFile(importFilePath)
 	if err != nil {
 		return fmt.Errorf("error when reading the file for identity provider: %s", err)
 	}
 
-	idpKeywordMapping := getIdpKeywordMapping(idpName)
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), idpKeywordMapping)
+	idpKeywordMapping := getIdpKeywordMapping(session, idpName)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), idpKeywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.IDENTITY_PROVIDERS, importFilePath, modifiedFileData, idpKeywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.IDENTITY_PROVIDERS, importFilePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
 
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.IDENTITY_PROVIDERS, idpName, utils.GetImportAction(idpId != ""), "")
+		return nil
+	}
 	if exportAPIExists && idpId == utils.RESIDENT_IDP_NAME {
-		return updateIdentityProvider(idpId, idpName, importFilePath, modifiedFileData)
+		return updateIdentityProvider(session, idpId, idpName, importFilePath, modifiedFileData)
 	}
 
 	format, err := utils.FormatFromExtension(filepath.Ext(importFilePath))
@@ -105,37 +117,35 @@ func importIdp(idpId string, idpName string, importFilePath string, exportAPIExi
 	}
 
 	if idpId == "" {
-		return importIdpWithCRUD(idpName, []byte(modifiedFileData), format)
+		return importIdpWithCRUD(session, idpName, []byte(modifiedFileData), format)
 	}
-	return updateIdpWithCRUD(idpId, idpName, []byte(modifiedFileData), format)
+	return updateIdpWithCRUD(session, idpId, idpName, []byte(modifiedFileData), format)
 }
 
-func importIdentityProvider(idpName, importFilePath, modifiedFileData string) error {
+func importIdentityProvider(session *utils.Session, idpName, importFilePath, modifiedFileData string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, idpName, "Creating new identity provider")
-	resp, err := utils.SendImportRequest(importFilePath, modifiedFileData, utils.IDENTITY_PROVIDERS)
+	resp, err := session.SendImportRequest(importFilePath, modifiedFileData, utils.IDENTITY_PROVIDERS)
 	if err != nil {
 		return fmt.Errorf("error when importing identity provider: %s", err)
 	}
 	defer resp.Body.Close()
-	utils.UpdateSuccessSummary(utils.IDENTITY_PROVIDERS, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, idpName, "Imported successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.IDENTITY_PROVIDERS, idpName, utils.IMPORT, "Imported successfully")
 	return nil
 }
 
-func updateIdentityProvider(idpId, idpName, importFilePath, modifiedFileData string) error {
+func updateIdentityProvider(session *utils.Session, idpId, idpName, importFilePath, modifiedFileData string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, idpName, "Updating identity provider")
-	err := utils.SendUpdateRequest(idpId, importFilePath, modifiedFileData, utils.IDENTITY_PROVIDERS)
+	err := session.SendUpdateRequest(idpId, importFilePath, modifiedFileData, utils.IDENTITY_PROVIDERS)
 	if err != nil {
 		return fmt.Errorf("error when updating identity provider: %s", err)
 	}
-	utils.UpdateSuccessSummary(utils.IDENTITY_PROVIDERS, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, idpName, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.IDENTITY_PROVIDERS, idpName, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func importIdpWithCRUD(idpName string, requestBody []byte, format utils.Format) error {
+func importIdpWithCRUD(session *utils.Session, idpName string, requestBody []byte, format utils.Format) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, idpName, "Creating new identity provider")
 
@@ -145,20 +155,19 @@ func importIdpWithCRUD(idpName string, requestBody []byte, format utils.Format)
 	}
 	isEnabled := idpMap["isEnabled"]
 
-	newIdpId, err := createIdp(idpMap)
+	newIdpId, err := createIdp(session, idpMap)
 	if err != nil {
 		return fmt.Errorf("error creating identity provider: %w", err)
 	}
-	if err := patchIdpIsEnabled(newIdpId, isEnabled); err != nil {
+	if err := patchIdpIsEnabled(session, newIdpId, isEnabled); err != nil {
 		return fmt.Errorf("error setting isEnabled for identity provider: %w", err)
 	}
 
-	utils.UpdateSuccessSummary(utils.IDENTITY_PROVIDERS, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, idpName, "Imported successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.IDENTITY_PROVIDERS, idpName, utils.IMPORT, "Imported successfully")
 	return nil
 }
 
-func updateIdpWithCRUD(idpId, idpName string, requestBody []byte, format utils.Format) error {
+func updateIdpWithCRUD(session *utils.Session, idpId, idpName string, requestBody []byte, format utils.Format) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, idpName, "Updating identity provider")
 
@@ -173,31 +182,30 @@ func updateIdpWithCRUD(idpId, idpName string, requestBody []byte, format utils.F
 
 	patchOps := buildIdpPatchOps(idpMap)
 	if len(patchOps) > 0 {
-		if err := patchIdp(idpId, patchOps); err != nil {
+		if err := patchIdp(session, idpId, patchOps); err != nil {
 			return fmt.Errorf("error updating identity provider: %w", err)
 		}
 	}
-	if err := updateIdpCertificate(idpId, idpStruct); err != nil {
+	if err := updateIdpCertificate(session, idpId, idpStruct); err != nil {
 		return fmt.Errorf("error updating certificate: %w", err)
 	}
 
-	if err := updateIdpSubResources(idpId, idpStruct); err != nil {
+	if err := updateIdpSubResources(session, idpId, idpStruct); err != nil {
 		return err
 	}
 
-	utils.UpdateSuccessSummary(utils.IDENTITY_PROVIDERS, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, idpName, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.IDENTITY_PROVIDERS, idpName, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func createIdp(idpMap map[string]interface{}) (string, error) {
+func createIdp(session *utils.Session, idpMap map[string]interface{}) (string, error) {
 
 	reqBody, err := createPostRequestBody(idpMap)
 	if err != nil {
 		return "", err
 	}
 
-	resp, err := utils.SendPostRequest(utils.IDENTITY_PROVIDERS, reqBody)
+	resp, err := session.SendPostRequest(utils.IDENTITY_PROVIDERS, reqBody)
 	if err != nil {
 		return "", err
 	}
@@ -210,7 +218,7 @@ func createIdp(idpMap map[string]interface{}) (string, error) {
 	return path.Base(location), nil
 }
 
-func patchIdpIsEnabled(idpId string, isEnabled interface{}) error {
+func patchIdpIsEnabled(session *utils.Session, idpId string, isEnabled interface{}) error {
 
 	enabled, ok := isEnabled.(bool)
 	if !ok {
@@ -223,12 +231,12 @@ func patchIdpIsEnabled(idpId string, isEnabled interface{}) error {
 	patchBody := buildIdpPatchOps(map[string]interface{}{
 		"isEnabled": false,
 	})
-	return patchIdp(idpId, patchBody)
+	return patchIdp(session, idpId, patchBody)
 }
 
-func updateIdpCertificate(idpId string, localIdpStruct idpConfig) error {
+func updateIdpCertificate(session *utils.Session, idpId string, localIdpStruct idpConfig) error {
 
-	patchOps, err := buildCertificatePatchOps(idpId, localIdpStruct)
+	patchOps, err := buildCertificatePatchOps(session, idpId, localIdpStruct)
 	if err != nil {
 		return fmt.Errorf("error building certificate patch operations: %w", err)
 	}
@@ -236,17 +244,17 @@ func updateIdpCertificate(idpId string, localIdpStruct idpConfig) error {
 	if len(patchOps) == 0 {
 		return nil
 	}
-	return patchIdp(idpId, patchOps)
+	return patchIdp(session, idpId, patchOps)
 }
 
-func updateIdpSubResources(idpId string, idpStruct idpConfig) error {
+func updateIdpSubResources(session *utils.Session, idpId string, idpStruct idpConfig) error {
 
 	if idpStruct.FederatedAuthenticators != nil {
 		body, err := json.Marshal(idpStruct.FederatedAuthenticators)
 		if err != nil {
 			return fmt.Errorf("error marshalling federated authenticators: %w", err)
 		}
-		resp, err := utils.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/federated-authenticators", body)
+		resp, err := session.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/federated-authenticators", body)
 		if err != nil {
 			return fmt.Errorf("error updating federated authenticators: %w", err)
 		}
@@ -258,7 +266,7 @@ func updateIdpSubResources(idpId string, idpStruct idpConfig) error {
 		if err != nil {
 			return fmt.Errorf("error marshalling claims: %w", err)
 		}
-		resp, err := utils.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/claims", body)
+		resp, err := session.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/claims", body)
 		if err != nil {
 			return fmt.Errorf("error updating claims: %w", err)
 		}
@@ -270,7 +278,7 @@ func updateIdpSubResources(idpId string, idpStruct idpConfig) error {
 		if err != nil {
 			return fmt.Errorf("error marshalling roles: %w", err)
 		}
-		resp, err := utils.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/roles", body)
+		resp, err := session.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/roles", body)
 		if err != nil {
 			return fmt.Errorf("error updating roles: %w", err)
 		}
@@ -282,7 +290,7 @@ func updateIdpSubResources(idpId string, idpStruct idpConfig) error {
 		if err != nil {
 			return fmt.Errorf("error marshalling groups: %w", err)
 		}
-		resp, err := utils.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/groups", body)
+		resp, err := session.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/groups", body)
 		if err != nil {
 			return fmt.Errorf("error updating groups: %w", err)
 		}
@@ -294,7 +302,7 @@ func updateIdpSubResources(idpId string, idpStruct idpConfig) error {
 		if err != nil {
 			return fmt.Errorf("error marshalling implicit association: %w", err)
 		}
-		resp, err := utils.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/implicit-association", body)
+		resp, err := session.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/implicit-association", body)
 		if err != nil {
 			return fmt.Errorf("error updating implicit association: %w", err)
 		}
@@ -307,7 +315,7 @@ func updateIdpSubResources(idpId string, idpStruct idpConfig) error {
 			if err != nil {
 				return fmt.Errorf("error marshalling JIT provisioning: %w", err)
 			}
-			resp, err := utils.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/provisioning/jit", body)
+			resp, err := session.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/provisioning/jit", body)
 			if err != nil {
 				return fmt.Errorf("error updating JIT provisioning: %w", err)
 			}
@@ -319,7 +327,7 @@ func updateIdpSubResources(idpId string, idpStruct idpConfig) error {
 			if err != nil {
 				return fmt.Errorf("error marshalling outbound connectors: %w", err)
 			}
-			resp, err := utils.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/provisioning/outbound-connectors", body)
+			resp, err := session.SendPutRequest(utils.IDENTITY_PROVIDERS, idpId+"/provisioning/outbound-connectors", body)
 			if err != nil {
 				return fmt.Errorf("error updating outbound connectors: %w", err)
 			}
@@ -330,14 +338,14 @@ func updateIdpSubResources(idpId string, idpStruct idpConfig) error {
 	return nil
 }
 
-func RemoveDeletedDeployedIdps(inputDirPath string) {
+func RemoveDeletedDeployedIdps(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, "", "Removing deleted identity providers...")
 
-	if !utils.TOOL_CONFIGS.AllowDelete {
+	if !session.IsDeleteAllowed(utils.IDENTITY_PROVIDERS) {
 		return
 	}
-	if utils.IsResourceTypeExcluded(utils.IDENTITY_PROVIDERS) {
+	if session.IsResourceTypeExcluded(utils.IDENTITY_PROVIDERS) {
 		return
 	}
 
@@ -351,7 +359,7 @@ func RemoveDeletedDeployedIdps(inputDirPath string) {
 		utils.PrintLog(utils.LogLevelError, utils.IDENTITY_PROVIDERS, "", fmt.Sprintf("Error reading local identity provider files: %s", err))
 		return
 	}
-	deployedIdps, err := getIdpList()
+	deployedIdps, err := getIdpList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.IDENTITY_PROVIDERS, "", fmt.Sprintf("Error when retrieving deployed identity provider list: %s", err))
 		utils.MarkResTypeFailure(utils.IDENTITY_PROVIDERS)
@@ -367,17 +375,20 @@ func RemoveDeletedDeployedIdps(inputDirPath string) {
 		if _, existsLocally := localResourceNames[idp.Name]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(idp.Name, utils.TOOL_CONFIGS.IdpConfigs) || idp.Name == utils.RESIDENT_IDP_NAME {
+		if utils.IsResourceExcluded(idp.Name, session.ToolConfigs.IdpConfigs) || idp.Name == utils.RESIDENT_IDP_NAME {
 			utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, idp.Name, "Excluded from deletion")
 			continue
 		}
 
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.IDENTITY_PROVIDERS, idp.Name, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.IDENTITY_PROVIDERS, idp.Name, "Not found locally. Deleting idp.")
-		if err := utils.SendDeleteRequest(idp.Id, utils.IDENTITY_PROVIDERS); err != nil {
-			utils.UpdateFailureSummary(utils.IDENTITY_PROVIDERS, idp.Name)
-			utils.PrintLog(utils.LogLevelError, utils.IDENTITY_PROVIDERS, idp.Name, fmt.Sprintf("Error deleting idp: %s", err))
+		if err := session.SendDeleteRequest(idp.Id, utils.IDENTITY_PROVIDERS); err != nil {
+			utils.UpdateFailureSummaryAndLog(utils.IDENTITY_PROVIDERS, idp.Name, utils.DELETE, err, fmt.Sprintf("Error deleting idp: %s", err))
 		} else {
-			utils.UpdateSuccessSummary(utils.IDENTITY_PROVIDERS, utils.DELETE)
+			utils.UpdateSuccessSummary(utils.IDENTITY_PROVIDERS, idp.Name, utils.DELETE)
 		}
 	}
 }
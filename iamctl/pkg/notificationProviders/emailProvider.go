@@ -20,12 +20,12 @@ package notificationProviders
 
 import "github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 
-func ExportAllEmailProviders(exportFilePath, format string) {
+func ExportAllEmailProviders(session *utils.Session, exportFilePath, format string) {
 
-	exportAll(utils.EMAIL_PROVIDERS, exportFilePath, format)
+	exportAll(session, utils.EMAIL_PROVIDERS, exportFilePath, format)
 }
 
-func ImportAllEmailProviders(inputDirPath string) {
+func ImportAllEmailProviders(session *utils.Session, inputDirPath string) {
 
-	importAll(utils.EMAIL_PROVIDERS, inputDirPath)
+	importAll(session, utils.EMAIL_PROVIDERS, inputDirPath)
 }
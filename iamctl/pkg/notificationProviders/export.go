@@ -19,76 +19,79 @@
 package notificationProviders
 
 import (
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func exportAll(resType utils.ResourceType, exportFilePath string, format string) {
+func exportAll(session *utils.Session, resType utils.ResourceType, exportFilePath string, format string) {
 
 	logName := getProviderLogName(resType)
 	utils.PrintLog(utils.LogLevelInfo, resType, "", fmt.Sprintf("Exporting %s...", logName))
 	exportFilePath = filepath.Join(exportFilePath, resType.String())
 
-	if resType == utils.EMAIL_PROVIDERS && utils.SERVER_CONFIGS.TenantDomain == utils.DEFAULT_TENANT_DOMAIN {
+	if resType == utils.EMAIL_PROVIDERS && session.ServerConfigs.TenantDomain == utils.DEFAULT_TENANT_DOMAIN {
 		utils.PrintLog(utils.LogLevelInfo, resType, "", "Exporting email providers for super tenant not supported.")
-		utils.UpdateSkipSummary(utils.EMAIL_PROVIDERS, "Not supported in super tenant")
+		utils.UpdateSkipSummary(utils.EMAIL_PROVIDERS, utils.SKIP_REASON_SUPER_TENANT)
 		return
 	}
-	if utils.ShouldSkip(resType) {
+	if session.ShouldSkip(resType) {
 		return
 	}
 
-	providers, err := getProviderList(resType)
+	providers, err := getProviderList(session, resType)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, resType, "", fmt.Sprintf("Error while retrieving the %s list: %s", logName, err))
 		utils.MarkResTypeFailure(resType)
 		return
 	}
 
-	if resType == utils.EMAIL_PROVIDERS && !utils.AreSecretsExcluded(utils.TOOL_CONFIGS.EmailProviderConfigs) {
+	if resType == utils.EMAIL_PROVIDERS && !session.AreSecretsExcluded(session.ToolConfigs.EmailProviderConfigs) {
 		utils.PrintLog(utils.LogLevelWarn, resType, "", "Secrets exclusion cannot be disabled for email providers. All secrets will be masked.")
 	}
 
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, resType, "", fmt.Sprintf("Error creating %s directory: %s", logName, err))
 			utils.MarkResTypeFailure(resType)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
+		if session.ToolConfigs.AllowDelete {
 			utils.RemoveDeletedLocalResources(exportFilePath, getDeployedProviderNames(providers))
 		}
 	}
 
-	for _, provider := range providers {
-		if !utils.IsResourceExcluded(provider.Name, getProviderResourceConfig(resType)) {
+	session.ProcessConcurrently(len(providers), func(i int) {
+		provider := providers[i]
+		if !utils.IsResourceExcluded(provider.Name, getProviderResourceConfig(session, resType)) {
 			utils.PrintLog(utils.LogLevelInfo, resType, provider.Name, fmt.Sprintf("Exporting %s", logName))
 
-			err := exportProvider(resType, logName, provider.Name, exportFilePath, format)
+			utils.MarkResourceStart(resType, provider.Name)
+			err := exportProvider(session, resType, logName, provider.Name, exportFilePath, format)
+			if errors.Is(err, utils.ErrFilteredOut) {
+				return
+			}
 			if err != nil {
-				utils.UpdateFailureSummary(resType, provider.Name)
-				utils.PrintLog(utils.LogLevelError, resType, provider.Name, fmt.Sprintf("Error while exporting %s: %s", logName, err))
+				utils.UpdateFailureSummaryAndLog(resType, provider.Name, utils.EXPORT, err, fmt.Sprintf("Error while exporting %s: %s", logName, err))
 			} else {
-				utils.UpdateSuccessSummary(resType, utils.EXPORT)
-				utils.PrintLog(utils.LogLevelInfo, resType, provider.Name, fmt.Sprintf("%s exported successfully", logName))
+				utils.UpdateSuccessSummaryAndLog(resType, provider.Name, utils.EXPORT, fmt.Sprintf("%s exported successfully", logName))
 			}
 		}
-	}
+	})
 }
 
-func exportProvider(resType utils.ResourceType, logName string, name string, outputDirPath string, formatString string) error {
+func exportProvider(session *utils.Session, resType utils.ResourceType, logName string, name string, outputDirPath string, formatString string) error {
 
-	data, err := utils.GetResourceData(resType, name)
+	data, err := session.GetResourceData(resType, name)
 	if err != nil {
 		return fmt.Errorf("error while getting %s: %w", logName, err)
 	}
 
-	data, err = processAuthSecrets(resType, data)
+	data, err = processAuthSecrets(session, resType, data)
 	if err != nil {
 		return fmt.Errorf("error while processing auth secrets: %w", err)
 	}
@@ -96,8 +99,8 @@ func exportProvider(resType utils.ResourceType, logName string, name string, out
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, name, format)
 
-	keywordMapping := getProviderKeywordMapping(resType, name)
-	modifiedData, err := utils.ProcessExportedData(data, exportedFileName, format, keywordMapping, resType)
+	keywordMapping := getProviderKeywordMapping(session, resType, name)
+	modifiedData, err := session.ProcessExportedData(data, exportedFileName, format, keywordMapping, resType)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -107,7 +110,7 @@ func exportProvider(resType utils.ResourceType, logName string, name string, out
 		return fmt.Errorf("error while serializing %s: %w", logName, err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, resType)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
@@ -27,18 +27,18 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func importAll(resType utils.ResourceType, inputDirPath string) {
+func importAll(session *utils.Session, resType utils.ResourceType, inputDirPath string) {
 
 	logName := getProviderLogName(resType)
 	utils.PrintLog(utils.LogLevelInfo, resType, "", fmt.Sprintf("Importing %s...", logName))
 	importFilePath := filepath.Join(inputDirPath, resType.String())
 
-	if resType == utils.EMAIL_PROVIDERS && utils.SERVER_CONFIGS.TenantDomain == utils.DEFAULT_TENANT_DOMAIN {
+	if resType == utils.EMAIL_PROVIDERS && session.ServerConfigs.TenantDomain == utils.DEFAULT_TENANT_DOMAIN {
 		utils.PrintLog(utils.LogLevelInfo, resType, "", "Importing email providers for super tenant not supported.")
-		utils.UpdateSkipSummary(utils.EMAIL_PROVIDERS, "Not supported in super tenant")
+		utils.UpdateSkipSummary(utils.EMAIL_PROVIDERS, utils.SKIP_REASON_SUPER_TENANT)
 		return
 	}
-	if utils.ShouldSkip(resType) {
+	if session.ShouldSkip(resType) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -46,7 +46,7 @@ func importAll(resType utils.ResourceType, inputDirPath string) {
 		return
 	}
 
-	existingProviderList, err := getProviderList(resType)
+	existingProviderList, err := getProviderList(session, resType)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, resType, "", fmt.Sprintf("Error retrieving the deployed %s list: %s", logName, err))
 		utils.MarkResTypeFailure(resType)
@@ -59,27 +59,28 @@ func importAll(resType utils.ResourceType, inputDirPath string) {
 		return
 	}
 
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedProviders(resType, files, existingProviderList, logName)
+	if session.IsDeleteAllowed(resType) {
+		removeDeletedDeployedProviders(session, resType, files, existingProviderList, logName)
 	}
 
-	for _, file := range files {
+	session.ProcessConcurrently(len(files), func(i int) {
+		file := files[i]
 		providerFilePath := filepath.Join(importFilePath, file.Name())
 		fileInfo := utils.GetFileInfo(providerFilePath)
 		providerName := fileInfo.ResourceName
 
-		if !utils.IsResourceExcluded(providerName, getProviderResourceConfig(resType)) {
+		if !utils.IsResourceExcluded(providerName, getProviderResourceConfig(session, resType)) && session.MatchesFileFilter(resType, providerFilePath, getProviderKeywordMapping(session, resType, providerName), utils.IMPORT) {
 			providerExists := isProviderExists(providerName, existingProviderList)
-			err := importProvider(resType, logName, providerName, providerExists, providerFilePath)
+			utils.MarkResourceStart(resType, providerName)
+			err := importProvider(session, resType, logName, providerName, providerExists, providerFilePath)
 			if err != nil {
-				utils.PrintLog(utils.LogLevelError, resType, providerName, fmt.Sprintf("Error importing %s: %s", logName, err))
-				utils.UpdateFailureSummary(resType, providerName)
+				utils.UpdateFailureSummaryAndLog(resType, providerName, utils.IMPORT, err, fmt.Sprintf("Error importing %s: %s", logName, err))
 			}
 		}
-	}
+	})
 }
 
-func importProvider(resType utils.ResourceType, logName string, name string, exists bool, importFilePath string) error {
+func importProvider(session *utils.Session, resType utils.ResourceType, logName string, name string, exists bool, importFilePath string) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(importFilePath))
 	if err != nil {
@@ -91,16 +92,28 @@ func importProvider(resType utils.ResourceType, logName string, name string, exi
 		return fmt.Errorf("error when reading the file for %s: %w", logName, err)
 	}
 
-	keywordMapping := getProviderKeywordMapping(resType, name)
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	keywordMapping := getProviderKeywordMapping(session, resType, name)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(resType, importFilePath, modifiedFileData, keywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(resType, importFilePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
+
+	if utils.DRY_RUN {
+		utils.AddToPlan(resType, name, utils.GetImportAction(exists), "")
+		return nil
+	}
 
 	if !exists {
-		return createProvider(resType, []byte(modifiedFileData), format, name, logName)
+		return createProvider(session, resType, []byte(modifiedFileData), format, name, logName)
 	}
-	return updateProvider(resType, name, []byte(modifiedFileData), format, logName)
+	return updateProvider(session, resType, name, []byte(modifiedFileData), format, logName)
 }
 
-func createProvider(resType utils.ResourceType, requestBody []byte, format utils.Format, name string, logName string) error {
+func createProvider(session *utils.Session, resType utils.ResourceType, requestBody []byte, format utils.Format, name string, logName string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, resType, name, fmt.Sprintf("Creating new %s", logName))
 
@@ -109,18 +122,17 @@ func createProvider(resType utils.ResourceType, requestBody []byte, format utils
 		return err
 	}
 
-	resp, err := utils.SendPostRequest(resType, jsonBody)
+	resp, err := session.SendPostRequest(resType, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when creating %s: %w", logName, err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(resType, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, resType, name, fmt.Sprintf("%s created successfully", logName))
+	utils.UpdateSuccessSummaryAndLog(resType, name, utils.IMPORT, fmt.Sprintf("%s created successfully", logName))
 	return nil
 }
 
-func updateProvider(resType utils.ResourceType, name string, requestBody []byte, format utils.Format, logName string) error {
+func updateProvider(session *utils.Session, resType utils.ResourceType, name string, requestBody []byte, format utils.Format, logName string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, resType, name, fmt.Sprintf("Updating %s", logName))
 
@@ -129,18 +141,17 @@ func updateProvider(resType utils.ResourceType, name string, requestBody []byte,
 		return err
 	}
 
-	resp, err := utils.SendPutRequest(resType, name, updateBody)
+	resp, err := session.SendPutRequest(resType, name, updateBody)
 	if err != nil {
 		return fmt.Errorf("error when updating %s: %w", logName, err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(resType, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, resType, name, fmt.Sprintf("%s updated successfully", logName))
+	utils.UpdateSuccessSummaryAndLog(resType, name, utils.UPDATE, fmt.Sprintf("%s updated successfully", logName))
 	return nil
 }
 
-func removeDeletedDeployedProviders(resType utils.ResourceType, localFiles []os.FileInfo, deployedProviders []notificationProvider, logName string) {
+func removeDeletedDeployedProviders(session *utils.Session, resType utils.ResourceType, localFiles []os.FileInfo, deployedProviders []notificationProvider, logName string) {
 
 	if len(deployedProviders) == 0 {
 		return
@@ -156,17 +167,20 @@ func removeDeletedDeployedProviders(resType utils.ResourceType, localFiles []os.
 		if _, existsLocally := localResourceNames[provider.Name]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(provider.Name, getProviderResourceConfig(resType)) {
+		if utils.IsResourceExcluded(provider.Name, getProviderResourceConfig(session, resType)) {
 			utils.PrintLog(utils.LogLevelInfo, resType, provider.Name, fmt.Sprintf("%s is excluded from deletion", logName))
 			continue
 		}
 
+		if utils.DRY_RUN {
+			utils.AddToPlan(resType, provider.Name, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, resType, provider.Name, fmt.Sprintf("%s not found locally. Deleting.", logName))
-		if err := utils.SendDeleteRequest(provider.Name, resType); err != nil {
-			utils.UpdateFailureSummary(resType, provider.Name)
-			utils.PrintLog(utils.LogLevelError, resType, provider.Name, fmt.Sprintf("Error deleting %s: %s", logName, err))
+		if err := session.SendDeleteRequest(provider.Name, resType); err != nil {
+			utils.UpdateFailureSummaryAndLog(resType, provider.Name, utils.DELETE, err, fmt.Sprintf("Error deleting %s: %s", logName, err))
 		} else {
-			utils.UpdateSuccessSummary(resType, utils.DELETE)
+			utils.UpdateSuccessSummary(resType, provider.Name, utils.DELETE)
 		}
 	}
 }
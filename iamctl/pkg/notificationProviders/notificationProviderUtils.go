@@ -29,24 +29,24 @@ type notificationProvider struct {
 	Name string `json:"name"`
 }
 
-func getProviderResourceConfig(resType utils.ResourceType) map[string]interface{} {
+func getProviderResourceConfig(session *utils.Session, resType utils.ResourceType) map[string]interface{} {
 
 	switch resType {
 	case utils.EMAIL_PROVIDERS:
-		return utils.TOOL_CONFIGS.EmailProviderConfigs
+		return session.ToolConfigs.EmailProviderConfigs
 	case utils.SMS_PROVIDERS:
-		return utils.TOOL_CONFIGS.SmsProviderConfigs
+		return session.ToolConfigs.SmsProviderConfigs
 	}
 	return nil
 }
 
-func getProviderKeywordConfig(resType utils.ResourceType) map[string]interface{} {
+func getProviderKeywordConfig(session *utils.Session, resType utils.ResourceType) map[string]interface{} {
 
 	switch resType {
 	case utils.EMAIL_PROVIDERS:
-		return utils.KEYWORD_CONFIGS.EmailProviderConfigs
+		return session.KeywordConfigs.EmailProviderConfigs
 	case utils.SMS_PROVIDERS:
-		return utils.KEYWORD_CONFIGS.SmsProviderConfigs
+		return session.KeywordConfigs.SmsProviderConfigs
 	}
 	return nil
 }
@@ -62,10 +62,10 @@ func getProviderLogName(resType utils.ResourceType) string {
 	return string(resType)
 }
 
-func getProviderList(resType utils.ResourceType) ([]notificationProvider, error) {
+func getProviderList(session *utils.Session, resType utils.ResourceType) ([]notificationProvider, error) {
 
 	var list []notificationProvider
-	body, err := utils.SendGetListRequest(resType)
+	body, err := session.SendGetListRequest(resType)
 	if err != nil {
 		return nil, fmt.Errorf("error while getting the list: %w", err)
 	}
@@ -84,13 +84,13 @@ func getDeployedProviderNames(providers []notificationProvider) []string {
 	return names
 }
 
-func getProviderKeywordMapping(resType utils.ResourceType, name string) map[string]interface{} {
+func getProviderKeywordMapping(session *utils.Session, resType utils.ResourceType, name string) map[string]interface{} {
 
-	kwConfig := getProviderKeywordConfig(resType)
+	kwConfig := getProviderKeywordConfig(session, resType)
 	if kwConfig != nil {
-		return utils.ResolveAdvancedKeywordMapping(name, kwConfig)
+		return session.ResolveAdvancedKeywordMapping(name, kwConfig)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func isProviderExists(name string, existingProviderList []notificationProvider) bool {
@@ -103,7 +103,7 @@ func isProviderExists(name string, existingProviderList []notificationProvider)
 	return false
 }
 
-func processAuthSecrets(resType utils.ResourceType, data interface{}) (interface{}, error) {
+func processAuthSecrets(session *utils.Session, resType utils.ResourceType, data interface{}) (interface{}, error) {
 
 	providerMap, ok := data.(map[string]interface{})
 	if !ok {
@@ -133,13 +133,13 @@ func processAuthSecrets(resType utils.ResourceType, data interface{}) (interface
 	case utils.SMS_PROVIDERS:
 		if provider != "Custom" {
 			delete(providerMap, "authentication")
-			if utils.AreSecretsExcluded(utils.TOOL_CONFIGS.SmsProviderConfigs) {
+			if session.AreSecretsExcluded(session.ToolConfigs.SmsProviderConfigs) {
 				providerMap["secret"] = utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
 			}
 			return providerMap, nil
 		}
 
-		if !utils.AreSecretsExcluded(utils.TOOL_CONFIGS.SmsProviderConfigs) {
+		if !session.AreSecretsExcluded(session.ToolConfigs.SmsProviderConfigs) {
 			utils.PrintLog(utils.LogLevelWarn, resType, "", "Secrets exclusion cannot be disabled for Custom sms providers. All secrets will be masked.")
 		}
 		delete(providerMap, "secret")
@@ -20,12 +20,12 @@ package notificationProviders
 
 import "github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 
-func ExportAllSmsProviders(exportFilePath, format string) {
+func ExportAllSmsProviders(session *utils.Session, exportFilePath, format string) {
 
-	exportAll(utils.SMS_PROVIDERS, exportFilePath, format)
+	exportAll(session, utils.SMS_PROVIDERS, exportFilePath, format)
 }
 
-func ImportAllSmsProviders(inputDirPath string) {
+func ImportAllSmsProviders(session *utils.Session, inputDirPath string) {
 
-	importAll(utils.SMS_PROVIDERS, inputDirPath)
+	importAll(session, utils.SMS_PROVIDERS, inputDirPath)
 }
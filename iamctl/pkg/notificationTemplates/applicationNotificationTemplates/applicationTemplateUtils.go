@@ -31,10 +31,10 @@ type appTemplate struct {
 	Locale string `json:"locale"`
 }
 
-func getAppTemplatesList(rt utils.ResourceType, typeId, appId string) ([]appTemplate, error) {
+func getAppTemplatesList(session *utils.Session, rt utils.ResourceType, typeId, appId string) ([]appTemplate, error) {
 
 	var list []appTemplate
-	body, err := utils.SendGetRequest(rt, typeId+"/app-templates/"+appId)
+	body, err := session.SendGetRequest(rt, typeId+"/app-templates/"+appId)
 	if err != nil {
 		return nil, err
 	}
@@ -20,14 +20,13 @@ package applicationNotificationTemplates
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportTemplateType(rt utils.ResourceType, typeId, displayName, typeDir string, format utils.Format, keywordMapping map[string]interface{}) (bool, error) {
+func ExportTemplateType(session *utils.Session, rt utils.ResourceType, typeId, displayName, typeDir string, format utils.Format, keywordMapping map[string]interface{}) (bool, error) {
 
 	appMap := utils.GetResourceIdentifierMap(utils.APPLICATIONS)
 	if len(appMap) == 0 {
@@ -42,7 +41,7 @@ func ExportTemplateType(rt utils.ResourceType, typeId, displayName, typeDir stri
 
 	var appsWithTemplates []string
 	for appId, appName := range appMap {
-		hadAppTemplates, err := exportTemplatesOfApp(rt, typeId, appId, appName, appsDir, format, keywordMapping)
+		hadAppTemplates, err := exportTemplatesOfApp(session, rt, typeId, appId, appName, appsDir, format, keywordMapping)
 		if err != nil {
 			return false, fmt.Errorf("error exporting templates of application %s: %w", appName, err)
 		}
@@ -51,10 +50,10 @@ func ExportTemplateType(rt utils.ResourceType, typeId, displayName, typeDir stri
 		}
 	}
 
-	if utils.TOOL_CONFIGS.AllowDelete && appsDirExistedBefore {
+	if session.ToolConfigs.AllowDelete && appsDirExistedBefore {
 		utils.RemoveDeletedLocalDirectories(appsDir, appsWithTemplates)
 		if len(appsWithTemplates) == 0 {
-			if err := os.Remove(appsDir); err != nil {
+			if err := utils.RemoveLocalDirectory(appsDir); err != nil {
 				utils.PrintLog(utils.LogLevelError, rt, displayName, fmt.Sprintf("Error removing application templates directory: %s", err))
 			} else {
 				utils.PrintLog(utils.LogLevelInfo, rt, displayName, fmt.Sprintf("Removed the directory: %s", ApplicationTemplatesDir))
@@ -64,9 +63,9 @@ func ExportTemplateType(rt utils.ResourceType, typeId, displayName, typeDir stri
 	return len(appsWithTemplates) > 0, nil
 }
 
-func exportTemplatesOfApp(rt utils.ResourceType, typeId, appId, appName, appsDir string, format utils.Format, keywordMapping map[string]interface{}) (bool, error) {
+func exportTemplatesOfApp(session *utils.Session, rt utils.ResourceType, typeId, appId, appName, appsDir string, format utils.Format, keywordMapping map[string]interface{}) (bool, error) {
 
-	templates, err := getAppTemplatesList(rt, typeId, appId)
+	templates, err := getAppTemplatesList(session, rt, typeId, appId)
 	if err != nil {
 		return false, fmt.Errorf("error retrieving templates list: %w", err)
 	}
@@ -76,31 +75,31 @@ func exportTemplatesOfApp(rt utils.ResourceType, typeId, appId, appName, appsDir
 
 	appDir := filepath.Join(appsDir, appName)
 	if _, err := os.Stat(appDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(appDir, 0700); err != nil {
+		if err := utils.CreateExportDir(appDir); err != nil {
 			return false, fmt.Errorf("error creating template directory: %w", err)
 		}
-	} else if utils.TOOL_CONFIGS.AllowDelete {
+	} else if session.ToolConfigs.AllowDelete {
 		utils.RemoveDeletedLocalResources(appDir, getDeployedAppTemplateLocales(templates))
 	}
 
 	for _, template := range templates {
-		if err := exportAppTemplate(rt, typeId, appId, template.Locale, appDir, format, keywordMapping); err != nil {
+		if err := exportAppTemplate(session, rt, typeId, appId, template.Locale, appDir, format, keywordMapping); err != nil {
 			return false, fmt.Errorf("error while exporting template %s. %w", template.Locale, err)
 		}
 	}
 	return true, nil
 }
 
-func exportAppTemplate(rt utils.ResourceType, typeId, appId, locale, appDir string, format utils.Format, keywordMapping map[string]interface{}) error {
+func exportAppTemplate(session *utils.Session, rt utils.ResourceType, typeId, appId, locale, appDir string, format utils.Format, keywordMapping map[string]interface{}) error {
 
-	templateData, err := utils.GetResourceData(rt, typeId+"/app-templates/"+appId+"/"+locale)
+	templateData, err := session.GetResourceData(rt, typeId+"/app-templates/"+appId+"/"+locale)
 	if err != nil {
 		return fmt.Errorf("error while getting template: %w", err)
 	}
 
 	exportedFileName := utils.GetExportedFilePath(appDir, locale, format)
 
-	modifiedData, err := utils.ProcessExportedData(templateData, exportedFileName, format, keywordMapping, rt)
+	modifiedData, err := session.ProcessExportedData(templateData, exportedFileName, format, keywordMapping, rt)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -109,7 +108,7 @@ func exportAppTemplate(rt utils.ResourceType, typeId, appId, locale, appDir stri
 	if err != nil {
 		return fmt.Errorf("error while serializing template: %w", err)
 	}
-	if err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644); err != nil {
+	if err = utils.WriteExportedFile(exportedFileName, modifiedFile, rt); err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
 
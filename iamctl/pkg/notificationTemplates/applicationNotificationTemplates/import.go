@@ -27,12 +27,12 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportTemplateType(rt utils.ResourceType, typeId, localTypePath string, keywordMapping map[string]interface{}, logName string) error {
+func ImportTemplateType(session *utils.Session, rt utils.ResourceType, typeId, displayName, localTypePath string, keywordMapping map[string]interface{}, logName string) error {
 
 	appsDir := filepath.Join(localTypePath, ApplicationTemplatesDir)
 	if _, err := os.Stat(appsDir); os.IsNotExist(err) {
-		if utils.TOOL_CONFIGS.AllowDelete {
-			err := removeDeployedTemplatesOfAllApps(rt, typeId)
+		if session.ToolConfigs.AllowDelete {
+			err := removeDeployedTemplatesOfAllApps(session, rt, typeId, displayName)
 			if err != nil {
 				return fmt.Errorf("error removing deployed application templates: %w", err)
 			}
@@ -53,27 +53,31 @@ func ImportTemplateType(rt utils.ResourceType, typeId, localTypePath string, key
 		}
 		appName := appDirEntry.Name()
 		appId, ok := appMap[appName]
+		if !ok && utils.DRY_RUN {
+			utils.AddSubResourceToPlan(rt, displayName, appName, utils.PLAN_CREATE, "Application templates of a new application")
+			continue
+		}
 		if !ok {
 			return fmt.Errorf("referenced application with identifier '%s' has not been imported", appName)
 		}
 		localAppDirs[appName] = struct{}{}
 
-		if err := importTemplatesOfApp(rt, typeId, appId, appName, appsDir, keywordMapping, logName); err != nil {
+		if err := importTemplatesOfApp(session, rt, typeId, displayName, appId, appName, appsDir, keywordMapping, logName); err != nil {
 			return fmt.Errorf("error importing templates of application %s: %w", appName, err)
 		}
 	}
 
-	if utils.TOOL_CONFIGS.AllowDelete {
-		if err := removeDeployedTemplatesOfDeletedApps(rt, typeId, appMap, localAppDirs); err != nil {
+	if session.ToolConfigs.AllowDelete {
+		if err := removeDeployedTemplatesOfDeletedApps(session, rt, typeId, displayName, appMap, localAppDirs); err != nil {
 			return fmt.Errorf("error removing templates of deleted applications: %w", err)
 		}
 	}
 	return nil
 }
 
-func importTemplatesOfApp(rt utils.ResourceType, typeId, appId, appName, appsDir string, keywordMapping map[string]interface{}, logName string) error {
+func importTemplatesOfApp(session *utils.Session, rt utils.ResourceType, typeId, displayName, appId, appName, appsDir string, keywordMapping map[string]interface{}, logName string) error {
 
-	deployedTemplates, err := getAppTemplatesList(rt, typeId, appId)
+	deployedTemplates, err := getAppTemplatesList(session, rt, typeId, appId)
 	if err != nil {
 		return fmt.Errorf("error getting templates list: %w", err)
 	}
@@ -83,8 +87,8 @@ func importTemplatesOfApp(rt utils.ResourceType, typeId, appId, appName, appsDir
 		return fmt.Errorf("error reading local template files: %w", err)
 	}
 
-	if utils.TOOL_CONFIGS.AllowDelete {
-		if err := removeDeletedDeployedAppTemplates(rt, typeId, appId, appName, localFiles, deployedTemplates); err != nil {
+	if session.ToolConfigs.AllowDelete {
+		if err := removeDeletedDeployedAppTemplates(session, rt, typeId, displayName, appId, appName, localFiles, deployedTemplates); err != nil {
 			return fmt.Errorf("error removing deleted templates: %w", err)
 		}
 	}
@@ -95,14 +99,14 @@ func importTemplatesOfApp(rt utils.ResourceType, typeId, appId, appName, appsDir
 		locale := fileInfo.ResourceName
 
 		exists := isAppTemplateExists(locale, deployedTemplates)
-		if err := importAppTemplate(rt, typeId, appId, locale, filePath, keywordMapping, exists, logName); err != nil {
+		if err := importAppTemplate(session, rt, typeId, appId, locale, filePath, keywordMapping, exists, logName); err != nil {
 			return fmt.Errorf("error while importing template %s. %w", locale, err)
 		}
 	}
 	return nil
 }
 
-func importAppTemplate(rt utils.ResourceType, typeId, appId, locale, filePath string, keywordMapping map[string]interface{}, exists bool, logName string) error {
+func importAppTemplate(session *utils.Session, rt utils.ResourceType, typeId, appId, locale, filePath string, keywordMapping map[string]interface{}, exists bool, logName string) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(filePath))
 	if err != nil {
@@ -113,22 +117,32 @@ func importAppTemplate(rt utils.ResourceType, typeId, appId, locale, filePath st
 		return fmt.Errorf("error when reading the file: %w", err)
 	}
 
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(rt, filePath, modifiedFileData, keywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(rt, filePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
 
+	if utils.DRY_RUN {
+		return nil
+	}
 	if !exists {
-		return createAppTemplate(rt, typeId, appId, []byte(modifiedFileData), format, logName)
+		return createAppTemplate(session, rt, typeId, appId, []byte(modifiedFileData), format, logName)
 	}
-	return updateAppTemplate(rt, typeId, appId, locale, []byte(modifiedFileData), format, logName)
+	return updateAppTemplate(session, rt, typeId, appId, locale, []byte(modifiedFileData), format, logName)
 }
 
-func createAppTemplate(rt utils.ResourceType, typeId, appId string, requestBody []byte, format utils.Format, logName string) error {
+func createAppTemplate(session *utils.Session, rt utils.ResourceType, typeId, appId string, requestBody []byte, format utils.Format, logName string) error {
 
 	jsonBody, err := utils.PrepareJSONRequestBody(requestBody, format, rt)
 	if err != nil {
 		return err
 	}
 
-	resp, err := utils.SendPostRequest(rt, jsonBody, utils.WithPathSuffix(typeId+"/app-templates/"+appId))
+	resp, err := session.SendPostRequest(rt, jsonBody, utils.WithPathSuffix(typeId+"/app-templates/"+appId))
 	if err != nil {
 		return fmt.Errorf("error when creating %s: %w", logName, err)
 	}
@@ -137,14 +151,14 @@ func createAppTemplate(rt utils.ResourceType, typeId, appId string, requestBody
 	return nil
 }
 
-func updateAppTemplate(rt utils.ResourceType, typeId, appId, locale string, requestBody []byte, format utils.Format, logName string) error {
+func updateAppTemplate(session *utils.Session, rt utils.ResourceType, typeId, appId, locale string, requestBody []byte, format utils.Format, logName string) error {
 
 	jsonBody, err := utils.PrepareJSONRequestBody(requestBody, format, rt, "locale")
 	if err != nil {
 		return err
 	}
 
-	resp, err := utils.SendPutRequest(rt, typeId+"/app-templates/"+appId+"/"+locale, jsonBody)
+	resp, err := session.SendPutRequest(rt, typeId+"/app-templates/"+appId+"/"+locale, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when updating %s: %w", logName, err)
 	}
@@ -153,7 +167,7 @@ func updateAppTemplate(rt utils.ResourceType, typeId, appId, locale string, requ
 	return nil
 }
 
-func removeDeployedTemplatesOfAllApps(rt utils.ResourceType, typeId string) error {
+func removeDeployedTemplatesOfAllApps(session *utils.Session, rt utils.ResourceType, typeId, displayName string) error {
 
 	appMap := utils.GetResourceIdentifierMap(utils.APPLICATIONS)
 	if len(appMap) == 0 {
@@ -161,35 +175,35 @@ func removeDeployedTemplatesOfAllApps(rt utils.ResourceType, typeId string) erro
 	}
 
 	for appName, appId := range appMap {
-		deployedTemplates, err := getAppTemplatesList(rt, typeId, appId)
+		deployedTemplates, err := getAppTemplatesList(session, rt, typeId, appId)
 		if err != nil {
 			return fmt.Errorf("error getting templates for application %s: %w", appName, err)
 		}
-		if err := removeDeletedDeployedAppTemplates(rt, typeId, appId, appName, []os.FileInfo{}, deployedTemplates); err != nil {
+		if err := removeDeletedDeployedAppTemplates(session, rt, typeId, displayName, appId, appName, []os.FileInfo{}, deployedTemplates); err != nil {
 			return fmt.Errorf("error removing templates for application %s: %w", appName, err)
 		}
 	}
 	return nil
 }
 
-func removeDeployedTemplatesOfDeletedApps(rt utils.ResourceType, typeId string, appMap map[string]string, localAppDirs map[string]struct{}) error {
+func removeDeployedTemplatesOfDeletedApps(session *utils.Session, rt utils.ResourceType, typeId, displayName string, appMap map[string]string, localAppDirs map[string]struct{}) error {
 
 	for appName, appId := range appMap {
 		if _, hasLocal := localAppDirs[appName]; hasLocal {
 			continue
 		}
-		deployedTemplates, err := getAppTemplatesList(rt, typeId, appId)
+		deployedTemplates, err := getAppTemplatesList(session, rt, typeId, appId)
 		if err != nil {
 			return fmt.Errorf("error getting templates for application %s: %w", appName, err)
 		}
-		if err := removeDeletedDeployedAppTemplates(rt, typeId, appId, appName, []os.FileInfo{}, deployedTemplates); err != nil {
+		if err := removeDeletedDeployedAppTemplates(session, rt, typeId, displayName, appId, appName, []os.FileInfo{}, deployedTemplates); err != nil {
 			return fmt.Errorf("error removing templates for application %s: %w", appName, err)
 		}
 	}
 	return nil
 }
 
-func removeDeletedDeployedAppTemplates(rt utils.ResourceType, typeId, appId, appName string, localFiles []os.FileInfo, deployedTemplates []appTemplate) error {
+func removeDeletedDeployedAppTemplates(session *utils.Session, rt utils.ResourceType, typeId, displayName, appId, appName string, localFiles []os.FileInfo, deployedTemplates []appTemplate) error {
 
 	if len(deployedTemplates) == 0 {
 		return nil
@@ -205,8 +219,12 @@ func removeDeletedDeployedAppTemplates(rt utils.ResourceType, typeId, appId, app
 		if _, existsLocally := localLocales[template.Locale]; existsLocally {
 			continue
 		}
+		if utils.DRY_RUN {
+			utils.AddSubResourceToPlan(rt, displayName, appName+"/"+template.Locale, utils.PLAN_DELETE, "Application template not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, rt, appName, fmt.Sprintf("Application template not found locally. Deleting: %s", template.Locale))
-		if err := utils.SendDeleteRequest(typeId+"/app-templates/"+appId+"/"+template.Locale, rt); err != nil {
+		if err := session.SendDeleteRequest(typeId+"/app-templates/"+appId+"/"+template.Locale, rt); err != nil {
 			return fmt.Errorf("error deleting template: %s. %w", template.Locale, err)
 		}
 	}
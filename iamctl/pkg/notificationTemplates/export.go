@@ -20,31 +20,31 @@ package notificationTemplates
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/notificationTemplates/applicationNotificationTemplates"
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(rt utils.ResourceType, exportFilePath string, format string) {
+func ExportAll(session *utils.Session, rt utils.ResourceType, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, rt, "", fmt.Sprintf("Exporting %s...", getTemplateLogName(rt)))
 	exportFilePath = filepath.Join(exportFilePath, rt.String())
 
-	if utils.ShouldSkip(rt) {
+	if session.ShouldSkip(rt) {
 		return
 	}
 
-	types, err := getTemplateTypeList(rt)
+	types, err := getTemplateTypeList(session, rt)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, rt, "", fmt.Sprintf("Error while retrieving the list: %s", err))
 		utils.MarkResTypeFailure(rt)
 		return
 	}
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, rt, "", fmt.Sprintf("Error creating %s directory: %s", getTemplateLogName(rt), err))
 			utils.MarkResTypeFailure(rt)
 			return
@@ -52,70 +52,74 @@ func ExportAll(rt utils.ResourceType, exportFilePath string, format string) {
 	}
 
 	var allTypeNames []string
-	var typesWithTemplates []string
 	for _, templateType := range types {
 		allTypeNames = append(allTypeNames, templateType.DisplayName)
-
-		if !utils.IsResourceExcluded(templateType.DisplayName, getTemplateResourceConfig(rt)) {
+	}
+	var typesWithTemplates []string
+	var typesLock sync.Mutex
+	session.ProcessConcurrently(len(types), func(i int) {
+		templateType := types[i]
+		if !utils.IsResourceExcluded(templateType.DisplayName, getTemplateResourceConfig(session, rt)) {
 			utils.PrintLog(utils.LogLevelInfo, rt, templateType.DisplayName, "Exporting")
-			hadTemplates, err := exportTemplateType(rt, templateType.ID, templateType.DisplayName, exportFilePath, format)
+			utils.MarkResourceStart(rt, templateType.DisplayName)
+			hadTemplates, err := exportTemplateType(session, rt, templateType.ID, templateType.DisplayName, exportFilePath, format)
 			if err != nil {
-				utils.UpdateFailureSummary(rt, templateType.DisplayName)
-				utils.PrintLog(utils.LogLevelError, rt, templateType.DisplayName, fmt.Sprintf("Error while exporting: %s", err))
+				utils.UpdateFailureSummaryAndLog(rt, templateType.DisplayName, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 			} else {
 				if hadTemplates {
+					typesLock.Lock()
 					typesWithTemplates = append(typesWithTemplates, templateType.DisplayName)
+					typesLock.Unlock()
 				}
-				utils.UpdateSuccessSummary(rt, utils.EXPORT)
-				utils.PrintLog(utils.LogLevelInfo, rt, templateType.DisplayName, "Exported successfully")
+				utils.UpdateSuccessSummaryAndLog(rt, templateType.DisplayName, utils.EXPORT, "Exported successfully")
 			}
 		}
-	}
+	})
 
-	if utils.TOOL_CONFIGS.AllowDelete {
+	if session.ToolConfigs.AllowDelete {
 		utils.RemoveDeletedLocalDirectories(exportFilePath, typesWithTemplates)
 	}
 
 	if err := writeTemplateTypesList(exportFilePath, allTypeNames, rt, utils.FormatFromString(format)); err != nil {
 		utils.PrintLog(utils.LogLevelError, rt, "", fmt.Sprintf("Error writing type list: %s", err))
-		utils.UpdateFailureSummary(rt, "TemplateTypes")
+		utils.UpdateFailureSummary(rt, "TemplateTypes", utils.EXPORT, err)
 	}
 }
 
-func exportTemplateType(rt utils.ResourceType, typeId, displayName, parentDir, formatString string) (bool, error) {
+func exportTemplateType(session *utils.Session, rt utils.ResourceType, typeId, displayName, parentDir, formatString string) (bool, error) {
 
 	format := utils.FormatFromString(formatString)
 	typeDir := filepath.Join(parentDir, displayName)
 	orgDir := filepath.Join(typeDir, orgTemplatesDir)
 
-	deployedTemplates, err := getTemplatesList(rt, typeId)
+	deployedTemplates, err := getTemplatesList(session, rt, typeId)
 	if err != nil {
 		return false, fmt.Errorf("error retrieving deployed templates: %w", err)
 	}
 
-	keywordMapping := getTemplateKeywordMapping(rt, displayName)
+	keywordMapping := getTemplateKeywordMapping(session, rt, displayName)
 	hadOrgTemplates := len(deployedTemplates) > 0
 
 	if hadOrgTemplates {
 		if _, err := os.Stat(orgDir); os.IsNotExist(err) {
-			if err := os.MkdirAll(orgDir, 0700); err != nil {
+			if err := utils.CreateExportDir(orgDir); err != nil {
 				return false, fmt.Errorf("error creating template type directory: %w", err)
 			}
 		} else {
-			if utils.TOOL_CONFIGS.AllowDelete {
+			if session.ToolConfigs.AllowDelete {
 				utils.RemoveDeletedLocalResources(orgDir, getDeployedTemplateLocales(deployedTemplates))
 			}
 		}
 
 		for _, template := range deployedTemplates {
-			err := exportTemplate(rt, typeId, template.Locale, orgDir, format, keywordMapping)
+			err := exportTemplate(session, rt, typeId, template.Locale, orgDir, format, keywordMapping)
 			if err != nil {
 				return false, fmt.Errorf("error while exporting template: %s. %w", template.Locale, err)
 			}
 		}
-	} else if utils.TOOL_CONFIGS.AllowDelete {
+	} else if session.ToolConfigs.AllowDelete {
 		if _, err := os.Stat(orgDir); err == nil {
-			if err := os.RemoveAll(orgDir); err != nil {
+			if err := utils.RemoveLocalDirectory(orgDir); err != nil {
 				utils.PrintLog(utils.LogLevelError, rt, displayName, fmt.Sprintf("Error removing organization templates directory: %s", err))
 			} else {
 				utils.PrintLog(utils.LogLevelInfo, rt, displayName, fmt.Sprintf("Removed the directory: %s", orgTemplatesDir))
@@ -123,7 +127,7 @@ func exportTemplateType(rt utils.ResourceType, typeId, displayName, parentDir, f
 		}
 	}
 
-	hadAppTemplates, err := applicationNotificationTemplates.ExportTemplateType(rt, typeId, displayName, typeDir, format, keywordMapping)
+	hadAppTemplates, err := applicationNotificationTemplates.ExportTemplateType(session, rt, typeId, displayName, typeDir, format, keywordMapping)
 	if err != nil {
 		return hadOrgTemplates, fmt.Errorf("error while exporting application templates: %w", err)
 	}
@@ -131,16 +135,16 @@ func exportTemplateType(rt utils.ResourceType, typeId, displayName, parentDir, f
 	return hadOrgTemplates || hadAppTemplates, nil
 }
 
-func exportTemplate(rt utils.ResourceType, typeId, locale, typeDir string, format utils.Format, keywordMapping map[string]interface{}) error {
+func exportTemplate(session *utils.Session, rt utils.ResourceType, typeId, locale, typeDir string, format utils.Format, keywordMapping map[string]interface{}) error {
 
-	templateData, err := utils.GetResourceData(rt, typeId+"/org-templates/"+locale)
+	templateData, err := session.GetResourceData(rt, typeId+"/org-templates/"+locale)
 	if err != nil {
 		return fmt.Errorf("error while getting template: %w", err)
 	}
 
 	exportedFileName := utils.GetExportedFilePath(typeDir, locale, format)
 
-	modifiedData, err := utils.ProcessExportedData(templateData, exportedFileName, format, keywordMapping, rt)
+	modifiedData, err := session.ProcessExportedData(templateData, exportedFileName, format, keywordMapping, rt)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -150,7 +154,7 @@ func exportTemplate(rt utils.ResourceType, typeId, locale, typeDir string, forma
 		return fmt.Errorf("error while serializing template: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, rt)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
@@ -28,13 +28,13 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(rt utils.ResourceType, inputDirPath string) {
+func ImportAll(session *utils.Session, rt utils.ResourceType, inputDirPath string) {
 
 	logName := getTemplateLogName(rt)
 	utils.PrintLog(utils.LogLevelInfo, rt, "", fmt.Sprintf("Importing %s...", logName))
 	importFilePath := filepath.Join(inputDirPath, rt.String())
 
-	if utils.ShouldSkip(rt) {
+	if session.ShouldSkip(rt) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -42,7 +42,7 @@ func ImportAll(rt utils.ResourceType, inputDirPath string) {
 		return
 	}
 
-	deployedTypes, err := getTemplateTypeList(rt)
+	deployedTypes, err := getTemplateTypeList(session, rt)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, rt, "", fmt.Sprintf("Error while retrieving the list: %s", err))
 		utils.MarkResTypeFailure(rt)
@@ -57,38 +57,43 @@ func ImportAll(rt utils.ResourceType, inputDirPath string) {
 	exportedTypeNames, err := readLocalTemplateTypeNames(importFilePath, rt)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, rt, "", fmt.Sprintf("Error reading type list: %s", err))
-		utils.UpdateFailureSummary(rt, "TemplateTypes")
+		utils.UpdateFailureSummary(rt, "TemplateTypes", utils.IMPORT, err)
 		return
 	}
 
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedTypes(rt, localTypeDirs, deployedTypes, exportedTypeNames, logName)
+	if session.ToolConfigs.AllowDelete {
+		removeDeletedDeployedTypes(session, rt, localTypeDirs, deployedTypes, exportedTypeNames, logName)
 	}
 
-	for _, entry := range localTypeDirs {
+	session.ProcessConcurrently(len(localTypeDirs), func(i int) {
+		entry := localTypeDirs[i]
 		if !entry.IsDir() {
-			continue
+			return
 		}
 		displayName := entry.Name()
 		localTypePath := filepath.Join(importFilePath, displayName)
 
-		if !utils.IsResourceExcluded(displayName, getTemplateResourceConfig(rt)) {
-			err := importTemplateType(rt, localTypePath, displayName, deployedTypes, logName)
+		if !utils.IsResourceExcluded(displayName, getTemplateResourceConfig(session, rt)) {
+			utils.MarkResourceStart(rt, displayName)
+			err := importTemplateType(session, rt, localTypePath, displayName, deployedTypes, logName)
 			if err != nil {
-				utils.UpdateFailureSummary(rt, displayName)
-				utils.PrintLog(utils.LogLevelError, rt, displayName, fmt.Sprintf("Error when importing: %s", err))
+				utils.UpdateFailureSummaryAndLog(rt, displayName, utils.IMPORT, err, fmt.Sprintf("Error when importing: %s", err))
 			}
 		}
-	}
+	})
 }
 
-func importTemplateType(rt utils.ResourceType, localTypePath, displayName string, deployedTypes []notificationTemplateType, logName string) error {
+func importTemplateType(session *utils.Session, rt utils.ResourceType, localTypePath, displayName string, deployedTypes []notificationTemplateType, logName string) error {
 
 	var typeId string
 	existingType := getTemplateTypeId(displayName, deployedTypes)
 	if existingType == "" {
+		if utils.DRY_RUN {
+			utils.AddToPlan(rt, displayName, utils.PLAN_CREATE, "")
+			return nil
+		}
 		utils.PrintLog(utils.LogLevelInfo, rt, displayName, fmt.Sprintf("Creating new %s type", logName))
-		createdId, err := createTemplateType(rt, displayName)
+		createdId, err := createTemplateType(session, rt, displayName)
 		if err != nil {
 			return fmt.Errorf("error creating template type: %w", err)
 		}
@@ -98,7 +103,7 @@ func importTemplateType(rt utils.ResourceType, localTypePath, displayName string
 		typeId = existingType
 	}
 
-	deployedTemplates, err := getTemplatesList(rt, typeId)
+	deployedTemplates, err := getTemplatesList(session, rt, typeId)
 	if err != nil {
 		return fmt.Errorf("error getting deployed templates: %w", err)
 	}
@@ -114,14 +119,14 @@ func importTemplateType(rt utils.ResourceType, localTypePath, displayName string
 		}
 	}
 
-	if utils.TOOL_CONFIGS.AllowDelete {
-		err := removeDeletedDeployedTemplates(rt, typeId, localFiles, deployedTemplates)
+	if session.ToolConfigs.AllowDelete {
+		err := removeDeletedDeployedTemplates(session, rt, typeId, displayName, localFiles, deployedTemplates)
 		if err != nil {
 			return fmt.Errorf("error removing deleted deployed templates: %w", err)
 		}
 	}
 
-	keywordMapping := getTemplateKeywordMapping(rt, displayName)
+	keywordMapping := getTemplateKeywordMapping(session, rt, displayName)
 
 	for _, file := range localFiles {
 		filePath := filepath.Join(orgDir, file.Name())
@@ -129,28 +134,28 @@ func importTemplateType(rt utils.ResourceType, localTypePath, displayName string
 		locale := fileInfo.ResourceName
 
 		exists := isTemplateExists(locale, deployedTemplates)
-		err := importTemplate(rt, typeId, locale, filePath, keywordMapping, exists, logName)
+		err := importTemplate(session, rt, typeId, locale, filePath, keywordMapping, exists, logName)
 		if err != nil {
 			return fmt.Errorf("error importing template: %s. %w", locale, err)
 		}
 	}
 
-	if err := applicationNotificationTemplates.ImportTemplateType(rt, typeId, localTypePath, keywordMapping, logName); err != nil {
+	if err := applicationNotificationTemplates.ImportTemplateType(session, rt, typeId, displayName, localTypePath, keywordMapping, logName); err != nil {
 		return fmt.Errorf("error while importing application templates: %w", err)
 	}
 
-	if existingType != "" {
-		utils.UpdateSuccessSummary(rt, utils.UPDATE)
-		utils.PrintLog(utils.LogLevelInfo, rt, displayName, "Updated successfully")
+	if utils.DRY_RUN {
+		utils.AddToPlan(rt, displayName, utils.PLAN_UPDATE, "")
+	} else if existingType != "" {
+		utils.UpdateSuccessSummaryAndLog(rt, displayName, utils.UPDATE, "Updated successfully")
 	} else {
-		utils.UpdateSuccessSummary(rt, utils.IMPORT)
-		utils.PrintLog(utils.LogLevelInfo, rt, displayName, "Imported successfully")
+		utils.UpdateSuccessSummaryAndLog(rt, displayName, utils.IMPORT, "Imported successfully")
 	}
 
 	return nil
 }
 
-func importTemplate(rt utils.ResourceType, typeId, locale, filePath string, keywordMapping map[string]interface{}, exists bool, logName string) error {
+func importTemplate(session *utils.Session, rt utils.ResourceType, typeId, locale, filePath string, keywordMapping map[string]interface{}, exists bool, logName string) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(filePath))
 	if err != nil {
@@ -162,22 +167,32 @@ func importTemplate(rt utils.ResourceType, typeId, locale, filePath string, keyw
 		return fmt.Errorf("error when reading the file: %w", err)
 	}
 
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(rt, filePath, modifiedFileData, keywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(rt, filePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
 
+	if utils.DRY_RUN {
+		return nil
+	}
 	if !exists {
-		return createTemplate(rt, typeId, []byte(modifiedFileData), format, logName)
+		return createTemplate(session, rt, typeId, []byte(modifiedFileData), format, logName)
 	}
-	return updateTemplate(rt, typeId, locale, []byte(modifiedFileData), format, logName)
+	return updateTemplate(session, rt, typeId, locale, []byte(modifiedFileData), format, logName)
 }
 
-func createTemplate(rt utils.ResourceType, typeId string, requestBody []byte, format utils.Format, logName string) error {
+func createTemplate(session *utils.Session, rt utils.ResourceType, typeId string, requestBody []byte, format utils.Format, logName string) error {
 
 	jsonBody, err := utils.PrepareJSONRequestBody(requestBody, format, rt)
 	if err != nil {
 		return err
 	}
 
-	resp, err := utils.SendPostRequest(rt, jsonBody, utils.WithPathSuffix(typeId+"/org-templates"))
+	resp, err := session.SendPostRequest(rt, jsonBody, utils.WithPathSuffix(typeId+"/org-templates"))
 	if err != nil {
 		return fmt.Errorf("error when creating %s: %w", logName, err)
 	}
@@ -186,14 +201,14 @@ func createTemplate(rt utils.ResourceType, typeId string, requestBody []byte, fo
 	return nil
 }
 
-func updateTemplate(rt utils.ResourceType, typeId, locale string, requestBody []byte, format utils.Format, logName string) error {
+func updateTemplate(session *utils.Session, rt utils.ResourceType, typeId, locale string, requestBody []byte, format utils.Format, logName string) error {
 
 	jsonBody, err := utils.PrepareJSONRequestBody(requestBody, format, rt, "locale")
 	if err != nil {
 		return err
 	}
 
-	resp, err := utils.SendPutRequest(rt, typeId+"/org-templates/"+locale, jsonBody)
+	resp, err := session.SendPutRequest(rt, typeId+"/org-templates/"+locale, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when updating %s: %w", logName, err)
 	}
@@ -202,7 +217,7 @@ func updateTemplate(rt utils.ResourceType, typeId, locale string, requestBody []
 	return nil
 }
 
-func removeDeletedDeployedTypes(rt utils.ResourceType, localDirs []os.FileInfo, deployedTypes []notificationTemplateType, exportedTypeNames []string, logName string) {
+func removeDeletedDeployedTypes(session *utils.Session, rt utils.ResourceType, localDirs []os.FileInfo, deployedTypes []notificationTemplateType, exportedTypeNames []string, logName string) {
 
 	if len(deployedTypes) == 0 {
 		return
@@ -223,31 +238,37 @@ func removeDeletedDeployedTypes(rt utils.ResourceType, localDirs []os.FileInfo,
 		if _, existsLocally := localDirNames[deployedType.DisplayName]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(deployedType.DisplayName, getTemplateResourceConfig(rt)) {
+		if utils.IsResourceExcluded(deployedType.DisplayName, getTemplateResourceConfig(session, rt)) {
 			utils.PrintLog(utils.LogLevelInfo, rt, deployedType.DisplayName, fmt.Sprintf("%s type excluded from deletion.", logName))
 			continue
 		}
 
+		if utils.DRY_RUN {
+			reason := "Not found locally"
+			if _, isExported := exportedNames[deployedType.DisplayName]; isExported {
+				reason = "Not found locally. Will be reset to default"
+			}
+			utils.AddToPlan(rt, deployedType.DisplayName, utils.PLAN_DELETE, reason)
+			continue
+		}
 		if _, isExported := exportedNames[deployedType.DisplayName]; isExported {
 			utils.PrintLog(utils.LogLevelInfo, rt, deployedType.DisplayName, fmt.Sprintf("%s type not found locally. Resetting.", logName))
-			if err := resetTemplateType(rt, deployedType.ID); err != nil {
-				utils.UpdateFailureSummary(rt, deployedType.DisplayName)
-				utils.PrintLog(utils.LogLevelError, rt, deployedType.DisplayName, fmt.Sprintf("Error resetting %s type: %s", logName, err))
+			if err := resetTemplateType(session, rt, deployedType.ID); err != nil {
+				utils.UpdateFailureSummaryAndLog(rt, deployedType.DisplayName, utils.DELETE, err, fmt.Sprintf("Error resetting %s type: %s", logName, err))
 				continue
 			}
 		} else {
 			utils.PrintLog(utils.LogLevelInfo, rt, deployedType.DisplayName, fmt.Sprintf("%s type not found locally. Deleting.", logName))
-			if err := utils.SendDeleteRequest(deployedType.ID, rt); err != nil {
-				utils.UpdateFailureSummary(rt, deployedType.DisplayName)
-				utils.PrintLog(utils.LogLevelError, rt, deployedType.DisplayName, fmt.Sprintf("Error deleting %s type: %s", logName, err))
+			if err := session.SendDeleteRequest(deployedType.ID, rt); err != nil {
+				utils.UpdateFailureSummaryAndLog(rt, deployedType.DisplayName, utils.DELETE, err, fmt.Sprintf("Error deleting %s type: %s", logName, err))
 				continue
 			}
 		}
-		utils.UpdateSuccessSummary(rt, utils.DELETE)
+		utils.UpdateSuccessSummary(rt, deployedType.DisplayName, utils.DELETE)
 	}
 }
 
-func removeDeletedDeployedTemplates(rt utils.ResourceType, typeId string, localFiles []os.FileInfo, deployedTemplates []notificationTemplate) error {
+func removeDeletedDeployedTemplates(session *utils.Session, rt utils.ResourceType, typeId, displayName string, localFiles []os.FileInfo, deployedTemplates []notificationTemplate) error {
 
 	if len(deployedTemplates) == 0 {
 		return nil
@@ -263,8 +284,12 @@ func removeDeletedDeployedTemplates(rt utils.ResourceType, typeId string, localF
 		if _, existsLocally := localLocales[template.Locale]; existsLocally {
 			continue
 		}
+		if utils.DRY_RUN {
+			utils.AddSubResourceToPlan(rt, displayName, template.Locale, utils.PLAN_DELETE, "Template not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, rt, template.Locale, "Template not found locally. Deleting.")
-		if err := utils.SendDeleteRequest(typeId+"/org-templates/"+template.Locale, rt); err != nil {
+		if err := session.SendDeleteRequest(typeId+"/org-templates/"+template.Locale, rt); err != nil {
 			return fmt.Errorf("error deleting template: %s. %w", template.Locale, err)
 		}
 	}
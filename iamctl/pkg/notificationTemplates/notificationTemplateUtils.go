@@ -39,24 +39,24 @@ type notificationTemplate struct {
 	Locale string `json:"locale"`
 }
 
-func getTemplateResourceConfig(rt utils.ResourceType) map[string]interface{} {
+func getTemplateResourceConfig(session *utils.Session, rt utils.ResourceType) map[string]interface{} {
 
 	switch rt {
 	case utils.EMAIL_TEMPLATES:
-		return utils.TOOL_CONFIGS.EmailTemplateConfigs
+		return session.ToolConfigs.EmailTemplateConfigs
 	case utils.SMS_TEMPLATES:
-		return utils.TOOL_CONFIGS.SmsTemplateConfigs
+		return session.ToolConfigs.SmsTemplateConfigs
 	}
 	return nil
 }
 
-func getTemplateKeywordConfig(rt utils.ResourceType) map[string]interface{} {
+func getTemplateKeywordConfig(session *utils.Session, rt utils.ResourceType) map[string]interface{} {
 
 	switch rt {
 	case utils.EMAIL_TEMPLATES:
-		return utils.KEYWORD_CONFIGS.EmailTemplateConfigs
+		return session.KeywordConfigs.EmailTemplateConfigs
 	case utils.SMS_TEMPLATES:
-		return utils.KEYWORD_CONFIGS.SmsTemplateConfigs
+		return session.KeywordConfigs.SmsTemplateConfigs
 	}
 	return nil
 }
@@ -83,10 +83,10 @@ func getTemplateChannel(rt utils.ResourceType) string {
 	return ""
 }
 
-func getTemplateTypeList(rt utils.ResourceType) ([]notificationTemplateType, error) {
+func getTemplateTypeList(session *utils.Session, rt utils.ResourceType) ([]notificationTemplateType, error) {
 
 	var list []notificationTemplateType
-	body, err := utils.SendGetListRequest(rt)
+	body, err := session.SendGetListRequest(rt)
 	if err != nil {
 		return nil, fmt.Errorf("error while getting the list: %w", err)
 	}
@@ -96,10 +96,10 @@ func getTemplateTypeList(rt utils.ResourceType) ([]notificationTemplateType, err
 	return list, nil
 }
 
-func getTemplatesList(rt utils.ResourceType, typeId string) ([]notificationTemplate, error) {
+func getTemplatesList(session *utils.Session, rt utils.ResourceType, typeId string) ([]notificationTemplate, error) {
 
 	var list []notificationTemplate
-	body, err := utils.SendGetRequest(rt, typeId+"/org-templates")
+	body, err := session.SendGetRequest(rt, typeId+"/org-templates")
 	if err != nil {
 		return nil, err
 	}
@@ -118,13 +118,13 @@ func getDeployedTemplateLocales(templates []notificationTemplate) []string {
 	return locales
 }
 
-func getTemplateKeywordMapping(rt utils.ResourceType, typeName string) map[string]interface{} {
+func getTemplateKeywordMapping(session *utils.Session, rt utils.ResourceType, typeName string) map[string]interface{} {
 
-	kwConfig := getTemplateKeywordConfig(rt)
+	kwConfig := getTemplateKeywordConfig(session, rt)
 	if kwConfig != nil {
-		return utils.ResolveAdvancedKeywordMapping(typeName, kwConfig)
+		return session.ResolveAdvancedKeywordMapping(typeName, kwConfig)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func getTemplateTypeId(displayName string, existingTypes []notificationTemplateType) string {
@@ -147,7 +147,7 @@ func isTemplateExists(locale string, existingTemplates []notificationTemplate) b
 	return false
 }
 
-func createTemplateType(rt utils.ResourceType, displayName string) (string, error) {
+func createTemplateType(session *utils.Session, rt utils.ResourceType, displayName string) (string, error) {
 
 	body := map[string]string{
 		"displayName": displayName,
@@ -157,7 +157,7 @@ func createTemplateType(rt utils.ResourceType, displayName string) (string, erro
 		return "", fmt.Errorf("error marshaling request: %w", err)
 	}
 
-	resp, err := utils.SendPostRequest(rt, jsonBody)
+	resp, err := session.SendPostRequest(rt, jsonBody)
 	if err != nil {
 		return "", err
 	}
@@ -175,9 +175,9 @@ func createTemplateType(rt utils.ResourceType, displayName string) (string, erro
 	return created.ID, nil
 }
 
-func resetTemplateType(rt utils.ResourceType, typeId string) error {
+func resetTemplateType(session *utils.Session, rt utils.ResourceType, typeId string) error {
 
-	reqURL := utils.GetTenantBaseUrl() + "/api/server/v1/notification/reset-template-type"
+	reqURL := session.GetTenantBaseUrl() + "/api/server/v1/notification/reset-template-type"
 
 	body := map[string]string{
 		"templateTypeId": typeId,
@@ -188,7 +188,7 @@ func resetTemplateType(rt utils.ResourceType, typeId string) error {
 		return fmt.Errorf("error marshaling request: %w", err)
 	}
 
-	resp, err := utils.SendCustomRequest("POST", reqURL, jsonBody, utils.MEDIA_TYPE_JSON)
+	resp, err := session.SendCustomRequest(rt, "POST", reqURL, jsonBody, utils.MEDIA_TYPE_JSON)
 	if err != nil {
 		return fmt.Errorf("error sending request: %w", err)
 	}
@@ -210,7 +210,7 @@ func writeTemplateTypesList(outputDirPath string, typeNames []string, rt utils.R
 	if err != nil {
 		return fmt.Errorf("error serializing list: %w", err)
 	}
-	if err := ioutil.WriteFile(exportedFileName, data, 0644); err != nil {
+	if err := utils.WriteExportedFile(exportedFileName, data, rt); err != nil {
 		return fmt.Errorf("error writing file: %w", err)
 	}
 	return nil
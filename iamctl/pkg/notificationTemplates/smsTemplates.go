@@ -20,12 +20,12 @@ package notificationTemplates
 
 import "github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 
-func ExportAllSmsTemplates(exportFilePath, format string) {
+func ExportAllSmsTemplates(session *utils.Session, exportFilePath, format string) {
 
-	ExportAll(utils.SMS_TEMPLATES, exportFilePath, format)
+	ExportAll(session, utils.SMS_TEMPLATES, exportFilePath, format)
 }
 
-func ImportAllSmsTemplates(inputDirPath string) {
+func ImportAllSmsTemplates(session *utils.Session, inputDirPath string) {
 
-	ImportAll(utils.SMS_TEMPLATES, inputDirPath)
+	ImportAll(session, utils.SMS_TEMPLATES, inputDirPath)
 }
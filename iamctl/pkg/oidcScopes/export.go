@@ -19,60 +19,63 @@
 package oidcScopes
 
 import (
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.OIDC_SCOPES, "", "Exporting OIDC scopes...")
 	exportFilePath = filepath.Join(exportFilePath, utils.OIDC_SCOPES.String())
 
-	if utils.ShouldSkip(utils.OIDC_SCOPES) {
+	if session.ShouldSkip(utils.OIDC_SCOPES) {
 		return
 	}
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.OIDC_SCOPES, "", fmt.Sprintf("Error creating OIDC scopes directory: %s", err))
 			utils.MarkResTypeFailure(utils.OIDC_SCOPES)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
-			deployedScopeNames := getDeployedOidcScopeNames()
+		if session.ToolConfigs.AllowDelete {
+			deployedScopeNames := getDeployedOidcScopeNames(session)
 			utils.RemoveDeletedLocalResources(exportFilePath, deployedScopeNames)
 		}
 	}
 
-	scopes, err := getOidcScopeList()
+	scopes, err := getOidcScopeList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.OIDC_SCOPES, "", fmt.Sprintf("Error retrieving the deployed OIDC scopes list: %s", err))
 		utils.MarkResTypeFailure(utils.OIDC_SCOPES)
 	} else {
-		for _, scope := range scopes {
-			if !utils.IsResourceExcluded(scope.Name, utils.TOOL_CONFIGS.OidcScopeConfigs) {
+		session.ProcessConcurrently(len(scopes), func(i int) {
+			scope := scopes[i]
+			if !utils.IsResourceExcluded(scope.Name, session.ToolConfigs.OidcScopeConfigs) {
 				utils.PrintLog(utils.LogLevelInfo, utils.OIDC_SCOPES, scope.Name, "Exporting")
 
-				err := exportOidcScope(scope.Name, exportFilePath, format)
+				utils.MarkResourceStart(utils.OIDC_SCOPES, scope.Name)
+				err := exportOidcScope(session, scope.Name, exportFilePath, format)
+				if errors.Is(err, utils.ErrFilteredOut) {
+					return
+				}
 				if err != nil {
-					utils.UpdateFailureSummary(utils.OIDC_SCOPES, scope.Name)
-					utils.PrintLog(utils.LogLevelError, utils.OIDC_SCOPES, scope.Name, fmt.Sprintf("Error while exporting: %s", err))
+					utils.UpdateFailureSummaryAndLog(utils.OIDC_SCOPES, scope.Name, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 				} else {
-					utils.UpdateSuccessSummary(utils.OIDC_SCOPES, utils.EXPORT)
-					utils.PrintLog(utils.LogLevelInfo, utils.OIDC_SCOPES, scope.Name, "Exported successfully")
+					utils.UpdateSuccessSummaryAndLog(utils.OIDC_SCOPES, scope.Name, utils.EXPORT, "Exported successfully")
 				}
 			}
-		}
+		})
 	}
 }
 
-func exportOidcScope(scopeName string, outputDirPath string, formatString string) error {
+func exportOidcScope(session *utils.Session, scopeName string, outputDirPath string, formatString string) error {
 
-	scope, err := utils.GetResourceData(utils.OIDC_SCOPES, scopeName)
+	scope, err := session.GetResourceData(utils.OIDC_SCOPES, scopeName)
 	if err != nil {
 		return fmt.Errorf("error while getting OIDC scope: %w", err)
 	}
@@ -80,8 +83,8 @@ func exportOidcScope(scopeName string, outputDirPath string, formatString string
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, scopeName, format)
 
-	scopeKeywordMapping := getOidcScopeKeywordMapping(scopeName)
-	modifiedScope, err := utils.ProcessExportedData(scope, exportedFileName, format, scopeKeywordMapping, utils.OIDC_SCOPES)
+	scopeKeywordMapping := getOidcScopeKeywordMapping(session, scopeName)
+	modifiedScope, err := session.ProcessExportedData(scope, exportedFileName, format, scopeKeywordMapping, utils.OIDC_SCOPES)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -91,7 +94,7 @@ func exportOidcScope(scopeName string, outputDirPath string, formatString string
 		return fmt.Errorf("error while serializing scope: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.OIDC_SCOPES)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
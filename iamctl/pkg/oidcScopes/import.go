@@ -27,12 +27,12 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.OIDC_SCOPES, "", "Importing OIDC scopes...")
 	importFilePath := filepath.Join(inputDirPath, utils.OIDC_SCOPES.String())
 
-	if utils.ShouldSkip(utils.OIDC_SCOPES) {
+	if session.ShouldSkip(utils.OIDC_SCOPES) {
 		return
 	}
 	var files []os.FileInfo
@@ -41,7 +41,7 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	existingScopeList, err := getOidcScopeList()
+	existingScopeList, err := getOidcScopeList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.OIDC_SCOPES, "", fmt.Sprintf("Error retrieving the deployed OIDC scope list: %s", err))
 		utils.MarkResTypeFailure(utils.OIDC_SCOPES)
@@ -54,27 +54,28 @@ func ImportAll(inputDirPath string) {
 		utils.MarkResTypeFailure(utils.OIDC_SCOPES)
 		return
 	}
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedScopes(files, existingScopeList)
+	if session.IsDeleteAllowed(utils.OIDC_SCOPES) {
+		removeDeletedDeployedScopes(session, files, existingScopeList)
 	}
 
-	for _, file := range files {
+	session.ProcessConcurrently(len(files), func(i int) {
+		file := files[i]
 		scopeFilePath := filepath.Join(importFilePath, file.Name())
 		fileInfo := utils.GetFileInfo(scopeFilePath)
 		scopeName := fileInfo.ResourceName
 
-		if !utils.IsResourceExcluded(scopeName, utils.TOOL_CONFIGS.OidcScopeConfigs) {
+		if !utils.IsResourceExcluded(scopeName, session.ToolConfigs.OidcScopeConfigs) && session.MatchesFileFilter(utils.OIDC_SCOPES, scopeFilePath, getOidcScopeKeywordMapping(session, scopeName), utils.IMPORT) {
 			scopeExists := isScopeExists(scopeName, existingScopeList)
-			err := importOidcScope(scopeName, scopeExists, scopeFilePath)
+			utils.MarkResourceStart(utils.OIDC_SCOPES, scopeName)
+			err := importOidcScope(session, scopeName, scopeExists, scopeFilePath)
 			if err != nil {
-				utils.PrintLog(utils.LogLevelError, utils.OIDC_SCOPES, scopeName, fmt.Sprintf("Error importing OIDC scope: %s", err))
-				utils.UpdateFailureSummary(utils.OIDC_SCOPES, scopeName)
+				utils.UpdateFailureSummaryAndLog(utils.OIDC_SCOPES, scopeName, utils.IMPORT, err, fmt.Sprintf("Error importing OIDC scope: %s", err))
 			}
 		}
-	}
+	})
 }
 
-func importOidcScope(scopeName string, scopeExists bool, importFilePath string) error {
+func importOidcScope(session *utils.Session, scopeName string, scopeExists bool, importFilePath string) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(importFilePath))
 	if err != nil {
@@ -86,16 +87,28 @@ func importOidcScope(scopeName string, scopeExists bool, importFilePath string)
 		return fmt.Errorf("error when reading the file for OIDC scope: %w", err)
 	}
 
-	scopeKeywordMapping := getOidcScopeKeywordMapping(scopeName)
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), scopeKeywordMapping)
+	scopeKeywordMapping := getOidcScopeKeywordMapping(session, scopeName)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), scopeKeywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.OIDC_SCOPES, importFilePath, modifiedFileData, scopeKeywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.OIDC_SCOPES, importFilePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
+
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.OIDC_SCOPES, scopeName, utils.GetImportAction(scopeExists), "")
+		return nil
+	}
 
 	if !scopeExists {
-		return importScope([]byte(modifiedFileData), format, scopeName)
+		return importScope(session, []byte(modifiedFileData), format, scopeName)
 	}
-	return updateScope(scopeName, []byte(modifiedFileData), format, scopeName)
+	return updateScope(session, scopeName, []byte(modifiedFileData), format, scopeName)
 }
 
-func importScope(requestBody []byte, format utils.Format, scopeName string) error {
+func importScope(session *utils.Session, requestBody []byte, format utils.Format, scopeName string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.OIDC_SCOPES, scopeName, "Creating new OIDC scope")
 
@@ -104,18 +117,17 @@ func importScope(requestBody []byte, format utils.Format, scopeName string) erro
 		return err
 	}
 
-	resp, err := utils.SendPostRequest(utils.OIDC_SCOPES, jsonBody)
+	resp, err := session.SendPostRequest(utils.OIDC_SCOPES, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when importing OIDC scope: %w", err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.OIDC_SCOPES, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.OIDC_SCOPES, scopeName, "Imported successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.OIDC_SCOPES, scopeName, utils.IMPORT, "Imported successfully")
 	return nil
 }
 
-func updateScope(scopeId string, requestBody []byte, format utils.Format, scopeName string) error {
+func updateScope(session *utils.Session, scopeId string, requestBody []byte, format utils.Format, scopeName string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.OIDC_SCOPES, scopeName, "Updating OIDC scope")
 
@@ -124,18 +136,17 @@ func updateScope(scopeId string, requestBody []byte, format utils.Format, scopeN
 		return err
 	}
 
-	resp, err := utils.SendPutRequest(utils.OIDC_SCOPES, scopeId, updateBody)
+	resp, err := session.SendPutRequest(utils.OIDC_SCOPES, scopeId, updateBody)
 	if err != nil {
 		return fmt.Errorf("error when updating OIDC scope: %w", err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.OIDC_SCOPES, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.OIDC_SCOPES, scopeName, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.OIDC_SCOPES, scopeName, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func removeDeletedDeployedScopes(localFiles []os.FileInfo, deployedScopes []oidcScope) {
+func removeDeletedDeployedScopes(session *utils.Session, localFiles []os.FileInfo, deployedScopes []oidcScope) {
 
 	if len(deployedScopes) == 0 {
 		return
@@ -151,17 +162,20 @@ func removeDeletedDeployedScopes(localFiles []os.FileInfo, deployedScopes []oidc
 		if _, existsLocally := localResourceNames[scope.Name]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(scope.Name, utils.TOOL_CONFIGS.OidcScopeConfigs) {
+		if utils.IsResourceExcluded(scope.Name, session.ToolConfigs.OidcScopeConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.OIDC_SCOPES, scope.Name, "Excluded from deletion.")
 			continue
 		}
 
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.OIDC_SCOPES, scope.Name, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.OIDC_SCOPES, scope.Name, "Not found locally. Deleting scope.")
-		if err := utils.SendDeleteRequest(scope.Name, utils.OIDC_SCOPES); err != nil {
-			utils.UpdateFailureSummary(utils.OIDC_SCOPES, scope.Name)
-			utils.PrintLog(utils.LogLevelError, utils.OIDC_SCOPES, scope.Name, fmt.Sprintf("Error deleting OIDC scope: %s", err))
+		if err := session.SendDeleteRequest(scope.Name, utils.OIDC_SCOPES); err != nil {
+			utils.UpdateFailureSummaryAndLog(utils.OIDC_SCOPES, scope.Name, utils.DELETE, err, fmt.Sprintf("Error deleting OIDC scope: %s", err))
 		} else {
-			utils.UpdateSuccessSummary(utils.OIDC_SCOPES, utils.DELETE)
+			utils.UpdateSuccessSummary(utils.OIDC_SCOPES, scope.Name, utils.DELETE)
 		}
 	}
 }
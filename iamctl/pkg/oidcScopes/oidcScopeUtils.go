@@ -29,10 +29,10 @@ type oidcScope struct {
 	Name string `json:"name"`
 }
 
-func getOidcScopeList() ([]oidcScope, error) {
+func getOidcScopeList(session *utils.Session) ([]oidcScope, error) {
 
 	var list []oidcScope
-	body, err := utils.SendGetListRequest(utils.OIDC_SCOPES)
+	body, err := session.SendGetListRequest(utils.OIDC_SCOPES)
 	if err != nil {
 		return nil, fmt.Errorf("error while retrieving OIDC scope list. %w", err)
 	}
@@ -42,9 +42,9 @@ func getOidcScopeList() ([]oidcScope, error) {
 	return list, nil
 }
 
-func getDeployedOidcScopeNames() []string {
+func getDeployedOidcScopeNames(session *utils.Session) []string {
 
-	scopes, err := getOidcScopeList()
+	scopes, err := getOidcScopeList(session)
 	if err != nil {
 		return []string{}
 	}
@@ -56,12 +56,12 @@ func getDeployedOidcScopeNames() []string {
 	return scopeNames
 }
 
-func getOidcScopeKeywordMapping(scopeName string) map[string]interface{} {
+func getOidcScopeKeywordMapping(session *utils.Session, scopeName string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.OidcScopeConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(scopeName, utils.KEYWORD_CONFIGS.OidcScopeConfigs)
+	if session.KeywordConfigs.OidcScopeConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(scopeName, session.KeywordConfigs.OidcScopeConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func isScopeExists(scopeName string, existingScopeList []oidcScope) bool {
@@ -19,23 +19,23 @@
 package organizations
 
 import (
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.ORGANIZATIONS, "", "Exporting organizations...")
 	exportFilePath = filepath.Join(exportFilePath, utils.ORGANIZATIONS.String())
 
-	if utils.ShouldSkip(utils.ORGANIZATIONS) {
+	if session.ShouldSkip(utils.ORGANIZATIONS) {
 		return
 	}
-	orgs, err := getOrganizationList()
+	orgs, err := getOrganizationList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.ORGANIZATIONS, "", fmt.Sprintf("Error retrieving organizations list: %s", err))
 		utils.MarkResTypeFailure(utils.ORGANIZATIONS)
@@ -43,38 +43,41 @@ func ExportAll(exportFilePath string, format string) {
 	}
 
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.ORGANIZATIONS, "", fmt.Sprintf("Error creating organizations directory: %s", err))
 			utils.MarkResTypeFailure(utils.ORGANIZATIONS)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
-			deployedResourceNames := getDeployedOrgResourceNames(orgs)
+		if session.ToolConfigs.AllowDelete {
+			deployedResourceNames := getDeployedOrgResourceNames(session, orgs)
 			utils.RemoveDeletedLocalResources(exportFilePath, deployedResourceNames)
 		}
 	}
 
-	for _, org := range orgs {
-		resourceName := getOrgResourceName(org)
-		if !utils.IsResourceExcluded(resourceName, utils.TOOL_CONFIGS.OrganizationConfigs) {
+	session.ProcessConcurrently(len(orgs), func(i int) {
+		org := orgs[i]
+		resourceName := getOrgResourceName(session, org)
+		if !utils.IsResourceExcluded(resourceName, session.ToolConfigs.OrganizationConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.ORGANIZATIONS, resourceName, "Exporting")
 
-			err := exportOrganization(org.Id, resourceName, exportFilePath, format)
+			utils.MarkResourceStart(utils.ORGANIZATIONS, resourceName)
+			err := exportOrganization(session, org.Id, resourceName, exportFilePath, format)
+			if errors.Is(err, utils.ErrFilteredOut) {
+				return
+			}
 			if err != nil {
-				utils.UpdateFailureSummary(utils.ORGANIZATIONS, resourceName)
-				utils.PrintLog(utils.LogLevelError, utils.ORGANIZATIONS, resourceName, fmt.Sprintf("Error while exporting: %s", err))
+				utils.UpdateFailureSummaryAndLog(utils.ORGANIZATIONS, resourceName, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 			} else {
-				utils.UpdateSuccessSummary(utils.ORGANIZATIONS, utils.EXPORT)
-				utils.PrintLog(utils.LogLevelInfo, utils.ORGANIZATIONS, resourceName, "Exported successfully")
+				utils.UpdateSuccessSummaryAndLog(utils.ORGANIZATIONS, resourceName, utils.EXPORT, "Exported successfully")
 			}
 		}
-	}
+	})
 }
 
-func exportOrganization(orgId, resourceName, outputDirPath, formatString string) error {
+func exportOrganization(session *utils.Session, orgId, resourceName, outputDirPath, formatString string) error {
 
-	org, err := utils.GetResourceData(utils.ORGANIZATIONS, orgId)
+	org, err := session.GetResourceData(utils.ORGANIZATIONS, orgId)
 	if err != nil {
 		return fmt.Errorf("error while getting organization: %w", err)
 	}
@@ -82,8 +85,8 @@ func exportOrganization(orgId, resourceName, outputDirPath, formatString string)
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, resourceName, format)
 
-	orgKeywordMapping := getOrganizationKeywordMapping(resourceName)
-	modifiedOrg, err := utils.ProcessExportedData(org, exportedFileName, format, orgKeywordMapping, utils.ORGANIZATIONS)
+	orgKeywordMapping := getOrganizationKeywordMapping(session, resourceName)
+	modifiedOrg, err := session.ProcessExportedData(org, exportedFileName, format, orgKeywordMapping, utils.ORGANIZATIONS)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -98,7 +101,7 @@ func exportOrganization(orgId, resourceName, outputDirPath, formatString string)
 		return fmt.Errorf("error while serializing organization: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.ORGANIZATIONS)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
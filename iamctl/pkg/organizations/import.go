@@ -28,12 +28,12 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.ORGANIZATIONS, "", "Importing organizations...")
 	importFilePath := filepath.Join(inputDirPath, utils.ORGANIZATIONS.String())
 
-	if utils.ShouldSkip(utils.ORGANIZATIONS) {
+	if session.ShouldSkip(utils.ORGANIZATIONS) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -41,7 +41,7 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	existingList, err := getOrganizationList()
+	existingList, err := getOrganizationList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.ORGANIZATIONS, "", fmt.Sprintf("Error retrieving the deployed organization list: %s", err))
 		utils.MarkResTypeFailure(utils.ORGANIZATIONS)
@@ -53,15 +53,15 @@ func ImportAll(inputDirPath string) {
 		utils.MarkResTypeFailure(utils.ORGANIZATIONS)
 		return
 	}
-	curOrgId, err = GetCurrentOrganizationId()
+	curOrgId, err = GetCurrentOrganizationId(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.ORGANIZATIONS, "", "Error while retrieving current organization ID")
 		utils.MarkResTypeFailure(utils.ORGANIZATIONS)
 		return
 	}
 
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedOrganizations(files, existingList)
+	if session.IsDeleteAllowed(utils.ORGANIZATIONS) {
+		removeDeletedDeployedOrganizations(session, files, existingList)
 	}
 
 	for _, file := range files {
@@ -69,18 +69,18 @@ func ImportAll(inputDirPath string) {
 		fileInfo := utils.GetFileInfo(orgFilePath)
 		resourceName := fileInfo.ResourceName
 
-		if !utils.IsResourceExcluded(resourceName, utils.TOOL_CONFIGS.OrganizationConfigs) {
-			orgId := getOrgId(resourceName, existingList)
-			err := importOrganization(resourceName, orgId, orgFilePath)
+		if !utils.IsResourceExcluded(resourceName, session.ToolConfigs.OrganizationConfigs) && session.MatchesFileFilter(utils.ORGANIZATIONS, orgFilePath, getOrganizationKeywordMapping(session, resourceName), utils.IMPORT) {
+			orgId := getOrgId(session, resourceName, existingList)
+			utils.MarkResourceStart(utils.ORGANIZATIONS, resourceName)
+			err := importOrganization(session, resourceName, orgId, orgFilePath)
 			if err != nil {
-				utils.PrintLog(utils.LogLevelError, utils.ORGANIZATIONS, resourceName, fmt.Sprintf("Error importing organization: %s", err))
-				utils.UpdateFailureSummary(utils.ORGANIZATIONS, resourceName)
+				utils.UpdateFailureSummaryAndLog(utils.ORGANIZATIONS, resourceName, utils.IMPORT, err, fmt.Sprintf("Error importing organization: %s", err))
 			}
 		}
 	}
 }
 
-func importOrganization(resourceName, orgId, importFilePath string) error {
+func importOrganization(session *utils.Session, resourceName, orgId, importFilePath string) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(importFilePath))
 	if err != nil {
@@ -92,25 +92,37 @@ func importOrganization(resourceName, orgId, importFilePath string) error {
 		return fmt.Errorf("error when reading the file for organization: %w", err)
 	}
 
-	orgKeywordMapping := getOrganizationKeywordMapping(resourceName)
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), orgKeywordMapping)
+	orgKeywordMapping := getOrganizationKeywordMapping(session, resourceName)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), orgKeywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.ORGANIZATIONS, importFilePath, modifiedFileData, orgKeywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.ORGANIZATIONS, importFilePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
+
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.ORGANIZATIONS, resourceName, utils.GetImportAction(orgId != ""), "")
+		return nil
+	}
 
 	if orgId == "" {
-		return createOrganization([]byte(modifiedFileData), format, resourceName)
+		return createOrganization(session, []byte(modifiedFileData), format, resourceName)
 	}
-	return updateOrganization(orgId, []byte(modifiedFileData), format, resourceName)
+	return updateOrganization(session, orgId, []byte(modifiedFileData), format, resourceName)
 }
 
-func createOrganization(requestBody []byte, format utils.Format, resourceName string) error {
+func createOrganization(session *utils.Session, requestBody []byte, format utils.Format, resourceName string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.ORGANIZATIONS, resourceName, "Creating new organization")
 
-	jsonBody, status, err := prepareOrganizationPostBody(requestBody, format, curOrgId)
+	jsonBody, status, err := prepareOrganizationPostBody(session, requestBody, format, curOrgId)
 	if err != nil {
 		return err
 	}
 
-	resp, err := utils.SendPostRequest(utils.ORGANIZATIONS, jsonBody)
+	resp, err := session.SendPostRequest(utils.ORGANIZATIONS, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when creating organization: %w", err)
 	}
@@ -125,16 +137,15 @@ func createOrganization(requestBody []byte, format utils.Format, resourceName st
 		return fmt.Errorf("error parsing create response: %w", err)
 	}
 
-	if err := patchOrganizationStatus(createdOrg.Id, status); err != nil {
+	if err := patchOrganizationStatus(session, createdOrg.Id, status); err != nil {
 		return fmt.Errorf("error updating status field: %w", err)
 	}
 
-	utils.UpdateSuccessSummary(utils.ORGANIZATIONS, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.ORGANIZATIONS, resourceName, "Created successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.ORGANIZATIONS, resourceName, utils.IMPORT, "Created successfully")
 	return nil
 }
 
-func updateOrganization(orgId string, requestBody []byte, format utils.Format, resourceName string) error {
+func updateOrganization(session *utils.Session, orgId string, requestBody []byte, format utils.Format, resourceName string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.ORGANIZATIONS, resourceName, "Updating organization")
 
@@ -144,18 +155,17 @@ func updateOrganization(orgId string, requestBody []byte, format utils.Format, r
 		return err
 	}
 
-	resp, err := utils.SendPutRequest(utils.ORGANIZATIONS, orgId, updateBody)
+	resp, err := session.SendPutRequest(utils.ORGANIZATIONS, orgId, updateBody)
 	if err != nil {
 		return fmt.Errorf("error when updating organization: %w", err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.ORGANIZATIONS, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.ORGANIZATIONS, resourceName, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.ORGANIZATIONS, resourceName, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func removeDeletedDeployedOrganizations(localFiles []os.FileInfo, deployedOrgs []organization) {
+func removeDeletedDeployedOrganizations(session *utils.Session, localFiles []os.FileInfo, deployedOrgs []organization) {
 
 	if len(deployedOrgs) == 0 {
 		return
@@ -168,21 +178,24 @@ func removeDeletedDeployedOrganizations(localFiles []os.FileInfo, deployedOrgs [
 	}
 
 	for _, org := range deployedOrgs {
-		resourceName := getOrgResourceName(org)
+		resourceName := getOrgResourceName(session, org)
 		if _, existsLocally := localResourceNames[resourceName]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(resourceName, utils.TOOL_CONFIGS.OrganizationConfigs) {
+		if utils.IsResourceExcluded(resourceName, session.ToolConfigs.OrganizationConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.ORGANIZATIONS, resourceName, "Excluded from deletion")
 			continue
 		}
 
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.ORGANIZATIONS, resourceName, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.ORGANIZATIONS, resourceName, "Not found locally. Deleting organization.")
-		if err := utils.SendDeleteRequest(org.Id, utils.ORGANIZATIONS); err != nil {
-			utils.UpdateFailureSummary(utils.ORGANIZATIONS, resourceName)
-			utils.PrintLog(utils.LogLevelError, utils.ORGANIZATIONS, resourceName, fmt.Sprintf("Error deleting organization: %s", err))
+		if err := session.SendDeleteRequest(org.Id, utils.ORGANIZATIONS); err != nil {
+			utils.UpdateFailureSummaryAndLog(utils.ORGANIZATIONS, resourceName, utils.DELETE, err, fmt.Sprintf("Error deleting organization: %s", err))
 		} else {
-			utils.UpdateSuccessSummary(utils.ORGANIZATIONS, utils.DELETE)
+			utils.UpdateSuccessSummary(utils.ORGANIZATIONS, resourceName, utils.DELETE)
 		}
 	}
 }
@@ -51,9 +51,9 @@ const (
 
 var curOrgId string
 
-func GetCurrentOrganizationId() (id string, err error) {
+func GetCurrentOrganizationId(session *utils.Session) (id string, err error) {
 
-	org, err := utils.SendGetRequest(utils.ORGANIZATIONS, "self")
+	org, err := session.SendGetRequest(utils.ORGANIZATIONS, "self")
 	if err != nil {
 		return "", fmt.Errorf("error while getting organization: %w", err)
 	}
@@ -65,9 +65,9 @@ func GetCurrentOrganizationId() (id string, err error) {
 	return curOrg.Id, nil
 }
 
-func getOrganizationList() ([]organization, error) {
+func getOrganizationList(session *utils.Session) ([]organization, error) {
 
-	body, err := utils.SendGetListRequest(utils.ORGANIZATIONS,
+	body, err := session.SendGetListRequest(utils.ORGANIZATIONS,
 		utils.WithQueryParams(map[string]string{"recursive": "false"}))
 	if err != nil {
 		return nil, fmt.Errorf("error while retrieving organization list: %w", err)
@@ -90,7 +90,7 @@ func getOrganizationList() ([]organization, error) {
 			break
 		}
 
-		resp, err := utils.SendCustomRequest(http.MethodGet, utils.SERVER_CONFIGS.ServerUrl+nextHref, nil, "")
+		resp, err := session.SendCustomRequest(utils.ORGANIZATIONS, http.MethodGet, session.ServerConfigs.ServerUrl+nextHref, nil, "")
 		if err != nil {
 			return nil, fmt.Errorf("error retrieving page of organization list: %w", err)
 		}
@@ -118,34 +118,34 @@ func getOrganizationList() ([]organization, error) {
 	return allResults, nil
 }
 
-func getDeployedOrgResourceNames(orgs []organization) []string {
+func getDeployedOrgResourceNames(session *utils.Session, orgs []organization) []string {
 
 	var names []string
 	for _, o := range orgs {
-		names = append(names, getOrgResourceName(o))
+		names = append(names, getOrgResourceName(session, o))
 	}
 	return names
 }
 
-func getOrganizationKeywordMapping(resourceName string) map[string]interface{} {
+func getOrganizationKeywordMapping(session *utils.Session, resourceName string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.OrganizationConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(resourceName, utils.KEYWORD_CONFIGS.OrganizationConfigs)
+	if session.KeywordConfigs.OrganizationConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(resourceName, session.KeywordConfigs.OrganizationConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
-func getOrgId(resourceName string, list []organization) string {
+func getOrgId(session *utils.Session, resourceName string, list []organization) string {
 
 	for _, o := range list {
-		if getOrgResourceName(o) == resourceName {
+		if getOrgResourceName(session, o) == resourceName {
 			return o.Id
 		}
 	}
 	return ""
 }
 
-func prepareOrganizationPostBody(requestBody []byte, format utils.Format, parentId string) (reqBody []byte, status interface{}, err error) {
+func prepareOrganizationPostBody(session *utils.Session, requestBody []byte, format utils.Format, parentId string) (reqBody []byte, status interface{}, err error) {
 
 	orgData, err := utils.DeserializeToMap(requestBody, format, utils.ORGANIZATIONS,
 		"id", "parent", "version", "permissions", "created", "lastModified", "hasChildren", "ancestorPath")
@@ -158,11 +158,11 @@ func prepareOrganizationPostBody(requestBody []byte, format utils.Format, parent
 	delete(orgData, "status")
 
 	// orgHandle is removed from POST requests for Asgardeo
-	if utils.SERVER_CONFIGS.ServerVersion == "" {
+	if session.ServerConfigs.ServerVersion == "" {
 		delete(orgData, "orgHandle")
 	}
 
-	if err := addCreatorAttributes(orgData); err != nil {
+	if err := addCreatorAttributes(session, orgData); err != nil {
 		return nil, nil, err
 	}
 
@@ -173,14 +173,14 @@ func prepareOrganizationPostBody(requestBody []byte, format utils.Format, parent
 	return jsonBody, status, nil
 }
 
-func addCreatorAttributes(orgData map[string]interface{}) error {
+func addCreatorAttributes(session *utils.Session, orgData map[string]interface{}) error {
 
-	if utils.TOOL_CONFIGS.OrganizationConfigs == nil {
+	if session.ToolConfigs.OrganizationConfigs == nil {
 		return nil
 	}
 
-	creatorId, hasId := utils.TOOL_CONFIGS.OrganizationConfigs["CREATOR_ID"]
-	creatorUsername, hasUsername := utils.TOOL_CONFIGS.OrganizationConfigs["CREATOR_USERNAME"]
+	creatorId, hasId := session.ToolConfigs.OrganizationConfigs["CREATOR_ID"]
+	creatorUsername, hasUsername := session.ToolConfigs.OrganizationConfigs["CREATOR_USERNAME"]
 	if !hasId || !hasUsername {
 		return nil
 	}
@@ -254,7 +254,7 @@ func removeCreatorAttributes(orgData interface{}) (interface{}, error) {
 	return dataMap, nil
 }
 
-func patchOrganizationStatus(orgId string, rawStatus interface{}) error {
+func patchOrganizationStatus(session *utils.Session, orgId string, rawStatus interface{}) error {
 
 	status, ok := rawStatus.(string)
 	if !ok {
@@ -273,7 +273,7 @@ func patchOrganizationStatus(orgId string, rawStatus interface{}) error {
 		return fmt.Errorf("error serializing PATCH body: %w", err)
 	}
 
-	resp, err := utils.SendPatchRequest(utils.ORGANIZATIONS, orgId, jsonBody)
+	resp, err := session.SendPatchRequest(utils.ORGANIZATIONS, orgId, jsonBody)
 	if err != nil {
 		return err
 	}
@@ -282,10 +282,10 @@ func patchOrganizationStatus(orgId string, rawStatus interface{}) error {
 	return nil
 }
 
-func getOrgResourceName(org organization) string {
+func getOrgResourceName(session *utils.Session, org organization) string {
 
 	// Uses org name as the resource identifier in Asgardeo, org handle for IS.
-	if utils.SERVER_CONFIGS.ServerVersion == "" {
+	if session.ServerConfigs.ServerVersion == "" {
 		return org.Name
 	}
 	return org.OrgHandle
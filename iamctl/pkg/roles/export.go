@@ -19,24 +19,24 @@
 package roles
 
 import (
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.ROLES, "", "Exporting roles...")
 	exportFilePath = filepath.Join(exportFilePath, utils.ROLES.String())
-	setRolesV2ApiExists()
+	setRolesV2ApiExists(session)
 
-	if utils.ShouldSkip(utils.ROLES) {
+	if session.ShouldSkip(utils.ROLES) {
 		return
 	}
-	roles, err := GetRoleList()
+	roles, err := GetRoleList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.ROLES, "", fmt.Sprintf("Error retrieving the deployed roles list: %s", err))
 		utils.MarkResTypeFailure(utils.ROLES)
@@ -44,43 +44,46 @@ func ExportAll(exportFilePath string, format string) {
 	}
 
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.ROLES, "", fmt.Sprintf("Error creating roles directory: %s", err))
 			utils.MarkResTypeFailure(utils.ROLES)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
+		if session.ToolConfigs.AllowDelete {
 			deployedRoleNames := getDeployedRoleLocalFileNames(roles)
 			utils.RemoveDeletedLocalResources(exportFilePath, deployedRoleNames)
 		}
 	}
 
-	for _, r := range roles {
-		if !utils.IsResourceExcluded(r.DisplayName, utils.TOOL_CONFIGS.RoleConfigs) {
+	session.ProcessConcurrently(len(roles), func(i int) {
+		r := roles[i]
+		if !utils.IsResourceExcluded(r.DisplayName, session.ToolConfigs.RoleConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.ROLES, r.DisplayName, "Exporting")
 
-			err := exportRole(r, exportFilePath, format)
+			utils.MarkResourceStart(utils.ROLES, r.DisplayName)
+			err := exportRole(session, r, exportFilePath, format)
+			if errors.Is(err, utils.ErrFilteredOut) {
+				return
+			}
 			if err != nil {
-				utils.UpdateFailureSummary(utils.ROLES, r.DisplayName)
-				utils.PrintLog(utils.LogLevelError, utils.ROLES, r.DisplayName, fmt.Sprintf("Error while exporting: %s", err))
+				utils.UpdateFailureSummaryAndLog(utils.ROLES, r.DisplayName, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 			} else {
 				utils.AddToIdentifierMap(utils.ROLES, r.Id, r.DisplayName, utils.EXPORT)
-				utils.UpdateSuccessSummary(utils.ROLES, utils.EXPORT)
-				utils.PrintLog(utils.LogLevelInfo, utils.ROLES, r.DisplayName, "Exported successfully")
+				utils.UpdateSuccessSummaryAndLog(utils.ROLES, r.DisplayName, utils.EXPORT, "Exported successfully")
 			}
 		}
-	}
+	})
 
 }
 
-func exportRole(r role, outputDirPath string, formatString string) error {
+func exportRole(session *utils.Session, r role, outputDirPath string, formatString string) error {
 
-	roleData, err := utils.GetResourceData(utils.ROLES, r.Id)
+	roleData, err := session.GetResourceData(utils.ROLES, r.Id)
 	if err != nil {
 		return fmt.Errorf("error while getting role: %w", err)
 	}
-	if utils.RolesV2ApiExists {
+	if session.RolesV2ApiExists {
 		roleData, err = processExportedRole(roleData)
 		if err != nil {
 			return fmt.Errorf("error while processing role permissions: %w", err)
@@ -90,8 +93,8 @@ func exportRole(r role, outputDirPath string, formatString string) error {
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, escapeRoleName(r.DisplayName), format)
 
-	roleKeywordMapping := getRoleKeywordMapping(r.DisplayName)
-	modifiedRole, err := utils.ProcessExportedData(roleData, exportedFileName, format, roleKeywordMapping, utils.ROLES)
+	roleKeywordMapping := getRoleKeywordMapping(session, r.DisplayName)
+	modifiedRole, err := session.ProcessExportedData(roleData, exportedFileName, format, roleKeywordMapping, utils.ROLES)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -101,7 +104,7 @@ func exportRole(r role, outputDirPath string, formatString string) error {
 		return fmt.Errorf("error while serializing role: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.ROLES)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
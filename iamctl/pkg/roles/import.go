@@ -27,13 +27,13 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.ROLES, "", "Importing roles...")
 	importFilePath := filepath.Join(inputDirPath, utils.ROLES.String())
-	setRolesV2ApiExists()
+	setRolesV2ApiExists(session)
 
-	if utils.ShouldSkip(utils.ROLES) {
+	if session.ShouldSkip(utils.ROLES) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -41,7 +41,7 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	existingRoleList, err := GetRoleList()
+	existingRoleList, err := GetRoleList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.ROLES, "", fmt.Sprintf("Error retrieving the deployed role list: %s", err))
 		utils.MarkResTypeFailure(utils.ROLES)
@@ -55,30 +55,32 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedRoles(files, existingRoleList)
+	if session.IsDeleteAllowed(utils.ROLES) {
+		removeDeletedDeployedRoles(session, files, existingRoleList)
 	}
 
-	for _, file := range files {
+	session.ProcessConcurrently(len(files), func(i int) {
+		file := files[i]
 		roleFilePath := filepath.Join(importFilePath, file.Name())
 		fileInfo := utils.GetFileInfo(roleFilePath)
 		displayName := unescapeName(fileInfo.ResourceName)
 
-		if !utils.IsResourceExcluded(displayName, utils.TOOL_CONFIGS.RoleConfigs) {
+		if !utils.IsResourceExcluded(displayName, session.ToolConfigs.RoleConfigs) && session.MatchesFileFilter(utils.ROLES, roleFilePath, getRoleKeywordMapping(session, displayName), utils.IMPORT) {
 			roleId := getRoleId(displayName, existingRoleList)
-			err := importRole(displayName, roleId, roleFilePath)
+			utils.MarkResourceStart(utils.ROLES, displayName)
+			err := importRole(session, displayName, roleId, roleFilePath)
 			if err != nil {
-				utils.PrintLog(utils.LogLevelError, utils.ROLES, displayName, fmt.Sprintf("Error importing role: %s", err))
-				utils.UpdateFailureSummary(utils.ROLES, displayName)
+				utils.UpdateFailureSummaryAndLog(utils.ROLES, displayName, utils.IMPORT, err, fmt.Sprintf("Error importing role: %s", err))
 			}
 		}
-	}
+	})
 }
 
-func importRole(displayName string, roleId string, importFilePath string) error {
+func importRole(session *utils.Session, displayName string, roleId string, importFilePath string) error {
 
-	if displayName == utils.ADMIN_ROLE || (utils.RolesV2ApiExists && (displayName == utils.ADMINISTRATOR_ROLE || displayName == utils.IMPERSONATOR_ROLE)) {
+	if displayName == utils.ADMIN_ROLE || (session.RolesV2ApiExists && (displayName == utils.ADMINISTRATOR_ROLE || displayName == utils.IMPERSONATOR_ROLE)) {
 		utils.PrintLog(utils.LogLevelInfo, utils.ROLES, displayName, "System role. Skipping import.")
+		utils.AddToPlan(utils.ROLES, displayName, utils.PLAN_NO_OP, "System role")
 		if roleId != "" {
 			utils.AddToIdentifierMap(utils.ROLES, roleId, displayName, utils.IMPORT)
 		}
@@ -95,16 +97,31 @@ func importRole(displayName string, roleId string, importFilePath string) error
 		return fmt.Errorf("error when reading the file for role: %w", err)
 	}
 
-	roleKeywordMapping := getRoleKeywordMapping(displayName)
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), roleKeywordMapping)
+	roleKeywordMapping := getRoleKeywordMapping(session, displayName)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), roleKeywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.ROLES, importFilePath, modifiedFileData, roleKeywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.ROLES, importFilePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
+
+	if utils.DRY_RUN {
+		if roleId != "" {
+			utils.AddToIdentifierMap(utils.ROLES, roleId, displayName, utils.IMPORT)
+		}
+		utils.AddToPlan(utils.ROLES, displayName, utils.GetImportAction(roleId != ""), "")
+		return nil
+	}
 
 	if roleId == "" {
-		return createRole([]byte(modifiedFileData), format, displayName)
+		return createRole(session, []byte(modifiedFileData), format, displayName)
 	}
-	return updateRole(roleId, []byte(modifiedFileData), format, displayName)
+	return updateRole(session, roleId, []byte(modifiedFileData), format, displayName)
 }
 
-func createRole(requestBody []byte, format utils.Format, displayName string) error {
+func createRole(session *utils.Session, requestBody []byte, format utils.Format, displayName string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.ROLES, displayName, "Creating new role")
 
@@ -114,8 +131,8 @@ func createRole(requestBody []byte, format utils.Format, displayName string) err
 	}
 
 	var roleData interface{} = roleMap
-	if utils.RolesV2ApiExists {
-		roleData, err = processAudienceForImport(roleMap)
+	if session.RolesV2ApiExists {
+		roleData, err = processAudienceForImport(session, roleMap)
 		if err != nil {
 			return fmt.Errorf("error processing role audience: %w", err)
 		}
@@ -126,7 +143,7 @@ func createRole(requestBody []byte, format utils.Format, displayName string) err
 		return fmt.Errorf("error serializing to JSON: %w", err)
 	}
 
-	resp, err := utils.SendPostRequest(utils.ROLES, jsonBody)
+	resp, err := session.SendPostRequest(utils.ROLES, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when creating role: %w", err)
 	}
@@ -138,12 +155,11 @@ func createRole(requestBody []byte, format utils.Format, displayName string) err
 	}
 	utils.AddToIdentifierMap(utils.ROLES, created.Id, created.DisplayName, utils.IMPORT)
 
-	utils.UpdateSuccessSummary(utils.ROLES, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.ROLES, displayName, "Created successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.ROLES, displayName, utils.IMPORT, "Created successfully")
 	return nil
 }
 
-func updateRole(roleId string, requestBody []byte, format utils.Format, displayName string) error {
+func updateRole(session *utils.Session, roleId string, requestBody []byte, format utils.Format, displayName string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.ROLES, displayName, "Updating role")
 
@@ -152,7 +168,7 @@ func updateRole(roleId string, requestBody []byte, format utils.Format, displayN
 		return fmt.Errorf("error building patch body for role: %w", err)
 	}
 
-	resp, err := utils.SendPatchRequest(utils.ROLES, roleId, patchBody)
+	resp, err := session.SendPatchRequest(utils.ROLES, roleId, patchBody)
 	if err != nil {
 		return fmt.Errorf("error when updating role: %w", err)
 	}
@@ -160,12 +176,11 @@ func updateRole(roleId string, requestBody []byte, format utils.Format, displayN
 
 	utils.AddToIdentifierMap(utils.ROLES, roleId, displayName, utils.IMPORT)
 
-	utils.UpdateSuccessSummary(utils.ROLES, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.ROLES, displayName, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.ROLES, displayName, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func removeDeletedDeployedRoles(localFiles []os.FileInfo, deployedRoles []role) {
+func removeDeletedDeployedRoles(session *utils.Session, localFiles []os.FileInfo, deployedRoles []role) {
 
 	if len(deployedRoles) == 0 {
 		return
@@ -182,21 +197,24 @@ func removeDeletedDeployedRoles(localFiles []os.FileInfo, deployedRoles []role)
 		if _, existsLocally := localResourceNames[fileName]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(r.DisplayName, utils.TOOL_CONFIGS.RoleConfigs) || r.DisplayName == utils.ADMIN_ROLE {
+		if utils.IsResourceExcluded(r.DisplayName, session.ToolConfigs.RoleConfigs) || r.DisplayName == utils.ADMIN_ROLE {
 			utils.PrintLog(utils.LogLevelInfo, utils.ROLES, r.DisplayName, "Excluded from deletion.")
 			continue
 		}
-		if utils.RolesV2ApiExists && (r.DisplayName == utils.ADMINISTRATOR_ROLE || r.DisplayName == utils.IMPERSONATOR_ROLE) {
+		if session.RolesV2ApiExists && (r.DisplayName == utils.ADMINISTRATOR_ROLE || r.DisplayName == utils.IMPERSONATOR_ROLE) {
 			utils.PrintLog(utils.LogLevelInfo, utils.ROLES, r.DisplayName, "Excluded from deletion.")
 			continue
 		}
 
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.ROLES, r.DisplayName, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.ROLES, r.DisplayName, "Not found locally. Deleting role.")
-		if err := utils.SendDeleteRequest(r.Id, utils.ROLES); err != nil {
-			utils.UpdateFailureSummary(utils.ROLES, r.DisplayName)
-			utils.PrintLog(utils.LogLevelError, utils.ROLES, r.DisplayName, fmt.Sprintf("Error deleting role: %s", err))
+		if err := session.SendDeleteRequest(r.Id, utils.ROLES); err != nil {
+			utils.UpdateFailureSummaryAndLog(utils.ROLES, r.DisplayName, utils.DELETE, err, fmt.Sprintf("Error deleting role: %s", err))
 		} else {
-			utils.UpdateSuccessSummary(utils.ROLES, utils.DELETE)
+			utils.UpdateSuccessSummary(utils.ROLES, r.DisplayName, utils.DELETE)
 		}
 	}
 }
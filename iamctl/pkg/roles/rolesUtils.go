@@ -42,9 +42,9 @@ type rolePatchRequest struct {
 	Schemas    []string         `json:"schemas"`
 }
 
-func GetRoleList() ([]role, error) {
+func GetRoleList(session *utils.Session) ([]role, error) {
 
-	data, err := utils.SendPaginatedGetListRequest(
+	data, err := session.SendPaginatedGetListRequest(
 		utils.ROLES,
 		"totalResults",
 		"itemsPerPage",
@@ -73,12 +73,12 @@ func getDeployedRoleLocalFileNames(roles []role) []string {
 	return names
 }
 
-func getRoleKeywordMapping(roleName string) map[string]interface{} {
+func getRoleKeywordMapping(session *utils.Session, roleName string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.RoleConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(roleName, utils.KEYWORD_CONFIGS.RoleConfigs)
+	if session.KeywordConfigs.RoleConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(roleName, session.KeywordConfigs.RoleConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func getRoleId(displayName string, roleList []role) string {
@@ -169,7 +169,7 @@ func processAudienceForExport(roleMap map[string]interface{}) (map[string]interf
 	return roleMap, nil
 }
 
-func processAudienceForImport(roleMap map[string]interface{}) (interface{}, error) {
+func processAudienceForImport(session *utils.Session, roleMap map[string]interface{}) (interface{}, error) {
 
 	audience, ok := roleMap["audience"].(map[string]interface{})
 	if !ok {
@@ -182,7 +182,7 @@ func processAudienceForImport(roleMap map[string]interface{}) (interface{}, erro
 
 	switch audType {
 	case "organization":
-		superOrgId, err := organizations.GetCurrentOrganizationId()
+		superOrgId, err := organizations.GetCurrentOrganizationId(session)
 		if err != nil {
 			return nil, fmt.Errorf("error while retrieving organization ID: %w", err)
 		}
@@ -212,14 +212,14 @@ func unescapeName(sanitizedFileName string) string {
 	return strings.ReplaceAll(sanitizedFileName, "Application%2F", "Application/")
 }
 
-func setRolesV2ApiExists() {
+func setRolesV2ApiExists(session *utils.Session) {
 
-	res, err := utils.CompareVersions(utils.SERVER_CONFIGS.ServerVersion, utils.MIN_VERSION_ROLES_V2_API)
+	res, err := utils.CompareVersions(session.ServerConfigs.ServerVersion, utils.MIN_VERSION_ROLES_V2_API)
 
 	// Use the V2 API when the server version is ""
 	if err != nil || res >= 0 {
-		utils.RolesV2ApiExists = true
+		session.RolesV2ApiExists = true
 	} else {
-		utils.RolesV2ApiExists = false
+		session.RolesV2ApiExists = false
 	}
 }
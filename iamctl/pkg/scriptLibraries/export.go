@@ -19,60 +19,63 @@
 package scriptLibraries
 
 import (
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.SCRIPT_LIBRARIES, "", "Exporting script libraries...")
 	exportFilePath = filepath.Join(exportFilePath, utils.SCRIPT_LIBRARIES.String())
 
-	if utils.ShouldSkip(utils.SCRIPT_LIBRARIES) {
+	if session.ShouldSkip(utils.SCRIPT_LIBRARIES) {
 		return
 	}
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.SCRIPT_LIBRARIES, "", fmt.Sprintf("Error creating script libraries directory: %s", err))
 			utils.MarkResTypeFailure(utils.SCRIPT_LIBRARIES)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
-			deployedNames := getDeployedScriptLibraryNames()
+		if session.ToolConfigs.AllowDelete {
+			deployedNames := getDeployedScriptLibraryNames(session)
 			utils.RemoveDeletedLocalResources(exportFilePath, deployedNames)
 		}
 	}
 
-	libraries, err := getScriptLibraryList()
+	libraries, err := getScriptLibraryList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.SCRIPT_LIBRARIES, "", fmt.Sprintf("Error retrieving the deployed script libraries list: %s", err))
 		utils.MarkResTypeFailure(utils.SCRIPT_LIBRARIES)
 	} else {
-		for _, library := range libraries {
-			if !utils.IsResourceExcluded(library.Name, utils.TOOL_CONFIGS.ScriptLibraryConfigs) {
+		session.ProcessConcurrently(len(libraries), func(i int) {
+			library := libraries[i]
+			if !utils.IsResourceExcluded(library.Name, session.ToolConfigs.ScriptLibraryConfigs) {
 				utils.PrintLog(utils.LogLevelInfo, utils.SCRIPT_LIBRARIES, library.Name, "Exporting")
 
-				err := exportScriptLibrary(library.Name, exportFilePath, format)
+				utils.MarkResourceStart(utils.SCRIPT_LIBRARIES, library.Name)
+				err := exportScriptLibrary(session, library.Name, exportFilePath, format)
+				if errors.Is(err, utils.ErrFilteredOut) {
+					return
+				}
 				if err != nil {
-					utils.UpdateFailureSummary(utils.SCRIPT_LIBRARIES, library.Name)
-					utils.PrintLog(utils.LogLevelError, utils.SCRIPT_LIBRARIES, library.Name, fmt.Sprintf("Error while exporting: %s", err))
+					utils.UpdateFailureSummaryAndLog(utils.SCRIPT_LIBRARIES, library.Name, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 				} else {
-					utils.UpdateSuccessSummary(utils.SCRIPT_LIBRARIES, utils.EXPORT)
-					utils.PrintLog(utils.LogLevelInfo, utils.SCRIPT_LIBRARIES, library.Name, "Exported successfully")
+					utils.UpdateSuccessSummaryAndLog(utils.SCRIPT_LIBRARIES, library.Name, utils.EXPORT, "Exported successfully")
 				}
 			}
-		}
+		})
 	}
 }
 
-func exportScriptLibrary(libraryName string, outputDirPath string, formatString string) error {
+func exportScriptLibrary(session *utils.Session, libraryName string, outputDirPath string, formatString string) error {
 
-	libraryData, err := getScriptLibraryData(libraryName)
+	libraryData, err := getScriptLibraryData(session, libraryName)
 	if err != nil {
 		return fmt.Errorf("error while getting script library: %w", err)
 	}
@@ -80,8 +83,8 @@ func exportScriptLibrary(libraryName string, outputDirPath string, formatString
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, libraryName, format)
 
-	keywordMapping := getScriptLibraryKeywordMapping(libraryName)
-	modifiedData, err := utils.ProcessExportedData(libraryData, exportedFileName, format, keywordMapping, utils.SCRIPT_LIBRARIES)
+	keywordMapping := getScriptLibraryKeywordMapping(session, libraryName)
+	modifiedData, err := session.ProcessExportedData(libraryData, exportedFileName, format, keywordMapping, utils.SCRIPT_LIBRARIES)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -91,7 +94,7 @@ func exportScriptLibrary(libraryName string, outputDirPath string, formatString
 		return fmt.Errorf("error while serializing script library: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.SCRIPT_LIBRARIES)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
@@ -27,12 +27,12 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.SCRIPT_LIBRARIES, "", "Importing script libraries...")
 	importFilePath := filepath.Join(inputDirPath, utils.SCRIPT_LIBRARIES.String())
 
-	if utils.ShouldSkip(utils.SCRIPT_LIBRARIES) {
+	if session.ShouldSkip(utils.SCRIPT_LIBRARIES) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -40,7 +40,7 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	existingList, err := getScriptLibraryList()
+	existingList, err := getScriptLibraryList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.SCRIPT_LIBRARIES, "", fmt.Sprintf("Error retrieving the deployed script library list: %s", err))
 		utils.MarkResTypeFailure(utils.SCRIPT_LIBRARIES)
@@ -53,27 +53,28 @@ func ImportAll(inputDirPath string) {
 		utils.MarkResTypeFailure(utils.SCRIPT_LIBRARIES)
 		return
 	}
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedScriptLibraries(files, existingList)
+	if session.IsDeleteAllowed(utils.SCRIPT_LIBRARIES) {
+		removeDeletedDeployedScriptLibraries(session, files, existingList)
 	}
 
-	for _, file := range files {
+	session.ProcessConcurrently(len(files), func(i int) {
+		file := files[i]
 		libraryFilePath := filepath.Join(importFilePath, file.Name())
 		fileInfo := utils.GetFileInfo(libraryFilePath)
 		libraryName := fileInfo.ResourceName
 
-		if !utils.IsResourceExcluded(libraryName, utils.TOOL_CONFIGS.ScriptLibraryConfigs) {
+		if !utils.IsResourceExcluded(libraryName, session.ToolConfigs.ScriptLibraryConfigs) && session.MatchesFileFilter(utils.SCRIPT_LIBRARIES, libraryFilePath, getScriptLibraryKeywordMapping(session, libraryName), utils.IMPORT) {
 			libraryExists := isScriptLibraryExists(libraryName, existingList)
-			err := importScriptLibrary(libraryName, libraryExists, libraryFilePath)
+			utils.MarkResourceStart(utils.SCRIPT_LIBRARIES, libraryName)
+			err := importScriptLibrary(session, libraryName, libraryExists, libraryFilePath)
 			if err != nil {
-				utils.PrintLog(utils.LogLevelError, utils.SCRIPT_LIBRARIES, libraryName, fmt.Sprintf("Error importing script library: %s", err))
-				utils.UpdateFailureSummary(utils.SCRIPT_LIBRARIES, libraryName)
+				utils.UpdateFailureSummaryAndLog(utils.SCRIPT_LIBRARIES, libraryName, utils.IMPORT, err, fmt.Sprintf("Error importing script library: %s", err))
 			}
 		}
-	}
+	})
 }
 
-func importScriptLibrary(libraryName string, libraryExists bool, importFilePath string) error {
+func importScriptLibrary(session *utils.Session, libraryName string, libraryExists bool, importFilePath string) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(importFilePath))
 	if err != nil {
@@ -85,16 +86,28 @@ func importScriptLibrary(libraryName string, libraryExists bool, importFilePath
 		return fmt.Errorf("error when reading the file for script library: %w", err)
 	}
 
-	keywordMapping := getScriptLibraryKeywordMapping(libraryName)
-	modifiedFileData := []byte(utils.ReplaceKeywords(string(fileBytes), keywordMapping))
+	keywordMapping := getScriptLibraryKeywordMapping(session, libraryName)
+	modifiedFileData := []byte(session.ReplaceKeywords(string(fileBytes), keywordMapping))
+	if err := utils.CheckUnresolvedKeywords(utils.SCRIPT_LIBRARIES, importFilePath, string(modifiedFileData), keywordMapping); err != nil {
+		return err
+	}
+	fileDataWithOverlay, err := session.ApplyOverlay(utils.SCRIPT_LIBRARIES, importFilePath, string(modifiedFileData))
+	if err != nil {
+		return err
+	}
+	modifiedFileData = []byte(fileDataWithOverlay)
 
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.SCRIPT_LIBRARIES, libraryName, utils.GetImportAction(libraryExists), "")
+		return nil
+	}
 	if !libraryExists {
-		return createScriptLibrary(libraryName, modifiedFileData, format)
+		return createScriptLibrary(session, libraryName, modifiedFileData, format)
 	}
-	return updateScriptLibrary(libraryName, modifiedFileData, format)
+	return updateScriptLibrary(session, libraryName, modifiedFileData, format)
 }
 
-func createScriptLibrary(name string, data []byte, format utils.Format) error {
+func createScriptLibrary(session *utils.Session, name string, data []byte, format utils.Format) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.SCRIPT_LIBRARIES, name, "Creating new script library")
 
@@ -103,18 +116,17 @@ func createScriptLibrary(name string, data []byte, format utils.Format) error {
 		return fmt.Errorf("error building multipart form: %w", err)
 	}
 
-	resp, err := utils.SendPostRequest(utils.SCRIPT_LIBRARIES, body, utils.WithContentType(contentType))
+	resp, err := session.SendPostRequest(utils.SCRIPT_LIBRARIES, body, utils.WithContentType(contentType))
 	if err != nil {
 		return fmt.Errorf("error when creating script library: %w", err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.SCRIPT_LIBRARIES, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.SCRIPT_LIBRARIES, name, "Created successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.SCRIPT_LIBRARIES, name, utils.IMPORT, "Created successfully")
 	return nil
 }
 
-func updateScriptLibrary(name string, data []byte, format utils.Format) error {
+func updateScriptLibrary(session *utils.Session, name string, data []byte, format utils.Format) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.SCRIPT_LIBRARIES, name, "Updating script library")
 
@@ -123,18 +135,17 @@ func updateScriptLibrary(name string, data []byte, format utils.Format) error {
 		return fmt.Errorf("error building multipart form: %w", err)
 	}
 
-	resp, err := utils.SendPutRequest(utils.SCRIPT_LIBRARIES, name, body, utils.WithContentType(contentType))
+	resp, err := session.SendPutRequest(utils.SCRIPT_LIBRARIES, name, body, utils.WithContentType(contentType))
 	if err != nil {
 		return fmt.Errorf("error when updating script library: %w", err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.SCRIPT_LIBRARIES, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.SCRIPT_LIBRARIES, name, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.SCRIPT_LIBRARIES, name, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func removeDeletedDeployedScriptLibraries(localFiles []os.FileInfo, deployedLibraries []scriptLibrary) {
+func removeDeletedDeployedScriptLibraries(session *utils.Session, localFiles []os.FileInfo, deployedLibraries []scriptLibrary) {
 
 	if len(deployedLibraries) == 0 {
 		return
@@ -150,17 +161,20 @@ func removeDeletedDeployedScriptLibraries(localFiles []os.FileInfo, deployedLibr
 		if _, existsLocally := localResourceNames[library.Name]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(library.Name, utils.TOOL_CONFIGS.ScriptLibraryConfigs) {
+		if utils.IsResourceExcluded(library.Name, session.ToolConfigs.ScriptLibraryConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.SCRIPT_LIBRARIES, library.Name, "Excluded from deletion.")
 			continue
 		}
 
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.SCRIPT_LIBRARIES, library.Name, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.SCRIPT_LIBRARIES, library.Name, "Not found locally. Deleting library.")
-		if err := utils.SendDeleteRequest(library.Name, utils.SCRIPT_LIBRARIES); err != nil {
-			utils.UpdateFailureSummary(utils.SCRIPT_LIBRARIES, library.Name)
-			utils.PrintLog(utils.LogLevelError, utils.SCRIPT_LIBRARIES, library.Name, fmt.Sprintf("Error deleting script library: %s", err))
+		if err := session.SendDeleteRequest(library.Name, utils.SCRIPT_LIBRARIES); err != nil {
+			utils.UpdateFailureSummaryAndLog(utils.SCRIPT_LIBRARIES, library.Name, utils.DELETE, err, fmt.Sprintf("Error deleting script library: %s", err))
 		} else {
-			utils.UpdateSuccessSummary(utils.SCRIPT_LIBRARIES, utils.DELETE)
+			utils.UpdateSuccessSummary(utils.SCRIPT_LIBRARIES, library.Name, utils.DELETE)
 		}
 	}
 }
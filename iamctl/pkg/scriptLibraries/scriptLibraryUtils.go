@@ -30,9 +30,9 @@ type scriptLibrary struct {
 	Description string `json:"description"`
 }
 
-func getScriptLibraryList() ([]scriptLibrary, error) {
+func getScriptLibraryList(session *utils.Session) ([]scriptLibrary, error) {
 
-	data, err := utils.SendPaginatedGetListRequest(
+	data, err := session.SendPaginatedGetListRequest(
 		utils.SCRIPT_LIBRARIES,
 		"totalResults",
 		"count",
@@ -51,9 +51,9 @@ func getScriptLibraryList() ([]scriptLibrary, error) {
 	return libraries, nil
 }
 
-func getDeployedScriptLibraryNames() []string {
+func getDeployedScriptLibraryNames(session *utils.Session) []string {
 
-	libraries, err := getScriptLibraryList()
+	libraries, err := getScriptLibraryList(session)
 	if err != nil {
 		return []string{}
 	}
@@ -65,12 +65,12 @@ func getDeployedScriptLibraryNames() []string {
 	return names
 }
 
-func getScriptLibraryKeywordMapping(libraryName string) map[string]interface{} {
+func getScriptLibraryKeywordMapping(session *utils.Session, libraryName string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.ScriptLibraryConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(libraryName, utils.KEYWORD_CONFIGS.ScriptLibraryConfigs)
+	if session.KeywordConfigs.ScriptLibraryConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(libraryName, session.KeywordConfigs.ScriptLibraryConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func isScriptLibraryExists(libraryName string, existingList []scriptLibrary) bool {
@@ -83,14 +83,14 @@ func isScriptLibraryExists(libraryName string, existingList []scriptLibrary) boo
 	return false
 }
 
-func getScriptLibraryData(libraryName string) (map[string]interface{}, error) {
+func getScriptLibraryData(session *utils.Session, libraryName string) (map[string]interface{}, error) {
 
-	libraryData, err := utils.GetResourceData(utils.SCRIPT_LIBRARIES, libraryName)
+	libraryData, err := session.GetResourceData(utils.SCRIPT_LIBRARIES, libraryName)
 	if err != nil {
 		return nil, fmt.Errorf("error while getting script library: %w", err)
 	}
 
-	contentBytes, err := utils.SendGetRequest(utils.SCRIPT_LIBRARIES, libraryName+"/content")
+	contentBytes, err := session.SendGetRequest(utils.SCRIPT_LIBRARIES, libraryName+"/content")
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving script library content: %w", err)
 	}
@@ -19,6 +19,7 @@
 package userstores
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"mime"
@@ -29,62 +30,66 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	// Export all userstores to the UserStores folder.
 	utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, "", "Exporting user stores...")
 	exportFilePath = filepath.Join(exportFilePath, utils.USERSTORES.String())
 
-	if utils.IsResourceTypeExcluded(utils.USERSTORES) {
+	if session.IsResourceTypeExcluded(utils.USERSTORES) {
 		return
 	}
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.USERSTORES, "", fmt.Sprintf("Error creating user stores directory: %s", err))
 			utils.MarkResTypeFailure(utils.USERSTORES)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
-			utils.RemoveDeletedLocalResources(exportFilePath, getDeployedUserstoreNames())
+		if session.ToolConfigs.AllowDelete {
+			utils.RemoveDeletedLocalResources(exportFilePath, getDeployedUserstoreNames(session))
 		}
 	}
 
-	exportAPIExists := utils.ExportAPIExists(utils.USERSTORES)
-	userstores, err := getUserStoreList()
+	exportAPIExists := session.ExportAPIExists(utils.USERSTORES)
+	userstores, err := getUserStoreList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.USERSTORES, "", fmt.Sprintf("Error retrieving the deployed user stores list: %s", err))
 		utils.MarkResTypeFailure(utils.USERSTORES)
 	} else {
-		if !utils.AreSecretsExcluded(utils.TOOL_CONFIGS.UserStoreConfigs) {
+		if !session.AreSecretsExcluded(session.ToolConfigs.UserStoreConfigs) {
 			utils.PrintLog(utils.LogLevelWarn, utils.USERSTORES, "", "Secrets exclusion cannot be disabled for user stores. All secrets will be masked.")
 		}
-		for _, userstore := range userstores {
-			if !utils.IsResourceExcluded(userstore.Name, utils.TOOL_CONFIGS.UserStoreConfigs) {
+		session.ProcessConcurrently(len(userstores), func(i int) {
+			userstore := userstores[i]
+			if !utils.IsResourceExcluded(userstore.Name, session.ToolConfigs.UserStoreConfigs) {
 				utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userstore.Name, "Exporting")
 
+				utils.MarkResourceStart(utils.USERSTORES, userstore.Name)
+				var err error
 				if exportAPIExists {
-					err = exportUserStore(userstore.Id, exportFilePath, format)
+					err = exportUserStore(session, userstore.Id, exportFilePath, format)
 				} else {
-					err = exportUserStoreWithCRUD(userstore.Id, userstore.Name, exportFilePath, format)
+					err = exportUserStoreWithCRUD(session, userstore.Id, userstore.Name, exportFilePath, format)
 				}
 
+				if errors.Is(err, utils.ErrFilteredOut) {
+					return
+				}
 				if err != nil {
-					utils.UpdateFailureSummary(utils.USERSTORES, userstore.Name)
-					utils.PrintLog(utils.LogLevelError, utils.USERSTORES, userstore.Name, fmt.Sprintf("Error while exporting: %s", err))
+					utils.UpdateFailureSummaryAndLog(utils.USERSTORES, userstore.Name, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
 				} else {
-					utils.UpdateSuccessSummary(utils.USERSTORES, utils.EXPORT)
-					utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userstore.Name, "Exported successfully")
+					utils.UpdateSuccessSummaryAndLog(utils.USERSTORES, userstore.Name, utils.EXPORT, "Exported successfully")
 				}
 			}
-		}
+		})
 	}
-	if utils.IsResourceTypeExcluded(utils.CLAIMS) || utils.IsResourceExcluded(utils.LOCAL_CLAIM_DIALECT_URI, utils.TOOL_CONFIGS.ClaimConfigs) {
+	if session.IsResourceTypeExcluded(utils.CLAIMS) || utils.IsResourceExcluded(utils.LOCAL_CLAIM_DIALECT_URI, session.ToolConfigs.ClaimConfigs) {
 		utils.PrintLog(utils.LogLevelWarn, utils.USERSTORES, "", "Local claim dialect is excluded from export. Export local claims to propagate new claim attribute mappings of user stores.")
 	}
 }
 
-func exportUserStore(userStoreId string, outputDirPath string, format string) error {
+func exportUserStore(session *utils.Session, userStoreId string, outputDirPath string, format string) error {
 
 	var fileType string
 	// TODO: Extend support for json and xml formats.
@@ -97,7 +102,7 @@ func exportUserStore(userStoreId string, outputDirPath string, format string) er
 		fileType = utils.MEDIA_TYPE_YAML
 	}
 
-	resp, err := utils.SendExportRequest(userStoreId, fileType, utils.USERSTORES, true)
+	resp, err := session.SendExportRequest(userStoreId, fileType, utils.USERSTORES, true)
 	if err != nil {
 		return fmt.Errorf("error while exporting the user store: %s", err)
 	}
@@ -120,22 +125,22 @@ func exportUserStore(userStoreId string, outputDirPath string, format string) er
 	// Use the common mask for senstive data.
 	modifiedBody := []byte(strings.ReplaceAll(string(body), USERSTORE_SECRET_MASK, utils.SENSITIVE_FIELD_MASK))
 
-	userStoreKeywordMapping := getUserStoreKeywordMapping(fileInfo.ResourceName)
-	modifiedFile, err := utils.ProcessExportedContent(exportedFileName, modifiedBody, userStoreKeywordMapping, utils.USERSTORES)
+	userStoreKeywordMapping := getUserStoreKeywordMapping(session, fileInfo.ResourceName)
+	modifiedFile, err := session.ProcessExportedContent(exportedFileName, modifiedBody, userStoreKeywordMapping, utils.USERSTORES)
 	if err != nil {
-		return fmt.Errorf("error while processing the exported content: %s", err)
+		return fmt.Errorf("error while processing the exported content: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.USERSTORES)
 	if err != nil {
 		return fmt.Errorf("error when writing the exported content to file: %w", err)
 	}
 	return nil
 }
 
-func exportUserStoreWithCRUD(userStoreId, userStoreName, outputDirPath, formatString string) error {
+func exportUserStoreWithCRUD(session *utils.Session, userStoreId, userStoreName, outputDirPath, formatString string) error {
 
-	userStore, err := getUserStore(userStoreId)
+	userStore, err := getUserStore(session, userStoreId)
 	if err != nil {
 		return fmt.Errorf("error while getting user store: %w", err)
 	}
@@ -143,8 +148,8 @@ func exportUserStoreWithCRUD(userStoreId, userStoreName, outputDirPath, formatSt
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, userStoreName, format)
 
-	storeKeywordMapping := getUserStoreKeywordMapping(userStoreName)
-	modifiedStore, err := utils.ProcessExportedData(userStore, exportedFileName, format, storeKeywordMapping, utils.USERSTORES)
+	storeKeywordMapping := getUserStoreKeywordMapping(session, userStoreName)
+	modifiedStore, err := session.ProcessExportedData(userStore, exportedFileName, format, storeKeywordMapping, utils.USERSTORES)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -154,7 +159,7 @@ func exportUserStoreWithCRUD(userStoreId, userStoreName, outputDirPath, formatSt
 		return fmt.Errorf("error while serializing user store: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.USERSTORES)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
@@ -162,9 +167,9 @@ func exportUserStoreWithCRUD(userStoreId, userStoreName, outputDirPath, formatSt
 	return nil
 }
 
-func getUserStore(userStoreId string) (interface{}, error) {
+func getUserStore(session *utils.Session, userStoreId string) (interface{}, error) {
 
-	resp, err := utils.SendGetRequest(utils.USERSTORES, userStoreId)
+	resp, err := session.SendGetRequest(utils.USERSTORES, userStoreId)
 	if err != nil {
 		return nil, fmt.Errorf("error while retrieving user store with id: %s. %s", userStoreId, err)
 	}
@@ -27,12 +27,12 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, "", "Importing user stores...")
 	importFilePath := filepath.Join(inputDirPath, utils.USERSTORES.String())
 
-	if utils.IsResourceTypeExcluded(utils.USERSTORES) {
+	if session.IsResourceTypeExcluded(utils.USERSTORES) {
 		return
 	}
 	var files []os.FileInfo
@@ -45,40 +45,42 @@ func ImportAll(inputDirPath string) {
 			utils.MarkResTypeFailure(utils.USERSTORES)
 			return
 		}
-		if utils.TOOL_CONFIGS.AllowDelete {
-			removeDeletedDeployedUserstores(files)
+		if session.IsDeleteAllowed(utils.USERSTORES) {
+			removeDeletedDeployedUserstores(session, files)
 		}
 	}
 
-	exportAPIexists := utils.ExportAPIExists(utils.USERSTORES)
-	for _, file := range files {
+	exportAPIexists := session.ExportAPIExists(utils.USERSTORES)
+	session.ProcessConcurrently(len(files), func(i int) {
+		file := files[i]
 		userStoreFilePath := filepath.Join(importFilePath, file.Name())
 		fileInfo := utils.GetFileInfo(userStoreFilePath)
 		userStoreName := fileInfo.ResourceName
 
-		if !utils.IsResourceExcluded(userStoreName, utils.TOOL_CONFIGS.UserStoreConfigs) {
-			userStoreId, err := getUserStoreId(userStoreName)
+		if !utils.IsResourceExcluded(userStoreName, session.ToolConfigs.UserStoreConfigs) && session.MatchesFileFilter(utils.USERSTORES, userStoreFilePath, getUserStoreKeywordMapping(session, userStoreName), utils.IMPORT) {
+			userStoreId, err := getUserStoreId(session, userStoreName)
 			if err != nil {
 				utils.PrintLog(utils.LogLevelError, utils.USERSTORES, userStoreName, fmt.Sprintf("Invalid file configurations: %s", err))
 			} else {
-				err := importUserStore(userStoreId, userStoreName, userStoreFilePath, exportAPIexists)
+				utils.MarkResourceStart(utils.USERSTORES, userStoreName)
+				err := importUserStore(session, userStoreId, userStoreName, userStoreFilePath, exportAPIexists)
 				if err != nil {
-					utils.PrintLog(utils.LogLevelError, utils.USERSTORES, userStoreName, fmt.Sprintf("Error importing user store: %s", err))
-					utils.UpdateFailureSummary(utils.USERSTORES, userStoreName)
+					utils.UpdateFailureSummaryAndLog(utils.USERSTORES, userStoreName, utils.IMPORT, err, fmt.Sprintf("Error importing user store: %s", err))
 				}
 			}
 		}
-	}
-	if utils.IsResourceTypeExcluded(utils.CLAIMS) || utils.IsResourceExcluded(utils.LOCAL_CLAIM_DIALECT_URI, utils.TOOL_CONFIGS.ClaimConfigs) {
+	})
+	if session.IsResourceTypeExcluded(utils.CLAIMS) || utils.IsResourceExcluded(utils.LOCAL_CLAIM_DIALECT_URI, session.ToolConfigs.ClaimConfigs) {
 		utils.PrintLog(utils.LogLevelWarn, utils.USERSTORES, "", "Local claim dialect is excluded from import. Import local claims to propagate new claim attribute mappings of user stores.")
 	}
 }
 
-func importUserStore(userStoreId, userStoreName, userStoreFilePath string, exportAPIexists bool) error {
+func importUserStore(session *utils.Session, userStoreId, userStoreName, userStoreFilePath string, exportAPIexists bool) error {
 
 	// AGENT and DEFAULT user stores are not allowed to be modified in Asgardeo
-	if utils.SERVER_CONFIGS.ServerVersion == "" && (userStoreName == utils.AGENT_USERSTORE || userStoreName == utils.DEFAULT_USERSTORE) {
+	if session.ServerConfigs.ServerVersion == "" && (userStoreName == utils.AGENT_USERSTORE || userStoreName == utils.DEFAULT_USERSTORE) {
 		utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userStoreName, "System user store. Skipping import.")
+		utils.AddToPlan(utils.USERSTORES, userStoreName, utils.PLAN_NO_OP, "System user store")
 		return nil
 	}
 	fileBytes, err := ioutil.ReadFile(userStoreFilePath)
@@ -87,15 +89,27 @@ func importUserStore(userStoreId, userStoreName, userStoreFilePath string, expor
 	}
 
 	// Replace keyword placeholders in the local file according to the keyword mappings added in configs.
-	userStoreKeywordMapping := getUserStoreKeywordMapping(userStoreName)
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), userStoreKeywordMapping)
+	userStoreKeywordMapping := getUserStoreKeywordMapping(session, userStoreName)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), userStoreKeywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.USERSTORES, userStoreFilePath, modifiedFileData, userStoreKeywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.USERSTORES, userStoreFilePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
+
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.USERSTORES, userStoreName, utils.GetImportAction(userStoreId != ""), "")
+		return nil
+	}
 
 	if exportAPIexists {
 		modifiedFileData = removeClaimAttributeMappings(modifiedFileData)
 		if userStoreId == "" {
-			return importUserStoreOperation(userStoreName, userStoreFilePath, modifiedFileData)
+			return importUserStoreOperation(session, userStoreName, userStoreFilePath, modifiedFileData)
 		}
-		return updateUserStoreOperation(userStoreId, userStoreName, userStoreFilePath, modifiedFileData)
+		return updateUserStoreOperation(session, userStoreId, userStoreName, userStoreFilePath, modifiedFileData)
 	}
 
 	format, err := utils.FormatFromExtension(filepath.Ext(userStoreFilePath))
@@ -104,37 +118,35 @@ func importUserStore(userStoreId, userStoreName, userStoreFilePath string, expor
 	}
 
 	if userStoreId == "" {
-		return importUserStoreWithCRUD(userStoreName, []byte(modifiedFileData), format)
+		return importUserStoreWithCRUD(session, userStoreName, []byte(modifiedFileData), format)
 	}
-	return updateUserStoreWithCRUD(userStoreId, userStoreName, []byte(modifiedFileData), format)
+	return updateUserStoreWithCRUD(session, userStoreId, userStoreName, []byte(modifiedFileData), format)
 }
 
-func importUserStoreOperation(userStoreName, userStoreFilePath, modifiedFileData string) error {
+func importUserStoreOperation(session *utils.Session, userStoreName, userStoreFilePath, modifiedFileData string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userStoreName, "Creating new user store")
-	resp, err := utils.SendImportRequest(userStoreFilePath, modifiedFileData, utils.USERSTORES)
+	resp, err := session.SendImportRequest(userStoreFilePath, modifiedFileData, utils.USERSTORES)
 	if err != nil {
 		return fmt.Errorf("error when importing user store: %s", err)
 	}
 	defer resp.Body.Close()
-	utils.UpdateSuccessSummary(utils.USERSTORES, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userStoreName, "Imported successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.USERSTORES, userStoreName, utils.IMPORT, "Imported successfully")
 	return nil
 }
 
-func updateUserStoreOperation(userStoreId, userStoreName, userStoreFilePath, modifiedFileData string) error {
+func updateUserStoreOperation(session *utils.Session, userStoreId, userStoreName, userStoreFilePath, modifiedFileData string) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userStoreName, "Updating user store")
-	err := utils.SendUpdateRequest(userStoreId, userStoreFilePath, modifiedFileData, utils.USERSTORES)
+	err := session.SendUpdateRequest(userStoreId, userStoreFilePath, modifiedFileData, utils.USERSTORES)
 	if err != nil {
 		return fmt.Errorf("error when updating user store: %s", err)
 	}
-	utils.UpdateSuccessSummary(utils.USERSTORES, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userStoreName, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.USERSTORES, userStoreName, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func importUserStoreWithCRUD(userStoreName string, requestBody []byte, format utils.Format) error {
+func importUserStoreWithCRUD(session *utils.Session, userStoreName string, requestBody []byte, format utils.Format) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userStoreName, "Creating new user store")
 
@@ -142,18 +154,17 @@ func importUserStoreWithCRUD(userStoreName string, requestBody []byte, format ut
 	if err != nil {
 		return err
 	}
-	resp, err := utils.SendPostRequest(utils.USERSTORES, jsonBody)
+	resp, err := session.SendPostRequest(utils.USERSTORES, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when importing user store: %w", err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.USERSTORES, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userStoreName, "Imported successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.USERSTORES, userStoreName, utils.IMPORT, "Imported successfully")
 	return nil
 }
 
-func updateUserStoreWithCRUD(userStoreId, userStoreName string, requestBody []byte, format utils.Format) error {
+func updateUserStoreWithCRUD(session *utils.Session, userStoreId, userStoreName string, requestBody []byte, format utils.Format) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userStoreName, "Updating user store")
 
@@ -161,21 +172,20 @@ func updateUserStoreWithCRUD(userStoreId, userStoreName string, requestBody []by
 	if err != nil {
 		return err
 	}
-	resp, err := utils.SendPutRequest(utils.USERSTORES, userStoreId, jsonBody)
+	resp, err := session.SendPutRequest(utils.USERSTORES, userStoreId, jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when updating user store: %w", err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.USERSTORES, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userStoreName, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.USERSTORES, userStoreName, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func removeDeletedDeployedUserstores(localFiles []os.FileInfo) {
+func removeDeletedDeployedUserstores(session *utils.Session, localFiles []os.FileInfo) {
 
 	// Remove deployed user stores that do not exist locally.
-	deployedUserstores, err := getUserStoreList()
+	deployedUserstores, err := getUserStoreList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.USERSTORES, "", fmt.Sprintf("Error retrieving deployed user stores: %s", err))
 		return
@@ -188,20 +198,24 @@ deployedResourcess:
 			}
 		}
 		// DEFAULT is a Asgardeo only system user store
-		if (utils.SERVER_CONFIGS.ServerVersion == "" && userstore.Name == utils.DEFAULT_USERSTORE) || userstore.Name == utils.AGENT_USERSTORE {
+		if (session.ServerConfigs.ServerVersion == "" && userstore.Name == utils.DEFAULT_USERSTORE) || userstore.Name == utils.AGENT_USERSTORE {
 			utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userstore.Name, "System user store. Skipping deletion.")
 			continue
 		}
-		if utils.IsResourceExcluded(userstore.Name, utils.TOOL_CONFIGS.UserStoreConfigs) {
+		if utils.IsResourceExcluded(userstore.Name, session.ToolConfigs.UserStoreConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userstore.Name, "Excluded from deletion.")
 			continue
 		}
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.USERSTORES, userstore.Name, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.USERSTORES, userstore.Name, "Not found locally. Deleting user store.")
-		err := utils.SendDeleteRequest(userstore.Id, utils.USERSTORES)
+		err := session.SendDeleteRequest(userstore.Id, utils.USERSTORES)
 		if err != nil {
-			utils.UpdateFailureSummary(utils.USERSTORES, userstore.Name)
-			utils.PrintLog(utils.LogLevelError, utils.USERSTORES, userstore.Name, fmt.Sprintf("Error deleting user store: %s", err))
+			utils.UpdateFailureSummaryAndLog(utils.USERSTORES, userstore.Name, utils.DELETE, err, fmt.Sprintf("Error deleting user store: %s", err))
+		} else {
+			utils.UpdateSuccessSummary(utils.USERSTORES, userstore.Name, utils.DELETE)
 		}
-		utils.UpdateSuccessSummary(utils.USERSTORES, utils.DELETE)
 	}
 }
@@ -38,10 +38,10 @@ type UserStoreConfigurations struct {
 	ID   string `yaml:"id"`
 }
 
-func getUserStoreList() ([]userStore, error) {
+func getUserStoreList(session *utils.Session) ([]userStore, error) {
 
 	var list []userStore
-	body, err := utils.SendGetListRequest(utils.USERSTORES)
+	body, err := session.SendGetListRequest(utils.USERSTORES)
 	if err != nil {
 		return nil, fmt.Errorf("error while retrieving user store list. %w", err)
 	}
@@ -51,9 +51,9 @@ func getUserStoreList() ([]userStore, error) {
 	return list, nil
 }
 
-func getDeployedUserstoreNames() []string {
+func getDeployedUserstoreNames(session *utils.Session) []string {
 
-	userstores, err := getUserStoreList()
+	userstores, err := getUserStoreList(session)
 	if err != nil {
 		return []string{}
 	}
@@ -65,17 +65,17 @@ func getDeployedUserstoreNames() []string {
 	return userstoreNames
 }
 
-func getUserStoreKeywordMapping(userStoreName string) map[string]interface{} {
+func getUserStoreKeywordMapping(session *utils.Session, userStoreName string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.UserStoreConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(userStoreName, utils.KEYWORD_CONFIGS.UserStoreConfigs)
+	if session.KeywordConfigs.UserStoreConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(userStoreName, session.KeywordConfigs.UserStoreConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
-func getUserStoreId(userStoreName string) (string, error) {
+func getUserStoreId(session *utils.Session, userStoreName string) (string, error) {
 
-	existingUserStoreList, err := getUserStoreList()
+	existingUserStoreList, err := getUserStoreList(session)
 	if err != nil {
 		return "", fmt.Errorf("error when retrieving the deployed user store list: %s", err)
 	}
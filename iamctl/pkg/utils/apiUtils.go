@@ -20,7 +20,6 @@ package utils
 
 import (
 	"bytes"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -36,6 +35,7 @@ import (
 
 const EXPORT = "export"
 const IMPORT = "import"
+const RENDER = "render"
 const UPDATE = "update"
 const DELETE = "delete"
 const LIST = "list"
@@ -120,16 +120,15 @@ func RemoveResponseFields(response interface{}, fieldsToRemove ...string) (inter
 	return responseMap, nil
 }
 
-func SendExportRequest(resourceId, fileType string, resourceType ResourceType, excludeSecrets bool) (resp *http.Response, err error) {
+func (s *Session) SendExportRequest(resourceId, fileType string, resourceType ResourceType, excludeSecrets bool) (resp *http.Response, err error) {
 
-	reqUrl := buildRequestUrl(EXPORT, resourceType, resourceId)
+	reqUrl := s.buildRequestUrl(EXPORT, resourceType, resourceId)
 	req, err := http.NewRequest("GET", reqUrl, strings.NewReader(""))
 	if err != nil {
 		return resp, fmt.Errorf("error while creating the export request: %s", err)
 	}
 	req.Header.Set("Content-Type", MEDIA_TYPE_FORM)
 	req.Header.Set("accept", fileType)
-	req.Header.Set("Authorization", "Bearer "+SERVER_CONFIGS.Token)
 
 	query := req.URL.Query()
 	if resourceType == APPLICATIONS {
@@ -142,15 +141,7 @@ func SendExportRequest(resourceId, fileType string, resourceType ResourceType, e
 
 	defer req.Body.Close()
 
-	httpClient := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
-
-	resp, err = httpClient.Do(req)
+	resp, err = s.doRequest(req, resourceType)
 	if err != nil {
 		return resp, fmt.Errorf("error while exporting resource: %s", err)
 	}
@@ -162,16 +153,19 @@ func SendExportRequest(resourceId, fileType string, resourceType ResourceType, e
 
 	PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("%s %s", req.Method, req.URL.String()))
 	debugBody, _ := ioutil.ReadAll(resp.Body)
-	PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", statusCode, string(debugBody)))
+	PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", statusCode, s.RedactPayload(resourceType, debugBody)))
 	if error, ok := ErrorCodes[statusCode]; ok {
 		return resp, fmt.Errorf("error while exporting resource: %s", error)
 	}
 	return resp, fmt.Errorf("unexpected error while exporting the resource with status code: %s", strconv.FormatInt(int64(statusCode), 10))
 }
 
-func SendImportRequest(importFilePath, fileData string, resourceType ResourceType) (*http.Response, error) {
+func (s *Session) SendImportRequest(importFilePath, fileData string, resourceType ResourceType) (*http.Response, error) {
 
-	reqUrl := buildRequestUrl(IMPORT, resourceType, "")
+	reqUrl := s.buildRequestUrl(IMPORT, resourceType, "")
+	if err := checkDryRun(http.MethodPost, reqUrl); err != nil {
+		return nil, err
+	}
 
 	var buf bytes.Buffer
 	var err error
@@ -204,28 +198,14 @@ func SendImportRequest(importFilePath, fileData string, resourceType ResourceTyp
 		return nil, fmt.Errorf("error when creating the import request: %s", err)
 	}
 
-	var capturedImportBody bytes.Buffer
-	var importBodyReader io.Reader = body
-	if TOOL_CONFIGS.Logs.LogRequestPayloads {
-		importBodyReader = io.TeeReader(body, &capturedImportBody)
-	}
-
-	request, err := http.NewRequest("POST", reqUrl, importBodyReader)
+	request, err := http.NewRequest("POST", reqUrl, bytes.NewReader(body.Bytes()))
 	if err != nil {
 		return nil, fmt.Errorf("error when creating the import request: %s", err)
 	}
 	request.Header.Add("Content-Type", writer.FormDataContentType())
-	request.Header.Set("Authorization", "Bearer "+SERVER_CONFIGS.Token)
 	defer request.Body.Close()
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
-	resp, err := client.Do(request)
+	resp, err := s.doRequest(request, resourceType)
 	if err != nil {
 		return nil, fmt.Errorf("error when sending the import request: %s", err)
 	}
@@ -237,20 +217,23 @@ func SendImportRequest(importFilePath, fileData string, resourceType ResourceTyp
 	debugBody, _ := ioutil.ReadAll(resp.Body)
 	resp.Body.Close()
 	PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("%s %s", request.Method, request.URL.String()))
-	if TOOL_CONFIGS.Logs.LogRequestPayloads {
-		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Request body: %s", capturedImportBody.String()))
+	if s.ToolConfigs.Logs.LogRequestPayloads {
+		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Request body: %s", s.RedactPayload(resourceType, []byte(fileData))))
 	}
-	PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", statusCode, string(debugBody)))
+	PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", statusCode, s.RedactPayload(resourceType, debugBody)))
 	if error, ok := ErrorCodes[statusCode]; ok {
 		return nil, fmt.Errorf("error response for the import request: %s", error)
 	}
 	return nil, fmt.Errorf("unexpected error when importing resource: %s", resp.Status)
 }
 
-func SendUpdateRequest(resourceId, importFilePath, fileData string, resourceType ResourceType) error {
+func (s *Session) SendUpdateRequest(resourceId, importFilePath, fileData string, resourceType ResourceType) error {
 
-	reqUrl := buildRequestUrl(UPDATE, resourceType, resourceId)
-	formattedReqUrl := addQueryParams(reqUrl, resourceType, UPDATE)
+	reqUrl := s.buildRequestUrl(UPDATE, resourceType, resourceId)
+	formattedReqUrl := s.addQueryParams(reqUrl, resourceType, UPDATE)
+	if err := checkDryRun(http.MethodPut, formattedReqUrl); err != nil {
+		return err
+	}
 
 	var buf bytes.Buffer
 	var err error
@@ -283,28 +266,14 @@ func SendUpdateRequest(resourceId, importFilePath, fileData string, resourceType
 		return fmt.Errorf("error when creating the import request: %s", err)
 	}
 
-	var capturedUpdateBody bytes.Buffer
-	var updateBodyReader io.Reader = body
-	if TOOL_CONFIGS.Logs.LogRequestPayloads {
-		updateBodyReader = io.TeeReader(body, &capturedUpdateBody)
-	}
-
-	request, err := http.NewRequest("PUT", formattedReqUrl, updateBodyReader)
+	request, err := http.NewRequest("PUT", formattedReqUrl, bytes.NewReader(body.Bytes()))
 	if err != nil {
 		return fmt.Errorf("error when creating the import request: %s", err)
 	}
 	request.Header.Add("Content-Type", writer.FormDataContentType())
-	request.Header.Set("Authorization", "Bearer "+SERVER_CONFIGS.Token)
 	defer request.Body.Close()
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
-	resp, err := client.Do(request)
+	resp, err := s.doRequest(request, resourceType)
 	if err != nil {
 		return fmt.Errorf("error when sending the import request: %s", err)
 	}
@@ -319,25 +288,27 @@ func SendUpdateRequest(resourceId, importFilePath, fileData string, resourceType
 	debugBody, _ := ioutil.ReadAll(resp.Body)
 	resp.Body.Close()
 	PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("%s %s", request.Method, request.URL.String()))
-	if TOOL_CONFIGS.Logs.LogRequestPayloads {
-		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Request body: %s", capturedUpdateBody.String()))
+	if s.ToolConfigs.Logs.LogRequestPayloads {
+		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Request body: %s", s.RedactPayload(resourceType, []byte(fileData))))
 	}
-	PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", statusCode, string(debugBody)))
+	PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", statusCode, s.RedactPayload(resourceType, debugBody)))
 	if error, ok := ErrorCodes[statusCode]; ok {
 		return fmt.Errorf("error response for the import request: %s", error)
 	}
 	return fmt.Errorf("unexpected error when importing resource: %s", resp.Status)
 }
 
-func SendDeleteRequest(resourceId string, resourceType ResourceType, opts ...SendOption) error {
+func (s *Session) SendDeleteRequest(resourceId string, resourceType ResourceType, opts ...SendOption) error {
 
 	cfg := applySendOptions(opts)
-	reqUrl := buildRequestUrl(DELETE, resourceType, resourceId)
+	reqUrl := s.buildRequestUrl(DELETE, resourceType, resourceId)
+	if err := checkDryRun(http.MethodDelete, reqUrl); err != nil {
+		return err
+	}
 	request, err := http.NewRequest("DELETE", reqUrl, bytes.NewBuffer(nil))
 	if err != nil {
 		return fmt.Errorf("error when creating the delete request: %s", err)
 	}
-	request.Header.Set("Authorization", "Bearer "+SERVER_CONFIGS.Token)
 
 	query := request.URL.Query()
 	for k, v := range cfg.queryParams {
@@ -346,14 +317,7 @@ func SendDeleteRequest(resourceId string, resourceType ResourceType, opts ...Sen
 	request.URL.RawQuery = query.Encode()
 	defer request.Body.Close()
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
-	resp, err := client.Do(request)
+	resp, err := s.doRequest(request, resourceType)
 	if err != nil {
 		return fmt.Errorf("error when sending the delete request: %s", err)
 	}
@@ -365,16 +329,16 @@ func SendDeleteRequest(resourceId string, resourceType ResourceType, opts ...Sen
 	debugBody, _ := ioutil.ReadAll(resp.Body)
 	resp.Body.Close()
 	PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("%s %s", request.Method, request.URL.String()))
-	PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", statusCode, string(debugBody)))
+	PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", statusCode, s.RedactPayload(resourceType, debugBody)))
 	if error, ok := ErrorCodes[statusCode]; ok {
 		return fmt.Errorf("error response for the delete request: %s", error)
 	}
 	return fmt.Errorf("unexpected error when deleting resource: %s", resp.Status)
 }
 
-func GetResourceData(resourceType ResourceType, resourceId string, opts ...SendOption) (interface{}, error) {
+func (s *Session) GetResourceData(resourceType ResourceType, resourceId string, opts ...SendOption) (interface{}, error) {
 
-	body, err := SendGetRequest(resourceType, resourceId, opts...)
+	body, err := s.SendGetRequest(resourceType, resourceId, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -409,17 +373,16 @@ func applySendOptions(opts []SendOption) *sendConfig {
 	return cfg
 }
 
-func SendGetRequest(resourceType ResourceType, resourceId string, opts ...SendOption) ([]byte, error) {
+func (s *Session) SendGetRequest(resourceType ResourceType, resourceId string, opts ...SendOption) ([]byte, error) {
 
 	cfg := applySendOptions(opts)
-	reqUrl := buildRequestUrl(GET, resourceType, resourceId)
-	formattedReqUrl := addQueryParams(reqUrl, resourceType, GET)
+	reqUrl := s.buildRequestUrl(GET, resourceType, resourceId)
+	formattedReqUrl := s.addQueryParams(reqUrl, resourceType, GET)
 	request, err := http.NewRequest("GET", formattedReqUrl, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error creating GET request: %w", err)
 	}
 
-	request.Header.Set("Authorization", "Bearer "+SERVER_CONFIGS.Token)
 	request.Header.Set("Accept", cfg.contentType)
 	query := request.URL.Query()
 	for k, v := range cfg.queryParams {
@@ -427,15 +390,7 @@ func SendGetRequest(resourceType ResourceType, resourceId string, opts ...SendOp
 	}
 	request.URL.RawQuery = query.Encode()
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
-
-	resp, err := client.Do(request)
+	resp, err := s.doRequest(request, resourceType)
 	if err != nil {
 		return nil, fmt.Errorf("error sending GET request: %w", err)
 	}
@@ -445,7 +400,7 @@ func SendGetRequest(resourceType ResourceType, resourceId string, opts ...SendOp
 		if !(request.Method == "GET" && resp.StatusCode == 404) {
 			debugBody, _ := ioutil.ReadAll(resp.Body)
 			PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("%s %s", request.Method, request.URL.String()))
-			PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", resp.StatusCode, string(debugBody)))
+			PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", resp.StatusCode, s.RedactPayload(resourceType, debugBody)))
 		}
 		if errMsg, ok := ErrorCodes[resp.StatusCode]; ok {
 			return nil, fmt.Errorf("error response for the GET request: %s", errMsg)
@@ -461,28 +416,22 @@ func SendGetRequest(resourceType ResourceType, resourceId string, opts ...SendOp
 	return body, nil
 }
 
-func SendPostRequest(resourceType ResourceType, requestBody []byte, opts ...SendOption) (*http.Response, error) {
+func (s *Session) SendPostRequest(resourceType ResourceType, requestBody []byte, opts ...SendOption) (*http.Response, error) {
 
 	cfg := applySendOptions(opts)
-	reqUrl := buildRequestUrl(POST, resourceType, cfg.pathSuffix)
+	reqUrl := s.buildRequestUrl(POST, resourceType, cfg.pathSuffix)
+	if err := checkDryRun(http.MethodPost, reqUrl); err != nil {
+		return nil, err
+	}
 
 	request, err := http.NewRequest("POST", reqUrl, bytes.NewBuffer(requestBody))
 	if err != nil {
 		return nil, fmt.Errorf("error creating POST request: %w", err)
 	}
 
-	request.Header.Set("Authorization", "Bearer "+SERVER_CONFIGS.Token)
 	request.Header.Set("Content-Type", cfg.contentType)
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
-
-	resp, err := client.Do(request)
+	resp, err := s.doRequest(request, resourceType)
 	if err != nil {
 		return nil, fmt.Errorf("error sending POST request: %w", err)
 	}
@@ -491,10 +440,10 @@ func SendPostRequest(resourceType ResourceType, requestBody []byte, opts ...Send
 		debugBody, _ := ioutil.ReadAll(resp.Body)
 		resp.Body.Close()
 		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("%s %s", request.Method, request.URL.String()))
-		if TOOL_CONFIGS.Logs.LogRequestPayloads {
-			PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Request body: %s", string(requestBody)))
+		if s.ToolConfigs.Logs.LogRequestPayloads {
+			PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Request body: %s", s.RedactPayload(resourceType, requestBody)))
 		}
-		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", resp.StatusCode, string(debugBody)))
+		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", resp.StatusCode, s.RedactPayload(resourceType, debugBody)))
 		if errMsg, ok := ErrorCodes[resp.StatusCode]; ok {
 			return nil, fmt.Errorf("error response for the POST request: %s", errMsg)
 		}
@@ -504,28 +453,22 @@ func SendPostRequest(resourceType ResourceType, requestBody []byte, opts ...Send
 	return resp, nil
 }
 
-func SendPutRequest(resourceType ResourceType, resourceId string, requestBody []byte, opts ...SendOption) (*http.Response, error) {
+func (s *Session) SendPutRequest(resourceType ResourceType, resourceId string, requestBody []byte, opts ...SendOption) (*http.Response, error) {
 
 	cfg := applySendOptions(opts)
-	reqUrl := buildRequestUrl(PUT, resourceType, resourceId)
+	reqUrl := s.buildRequestUrl(PUT, resourceType, resourceId)
+	if err := checkDryRun(http.MethodPut, reqUrl); err != nil {
+		return nil, err
+	}
 
 	request, err := http.NewRequest("PUT", reqUrl, bytes.NewBuffer(requestBody))
 	if err != nil {
 		return nil, fmt.Errorf("error creating PUT request: %w", err)
 	}
 
-	request.Header.Set("Authorization", "Bearer "+SERVER_CONFIGS.Token)
 	request.Header.Set("Content-Type", cfg.contentType)
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
-
-	resp, err := client.Do(request)
+	resp, err := s.doRequest(request, resourceType)
 	if err != nil {
 		return nil, fmt.Errorf("error sending PUT request: %w", err)
 	}
@@ -534,10 +477,10 @@ func SendPutRequest(resourceType ResourceType, resourceId string, requestBody []
 		debugBody, _ := ioutil.ReadAll(resp.Body)
 		resp.Body.Close()
 		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("%s %s", request.Method, request.URL.String()))
-		if TOOL_CONFIGS.Logs.LogRequestPayloads {
-			PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Request body: %s", string(requestBody)))
+		if s.ToolConfigs.Logs.LogRequestPayloads {
+			PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Request body: %s", s.RedactPayload(resourceType, requestBody)))
 		}
-		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", resp.StatusCode, string(debugBody)))
+		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", resp.StatusCode, s.RedactPayload(resourceType, debugBody)))
 		if errMsg, ok := ErrorCodes[resp.StatusCode]; ok {
 			return nil, fmt.Errorf("error response for the PUT request: %s", errMsg)
 		}
@@ -547,26 +490,20 @@ func SendPutRequest(resourceType ResourceType, resourceId string, requestBody []
 	return resp, nil
 }
 
-func SendPatchRequest(resourceType ResourceType, resourceId string, requestBody []byte) (*http.Response, error) {
+func (s *Session) SendPatchRequest(resourceType ResourceType, resourceId string, requestBody []byte) (*http.Response, error) {
 
-	reqUrl := buildRequestUrl(PATCH, resourceType, resourceId)
+	reqUrl := s.buildRequestUrl(PATCH, resourceType, resourceId)
+	if err := checkDryRun(http.MethodPatch, reqUrl); err != nil {
+		return nil, err
+	}
 	request, err := http.NewRequest("PATCH", reqUrl, bytes.NewBuffer(requestBody))
 	if err != nil {
 		return nil, fmt.Errorf("error creating PATCH request: %w", err)
 	}
 
-	request.Header.Set("Authorization", "Bearer "+SERVER_CONFIGS.Token)
 	request.Header.Set("Content-Type", MEDIA_TYPE_JSON)
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
-
-	resp, err := client.Do(request)
+	resp, err := s.doRequest(request, resourceType)
 	if err != nil {
 		return nil, fmt.Errorf("error sending PATCH request: %w", err)
 	}
@@ -575,10 +512,10 @@ func SendPatchRequest(resourceType ResourceType, resourceId string, requestBody
 		debugBody, _ := ioutil.ReadAll(resp.Body)
 		resp.Body.Close()
 		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("%s %s", request.Method, request.URL.String()))
-		if TOOL_CONFIGS.Logs.LogRequestPayloads {
-			PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Request body: %s", string(requestBody)))
+		if s.ToolConfigs.Logs.LogRequestPayloads {
+			PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Request body: %s", s.RedactPayload(resourceType, requestBody)))
 		}
-		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", resp.StatusCode, string(debugBody)))
+		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", resp.StatusCode, s.RedactPayload(resourceType, debugBody)))
 		if errMsg, ok := ErrorCodes[resp.StatusCode]; ok {
 			return nil, fmt.Errorf("error response for the PATCH request: %s", errMsg)
 		}
@@ -588,17 +525,16 @@ func SendPatchRequest(resourceType ResourceType, resourceId string, requestBody
 	return resp, nil
 }
 
-func SendGetListRequest(resourceType ResourceType, opts ...SendOption) ([]byte, error) {
+func (s *Session) SendGetListRequest(resourceType ResourceType, opts ...SendOption) ([]byte, error) {
 
 	cfg := applySendOptions(opts)
-	reqUrl := buildRequestUrl(LIST, resourceType, "")
-	reqUrl = addQueryParams(reqUrl, resourceType, LIST)
+	reqUrl := s.buildRequestUrl(LIST, resourceType, "")
+	reqUrl = s.addQueryParams(reqUrl, resourceType, LIST)
 
 	req, err := http.NewRequest("GET", reqUrl, bytes.NewBuffer(nil))
 	if err != nil {
 		return nil, fmt.Errorf("error creating GET list request: %w", err)
 	}
-	req.Header.Set("Authorization", "Bearer "+SERVER_CONFIGS.Token)
 	req.Header.Set("Accept", MEDIA_TYPE_JSON)
 
 	query := req.URL.Query()
@@ -608,13 +544,7 @@ func SendGetListRequest(resourceType ResourceType, opts ...SendOption) ([]byte,
 	req.URL.RawQuery = query.Encode()
 	defer req.Body.Close()
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
-	}
-
-	resp, err := client.Do(req)
+	resp, err := s.doRequest(req, resourceType)
 	if err != nil {
 		return nil, fmt.Errorf("error sending GET list request. %w", err)
 	}
@@ -623,7 +553,7 @@ func SendGetListRequest(resourceType ResourceType, opts ...SendOption) ([]byte,
 	if resp.StatusCode != http.StatusOK {
 		debugBody, _ := ioutil.ReadAll(resp.Body)
 		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("%s %s", req.Method, req.URL.String()))
-		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", resp.StatusCode, string(debugBody)))
+		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", resp.StatusCode, s.RedactPayload(resourceType, debugBody)))
 		if errMsg, ok := ErrorCodes[resp.StatusCode]; ok {
 			return nil, fmt.Errorf("error response for the GET list request. Error: %s", errMsg)
 		}
@@ -637,11 +567,11 @@ func SendGetListRequest(resourceType ResourceType, opts ...SendOption) ([]byte,
 	return body, nil
 }
 
-func SendPaginatedGetListRequest(resourceType ResourceType, totField, curCountField, offsetField, limitField, resultsField string, startIndex int, opts ...SendOption) ([]byte, error) {
+func (s *Session) SendPaginatedGetListRequest(resourceType ResourceType, totField, curCountField, offsetField, limitField, resultsField string, startIndex int, opts ...SendOption) ([]byte, error) {
 
 	cfg := applySendOptions(opts)
 
-	firstBody, err := SendGetListRequest(resourceType, WithQueryParams(cfg.queryParams))
+	firstBody, err := s.SendGetListRequest(resourceType, WithQueryParams(cfg.queryParams))
 	if err != nil {
 		return nil, err
 	}
@@ -685,7 +615,7 @@ func SendPaginatedGetListRequest(resourceType ResourceType, totField, curCountFi
 		pageParams[offsetField] = strconv.Itoa(nextOffset)
 		pageParams[limitField] = strconv.Itoa(pageSize)
 
-		pageBody, err := SendGetListRequest(resourceType, WithQueryParams(pageParams))
+		pageBody, err := s.SendGetListRequest(resourceType, WithQueryParams(pageParams))
 		if err != nil {
 			return nil, err
 		}
@@ -711,7 +641,11 @@ func SendPaginatedGetListRequest(resourceType ResourceType, totField, curCountFi
 	return data, nil
 }
 
-func SendCustomRequest(method, reqURL string, body []byte, contentType string) (*http.Response, error) {
+func (s *Session) SendCustomRequest(resourceType ResourceType, method, reqURL string, body []byte, contentType string) (*http.Response, error) {
+
+	if err := checkDryRun(method, reqURL); err != nil {
+		return nil, err
+	}
 
 	var reqBody io.Reader
 	if body != nil {
@@ -722,20 +656,11 @@ func SendCustomRequest(method, reqURL string, body []byte, contentType string) (
 	if err != nil {
 		return nil, fmt.Errorf("error creating %s request: %w", method, err)
 	}
-	req.Header.Set("Authorization", "Bearer "+SERVER_CONFIGS.Token)
 	if contentType != "" {
 		req.Header.Set("Content-Type", contentType)
 	}
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
-
-	resp, err := client.Do(req)
+	resp, err := s.doRequest(req, resourceType)
 	if err != nil {
 		return nil, fmt.Errorf("error sending %s request: %w", method, err)
 	}
@@ -745,15 +670,15 @@ func SendCustomRequest(method, reqURL string, body []byte, contentType string) (
 		resp.Body.Close()
 		resp.Body = ioutil.NopCloser(bytes.NewReader(debugBody))
 		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("%s %s", req.Method, req.URL.String()))
-		if TOOL_CONFIGS.Logs.LogRequestPayloads {
-			PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Request body: %s", string(body)))
+		if s.ToolConfigs.Logs.LogRequestPayloads {
+			PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Request body: %s", s.RedactPayload(resourceType, body)))
 		}
-		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", resp.StatusCode, string(debugBody)))
+		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Response [%d]: %s", resp.StatusCode, s.RedactPayload(resourceType, debugBody)))
 	}
 	return resp, nil
 }
 
-func getResourcePath(resourceType ResourceType) string {
+func (s *Session) getResourcePath(resourceType ResourceType) string {
 
 	switch resourceType {
 	case APPLICATIONS:
@@ -769,7 +694,7 @@ func getResourcePath(resourceType ResourceType) string {
 	case CHALLENGE_QUESTIONS:
 		return "challenges"
 	case EMAIL_TEMPLATES:
-		if NotificationTemplatesApiExists {
+		if s.NotificationTemplatesApiExists {
 			return "notification/email/template-types"
 		}
 		return "email/template-types"
@@ -807,65 +732,65 @@ func getResourcePath(resourceType ResourceType) string {
 	return ""
 }
 
-func GetTenantBaseUrl() string {
+func (s *Session) GetTenantBaseUrl() string {
 
-	basePath := "/t/" + SERVER_CONFIGS.TenantDomain
-	if IsSubOrganization() {
+	basePath := "/t/" + s.ServerConfigs.TenantDomain
+	if s.IsSubOrganization() {
 		basePath += "/o"
 	}
-	return SERVER_CONFIGS.ServerUrl + basePath
+	return s.ServerConfigs.ServerUrl + basePath
 }
 
-func getResourceBaseUrl(resourceType ResourceType) string {
+func (s *Session) getResourceBaseUrl(resourceType ResourceType) string {
 
-	base := GetTenantBaseUrl()
+	base := s.GetTenantBaseUrl()
 	switch resourceType {
 	case ROLES:
-		if RolesV2ApiExists {
+		if s.RolesV2ApiExists {
 			return base + "/scim2/v2/Roles/"
 		}
 		return base + "/scim2/Roles/"
 	case EMAIL_PROVIDERS, SMS_PROVIDERS:
-		return base + "/api/server/v2/" + getResourcePath(resourceType) + "/"
+		return base + "/api/server/v2/" + s.getResourcePath(resourceType) + "/"
 	default:
-		return base + "/api/server/v1/" + getResourcePath(resourceType) + "/"
+		return base + "/api/server/v1/" + s.getResourcePath(resourceType) + "/"
 	}
 }
 
-func buildRequestUrl(requestType string, resourceType ResourceType, resourceId string) (reqUrl string) {
+func (s *Session) buildRequestUrl(requestType string, resourceType ResourceType, resourceId string) (reqUrl string) {
 
 	switch requestType {
 	case EXPORT:
 		if resourceType == APPLICATIONS {
-			reqUrl = getResourceBaseUrl(resourceType) + resourceId + "/exportFile"
+			reqUrl = s.getResourceBaseUrl(resourceType) + resourceId + "/exportFile"
 		} else {
-			reqUrl = getResourceBaseUrl(resourceType) + resourceId + "/" + EXPORT
+			reqUrl = s.getResourceBaseUrl(resourceType) + resourceId + "/" + EXPORT
 		}
 	case IMPORT:
-		reqUrl = getResourceBaseUrl(resourceType) + IMPORT
+		reqUrl = s.getResourceBaseUrl(resourceType) + IMPORT
 	case UPDATE:
 		if resourceType == APPLICATIONS || resourceType == CLAIMS {
-			reqUrl = getResourceBaseUrl(resourceType) + IMPORT
+			reqUrl = s.getResourceBaseUrl(resourceType) + IMPORT
 		} else {
-			reqUrl = getResourceBaseUrl(resourceType) + resourceId + "/" + IMPORT
+			reqUrl = s.getResourceBaseUrl(resourceType) + resourceId + "/" + IMPORT
 		}
 	case LIST:
-		reqUrl = getResourceBaseUrl(resourceType)
+		reqUrl = s.getResourceBaseUrl(resourceType)
 	case GET:
-		reqUrl = getResourceBaseUrl(resourceType) + resourceId
+		reqUrl = s.getResourceBaseUrl(resourceType) + resourceId
 	case POST:
-		reqUrl = getResourceBaseUrl(resourceType) + resourceId
+		reqUrl = s.getResourceBaseUrl(resourceType) + resourceId
 	case PUT:
-		reqUrl = getResourceBaseUrl(resourceType) + resourceId
+		reqUrl = s.getResourceBaseUrl(resourceType) + resourceId
 	case PATCH:
-		reqUrl = getResourceBaseUrl(resourceType) + resourceId
+		reqUrl = s.getResourceBaseUrl(resourceType) + resourceId
 	case DELETE:
-		reqUrl = getResourceBaseUrl(resourceType) + resourceId
+		reqUrl = s.getResourceBaseUrl(resourceType) + resourceId
 	}
 	return strings.TrimSuffix(reqUrl, "/")
 }
 
-func addQueryParams(reqURL string, resourceType ResourceType, operation string) string {
+func (s *Session) addQueryParams(reqURL string, resourceType ResourceType, operation string) string {
 
 	url, err := url.Parse(reqURL)
 	if err != nil {
@@ -877,7 +802,7 @@ func addQueryParams(reqURL string, resourceType ResourceType, operation string)
 
 	switch resourceType {
 	case CLAIMS:
-		if operation == UPDATE && TOOL_CONFIGS.AllowDelete {
+		if operation == UPDATE && s.ToolConfigs.AllowDelete {
 			queryParams.Set("preserveClaims", "true")
 		}
 	case ROLES:
@@ -20,6 +20,7 @@ package utils
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 )
@@ -83,3 +84,41 @@ func ConvertToStringKeyMap(input interface{}) interface{} {
 		return v
 	}
 }
+
+// GetResourceFilesDir returns the directory containing the resource type directories of the given resource files,
+// and the names of the resources. The files should be in the directory of one of the given resource types.
+func GetResourceFilesDir(resourceTypes []ResourceType, filePaths []string) (string, []string, error) {
+
+	var inputDirPath string
+	var resourceNames []string
+	for _, filePath := range filePaths {
+		absPath, err := filepath.Abs(filePath)
+		if err != nil {
+			return "", nil, fmt.Errorf("error resolving path %s: %w", filePath, err)
+		}
+		if _, err := os.Stat(absPath); err != nil {
+			return "", nil, fmt.Errorf("error reading resource file: %w", err)
+		}
+
+		baseDir := ""
+		for _, resourceType := range resourceTypes {
+			candidate := filepath.Dir(filepath.Dir(absPath))
+			if resourceType == BRANDING_PREFERENCES || resourceType == CUSTOM_TEXTS {
+				candidate = filepath.Dir(candidate)
+			}
+			if GetResourceTypeDir(candidate, resourceType) == filepath.Dir(absPath) {
+				baseDir = candidate
+				break
+			}
+		}
+		if baseDir == "" {
+			return "", nil, fmt.Errorf("%s is not in a %s directory", filePath, resourceTypes[0])
+		}
+		if inputDirPath != "" && inputDirPath != baseDir {
+			return "", nil, fmt.Errorf("all resource files should be in the same %s directory", resourceTypes[0])
+		}
+		inputDirPath = baseDir
+		resourceNames = append(resourceNames, GetFileInfo(absPath).ResourceName)
+	}
+	return inputDirPath, resourceNames, nil
+}
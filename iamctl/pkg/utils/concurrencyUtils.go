@@ -0,0 +1,47 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import "sync"
+
+// ProcessConcurrently calls process once for each index from 0 to count - 1. Up to the configured number
+// of calls run at the same time, and the function returns once all of them have completed.
+func (s *Session) ProcessConcurrently(count int, process func(index int)) {
+
+	concurrency := s.ToolConfigs.Concurrency
+	if concurrency <= 1 || count <= 1 {
+		for i := 0; i < count; i++ {
+			process(i)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, concurrency)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(index int) {
+			defer wg.Done()
+			defer func() { <-workers }()
+			process(index)
+		}(i)
+	}
+	wg.Wait()
+}
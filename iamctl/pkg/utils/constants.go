@@ -18,6 +18,8 @@
 
 package utils
 
+import "time"
+
 // Resource type configs
 const APPLICATIONS_CONFIG = "APPLICATIONS"
 const IDP_CONFIG = "IDENTITY_PROVIDERS"
@@ -45,8 +47,16 @@ const FLOWS_CONFIG = "FLOWS"
 // Tool configs
 const EXCLUDE_CONFIG = "EXCLUDE"
 const INCLUDE_ONLY_CONFIG = "INCLUDE_ONLY"
+
+// Resources excluded with the --exclude-resource flag, which are excluded even if they match an INCLUDE_ONLY rule
+const EXCLUDE_OVERRIDES_CONFIG = "EXCLUDE_OVERRIDES"
 const EXCLUDE_SECRETS_CONFIG = "EXCLUDE_SECRETS"
 const ALLOW_DELETE_CONFIG = "ALLOW_DELETE"
+const FILTER_CONFIG = "FILTER"
+
+// Prefix of the INCLUDE_ONLY and EXCLUDE rules given as regular expressions
+const REGEX_RULE_PREFIX = "re:"
+const GLOB_RULE_PREFIX = "glob:"
 
 // Keyword configs
 const KEYWORD_MAPPINGS_CONFIG = "KEYWORD_MAPPINGS"
@@ -61,6 +71,10 @@ const SERVER_VERSION_CONFIG = "SERVER_VERSION"
 const TOOL_CONFIG_PATH = "TOOL_CONFIG_PATH"
 const KEYWORD_CONFIG_PATH = "KEYWORD_CONFIG_PATH"
 const TOKEN_CONFIG = "TOKEN"
+const INSECURE_SKIP_VERIFY_CONFIG = "INSECURE_SKIP_VERIFY"
+const CA_CERT_PATH_CONFIG = "CA_CERT_PATH"
+const CLIENT_CERT_PATH_CONFIG = "CLIENT_CERT_PATH"
+const CLIENT_KEY_PATH_CONFIG = "CLIENT_KEY_PATH"
 
 // Resource types
 type ResourceType string
@@ -104,6 +118,10 @@ const IDENTITY_PROVIDERS_EXPORT_API ResourceType = "IdentityProvidersExportAPI"
 const SERVER_CONFIG_FILE = "serverConfig.json"
 const TOOL_CONFIG_FILE = "toolConfig.json"
 const KEYWORD_CONFIG_FILE = "keywordConfig.json"
+const SNAPSHOT_MANIFEST_FILE = "snapshot.json"
+
+const SNAPSHOTS_DIR = "snapshots"
+const OVERLAYS_DIR = "overlays"
 
 type Format string
 
@@ -121,6 +139,15 @@ const MEDIA_TYPE_FORM = "application/x-www-form-urlencoded"
 const MEDIA_TYPE_PKIX_CERT = "application/pkix-cert"
 
 const DEFAULT_TENANT_DOMAIN = "carbon.super"
+const TOKEN_REFRESH_MARGIN = 60 * time.Second // Time before expiry at which the access token is refreshed
+const DEFAULT_RETRY_MAX_ATTEMPTS = 3
+const DEFAULT_RETRY_BASE_DELAY_MS = 500
+const MAX_RETRY_DELAY = 30 * time.Second
+const MAX_RETRY_AFTER = 2 * time.Minute
+const DEFAULT_CONCURRENCY = 1
+const DEFAULT_RESOURCE_TYPE_CONCURRENCY = 1
+const DEFAULT_LOG_MAX_FILE_SIZE_MB = 10
+const DEFAULT_LOG_MAX_BACKUPS = 5
 const SENSITIVE_FIELD_MASK = "'********'"
 const SENSITIVE_FIELD_MASK_WITHOUT_QUOTES = "********"
 const RESIDENT_IDP_NAME = "LOCAL"
@@ -0,0 +1,551 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type DiffStatus string
+
+const (
+	DIFF_ONLY_SOURCE DiffStatus = "only-source"
+	DIFF_ONLY_TARGET DiffStatus = "only-target"
+	DIFF_MODIFIED    DiffStatus = "modified"
+)
+
+type FieldDiff struct {
+	Path   string      `json:"path"`
+	Status DiffStatus  `json:"status"`
+	Source interface{} `json:"source,omitempty"`
+	Target interface{} `json:"target,omitempty"`
+}
+
+type ResourceDiff struct {
+	ResourceType string      `json:"resourceType"`
+	ResourceName string      `json:"resourceName"`
+	FilePath     string      `json:"filePath"`
+	Status       DiffStatus  `json:"status"`
+	Fields       []FieldDiff `json:"fields,omitempty"`
+}
+
+type DiffReport struct {
+	Source    string         `json:"source"`
+	Target    string         `json:"target"`
+	Resources []ResourceDiff `json:"resources"`
+}
+
+type ExportedFile struct {
+	ResourceType ResourceType
+	Content      []byte
+}
+
+// In memory export mode keeps the exported files in ExportedFiles instead of writing them to the output
+// directory. Local files that would have been removed with ALLOW_DELETE are collected in LocalOnlyFiles.
+var inMemoryExport bool
+var reverseKeywords bool
+var deployedExport bool
+var ExportedFiles map[string]ExportedFile
+var LocalOnlyFiles []string
+var exportedFilesLock sync.Mutex
+
+func StartInMemoryExport() {
+
+	inMemoryExport = true
+	reverseKeywords = false
+	deployedExport = false
+	ExportedFiles = make(map[string]ExportedFile)
+	LocalOnlyFiles = nil
+}
+
+// StopInMemoryExport stops the in memory export, so that later exports are written to the output directory.
+func StopInMemoryExport() {
+
+	inMemoryExport = false
+	reverseKeywords = false
+	deployedExport = false
+	ExportedFiles = nil
+	LocalOnlyFiles = nil
+}
+
+// StartEnvironmentExport starts an in memory export that does not depend on local files. Instead of adding
+// the keywords found in local files, values of the keyword mappings are replaced with the keywords.
+func StartEnvironmentExport() {
+
+	StartInMemoryExport()
+	reverseKeywords = true
+}
+
+// StartDeployedExport starts an in memory export that keeps the deployed values as they are, without adding
+// keywords, so that the exported files can be compared with the local files after the keywords are replaced.
+func StartDeployedExport() {
+
+	StartInMemoryExport()
+	deployedExport = true
+}
+
+func IsInMemoryExport() bool {
+	return inMemoryExport
+}
+
+func WriteExportedFile(fileName string, content []byte, resourceType ResourceType) error {
+
+	if inMemoryExport {
+		exportedFilesLock.Lock()
+		defer exportedFilesLock.Unlock()
+		ExportedFiles[fileName] = ExportedFile{ResourceType: resourceType, Content: content}
+		return nil
+	}
+	return ioutil.WriteFile(fileName, content, 0600)
+}
+
+func CreateExportDir(dirPath string) error {
+
+	if inMemoryExport {
+		return nil
+	}
+	return os.MkdirAll(dirPath, 0700)
+}
+
+func addLocalOnlyFiles(dirPath string) {
+
+	filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			addLocalOnlyFile(path)
+		}
+		return nil
+	})
+}
+
+// Resource types are exported concurrently, so the local only files are collected under the lock.
+func addLocalOnlyFile(filePath string) {
+
+	exportedFilesLock.Lock()
+	LocalOnlyFiles = append(LocalOnlyFiles, filePath)
+	exportedFilesLock.Unlock()
+}
+
+// DiffExportedFiles compares the local files in the given directory against the files exported in memory.
+func (s *Session) DiffExportedFiles(localDir string) []ResourceDiff {
+
+	var exportedFileNames []string
+	for fileName := range ExportedFiles {
+		exportedFileNames = append(exportedFileNames, fileName)
+	}
+	sort.Strings(exportedFileNames)
+
+	var diffs []ResourceDiff
+	for _, fileName := range exportedFileNames {
+		exportedFile := ExportedFiles[fileName]
+		resourceDiff := newResourceDiff(localDir, fileName)
+
+		localContent, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			resourceDiff.Status = DIFF_ONLY_TARGET
+			diffs = append(diffs, resourceDiff)
+			continue
+		}
+		fields, err := s.DiffFileContent(fileName, localContent, exportedFile.Content, exportedFile.ResourceType)
+		if err != nil {
+			PrintLog(LogLevelError, UtilsResourceWrapper, "", fmt.Sprintf("Error comparing %s: %s", fileName, err))
+			continue
+		}
+		if len(fields) > 0 {
+			resourceDiff.Status = DIFF_MODIFIED
+			resourceDiff.Fields = fields
+			diffs = append(diffs, resourceDiff)
+		}
+	}
+
+	localOnlyFiles := append([]string{}, LocalOnlyFiles...)
+	sort.Strings(localOnlyFiles)
+	for _, fileName := range localOnlyFiles {
+		if _, exported := ExportedFiles[fileName]; exported {
+			continue
+		}
+		resourceDiff := newResourceDiff(localDir, fileName)
+		resourceDiff.Status = DIFF_ONLY_SOURCE
+		diffs = append(diffs, resourceDiff)
+	}
+	return diffs
+}
+
+// DiffExports compares the files exported in memory from two environments.
+func (s *Session) DiffExports(sourceFiles, targetFiles map[string]ExportedFile) []ResourceDiff {
+
+	var fileNames []string
+	for fileName := range sourceFiles {
+		fileNames = append(fileNames, fileName)
+	}
+	for fileName := range targetFiles {
+		if _, exists := sourceFiles[fileName]; !exists {
+			fileNames = append(fileNames, fileName)
+		}
+	}
+	sort.Strings(fileNames)
+
+	var diffs []ResourceDiff
+	for _, fileName := range fileNames {
+		sourceFile, inSource := sourceFiles[fileName]
+		targetFile, inTarget := targetFiles[fileName]
+		resourceDiff := newResourceDiff("", fileName)
+		switch {
+		case !inSource:
+			resourceDiff.Status = DIFF_ONLY_TARGET
+		case !inTarget:
+			resourceDiff.Status = DIFF_ONLY_SOURCE
+		default:
+			fields, err := s.DiffFileContent(fileName, sourceFile.Content, targetFile.Content, sourceFile.ResourceType)
+			if err != nil {
+				PrintLog(LogLevelError, UtilsResourceWrapper, "", fmt.Sprintf("Error comparing %s: %s", fileName, err))
+				continue
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			resourceDiff.Status = DIFF_MODIFIED
+			resourceDiff.Fields = fields
+		}
+		diffs = append(diffs, resourceDiff)
+	}
+	return diffs
+}
+
+// ReplaceValuesWithKeywords replaces the values of the keyword mappings in all string fields with the keywords,
+// so that values that only differ by an environment specific keyword value are considered equal.
+func ReplaceValuesWithKeywords(data interface{}, keywordMapping map[string]interface{}) interface{} {
+
+	var keywords []string
+	for keyword, value := range keywordMapping {
+		if value, ok := value.(string); ok && value != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	// Replace longer values first so that a value containing another value is replaced as a whole.
+	sort.Slice(keywords, func(i, j int) bool {
+		first, second := keywordMapping[keywords[i]].(string), keywordMapping[keywords[j]].(string)
+		if len(first) != len(second) {
+			return len(first) > len(second)
+		}
+		return keywords[i] < keywords[j]
+	})
+	return replaceValuesWithKeywords(data, keywords, keywordMapping)
+}
+
+func replaceValuesWithKeywords(data interface{}, keywords []string, keywordMapping map[string]interface{}) interface{} {
+
+	switch v := data.(type) {
+	case map[interface{}]interface{}:
+		for key, value := range v {
+			v[key] = replaceValuesWithKeywords(value, keywords, keywordMapping)
+		}
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = replaceValuesWithKeywords(value, keywords, keywordMapping)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = replaceValuesWithKeywords(value, keywords, keywordMapping)
+		}
+	case string:
+		for _, keyword := range keywords {
+			v = strings.ReplaceAll(v, keywordMapping[keyword].(string), "{{"+keyword+"}}")
+		}
+		return v
+	}
+	return data
+}
+
+func newResourceDiff(localDir, fileName string) ResourceDiff {
+
+	relativePath, err := filepath.Rel(localDir, fileName)
+	if err != nil {
+		relativePath = fileName
+	}
+	return ResourceDiff{
+		ResourceType: strings.SplitN(filepath.ToSlash(relativePath), "/", 2)[0],
+		ResourceName: GetFileInfo(fileName).ResourceName,
+		FilePath:     relativePath,
+	}
+}
+
+// DiffFileContent compares the content of a source file with the exported content of the same resource.
+func (s *Session) DiffFileContent(fileName string, sourceContent, targetContent []byte, resourceType ResourceType) ([]FieldDiff, error) {
+
+	sourceData, err := deserializeFileContent(fileName, sourceContent, resourceType)
+	if err != nil {
+		return nil, fmt.Errorf("error when deserializing source content: %w", err)
+	}
+	targetData, err := deserializeFileContent(fileName, targetContent, resourceType)
+	if err != nil {
+		return nil, fmt.Errorf("error when deserializing exported content: %w", err)
+	}
+	return s.DiffData(sourceData, targetData, resourceType), nil
+}
+
+// Deserializes the content of a resource file in the format of its extension.
+func deserializeFileContent(fileName string, content []byte, resourceType ResourceType) (interface{}, error) {
+
+	format, err := FormatFromExtension(filepath.Ext(fileName))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported file format: %w", err)
+	}
+	if format == FormatYAML {
+		content = ReplaceTypeTags(content)
+	}
+	return Deserialize(content, format, resourceType)
+}
+
+// DiffData returns the field level differences between the source and target data of a resource.
+// Arrays of objects are matched by the array identifiers of the resource type, so reordered elements are not reported.
+func (s *Session) DiffData(sourceData, targetData interface{}, resourceType ResourceType) []FieldDiff {
+
+	sourceData = ConvertToStringKeyMap(sourceData)
+	targetData = ConvertToStringKeyMap(targetData)
+	return diffValues("", resourceType.String(), sourceData, targetData, s.GetArrayIdentifiers(resourceType))
+}
+
+func diffValues(path, fieldName string, sourceValue, targetValue interface{}, identifiers map[string]string) []FieldDiff {
+
+	switch source := sourceValue.(type) {
+	case map[string]interface{}:
+		if target, ok := targetValue.(map[string]interface{}); ok {
+			return diffMaps(path, source, target, identifiers)
+		}
+	case []interface{}:
+		if target, ok := targetValue.([]interface{}); ok {
+			return diffArrays(path, fieldName, source, target, identifiers)
+		}
+	}
+	if reflect.DeepEqual(sourceValue, targetValue) {
+		return nil
+	}
+	return []FieldDiff{{Path: path, Status: DIFF_MODIFIED, Source: sourceValue, Target: targetValue}}
+}
+
+func diffMaps(path string, source, target map[string]interface{}, identifiers map[string]string) []FieldDiff {
+
+	var diffs []FieldDiff
+	for _, key := range sortedUnionKeys(source, target) {
+		diffs = append(diffs, diffEntry(extendPath(path, key), key, source, target, identifiers)...)
+	}
+	return diffs
+}
+
+func diffEntry(path, key string, source, target map[string]interface{}, identifiers map[string]string) []FieldDiff {
+
+	sourceValue, inSource := source[key]
+	targetValue, inTarget := target[key]
+	if !inSource {
+		return []FieldDiff{{Path: path, Status: DIFF_ONLY_TARGET, Target: targetValue}}
+	}
+	if !inTarget {
+		return []FieldDiff{{Path: path, Status: DIFF_ONLY_SOURCE, Source: sourceValue}}
+	}
+	return diffValues(path, key, sourceValue, targetValue, identifiers)
+}
+
+func diffArrays(path, arrayName string, source, target []interface{}, identifiers map[string]string) []FieldDiff {
+
+	sourceElements, sourceKeyed := getKeyedElements(arrayName, source, identifiers)
+	targetElements, targetKeyed := getKeyedElements(arrayName, target, identifiers)
+	if !sourceKeyed || !targetKeyed {
+		if reflect.DeepEqual(source, target) {
+			return nil
+		}
+		return []FieldDiff{{Path: path, Status: DIFF_MODIFIED, Source: source, Target: target}}
+	}
+
+	// Elements are compared by key using the same path syntax as keyword locations.
+	var diffs []FieldDiff
+	for _, key := range sortedUnionKeys(sourceElements, targetElements) {
+		sourceElement, inSource := sourceElements[key]
+		targetElement, inTarget := targetElements[key]
+		elementPath := path
+		if isObject(sourceElement) || isObject(targetElement) {
+			elementPath = extendPath(path, key)
+		}
+		switch {
+		case !inSource:
+			diffs = append(diffs, FieldDiff{Path: elementPath, Status: DIFF_ONLY_TARGET, Target: targetElement})
+		case !inTarget:
+			diffs = append(diffs, FieldDiff{Path: elementPath, Status: DIFF_ONLY_SOURCE, Source: sourceElement})
+		default:
+			diffs = append(diffs, diffValues(elementPath, arrayName, sourceElement, targetElement, identifiers)...)
+		}
+	}
+	return diffs
+}
+
+// getKeyedElements maps the elements of an array by their identifiers. Scalar elements are keyed by their value.
+// Returns false if the elements cannot be uniquely identified.
+func getKeyedElements(arrayName string, elements []interface{}, identifiers map[string]string) (map[string]interface{}, bool) {
+
+	identifier := identifiers[arrayName]
+	if identifier == "" {
+		identifier = "name"
+	}
+
+	keyedElements := make(map[string]interface{})
+	for _, element := range elements {
+		var key string
+		switch v := element.(type) {
+		case map[string]interface{}:
+			identifierValue := GetValue(v, identifier)
+			if identifierValue == "" {
+				return nil, false
+			}
+			key = fmt.Sprintf("[%s=%s]", identifier, identifierValue)
+		case []interface{}:
+			return nil, false
+		default:
+			key = fmt.Sprintf("%v", v)
+		}
+		if _, exists := keyedElements[key]; exists {
+			return nil, false
+		}
+		keyedElements[key] = element
+	}
+	return keyedElements, true
+}
+
+func isObject(value interface{}) bool {
+
+	_, ok := value.(map[string]interface{})
+	return ok
+}
+
+func sortedUnionKeys(first, second map[string]interface{}) []string {
+
+	var keys []string
+	for key := range first {
+		keys = append(keys, key)
+	}
+	for key := range second {
+		if _, exists := first[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ValidateOutputFormat returns an error if the output format of a diff or keyword suggestion is not supported.
+func ValidateOutputFormat(outputFormat string) error {
+
+	if outputFormat != "text" && outputFormat != "json" {
+		return fmt.Errorf("unsupported output format: %s. Supported formats are text and json", outputFormat)
+	}
+	return nil
+}
+
+// ValidateDiffSources returns an error if the flags of the diff command do not select either the local resources
+// and an environment, or two environments.
+func ValidateDiffSources(configFile, inputDirPath, fromConfigFile, toConfigFile string) error {
+
+	if fromConfigFile == "" && toConfigFile == "" {
+		return nil
+	}
+	if fromConfigFile == "" || toConfigFile == "" {
+		return fmt.Errorf("both --from and --to flags are required to compare two environments")
+	}
+	if configFile != "" || inputDirPath != "" {
+		return fmt.Errorf("the --config and --inputDir flags cannot be used with --from and --to flags")
+	}
+	return nil
+}
+
+func PrintDiff(report DiffReport, outputFormat string) error {
+
+	if outputFormat == "json" {
+		if report.Resources == nil {
+			report.Resources = []ResourceDiff{}
+		}
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error when marshalling diff output: %w", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+	if outputFormat != "text" {
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	fmt.Println("========================================")
+	fmt.Printf("Diff (%s vs %s)\n", report.Source, report.Target)
+	fmt.Println("========================================")
+	if len(report.Resources) == 0 {
+		fmt.Println("No drift detected.")
+		fmt.Println("========================================")
+		return nil
+	}
+	for _, diff := range report.Resources {
+		switch diff.Status {
+		case DIFF_ONLY_SOURCE:
+			fmt.Printf("- %s (only in %s)\n", diff.FilePath, report.Source)
+		case DIFF_ONLY_TARGET:
+			fmt.Printf("+ %s (only in %s)\n", diff.FilePath, report.Target)
+		default:
+			fmt.Printf("~ %s\n", diff.FilePath)
+			for _, field := range diff.Fields {
+				printFieldDiff(field)
+			}
+		}
+	}
+	fmt.Println("========================================")
+	fmt.Printf("%d resources differ.\n", len(report.Resources))
+	fmt.Println("========================================")
+	return nil
+}
+
+func printFieldDiff(field FieldDiff) {
+
+	switch field.Status {
+	case DIFF_ONLY_SOURCE:
+		fmt.Printf("    - %s: %s\n", field.Path, formatDiffValue(field.Source))
+	case DIFF_ONLY_TARGET:
+		fmt.Printf("    + %s: %s\n", field.Path, formatDiffValue(field.Target))
+	default:
+		fmt.Printf("    ~ %s: %s => %s\n", field.Path, formatDiffValue(field.Source), formatDiffValue(field.Target))
+	}
+}
+
+func formatDiffValue(value interface{}) string {
+
+	if value == nil {
+		return "null"
+	}
+	if str, ok := value.(string); ok {
+		return str
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
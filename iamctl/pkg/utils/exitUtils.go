@@ -0,0 +1,95 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"log"
+	"os"
+)
+
+// Exit codes of the CLI, so that pipelines can tell the reason of a failed run apart.
+const (
+	EXIT_CODE_SUCCESS          = 0
+	EXIT_CODE_TOTAL_FAILURE    = 1
+	EXIT_CODE_CONFIG_ERROR     = 2
+	EXIT_CODE_AUTH_FAILURE     = 3
+	EXIT_CODE_PARTIAL_FAILURE  = 4
+	EXIT_CODE_UNSUPPORTED      = 5
+	EXIT_CODE_SNAPSHOT_FAILURE = 6
+)
+
+// ExitWithCode logs the given message and exits with the given code.
+func ExitWithCode(code int, v ...interface{}) {
+
+	log.Println(v...)
+	os.Exit(code)
+}
+
+// GetExitCode returns the exit code for the outcome of the run. A run fails partially if some operations
+// succeeded and totally otherwise. In strict mode, resource types skipped as they are not supported in the
+// server version are treated as an error.
+func GetExitCode(strict bool) int {
+
+	if HasFailures() {
+		summaryLock.Lock()
+		defer summaryLock.Unlock()
+		if AggregatedSummary.SuccessfulOperations > 0 {
+			return EXIT_CODE_PARTIAL_FAILURE
+		}
+		return EXIT_CODE_TOTAL_FAILURE
+	}
+	if strict && hasUnsupportedSkips() {
+		return EXIT_CODE_UNSUPPORTED
+	}
+	return EXIT_CODE_SUCCESS
+}
+
+// GetRunExitCode returns the exit code for the outcome of the run when the report of the run could not be written.
+// The outcome of the run is kept, and a successful run exits with the config error code, as the report file given
+// with the flags could not be written.
+func GetRunExitCode(strict bool, reportErr error) int {
+
+	exitCode := GetExitCode(strict)
+	if reportErr != nil && exitCode == EXIT_CODE_SUCCESS {
+		return EXIT_CODE_CONFIG_ERROR
+	}
+	return exitCode
+}
+
+// GetWriteExitCode returns the exit code when writing the output files of a command fails. The command fails
+// partially if some of the files were written before the failure.
+func GetWriteExitCode(written bool) int {
+
+	if written {
+		return EXIT_CODE_PARTIAL_FAILURE
+	}
+	return EXIT_CODE_TOTAL_FAILURE
+}
+
+func hasUnsupportedSkips() bool {
+
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
+	for _, summary := range ResTypeSummaryMap {
+		if summary.Skipped && summary.SkipReason == SKIP_REASON_UNSUPPORTED_VERSION {
+			return true
+		}
+	}
+	return false
+}
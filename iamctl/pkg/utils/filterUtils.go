@@ -0,0 +1,386 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ErrFilteredOut is returned when a resource does not match the FILTER config of its resource type.
+var ErrFilteredOut = errors.New("resource does not match the filter")
+
+// Resource types for which the FILTER config is not supported, as each resource is exported as a directory
+// of multiple files, or the resource type has a single resource.
+var unfilterableResourceTypes = []ResourceType{EMAIL_TEMPLATES, SMS_TEMPLATES, CUSTOM_TEXTS, ACTIONS,
+	GOVERNANCE_CONNECTORS, VALIDATION_RULES, BRANDING_PREFERENCES}
+
+// Parsed FILTER expressions. Invalid expressions are stored with the parse error.
+var parsedFilters sync.Map
+
+type parsedFilter struct {
+	filter Filter
+	err    error
+}
+
+// Filter that matches no resource. Used in place of an invalid FILTER expression, so that an invalid filter
+// never widens the resources that are imported or exported.
+type noMatchFilter struct{}
+
+func (f noMatchFilter) Matches(data interface{}) bool {
+	return false
+}
+
+// Filter is a parsed FILTER expression.
+type Filter interface {
+	Matches(data interface{}) bool
+}
+
+type andFilter struct {
+	left, right Filter
+}
+
+type orFilter struct {
+	left, right Filter
+}
+
+// Compares the value at a path with a literal. A nil literal compares with null.
+type comparisonFilter struct {
+	path    string
+	equals  bool
+	literal *string
+}
+
+func (f andFilter) Matches(data interface{}) bool {
+	return f.left.Matches(data) && f.right.Matches(data)
+}
+
+func (f orFilter) Matches(data interface{}) bool {
+	return f.left.Matches(data) || f.right.Matches(data)
+}
+
+// Paths with all_items selectors match if the comparison holds for any of the items.
+func (f comparisonFilter) Matches(data interface{}) bool {
+
+	paths, err := ResolveAllItemsPaths(data, f.path)
+	if err != nil || len(paths) == 0 {
+		return f.compare(data, "") == f.equals
+	}
+	for _, path := range paths {
+		if f.compare(data, path) == f.equals {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the value at the path equals the literal.
+func (f comparisonFilter) compare(data interface{}, path string) bool {
+
+	var value interface{}
+	if path != "" {
+		value = getRawValue(data, path)
+	}
+	if f.literal == nil {
+		return value == nil
+	}
+	return value != nil && GetValue(data, path) == *f.literal
+}
+
+// ParseFilter parses a FILTER expression. An expression compares the value at a resource path with a literal
+// using == or !=, e.g. audience.type == APPLICATION, or inboundProtocolConfiguration.saml != null. Comparisons
+// can be combined with && and ||, and grouped with parentheses.
+func ParseFilter(expression string) (Filter, error) {
+
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+	parser := &filterParser{tokens: tokens}
+	filter, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if parser.position < len(parser.tokens) {
+		return nil, fmt.Errorf("unexpected %s in filter", parser.tokens[parser.position].value)
+	}
+	return filter, nil
+}
+
+type filterToken struct {
+	value  string
+	quoted bool
+}
+
+type filterParser struct {
+	tokens   []filterToken
+	position int
+}
+
+func (p *filterParser) next() (filterToken, bool) {
+
+	if p.position >= len(p.tokens) {
+		return filterToken{}, false
+	}
+	token := p.tokens[p.position]
+	p.position++
+	return token, true
+}
+
+func (p *filterParser) peek(value string) bool {
+	return p.position < len(p.tokens) && !p.tokens[p.position].quoted && p.tokens[p.position].value == value
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("||") {
+		p.position++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("&&") {
+		p.position++
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = andFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseComparison() (Filter, error) {
+
+	if p.peek("(") {
+		p.position++
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peek(")") {
+			return nil, fmt.Errorf("missing ) in filter")
+		}
+		p.position++
+		return filter, nil
+	}
+
+	path, ok := p.next()
+	if !ok || path.quoted || isFilterOperator(path.value) {
+		return nil, fmt.Errorf("expected a resource path in filter")
+	}
+	operator, ok := p.next()
+	if !ok || operator.quoted || (operator.value != "==" && operator.value != "!=") {
+		return nil, fmt.Errorf("expected == or != after %s in filter", path.value)
+	}
+	literal, ok := p.next()
+	if !ok || (!literal.quoted && isFilterOperator(literal.value)) {
+		return nil, fmt.Errorf("expected a value after %s %s in filter", path.value, operator.value)
+	}
+
+	comparison := comparisonFilter{path: path.value, equals: operator.value == "=="}
+	if literal.quoted || literal.value != "null" {
+		comparison.literal = &literal.value
+	}
+	return comparison, nil
+}
+
+func isFilterOperator(value string) bool {
+	return value == "==" || value == "!=" || value == "&&" || value == "||" || value == "(" || value == ")"
+}
+
+// Splits a filter expression into paths, operators and values. Values can be quoted with single or double quotes,
+// and selectors such as [name=value] in paths can contain spaces and operator characters.
+func tokenizeFilter(expression string) ([]filterToken, error) {
+
+	var tokens []filterToken
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		switch {
+		case unicode.IsSpace(runes[i]):
+			i++
+		case runes[i] == '(' || runes[i] == ')':
+			tokens = append(tokens, filterToken{value: string(runes[i])})
+			i++
+		case i+1 < len(runes) && isFilterOperator(string(runes[i:i+2])):
+			tokens = append(tokens, filterToken{value: string(runes[i : i+2])})
+			i += 2
+		case runes[i] == '\'' || runes[i] == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != runes[i] {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated quote in filter")
+			}
+			tokens = append(tokens, filterToken{value: string(runes[i+1 : end]), quoted: true})
+			i = end + 1
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' &&
+				!(i+1 < len(runes) && isFilterOperator(string(runes[i:i+2]))) {
+				if runes[i] == '[' {
+					for i < len(runes) && runes[i] != ']' {
+						i++
+					}
+					if i == len(runes) {
+						return nil, fmt.Errorf("missing ] in filter")
+					}
+				}
+				i++
+			}
+			tokens = append(tokens, filterToken{value: string(runes[start:i])})
+		}
+	}
+	return tokens, nil
+}
+
+// Returns the parsed FILTER config of the resource type and its expression. The parsed filter is nil if a filter
+// is not configured. An invalid expression, which is rejected when the tool configs are loaded, matches no resource.
+func (s *Session) getFilter(resourceType ResourceType) (Filter, string) {
+
+	configs := s.ToolConfigs.resourceConfigs(resourceType)
+	if configs == nil {
+		return nil, ""
+	}
+	expression, ok := (*configs)[FILTER_CONFIG].(string)
+	if !ok || strings.TrimSpace(expression) == "" {
+		return nil, ""
+	}
+	if containsResourceType(unfilterableResourceTypes, resourceType) {
+		return nil, ""
+	}
+
+	parsed, loaded := parsedFilters.Load(expression)
+	if !loaded {
+		filter, err := ParseFilter(expression)
+		parsed, loaded = parsedFilters.LoadOrStore(expression, parsedFilter{filter: filter, err: err})
+		if !loaded && err != nil {
+			PrintLog(LogLevelError, resourceType, "", fmt.Sprintf("Invalid FILTER config %q: %s. No resource matches the filter.", expression, err))
+		}
+	}
+	if parsed.(parsedFilter).err != nil {
+		return noMatchFilter{}, expression
+	}
+	return parsed.(parsedFilter).filter, expression
+}
+
+// Checks that the FILTER configs of all resource types are valid expressions.
+func (t ToolConfigs) validateFilters() error {
+
+	for _, resourceType := range ResourceTypes {
+		configs := t.resourceConfigs(resourceType)
+		if configs == nil {
+			continue
+		}
+		value, exists := (*configs)[FILTER_CONFIG]
+		if !exists {
+			continue
+		}
+		expression, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("FILTER config of %s must be an expression", resourceType)
+		}
+		if strings.TrimSpace(expression) == "" {
+			continue
+		}
+		if _, err := ParseFilter(expression); err != nil {
+			return fmt.Errorf("invalid FILTER config %q of %s: %w", expression, resourceType, err)
+		}
+	}
+	return nil
+}
+
+// MatchesFilter returns true if the resource data matches the FILTER config of the resource type,
+// or if a filter is not configured.
+func (s *Session) MatchesFilter(resourceType ResourceType, resourceName string, data interface{}) bool {
+
+	filter, expression := s.getFilter(resourceType)
+	if filter == nil || filter.Matches(data) {
+		return true
+	}
+	PrintLog(LogLevelDebug, resourceType, resourceName, fmt.Sprintf("Excluded resource. Resource does not match FILTER: %s", expression))
+	UpdateFilteredOutSummary(resourceType)
+	return false
+}
+
+// MatchesFileFilter returns true if the content of the resource file, with the keywords replaced, matches the
+// FILTER config of the resource type. Files that cannot be read are left to be reported during the import.
+// Files that cannot be matched against the filter are reported as failed and do not match.
+func (s *Session) MatchesFileFilter(resourceType ResourceType, filePath string, keywordMapping map[string]interface{}, operation string) bool {
+
+	if filter, _ := s.getFilter(resourceType); filter == nil {
+		return true
+	}
+	format, err := FormatFromExtension(filepath.Ext(filePath))
+	if err != nil {
+		return true
+	}
+	fileBytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return true
+	}
+	fileContent := []byte(s.ReplaceKeywords(string(fileBytes), keywordMapping))
+	if format == FormatYAML {
+		fileContent = ReplaceTypeTags(fileContent)
+	}
+	data, err := Deserialize(fileContent, format, resourceType)
+	if err != nil {
+		resourceName := GetFileInfo(filePath).ResourceName
+		UpdateFailureSummaryAndLog(resourceType, resourceName, operation, fmt.Errorf("unable to match the file against the FILTER config: %w", err),
+			fmt.Sprintf("Unable to match the file against the FILTER config: %s", err))
+		return false
+	}
+	return s.MatchesFilter(resourceType, GetFileInfo(filePath).ResourceName, data)
+}
+
+// IsDeleteAllowed returns true if deployed resources of the resource type can be deleted during import.
+// Deletion is disabled for resource types with a FILTER config, as the deployed resources that are not
+// available locally cannot be matched against the filter.
+func (s *Session) IsDeleteAllowed(resourceType ResourceType) bool {
+
+	if !s.ToolConfigs.AllowDelete {
+		return false
+	}
+	if _, expression := s.getFilter(resourceType); expression != "" {
+		PrintLog(LogLevelDebug, resourceType, "", "Deployed resources are not deleted as a FILTER is configured")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,368 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type KeywordLocation struct {
+	ResourceType string      `json:"resourceType"`
+	ResourceName string      `json:"resourceName"`
+	FilePath     string      `json:"filePath"`
+	Path         string      `json:"path"`
+	Value        interface{} `json:"value"`
+
+	resourceType ResourceType
+	sourceValue  interface{}
+	prefix       string
+	suffix       string
+}
+
+type KeywordSuggestion struct {
+	Keyword   string            `json:"keyword"`
+	Source    interface{}       `json:"source"`
+	Target    interface{}       `json:"target"`
+	Locations []KeywordLocation `json:"locations"`
+}
+
+type KeywordSuggestionReport struct {
+	Source      string              `json:"source"`
+	Target      string              `json:"target"`
+	Suggestions []KeywordSuggestion `json:"suggestions"`
+}
+
+// Characters that separate the parts of a value, such as the host of a URL, which are kept out of a suggested keyword.
+const keywordValueDelimiters = "/:?&=#,;@"
+
+var camelCaseBoundaryPattern = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+var nonKeywordCharsPattern = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// SuggestKeywords proposes keywords for the scalar fields that differ between the files exported from two environments.
+// Fields that differ by the same values share a keyword, and names in reservedKeywords are not proposed.
+func (s *Session) SuggestKeywords(sourceFiles, targetFiles map[string]ExportedFile, reservedKeywords []string) []KeywordSuggestion {
+
+	var suggestions []*KeywordSuggestion
+	suggestionsByValues := make(map[string]*KeywordSuggestion)
+	for _, diff := range s.DiffExports(sourceFiles, targetFiles) {
+		if diff.Status != DIFF_MODIFIED {
+			continue
+		}
+		for _, field := range diff.Fields {
+			if field.Status != DIFF_MODIFIED || !isKeywordCandidate(field.Source) || !isKeywordCandidate(field.Target) {
+				continue
+			}
+			location := KeywordLocation{
+				ResourceType: diff.ResourceType,
+				ResourceName: diff.ResourceName,
+				FilePath:     diff.FilePath,
+				Path:         field.Path,
+				resourceType: sourceFiles[diff.FilePath].ResourceType,
+				sourceValue:  field.Source,
+			}
+			sourceValue, targetValue := field.Source, field.Target
+			sourceString, sourceIsString := field.Source.(string)
+			targetString, targetIsString := field.Target.(string)
+			if sourceIsString && targetIsString {
+				location.prefix, sourceValue, targetValue, location.suffix = splitDifferingPart(sourceString, targetString)
+			}
+
+			values, _ := json.Marshal([]interface{}{sourceValue, targetValue})
+			suggestion, exists := suggestionsByValues[string(values)]
+			if !exists {
+				suggestion = &KeywordSuggestion{Source: sourceValue, Target: targetValue}
+				suggestionsByValues[string(values)] = suggestion
+				suggestions = append(suggestions, suggestion)
+			}
+			suggestion.Locations = append(suggestion.Locations, location)
+		}
+	}
+
+	usedKeywords := make(map[string]bool)
+	for _, keyword := range reservedKeywords {
+		usedKeywords[keyword] = true
+	}
+	result := make([]KeywordSuggestion, len(suggestions))
+	for i, suggestion := range suggestions {
+		suggestion.Keyword = uniqueKeywordName(suggestKeywordName(suggestion.Locations), usedKeywords)
+		for j, location := range suggestion.Locations {
+			placeholder := "{{" + suggestion.Keyword + "}}"
+			suggestion.Locations[j].Value = location.prefix + placeholder + location.suffix
+		}
+		result[i] = *suggestion
+	}
+	return result
+}
+
+// Only scalar values that do not already contain keyword placeholders can be replaced with a suggested keyword.
+func isKeywordCandidate(value interface{}) bool {
+
+	switch v := value.(type) {
+	case nil, map[string]interface{}, []interface{}:
+		return false
+	case string:
+		return v != "" && !keywordPlaceholderPattern.MatchString(v)
+	default:
+		return true
+	}
+}
+
+// splitDifferingPart splits two strings into their common prefix and suffix and the parts that differ.
+// The differing parts are extended to the nearest delimiters, so that for example the whole host of two URLs is used.
+func splitDifferingPart(source, target string) (prefix, sourcePart, targetPart, suffix string) {
+
+	prefixLength := 0
+	for prefixLength < len(source) && prefixLength < len(target) && source[prefixLength] == target[prefixLength] {
+		prefixLength++
+	}
+	suffixLength := 0
+	for suffixLength < len(source)-prefixLength && suffixLength < len(target)-prefixLength &&
+		source[len(source)-1-suffixLength] == target[len(target)-1-suffixLength] {
+		suffixLength++
+	}
+
+	prefixLength = strings.LastIndexAny(source[:prefixLength], keywordValueDelimiters) + 1
+	suffixStart := len(source) - suffixLength
+	if index := strings.IndexAny(source[suffixStart:], keywordValueDelimiters); index >= 0 {
+		suffixLength -= index
+	} else {
+		suffixLength = 0
+	}
+
+	prefix = source[:prefixLength]
+	suffix = source[len(source)-suffixLength:]
+	sourcePart = source[prefixLength : len(source)-suffixLength]
+	targetPart = target[prefixLength : len(target)-suffixLength]
+	if sourcePart == "" || targetPart == "" {
+		return "", source, target, ""
+	}
+	return prefix, sourcePart, targetPart, suffix
+}
+
+// suggestKeywordName derives a keyword name from the field of the first location. The resource name is added
+// when all the locations are in the same resource, and HOST is added for the host of a URL.
+func suggestKeywordName(locations []KeywordLocation) string {
+
+	location := locations[0]
+	var fieldName string
+	for _, key := range GetPathKeys(location.Path) {
+		if !strings.HasPrefix(key, "[") {
+			fieldName = key
+		}
+	}
+
+	nameParts := []string{fieldName}
+	sameResource := true
+	for _, other := range locations[1:] {
+		if other.FilePath != location.FilePath {
+			sameResource = false
+			break
+		}
+	}
+	if sameResource {
+		nameParts = append([]string{location.ResourceName}, nameParts...)
+	}
+	if strings.HasSuffix(location.prefix, "://") {
+		nameParts = append(nameParts, "HOST")
+	}
+
+	name := camelCaseBoundaryPattern.ReplaceAllString(strings.Join(nameParts, "_"), "${1}_${2}")
+	name = strings.Trim(nonKeywordCharsPattern.ReplaceAllString(strings.ToUpper(name), "_"), "_")
+	if !keywordNamePattern.MatchString(name) {
+		name = strings.TrimSuffix("KEYWORD_"+name, "_")
+	}
+	return name
+}
+
+func uniqueKeywordName(name string, usedKeywords map[string]bool) string {
+
+	uniqueName := name
+	for i := 2; usedKeywords[uniqueName]; i++ {
+		uniqueName = fmt.Sprintf("%s_%d", name, i)
+	}
+	usedKeywords[uniqueName] = true
+	return uniqueName
+}
+
+// GetSuggestedKeywordMappings returns the keyword mappings of the suggested keywords for the source and the target environments.
+func GetSuggestedKeywordMappings(suggestions []KeywordSuggestion) (sourceMappings, targetMappings map[string]interface{}) {
+
+	sourceMappings = make(map[string]interface{})
+	targetMappings = make(map[string]interface{})
+	for _, suggestion := range suggestions {
+		sourceMappings[suggestion.Keyword] = suggestion.Source
+		targetMappings[suggestion.Keyword] = suggestion.Target
+	}
+	return sourceMappings, targetMappings
+}
+
+// WriteKeywordPlaceholders writes the placeholders of the suggested keywords into the local files in the given directory.
+// Fields whose local value is not the source value are skipped. Returns the paths of the updated files.
+func WriteKeywordPlaceholders(localDir string, suggestions []KeywordSuggestion) ([]string, error) {
+
+	locationsByFile := make(map[string][]KeywordLocation)
+	for _, suggestion := range suggestions {
+		for _, location := range suggestion.Locations {
+			locationsByFile[location.FilePath] = append(locationsByFile[location.FilePath], location)
+		}
+	}
+	var filePaths []string
+	for filePath := range locationsByFile {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	var updatedFiles []string
+	for _, filePath := range filePaths {
+		localFilePath := filepath.Join(localDir, filePath)
+		updated, err := writeFileKeywordPlaceholders(localFilePath, locationsByFile[filePath])
+		if err != nil {
+			return updatedFiles, fmt.Errorf("error when writing keywords to %s: %w", localFilePath, err)
+		}
+		if updated {
+			updatedFiles = append(updatedFiles, localFilePath)
+		}
+	}
+	return updatedFiles, nil
+}
+
+func writeFileKeywordPlaceholders(localFilePath string, locations []KeywordLocation) (bool, error) {
+
+	content, err := ioutil.ReadFile(localFilePath)
+	if err != nil {
+		PrintLog(LogLevelWarn, UtilsResourceWrapper, "", fmt.Sprintf("Local file not found at %s. Skipping the keywords of the file.", localFilePath))
+		return false, nil
+	}
+	format, err := FormatFromExtension(filepath.Ext(localFilePath))
+	if err != nil {
+		return false, fmt.Errorf("unsupported file format: %w", err)
+	}
+	if format == FormatYAML {
+		content = ReplaceTypeTags(content)
+	}
+	resourceType := locations[0].resourceType
+	data, err := Deserialize(content, format, resourceType)
+	if err != nil {
+		return false, fmt.Errorf("error when deserializing local content: %w", err)
+	}
+
+	updated := false
+	for _, location := range locations {
+		if !reflect.DeepEqual(getRawValue(data, location.Path), location.sourceValue) {
+			PrintLog(LogLevelWarn, UtilsResourceWrapper, "", fmt.Sprintf("Value of %s in %s does not match the source environment. Skipping the keyword.", location.Path, localFilePath))
+			continue
+		}
+		data = ReplaceRawValue(data, location.Path, location.Value)
+		updated = true
+	}
+	if !updated {
+		return false, nil
+	}
+
+	modifiedContent, err := Serialize(data, format, resourceType)
+	if err != nil {
+		return false, fmt.Errorf("error when serializing local content: %w", err)
+	}
+	if format == FormatYAML {
+		modifiedContent = AddTypeTags(modifiedContent)
+	}
+	return true, ioutil.WriteFile(localFilePath, modifiedContent, 0644)
+}
+
+// AddKeywordMappings adds keyword mappings to the KEYWORD_MAPPINGS of a keyword config file.
+// Existing mappings and the other configs in the file are kept as they are.
+func AddKeywordMappings(keywordConfigPath string, mappings map[string]interface{}) error {
+
+	if keywordConfigPath == "" {
+		return fmt.Errorf("keyword config file path is not defined")
+	}
+	content, err := ioutil.ReadFile(keywordConfigPath)
+	if err != nil {
+		return fmt.Errorf("error when reading the keyword config file: %w", err)
+	}
+
+	keywordConfigs := make(map[string]interface{})
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &keywordConfigs); err != nil {
+			return fmt.Errorf("keyword configs are not in the correct format: %w", err)
+		}
+	}
+	keywordMappings, ok := keywordConfigs["KEYWORD_MAPPINGS"].(map[string]interface{})
+	if !ok {
+		keywordMappings = make(map[string]interface{})
+	}
+	for keyword, value := range mappings {
+		if _, exists := keywordMappings[keyword]; !exists {
+			keywordMappings[keyword] = value
+		}
+	}
+	keywordConfigs["KEYWORD_MAPPINGS"] = keywordMappings
+
+	modifiedContent, err := json.MarshalIndent(keywordConfigs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error when marshalling keyword configs: %w", err)
+	}
+	return ioutil.WriteFile(keywordConfigPath, modifiedContent, 0644)
+}
+
+func PrintKeywordSuggestions(report KeywordSuggestionReport, outputFormat string) error {
+
+	if outputFormat == "json" {
+		if report.Suggestions == nil {
+			report.Suggestions = []KeywordSuggestion{}
+		}
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error when marshalling keyword suggestions: %w", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+	if outputFormat != "text" {
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	fmt.Println("========================================")
+	fmt.Printf("Keyword suggestions (%s vs %s)\n", report.Source, report.Target)
+	fmt.Println("========================================")
+	if len(report.Suggestions) == 0 {
+		fmt.Println("No differing values found.")
+		fmt.Println("========================================")
+		return nil
+	}
+	for _, suggestion := range report.Suggestions {
+		fmt.Println(suggestion.Keyword)
+		fmt.Printf("    %s: %s\n", report.Source, formatDiffValue(suggestion.Source))
+		fmt.Printf("    %s: %s\n", report.Target, formatDiffValue(suggestion.Target))
+		for _, location := range suggestion.Locations {
+			fmt.Printf("    ~ %s: %s => %s\n", location.FilePath, location.Path, formatDiffValue(location.Value))
+		}
+	}
+	fmt.Println("========================================")
+	fmt.Printf("%d keywords suggested.\n", len(report.Suggestions))
+	fmt.Println("========================================")
+	return nil
+}
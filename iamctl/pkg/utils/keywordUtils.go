@@ -19,6 +19,7 @@
 package utils
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -26,24 +27,266 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 )
 
-func ReplaceKeywords(fileContent string, keywordMapping map[string]interface{}) string {
+// ReplaceKeywords replaces the keyword placeholders in the file content with the values of the keyword mapping.
+// A placeholder can give a default value, such as {{HOST:-localhost}}, which is used if the keyword has no value.
+// Boolean, number, list and object values are written unquoted, so that a placeholder that is the whole value of
+// a field, such as "{{ENABLED}}", is replaced with a typed value. Booleans and numbers can also be a part of a
+// string value, while lists and objects can only be the whole value of a field.
+func (s *Session) ReplaceKeywords(fileContent string, keywordMapping map[string]interface{}) string {
 
+	secrets := make(map[string]string)
 	// Loop over the keyword mapping and replace each keyword in the file.
 	for keyword, value := range keywordMapping {
-		if value, ok := value.(string); ok {
-			fileContent = strings.ReplaceAll(fileContent, "{{"+keyword+"}}", value)
-		} else {
-			PrintLog(LogLevelError, UtilsResourceWrapper, "", fmt.Sprintf("keyword value for %s is not a string", keyword))
+		if !strings.Contains(fileContent, "{{"+keyword+"}}") && !strings.Contains(fileContent, "{{"+keyword+":-") {
+			continue
+		}
+		stringValue, ok := value.(string)
+		if !ok {
+			fileContent = replaceTypedKeyword(fileContent, keyword, value)
+			continue
+		}
+		// Secrets are only resolved for the keywords used in the file.
+		resolvedValue, err := s.ResolveSecrets(stringValue)
+		if err != nil {
+			PrintLog(LogLevelError, UtilsResourceWrapper, "", fmt.Sprintf("keyword value for %s could not be resolved: %s", keyword, err))
+			continue
+		}
+		if resolvedValue == "" {
+			// Placeholders with a default value are replaced with the default value below.
+			fileContent = strings.ReplaceAll(fileContent, "{{"+keyword+"}}", "")
+			continue
 		}
+		if resolvedValue != stringValue {
+			// Secrets are escaped for the format of the file once all the keywords are replaced.
+			token := fmt.Sprintf(secretTokenFormat, len(secrets))
+			secrets[token] = resolvedValue
+			resolvedValue = token
+		}
+		fileContent = getKeywordPattern("", keyword).ReplaceAllLiteralString(fileContent, resolvedValue)
+	}
+	fileContent = keywordDefaultPattern.ReplaceAllString(fileContent, "${2}")
+	if len(secrets) > 0 {
+		fileContent = substituteSecrets(fileContent, secrets)
 	}
 	return fileContent
 }
 
-func ProcessExportedData(exportedData interface{}, localFilePath string, format Format, keywordMapping map[string]interface{}, resourceType ResourceType) (interface{}, error) {
+// Matches keyword placeholders with a default value, such as {{HOST:-localhost}}.
+var keywordDefaultPattern = regexp.MustCompile(`\{\{([A-Za-z0-9_.-]+):-([^}]*)\}\}`)
+var keywordPatterns sync.Map
+
+// Returns the pattern matching the placeholders of the keyword, with or without a default value. The whole value
+// pattern matches a placeholder that is quoted or is the only value on a YAML line, along with its quotes, and
+// the element pattern matches a placeholder that is an element of a YAML list.
+func getKeywordPattern(kind, keyword string) *regexp.Regexp {
+
+	if pattern, ok := keywordPatterns.Load(kind + ":" + keyword); ok {
+		return pattern.(*regexp.Regexp)
+	}
+	placeholder := `\{\{` + regexp.QuoteMeta(keyword) + `(?::-[^}]*)?\}\}`
+	expression := placeholder
+	switch kind {
+	case "whole":
+		expression = `"` + placeholder + `"|'` + placeholder + `'|(?m)^(\s*(?:-\s+|[^\s#][^#\n]*?:\s+))` + placeholder + `[ \t]*$`
+	case "element":
+		expression = `(?m)^([ \t]*-[ \t]+)(?:"` + placeholder + `"|'` + placeholder + `'|` + placeholder + `)[ \t]*$`
+	}
+	pattern, _ := keywordPatterns.LoadOrStore(kind+":"+keyword, regexp.MustCompile(expression))
+	return pattern.(*regexp.Regexp)
+}
+
+// Replaces the placeholders of a keyword with a boolean, number, list or object value written in JSON, which is
+// valid in both YAML and JSON files.
+func replaceTypedKeyword(fileContent, keyword string, value interface{}) string {
+
+	literal, err := json.Marshal(value)
+	if err != nil {
+		PrintLog(LogLevelError, UtilsResourceWrapper, "", fmt.Sprintf("keyword value for %s could not be resolved: %s", keyword, err))
+		return fileContent
+	}
+	if list, ok := value.([]interface{}); ok {
+		// Placeholders that are an element of a YAML list are replaced with the elements of the list.
+		fileContent = getKeywordPattern("element", keyword).ReplaceAllStringFunc(fileContent, func(element string) string {
+			prefix := getKeywordPattern("element", keyword).FindStringSubmatch(element)[1]
+			elements := make([]string, 0, len(list))
+			for _, item := range list {
+				itemLiteral, err := json.Marshal(item)
+				if err != nil {
+					return element
+				}
+				elements = append(elements, prefix+string(itemLiteral))
+			}
+			return strings.Join(elements, "\n")
+		})
+	}
+	fileContent = getKeywordPattern("whole", keyword).ReplaceAllString(fileContent, "${1}"+strings.ReplaceAll(string(literal), "$", "$$"))
+	switch value.(type) {
+	case []interface{}, map[string]interface{}:
+		return fileContent
+	}
+	return getKeywordPattern("", keyword).ReplaceAllLiteralString(fileContent, string(literal))
+}
+
+// Matches keyword placeholders. Keywords are named in upper case, which keeps them apart from the placeholders
+// resolved by the server, such as {{user-name}} in email templates.
+var keywordPlaceholderPattern = regexp.MustCompile(`\{\{([A-Za-z0-9_.-]+)\}\}`)
+var keywordNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// Resource types with content that has placeholders resolved by the server. Placeholders without a keyword mapping
+// are expected in them, and are not logged.
+var serverPlaceholderResourceTypes = map[ResourceType]bool{
+	EMAIL_TEMPLATES: true,
+	SMS_TEMPLATES:   true,
+}
+
+// UnresolvedKeyword is a keyword placeholder left in a resource after the keywords are replaced.
+type UnresolvedKeyword struct {
+	Keyword string
+	Path    string
+	Reason  string
+}
+
+func (k UnresolvedKeyword) String() string {
+	return fmt.Sprintf("{{%s}} at %s (%s)", k.Keyword, k.Path, k.Reason)
+}
+
+// FindUnresolvedKeywords returns the keyword placeholders left in the content of a resource file after the keywords
+// are replaced, with the path of the field they are in. The line number is used as the path if the content cannot
+// be parsed in the format of the file.
+func FindUnresolvedKeywords(fileName, fileContent string, keywordMapping map[string]interface{}) []UnresolvedKeyword {
+
+	unresolved, _ := findPlaceholders(fileName, fileContent, keywordMapping)
+	return unresolved
+}
+
+// Returns the keyword placeholders left in the content, and the other placeholders that have no keyword mapping.
+func findPlaceholders(fileName, fileContent string, keywordMapping map[string]interface{}) (unresolved, unmapped []UnresolvedKeyword) {
+
+	if !keywordPlaceholderPattern.MatchString(fileContent) {
+		return nil, nil
+	}
+	placeholders := &unresolvedPlaceholders{}
+	if format, err := FormatFromExtension(filepath.Ext(fileName)); err == nil {
+		content := []byte(fileContent)
+		if format == FormatYAML {
+			content = ReplaceTypeTags(content)
+		}
+		if data, err := Deserialize(content, format, UtilsResourceWrapper); err == nil {
+			findUnresolvedKeywords(data, "", keywordMapping, placeholders)
+			return placeholders.keywords, placeholders.unmapped
+		}
+	}
+	for i, line := range strings.Split(fileContent, "\n") {
+		addUnresolvedKeywords(line, fmt.Sprintf("line %d", i+1), keywordMapping, placeholders)
+	}
+	return placeholders.keywords, placeholders.unmapped
+}
+
+type unresolvedPlaceholders struct {
+	keywords []UnresolvedKeyword
+	unmapped []UnresolvedKeyword
+}
+
+func findUnresolvedKeywords(data interface{}, path string, keywordMapping map[string]interface{}, unresolved *unresolvedPlaceholders) {
+
+	switch v := data.(type) {
+	case map[interface{}]interface{}:
+		keys := make([]string, 0, len(v))
+		values := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			keys = append(keys, fmt.Sprintf("%v", key))
+			values[fmt.Sprintf("%v", key)] = value
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			findUnresolvedKeywords(values[key], joinFieldPath(path, key), keywordMapping, unresolved)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			findUnresolvedKeywords(v[key], joinFieldPath(path, key), keywordMapping, unresolved)
+		}
+	case []interface{}:
+		for i, value := range v {
+			findUnresolvedKeywords(value, fmt.Sprintf("%s[%d]", path, i), keywordMapping, unresolved)
+		}
+	case string:
+		addUnresolvedKeywords(v, path, keywordMapping, unresolved)
+	}
+}
+
+func addUnresolvedKeywords(value, path string, keywordMapping map[string]interface{}, unresolved *unresolvedPlaceholders) {
+
+	for _, match := range keywordPlaceholderPattern.FindAllStringSubmatch(value, -1) {
+		keyword := match[1]
+		mappedValue, mapped := keywordMapping[keyword]
+		if !mapped && !keywordNamePattern.MatchString(keyword) {
+			unresolved.unmapped = append(unresolved.unmapped, UnresolvedKeyword{Keyword: keyword, Path: path, Reason: "not a keyword name"})
+			continue
+		}
+		reason := "no keyword mapping found"
+		if mapped {
+			if _, ok := mappedValue.(string); ok {
+				reason = "keyword value could not be resolved"
+			} else {
+				reason = "list and object keyword values can only be the whole value of a field"
+			}
+		}
+		unresolved.keywords = append(unresolved.keywords, UnresolvedKeyword{Keyword: keyword, Path: path, Reason: reason})
+	}
+}
+
+func joinFieldPath(path, field string) string {
+
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// CheckUnresolvedKeywords returns an error listing the keyword placeholders left in the content of the given
+// resource file after the keywords are replaced, so that the resource is not imported with them. Placeholders
+// that are not named as keywords are logged as warnings, unless the server resolves placeholders in the resource type.
+func CheckUnresolvedKeywords(resourceType ResourceType, filePath, fileContent string, keywordMapping map[string]interface{}) error {
+
+	unresolved, unmapped := findPlaceholders(filePath, fileContent, keywordMapping)
+	if !serverPlaceholderResourceTypes[resourceType] {
+		for _, placeholder := range unmapped {
+			PrintLog(LogLevelWarn, resourceType, GetFileInfo(filePath).ResourceName,
+				fmt.Sprintf("No keyword mapping found for {{%s}} at %s. Keywords are named in upper case", placeholder.Keyword, placeholder.Path))
+		}
+	}
+	if len(unresolved) == 0 {
+		return nil
+	}
+	details := make([]string, len(unresolved))
+	for i, keyword := range unresolved {
+		details[i] = keyword.String()
+	}
+	return fmt.Errorf("unresolved keywords in %s: %s", filePath, strings.Join(details, ", "))
+}
+
+func (s *Session) ProcessExportedData(exportedData interface{}, localFilePath string, format Format, keywordMapping map[string]interface{}, resourceType ResourceType) (interface{}, error) {
+
+	if !s.MatchesFilter(resourceType, GetFileInfo(localFilePath).ResourceName, exportedData) {
+		return nil, ErrFilteredOut
+	}
+	if deployedExport {
+		return exportedData, nil
+	}
+	if reverseKeywords {
+		return ReplaceValuesWithKeywords(exportedData, keywordMapping), nil
+	}
 
 	localFileContent, err := ioutil.ReadFile(localFilePath)
 	if err != nil {
@@ -52,7 +295,7 @@ func ProcessExportedData(exportedData interface{}, localFilePath string, format
 	}
 
 	// Replace ESVs in the exported file according to the keyword placeholders added in the local file.
-	modifiedData, err := AddLocalKeywords(exportedData, format, localFileContent, keywordMapping, resourceType)
+	modifiedData, err := s.AddLocalKeywords(exportedData, format, localFileContent, keywordMapping, resourceType)
 	if err != nil {
 		PrintLog(LogLevelError, UtilsResourceWrapper, "", fmt.Sprintf("Error processing keywords. Using exported content. %s", err))
 		return exportedData, nil
@@ -61,7 +304,7 @@ func ProcessExportedData(exportedData interface{}, localFilePath string, format
 	return modifiedData, nil
 }
 
-func ProcessExportedContent(exportedFileName string, exportedFileContent []byte, keywordMapping map[string]interface{}, resourceType ResourceType) ([]byte, error) {
+func (s *Session) ProcessExportedContent(exportedFileName string, exportedFileContent []byte, keywordMapping map[string]interface{}, resourceType ResourceType) ([]byte, error) {
 
 	format, err := FormatFromExtension(filepath.Ext(exportedFileName))
 	if err != nil {
@@ -79,8 +322,10 @@ func ProcessExportedContent(exportedFileName string, exportedFileContent []byte,
 	}
 
 	// Process exported content
-	modifiedData, err := ProcessExportedData(exportedData, exportedFileName, format, keywordMapping, resourceType)
-	if err != nil {
+	modifiedData, err := s.ProcessExportedData(exportedData, exportedFileName, format, keywordMapping, resourceType)
+	if errors.Is(err, ErrFilteredOut) {
+		return nil, err
+	} else if err != nil {
 		PrintLog(LogLevelError, UtilsResourceWrapper, "", fmt.Sprintf("Error when processing with keywords. Using exported content. %s", err))
 		modifiedData = exportedData
 	}
@@ -99,11 +344,11 @@ func ProcessExportedContent(exportedFileName string, exportedFileContent []byte,
 
 // Adds local keywords for exported content specifically in YAML format.
 // Use AddLocalKeywords for granular control over different formats.
-func AddKeywords(exportedYaml interface{}, localFileContent []byte, keywordMapping map[string]interface{}, resourceType ResourceType) (interface{}, error) {
-	return AddLocalKeywords(exportedYaml, FormatYAML, localFileContent, keywordMapping, resourceType)
+func (s *Session) AddKeywords(exportedYaml interface{}, localFileContent []byte, keywordMapping map[string]interface{}, resourceType ResourceType) (interface{}, error) {
+	return s.AddLocalKeywords(exportedYaml, FormatYAML, localFileContent, keywordMapping, resourceType)
 }
 
-func AddLocalKeywords(exportedData interface{}, format Format, localFileContent []byte, keywordMapping map[string]interface{}, resourceType ResourceType) (interface{}, error) {
+func (s *Session) AddLocalKeywords(exportedData interface{}, format Format, localFileContent []byte, keywordMapping map[string]interface{}, resourceType ResourceType) (interface{}, error) {
 
 	localData, err := Deserialize(localFileContent, format, resourceType)
 	if err != nil || localData == nil {
@@ -111,34 +356,34 @@ func AddLocalKeywords(exportedData interface{}, format Format, localFileContent
 	}
 
 	if preprocessFunc, exists := DataPreprocessFuncs[resourceType]; exists {
-		localData, err = preprocessFunc(localData)
+		localData, err = preprocessFunc(s, localData)
 		if err != nil {
 			return exportedData, fmt.Errorf("error occurred while preprocessing local data: %w", err)
 		}
 	}
 
 	// Get keyword locations in local file.
-	keywordLocations := GetKeywordLocations(localData, []string{}, keywordMapping, resourceType)
+	keywordLocations := s.GetKeywordLocations(localData, []string{}, keywordMapping, resourceType)
 
 	// Compare the fields with keywords in the exported file and the local file and modify the exported file.
-	exportedData = ModifyFieldsWithKeywords(exportedData, localData, keywordLocations, keywordMapping)
+	exportedData = s.ModifyFieldsWithKeywords(exportedData, localData, keywordLocations, keywordMapping)
 
 	return exportedData, nil
 }
 
-func GetKeywordLocations(fileData interface{}, path []string, keywordMapping map[string]interface{}, resourceType ResourceType) []string {
+func (s *Session) GetKeywordLocations(fileData interface{}, path []string, keywordMapping map[string]interface{}, resourceType ResourceType) []string {
 
 	var keys []string
 	switch v := fileData.(type) {
 	case map[interface{}]interface{}:
 		for k, val := range v {
 			newPath := append(path, fmt.Sprintf("%v", k))
-			keys = append(keys, GetKeywordLocations(val, newPath, keywordMapping, resourceType)...)
+			keys = append(keys, s.GetKeywordLocations(val, newPath, keywordMapping, resourceType)...)
 		}
 	case map[string]interface{}:
 		for k, val := range v {
 			newPath := append(path, fmt.Sprintf("%v", k))
-			keys = append(keys, GetKeywordLocations(val, newPath, keywordMapping, resourceType)...)
+			keys = append(keys, s.GetKeywordLocations(val, newPath, keywordMapping, resourceType)...)
 		}
 	case []interface{}:
 		for _, val := range v {
@@ -149,7 +394,7 @@ func GetKeywordLocations(fileData interface{}, path []string, keywordMapping map
 				}
 				break
 			} else {
-				arrayIdentifiers := GetArrayIdentifiers(resourceType)
+				arrayIdentifiers := s.GetArrayIdentifiers(resourceType)
 				var arrayName string
 				if len(path) > 0 {
 					arrayName = path[len(path)-1]
@@ -162,7 +407,7 @@ func GetKeywordLocations(fileData interface{}, path []string, keywordMapping map
 					break
 				}
 				newPath := append(path, arrayElementPath)
-				keys = append(keys, GetKeywordLocations(val, newPath, keywordMapping, resourceType)...)
+				keys = append(keys, s.GetKeywordLocations(val, newPath, keywordMapping, resourceType)...)
 			}
 		}
 	case string:
@@ -174,11 +419,11 @@ func GetKeywordLocations(fileData interface{}, path []string, keywordMapping map
 	return keys
 }
 
-func GetArrayIdentifiers(resourceType ResourceType) map[string]string {
+func (s *Session) GetArrayIdentifiers(resourceType ResourceType) map[string]string {
 
 	switch resourceType {
 	case APPLICATIONS:
-		if ExportAPIExists(APPLICATIONS) {
+		if s.ExportAPIExists(APPLICATIONS) {
 			return appExportAPIArrayIdentifiers
 		}
 		return appGetAPIArrayIdentifiers
@@ -240,6 +485,9 @@ func resolvePathWithIdentifiers(arrayName string, element interface{}, identifie
 
 func ContainsKeywords(data string, keywordMapping map[string]interface{}) bool {
 
+	if keywordDefaultPattern.MatchString(data) {
+		return true
+	}
 	for keyword := range keywordMapping {
 		if strings.Contains(data, "{{"+keyword+"}}") {
 			return true
@@ -248,30 +496,46 @@ func ContainsKeywords(data string, keywordMapping map[string]interface{}) bool {
 	return false
 }
 
-func ModifyFieldsWithKeywords(exportedFileData interface{}, localFileData interface{},
+func (s *Session) ModifyFieldsWithKeywords(exportedFileData interface{}, localFileData interface{},
 	keywordLocations []string, keywordMap map[string]interface{}) interface{} {
 
 	for _, location := range keywordLocations {
 
 		localValue := GetValue(localFileData, location)
-		localReplacedValue := ReplaceKeywords(localValue, keywordMap)
 		exportedValue := GetValue(exportedFileData, location)
 
-		if exportedValue != localReplacedValue {
-			if exportedValue == strings.ReplaceAll(SENSITIVE_FIELD_MASK, "'", "") {
-				ReplaceValue(exportedFileData, location, localValue)
-				PrintLog(LogLevelInfo, UtilsResourceWrapper, "", fmt.Sprintf("Keyword added at %s field", location))
-			} else {
-				PrintLog(LogLevelWarn, UtilsResourceWrapper, "", fmt.Sprintf("Keywords at %s field in the local file will be replaced by exported content.", location))
-			}
-		} else {
+		// Masked secrets are checked first, so that secret keywords are not resolved during the export.
+		if exportedValue == strings.ReplaceAll(SENSITIVE_FIELD_MASK, "'", "") || exportedValue == s.ReplaceKeywords(localValue, keywordMap) ||
+			matchesTypedKeyword(getRawValue(exportedFileData, location), localValue, keywordMap) {
 			ReplaceValue(exportedFileData, location, localValue)
 			PrintLog(LogLevelInfo, UtilsResourceWrapper, "", fmt.Sprintf("Keyword added at %s field", location))
+		} else {
+			PrintLog(LogLevelWarn, UtilsResourceWrapper, "", fmt.Sprintf("Keywords at %s field in the local file will be replaced by exported content.", location))
 		}
 	}
 	return exportedFileData
 }
 
+// Returns true if the local value is a placeholder of a list or object keyword with the exported value.
+func matchesTypedKeyword(exportedValue interface{}, localValue string, keywordMap map[string]interface{}) bool {
+
+	match := keywordPlaceholderPattern.FindStringSubmatch(localValue)
+	if match == nil || match[0] != localValue {
+		return false
+	}
+	switch keywordMap[match[1]].(type) {
+	case []interface{}, map[string]interface{}:
+	default:
+		return false
+	}
+	exportedJson, err := json.Marshal(exportedValue)
+	if err != nil {
+		return false
+	}
+	keywordJson, err := json.Marshal(keywordMap[match[1]])
+	return err == nil && string(exportedJson) == string(keywordJson)
+}
+
 func GetValue(data interface{}, key string) string {
 
 	value := getRawValue(data, key)
@@ -440,4 +704,4 @@ func extendPath(base, segment string) string {
 	return base + "." + segment
 }
 
-var DataPreprocessFuncs = map[ResourceType]func(interface{}) (interface{}, error){}
+var DataPreprocessFuncs = map[ResourceType]func(*Session, interface{}) (interface{}, error){}
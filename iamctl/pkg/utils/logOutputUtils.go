@@ -0,0 +1,240 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type LogFormat string
+
+const (
+	LogFormatText LogFormat = "text"
+	LogFormatJSON LogFormat = "json"
+)
+
+// LogEvent is a log entry written as a single JSON object when the JSON log format is enabled.
+type LogEvent struct {
+	Timestamp     string `json:"timestamp"`
+	Level         string `json:"level"`
+	ResourceType  string `json:"resourceType,omitempty"`
+	ResourceName  string `json:"resourceName,omitempty"`
+	Operation     string `json:"operation,omitempty"`
+	Method        string `json:"method,omitempty"`
+	Url           string `json:"url,omitempty"`
+	Status        int    `json:"status,omitempty"`
+	CorrelationId string `json:"correlationId,omitempty"`
+	DurationMs    int64  `json:"durationMs,omitempty"`
+	Message       string `json:"message"`
+}
+
+var CURRENT_LOG_FORMAT = LogFormatText
+
+// Writes the JSON log events without the date prefix of the standard logger.
+var jsonLogger = log.New(os.Stderr, "", 0)
+
+var logFile *rotatingFile
+
+func ParseLogFormat(value string) (LogFormat, error) {
+
+	switch LogFormat(strings.ToLower(strings.TrimSpace(value))) {
+	case "", LogFormatText:
+		return LogFormatText, nil
+	case LogFormatJSON:
+		return LogFormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported log format: %s. Supported formats are text and json", value)
+	}
+}
+
+// ConfigureLogs sets the log format and, if a log file is given, writes the logs to the file in addition to the console.
+func ConfigureLogs(logsConfig LogsConfig) error {
+
+	format, err := ParseLogFormat(logsConfig.Format)
+	if err != nil {
+		return err
+	}
+	CURRENT_LOG_FORMAT = format
+
+	if logFile != nil {
+		if logsConfig.File == logFile.path {
+			return nil
+		}
+		logFile.Close()
+		logFile = nil
+	}
+	var output io.Writer = os.Stderr
+	if logsConfig.File != "" {
+		logFile, err = openRotatingFile(logsConfig.File, logsConfig.MaxFileSizeMB, logsConfig.MaxBackups)
+		if err != nil {
+			return err
+		}
+		output = io.MultiWriter(os.Stderr, logFile)
+	}
+	log.SetOutput(output)
+	jsonLogger.SetOutput(output)
+	return nil
+}
+
+// PrintHttpLog logs a request sent to the server along with its correlation ID, the status code of the response
+// and the time taken. Requests are logged at the DEBUG level, or at the INFO level when tracing is enabled.
+func PrintHttpLog(resourceType ResourceType, req *http.Request, statusCode int, duration time.Duration) {
+
+	level := LogLevelDebug
+	if TRACE_REQUESTS {
+		level = LogLevelInfo
+	}
+	if level < CURRENT_LOG_LEVEL {
+		return
+	}
+	correlationId := req.Header.Get(CORRELATION_ID_HEADER)
+	message := fmt.Sprintf("%s %s [%d] %s (correlation ID: %s)", req.Method, req.URL.String(), statusCode,
+		duration.Round(time.Millisecond), correlationId)
+	writeLog(level, fmt.Sprintf("%s - %s", resourceType, message), LogEvent{
+		ResourceType:  resourceType.String(),
+		Method:        req.Method,
+		Url:           req.URL.String(),
+		Status:        statusCode,
+		CorrelationId: correlationId,
+		DurationMs:    duration.Milliseconds(),
+		Message:       message,
+	})
+}
+
+// Logs the outcome of an operation on a resource as a single event, along with the operation in the JSON format.
+func printResultLog(level LogLevel, resourceType ResourceType, resourceName, operation, message string) {
+
+	if level < CURRENT_LOG_LEVEL {
+		return
+	}
+	writeLog(level, logBody(resourceType, resourceName, message), LogEvent{
+		ResourceType: resourceType.String(),
+		ResourceName: resourceName,
+		Operation:    operation,
+		Message:      message,
+	})
+}
+
+// Writes the given text in the text format, or the event as a JSON object in the JSON format.
+func writeLog(level LogLevel, text string, event LogEvent) {
+
+	if CURRENT_LOG_FORMAT != LogFormatJSON {
+		log.Printf("%s %s", levelPrefix(level), text)
+		return
+	}
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+	event.Level = levelName(level)
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("%s %s", levelPrefix(level), text)
+		return
+	}
+	jsonLogger.Println(string(data))
+}
+
+// A log file that is rotated when it exceeds the maximum size. Rotated files are kept with the suffixes .1, .2 and
+// so on up to the maximum number of backups, .1 being the most recent.
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func openRotatingFile(path string, maxSizeMB, maxBackups int) (*rotatingFile, error) {
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("error creating log file directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error reading log file: %w", err)
+	}
+	return &rotatingFile{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxBackups: maxBackups,
+		file:       file,
+		size:       info.Size(),
+	}, nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) Close() error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+func (r *rotatingFile) rotate() error {
+
+	r.file.Close()
+	if r.maxBackups > 0 {
+		os.Remove(r.backupPath(r.maxBackups))
+		for i := r.maxBackups - 1; i >= 1; i-- {
+			os.Rename(r.backupPath(i), r.backupPath(i+1))
+		}
+		if err := os.Rename(r.path, r.backupPath(1)); err != nil {
+			return fmt.Errorf("error rotating log file: %w", err)
+		}
+	}
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("error opening log file: %w", err)
+	}
+	r.file = file
+	r.size = 0
+	return nil
+}
+
+func (r *rotatingFile) backupPath(index int) string {
+
+	return r.path + "." + strconv.Itoa(index)
+}
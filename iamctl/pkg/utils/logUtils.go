@@ -20,8 +20,8 @@ package utils
 
 import (
 	"fmt"
-	"log"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -29,6 +29,7 @@ type Summary struct {
 	SuccessfulOperations int
 	FailedOperations     int
 	TotalRequests        int
+	RetriedRequests      int
 }
 
 type ResourceTypeSummary struct {
@@ -38,12 +39,22 @@ type ResourceTypeSummary struct {
 	SuccessfulUpdate            int
 	FailedCount                 int
 	DeletedCount                int
+	FilteredOutCount            int
 	SecretGeneratedApplications []string
 	FailedResources             []string
 	Skipped                     bool
 	SkipReason                  string
 	Failed                      bool
 	Duration                    time.Duration
+	Resources                   []ResourceResult
+}
+
+// ResourceResult is the outcome of an operation on a single resource.
+type ResourceResult struct {
+	ResourceName string
+	Operation    string
+	Duration     time.Duration
+	Error        string
 }
 
 var (
@@ -54,6 +65,12 @@ var (
 	StartTime         time.Time
 )
 
+// Start times of the resources being processed, keyed by the resource type and name.
+var resourceStartTimes = make(map[string]time.Time)
+
+// Guards the summaries and warnings, which are updated by concurrently processed resources.
+var summaryLock sync.Mutex
+
 var CURRENT_LOG_LEVEL LogLevel = LogLevelInfo
 
 func resolveLogLevel(levelStr string) LogLevel {
@@ -70,27 +87,36 @@ func resolveLogLevel(levelStr string) LogLevel {
 	}
 }
 
-func levelPrefix(level LogLevel) string {
+func levelName(level LogLevel) string {
 
 	switch level {
 	case LogLevelDebug:
-		return "DEBUG:"
+		return "DEBUG"
 	case LogLevelWarn:
-		return "WARN:"
+		return "WARN"
 	case LogLevelError:
-		return "ERROR:"
+		return "ERROR"
 	default:
-		return "INFO:"
+		return "INFO"
 	}
 }
 
+func levelPrefix(level LogLevel) string {
+
+	return levelName(level) + ":"
+}
+
 func MarkResTypeStart(resourceType ResourceType) {
 
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
 	ResTypeStartTimes[resourceType] = time.Now()
 }
 
 func MarkResTypeEnd(resourceType ResourceType) {
 
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
 	startTime, ok := ResTypeStartTimes[resourceType]
 	if !ok {
 		return
@@ -99,10 +125,31 @@ func MarkResTypeEnd(resourceType ResourceType) {
 	summary := getOrInitSummary(resourceType)
 	summary.Duration = time.Since(startTime).Round(time.Millisecond)
 	ResTypeSummaryMap[resourceType] = summary
+	if summary.FilteredOutCount > 0 {
+		PrintLog(LogLevelInfo, resourceType, "", fmt.Sprintf("Excluded %d resources that do not match the FILTER config", summary.FilteredOutCount))
+	}
+}
+
+// MarkResourceStart records the time processing of a resource started, to report the duration of its operation.
+func MarkResourceStart(resourceType ResourceType, resourceName string) {
+
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
+	resourceStartTimes[resourceStartKey(resourceType, resourceName)] = time.Now()
 }
 
+// Reasons for skipping a resource type.
+const (
+	SKIP_REASON_UNSUPPORTED_VERSION = "Not supported in server version"
+	SKIP_REASON_SUB_ORGANIZATION    = "Not supported in sub-organizations"
+	SKIP_REASON_EXCLUDED            = "Excluded via tool configs"
+	SKIP_REASON_SUPER_TENANT        = "Not supported in super tenant"
+)
+
 func UpdateSkipSummary(resourceType ResourceType, reason string) {
 
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
 	InitializeResTypeSummaryMap()
 	summary := getOrInitSummary(resourceType)
 	summary.Skipped = true
@@ -112,22 +159,57 @@ func UpdateSkipSummary(resourceType ResourceType, reason string) {
 
 func MarkResTypeFailure(resourceType ResourceType) {
 
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
 	InitializeResTypeSummaryMap()
 	summary := getOrInitSummary(resourceType)
 	summary.Failed = true
 	ResTypeSummaryMap[resourceType] = summary
 }
 
+// UpdateFilteredOutSummary counts a resource that is excluded as it does not match the FILTER config.
+func UpdateFilteredOutSummary(resourceType ResourceType) {
+
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
+	InitializeResTypeSummaryMap()
+	summary := getOrInitSummary(resourceType)
+	summary.FilteredOutCount++
+	ResTypeSummaryMap[resourceType] = summary
+}
+
 func AddNewSecretIndicatorToSummary(appName string) {
 
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
 	InitializeResTypeSummaryMap()
 	summary := getOrInitSummary(APPLICATIONS)
 	summary.SecretGeneratedApplications = append(summary.SecretGeneratedApplications, appName)
 	ResTypeSummaryMap[APPLICATIONS] = summary
 }
 
-func UpdateSuccessSummary(resourceType ResourceType, operation string) {
+// UpdateSuccessSummary records a successful operation on a resource. In the JSON log format, the outcome is also
+// logged as an event, as the text logs of such operations have no message of their own.
+func UpdateSuccessSummary(resourceType ResourceType, resourceName string, operation string) {
+
+	updateSuccessSummary(resourceType, resourceName, operation)
+	if CURRENT_LOG_FORMAT == LogFormatJSON {
+		printResultLog(LogLevelInfo, resourceType, resourceName, operation, operation+" successful")
+	}
+}
+
+// UpdateSuccessSummaryAndLog records a successful operation on a resource and logs the given message at the INFO
+// level, as a single event with the operation in the JSON log format.
+func UpdateSuccessSummaryAndLog(resourceType ResourceType, resourceName, operation, message string) {
 
+	updateSuccessSummary(resourceType, resourceName, operation)
+	printResultLog(LogLevelInfo, resourceType, resourceName, operation, message)
+}
+
+func updateSuccessSummary(resourceType ResourceType, resourceName string, operation string) {
+
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
 	InitializeResTypeSummaryMap()
 
 	AggregatedSummary.TotalRequests++
@@ -144,11 +226,32 @@ func UpdateSuccessSummary(resourceType ResourceType, operation string) {
 	case DELETE:
 		summary.DeletedCount++
 	}
+	summary.Resources = append(summary.Resources, newResourceResult(resourceType, resourceName, operation, nil))
 	ResTypeSummaryMap[resourceType] = summary
 }
 
-func UpdateFailureSummary(resourceType ResourceType, resourceName string) {
+// UpdateFailureSummary records a failed operation on a resource. In the JSON log format, the outcome is also
+// logged as an event, as the text logs of such operations have no message of their own.
+func UpdateFailureSummary(resourceType ResourceType, resourceName string, operation string, err error) {
+
+	updateFailureSummary(resourceType, resourceName, operation, err)
+	if CURRENT_LOG_FORMAT == LogFormatJSON {
+		printResultLog(LogLevelError, resourceType, resourceName, operation, fmt.Sprintf("%s failed: %s", operation, err))
+	}
+}
+
+// UpdateFailureSummaryAndLog records a failed operation on a resource and logs the given message at the ERROR
+// level, as a single event with the operation in the JSON log format.
+func UpdateFailureSummaryAndLog(resourceType ResourceType, resourceName, operation string, err error, message string) {
+
+	updateFailureSummary(resourceType, resourceName, operation, err)
+	printResultLog(LogLevelError, resourceType, resourceName, operation, message)
+}
+
+func updateFailureSummary(resourceType ResourceType, resourceName string, operation string, err error) {
 
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
 	InitializeResTypeSummaryMap()
 
 	AggregatedSummary.TotalRequests++
@@ -157,25 +260,89 @@ func UpdateFailureSummary(resourceType ResourceType, resourceName string) {
 	summary := getOrInitSummary(resourceType)
 	summary.FailedCount++
 	summary.FailedResources = append(summary.FailedResources, resourceName)
+	summary.Resources = append(summary.Resources, newResourceResult(resourceType, resourceName, operation, err))
 	ResTypeSummaryMap[resourceType] = summary
 }
 
-func PrintLog(level LogLevel, packageName ResourceType, resourceName string, msg string) {
+// Creates the result of an operation on a resource. The duration is only known if the start of the resource was marked.
+func newResourceResult(resourceType ResourceType, resourceName string, operation string, err error) ResourceResult {
 
-	var body string
-	if resourceName == "" {
-		body = fmt.Sprintf("%s - %s", packageName, msg)
-	} else {
-		body = fmt.Sprintf("%s - %s - %s", packageName, resourceName, msg)
+	result := ResourceResult{ResourceName: resourceName, Operation: operation}
+	if startTime, ok := resourceStartTimes[resourceStartKey(resourceType, resourceName)]; ok {
+		result.Duration = time.Since(startTime).Round(time.Millisecond)
+	}
+	if err != nil {
+		result.Error = err.Error()
 	}
+	return result
+}
+
+func resourceStartKey(resourceType ResourceType, resourceName string) string {
+
+	return string(resourceType) + "/" + resourceName
+}
+
+// HasFailures returns true if any operation or resource type has failed.
+func HasFailures() bool {
+
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
+	if AggregatedSummary.FailedOperations > 0 {
+		return true
+	}
+	for _, summary := range ResTypeSummaryMap {
+		if summary.Failed {
+			return true
+		}
+	}
+	return false
+}
+
+// ResetSummary clears the summaries and warnings collected so far.
+func ResetSummary() {
+
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
+	AggregatedSummary = Summary{}
+	ResTypeSummaryMap = nil
+	Warnings = nil
+	ResTypeStartTimes = make(map[ResourceType]time.Time)
+	resourceStartTimes = make(map[string]time.Time)
+	resetRequestTimings()
+}
+
+func UpdateRetrySummary() {
+
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
+	AggregatedSummary.RetriedRequests++
+}
+
+func PrintLog(level LogLevel, packageName ResourceType, resourceName string, msg string) {
+
+	body := logBody(packageName, resourceName, msg)
 
 	if level == LogLevelWarn {
+		summaryLock.Lock()
 		Warnings = append(Warnings, body)
+		summaryLock.Unlock()
 	}
 	if level < CURRENT_LOG_LEVEL {
 		return
 	}
-	log.Printf("%s %s", levelPrefix(level), body)
+	writeLog(level, body, LogEvent{
+		ResourceType: packageName.String(),
+		ResourceName: resourceName,
+		Message:      msg,
+	})
+}
+
+func logBody(packageName ResourceType, resourceName string, msg string) string {
+
+	if resourceName == "" {
+		return fmt.Sprintf("%s - %s", packageName, msg)
+	}
+	return fmt.Sprintf("%s - %s - %s", packageName, resourceName, msg)
 }
 
 func PrintSummary(Operation string) {
@@ -203,12 +370,16 @@ func PrintSummary(Operation string) {
 	fmt.Println("========================================")
 	fmt.Println("Total Summary:")
 	fmt.Println("========================================")
+	fmt.Printf("Run ID: %s\n", RunId)
 	fmt.Printf("Total Operations: %d\n", AggregatedSummary.TotalRequests)
 	fmt.Printf("Successful Operations: %d\n", AggregatedSummary.SuccessfulOperations)
 	fmt.Printf("Failed Operations: %d\n", AggregatedSummary.FailedOperations)
 	fmt.Printf("Successful Resource Types: %d\n", successCount)
 	fmt.Printf("Skipped Resource Types: %d\n", len(skippedTypes))
 	fmt.Printf("Failed Resource Types: %d\n", len(failedTypes))
+	if AggregatedSummary.RetriedRequests > 0 {
+		fmt.Printf("Retried Requests: %d\n", AggregatedSummary.RetriedRequests)
+	}
 	if !StartTime.IsZero() {
 		fmt.Printf("Total Execution Time: %s\n", time.Since(StartTime).Round(time.Millisecond))
 	}
@@ -246,6 +417,9 @@ func PrintSummary(Operation string) {
 			fmt.Printf("%s\n", w)
 		}
 	}
+	if TRACE_REQUESTS {
+		printRequestTimings()
+	}
 	fmt.Println("========================================")
 }
 
@@ -0,0 +1,471 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Directive of a strategic merge patch element to remove the matching element from the array.
+const overlayPatchDirective = "$patch"
+const overlayPatchDelete = "delete"
+
+var overlayExtensions = []string{".yml", ".yaml", ".json"}
+
+// ApplyOverlay applies the overlay of the environment for a resource file, if there is one, to the content of the
+// resource after the keywords are replaced. An overlay is a strategic merge patch, given as an object, or an
+// RFC 6902 JSON patch, given as a list of operations.
+func (s *Session) ApplyOverlay(resourceType ResourceType, filePath, fileContent string) (string, error) {
+
+	overlayPath := s.getOverlayPath(resourceType, filePath)
+	if overlayPath == "" {
+		return fileContent, nil
+	}
+	PrintLog(LogLevelDebug, resourceType, GetFileInfo(filePath).ResourceName, fmt.Sprintf("Applying overlay: %s", overlayPath))
+
+	overlay, err := readOverlay(overlayPath)
+	if err != nil {
+		return "", fmt.Errorf("error when reading overlay %s: %w", overlayPath, err)
+	}
+	format, err := FormatFromExtension(filepath.Ext(filePath))
+	if err != nil {
+		return "", fmt.Errorf("unsupported file format: %w", err)
+	}
+	content := []byte(fileContent)
+	if format == FormatYAML {
+		content = ReplaceTypeTags(content)
+	}
+	data, err := Deserialize(content, format, resourceType)
+	if err != nil {
+		return "", fmt.Errorf("error when deserializing resource content: %w", err)
+	}
+	data = ConvertToStringKeyMap(data)
+
+	identifiers := s.GetArrayIdentifiers(resourceType)
+	if resourceType == IDENTITY_PROVIDERS && s.ExportAPIExists(IDENTITY_PROVIDERS) {
+		identifiers = s.GetArrayIdentifiers(IDENTITY_PROVIDERS_EXPORT_API)
+	}
+	switch patch := overlay.(type) {
+	case []interface{}:
+		data, err = ApplyJSONPatch(data, patch)
+	case map[string]interface{}:
+		data = ApplyMergePatch(data, patch, resourceType.String(), identifiers)
+	default:
+		err = fmt.Errorf("overlay must be an object or a list of JSON patch operations")
+	}
+	if err != nil {
+		return "", fmt.Errorf("error when applying overlay %s: %w", overlayPath, err)
+	}
+
+	modifiedContent, err := Serialize(data, format, resourceType)
+	if err != nil {
+		return "", fmt.Errorf("error when serializing resource content with overlay: %w", err)
+	}
+	if format == FormatYAML {
+		modifiedContent = AddTypeTags(modifiedContent)
+	}
+	return string(modifiedContent), nil
+}
+
+// Returns the overlay of a resource file, found at the same path relative to the resource type directory in the
+// overlay directory of the environment, with any of the supported extensions. Returns an empty string if there is none.
+func (s *Session) getOverlayPath(resourceType ResourceType, filePath string) string {
+
+	if s.OverlayDir == "" {
+		return ""
+	}
+	pathParts := strings.Split(filepath.ToSlash(filepath.Clean(filePath)), "/")
+	typeDirIndex := -1
+	for i := len(pathParts) - 2; i >= 0; i-- {
+		if pathParts[i] == resourceType.String() {
+			typeDirIndex = i
+			break
+		}
+	}
+	if typeDirIndex < 0 {
+		return ""
+	}
+
+	relativePath := filepath.Join(pathParts[typeDirIndex:]...)
+	basePath := filepath.Join(s.OverlayDir, strings.TrimSuffix(relativePath, filepath.Ext(relativePath)))
+	for _, extension := range overlayExtensions {
+		if info, err := os.Stat(basePath + extension); err == nil && !info.IsDir() {
+			return basePath + extension
+		}
+	}
+	return ""
+}
+
+func readOverlay(overlayPath string) (interface{}, error) {
+
+	content, err := ioutil.ReadFile(overlayPath)
+	if err != nil {
+		return nil, err
+	}
+	format, err := FormatFromExtension(filepath.Ext(overlayPath))
+	if err != nil {
+		return nil, err
+	}
+	overlay, err := Deserialize(content, format, "")
+	if err != nil {
+		return nil, err
+	}
+	return ConvertToStringKeyMap(overlay), nil
+}
+
+// ApplyMergePatch merges a strategic merge patch into the data. Objects are merged recursively and fields set to null
+// are removed. Elements of arrays of objects are matched by the array identifiers and merged, elements that do not
+// match are added, and elements with "$patch: delete" are removed. Other arrays and values are replaced.
+func ApplyMergePatch(data interface{}, patch interface{}, fieldName string, identifiers map[string]string) interface{} {
+
+	switch patchValue := patch.(type) {
+	case map[string]interface{}:
+		dataMap, ok := data.(map[string]interface{})
+		if !ok {
+			dataMap = make(map[string]interface{})
+		}
+		for key, value := range patchValue {
+			if key == overlayPatchDirective {
+				continue
+			}
+			if value == nil {
+				delete(dataMap, key)
+				continue
+			}
+			dataMap[key] = ApplyMergePatch(dataMap[key], value, key, identifiers)
+		}
+		return dataMap
+	case []interface{}:
+		dataArray, ok := data.([]interface{})
+		if ok && len(patchValue) > 0 && isKeyedArray(fieldName, dataArray, identifiers) && isKeyedArray(fieldName, patchValue, identifiers) {
+			return mergeKeyedArrays(dataArray, patchValue, fieldName, identifiers)
+		}
+		replaced := make([]interface{}, len(patchValue))
+		for i, element := range patchValue {
+			replaced[i] = ApplyMergePatch(nil, element, fieldName, identifiers)
+		}
+		return replaced
+	default:
+		return patch
+	}
+}
+
+func mergeKeyedArrays(data, patch []interface{}, arrayName string, identifiers map[string]string) []interface{} {
+
+	identifier := getArrayIdentifier(arrayName, identifiers)
+	merged := append([]interface{}{}, data...)
+	for _, element := range patch {
+		patchElement := element.(map[string]interface{})
+		key := GetValue(patchElement, identifier)
+		index := -1
+		for i, dataElement := range merged {
+			if GetValue(dataElement, identifier) == key {
+				index = i
+				break
+			}
+		}
+
+		switch {
+		case patchElement[overlayPatchDirective] == overlayPatchDelete:
+			if index >= 0 {
+				merged = append(merged[:index], merged[index+1:]...)
+			}
+		case index >= 0:
+			merged[index] = ApplyMergePatch(merged[index], patchElement, arrayName, identifiers)
+		default:
+			merged = append(merged, ApplyMergePatch(nil, patchElement, arrayName, identifiers))
+		}
+	}
+	return merged
+}
+
+// Returns true if all the elements of the array are objects with a value for the identifier of the array.
+func isKeyedArray(arrayName string, elements []interface{}, identifiers map[string]string) bool {
+
+	identifier := getArrayIdentifier(arrayName, identifiers)
+	for _, element := range elements {
+		if !isObject(element) || GetValue(element, identifier) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func getArrayIdentifier(arrayName string, identifiers map[string]string) string {
+
+	if identifier := identifiers[arrayName]; identifier != "" {
+		return identifier
+	}
+	return "name"
+}
+
+// ApplyJSONPatch applies the operations of an RFC 6902 JSON patch to the data. In addition to array indexes, array
+// elements can be referenced in the paths with the same [identifier=value] syntax used for keyword locations.
+func ApplyJSONPatch(data interface{}, operations []interface{}) (interface{}, error) {
+
+	for i, op := range operations {
+		operation, ok := op.(map[string]interface{})
+		if !ok {
+			return data, fmt.Errorf("operation %d is not an object", i)
+		}
+		var err error
+		data, err = applyJSONPatchOperation(data, operation)
+		if err != nil {
+			return data, fmt.Errorf("operation %d (%v %v): %w", i, operation["op"], operation["path"], err)
+		}
+	}
+	return data, nil
+}
+
+func applyJSONPatchOperation(data interface{}, operation map[string]interface{}) (interface{}, error) {
+
+	path, err := parseJSONPointer(operation["path"])
+	if err != nil {
+		return data, err
+	}
+	value, hasValue := operation["value"]
+
+	switch operation["op"] {
+	case "add":
+		if !hasValue {
+			return data, errors.New("value is required")
+		}
+		return addPointerValue(data, path, value)
+	case "remove":
+		return removePointerValue(data, path)
+	case "replace":
+		if !hasValue {
+			return data, errors.New("value is required")
+		}
+		if _, err := getPointerValue(data, path); err != nil {
+			return data, err
+		}
+		return setPointerValue(data, path, value, false)
+	case "move", "copy":
+		from, err := parseJSONPointer(operation["from"])
+		if err != nil {
+			return data, fmt.Errorf("invalid from: %w", err)
+		}
+		fromValue, err := getPointerValue(data, from)
+		if err != nil {
+			return data, err
+		}
+		if operation["op"] == "move" {
+			if data, err = removePointerValue(data, from); err != nil {
+				return data, err
+			}
+		} else if fromValue, err = copyValue(fromValue); err != nil {
+			return data, err
+		}
+		return addPointerValue(data, path, fromValue)
+	case "test":
+		current, err := getPointerValue(data, path)
+		if err != nil {
+			return data, err
+		}
+		if !jsonEqual(current, value) {
+			return data, errors.New("test failed")
+		}
+		return data, nil
+	default:
+		return data, fmt.Errorf("unsupported operation: %v", operation["op"])
+	}
+}
+
+func parseJSONPointer(pointer interface{}) ([]string, error) {
+
+	path, ok := pointer.(string)
+	if !ok {
+		return nil, errors.New("path is required")
+	}
+	if path == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("path %s must start with /", path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+	return tokens, nil
+}
+
+func getPointerValue(data interface{}, path []string) (interface{}, error) {
+
+	for _, token := range path {
+		switch v := data.(type) {
+		case map[string]interface{}:
+			value, exists := v[token]
+			if !exists {
+				return nil, fmt.Errorf("field %s not found", token)
+			}
+			data = value
+		case []interface{}:
+			index, err := getPointerIndex(v, token, false)
+			if err != nil {
+				return nil, err
+			}
+			data = v[index]
+		default:
+			return nil, fmt.Errorf("cannot resolve %s in a value that is not an object or an array", token)
+		}
+	}
+	return data, nil
+}
+
+func addPointerValue(data interface{}, path []string, value interface{}) (interface{}, error) {
+	return setPointerValue(data, path, value, true)
+}
+
+// Sets the value at the path and returns the updated data. Values are inserted into arrays when insert is true.
+func setPointerValue(data interface{}, path []string, value interface{}, insert bool) (interface{}, error) {
+
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updatePointerParent(data, path, func(parent interface{}, token string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			v[token] = value
+			return v, nil
+		case []interface{}:
+			index, err := getPointerIndex(v, token, insert)
+			if err != nil {
+				return v, err
+			}
+			if !insert {
+				v[index] = value
+				return v, nil
+			}
+			v = append(v, nil)
+			copy(v[index+1:], v[index:])
+			v[index] = value
+			return v, nil
+		default:
+			return parent, fmt.Errorf("cannot set %s in a value that is not an object or an array", token)
+		}
+	})
+}
+
+func removePointerValue(data interface{}, path []string) (interface{}, error) {
+
+	if len(path) == 0 {
+		return data, errors.New("the whole resource cannot be removed")
+	}
+	return updatePointerParent(data, path, func(parent interface{}, token string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			if _, exists := v[token]; !exists {
+				return v, fmt.Errorf("field %s not found", token)
+			}
+			delete(v, token)
+			return v, nil
+		case []interface{}:
+			index, err := getPointerIndex(v, token, false)
+			if err != nil {
+				return v, err
+			}
+			return append(v[:index], v[index+1:]...), nil
+		default:
+			return parent, fmt.Errorf("cannot remove %s from a value that is not an object or an array", token)
+		}
+	})
+}
+
+// Calls the update function with the parent of the value at the path and the last token, and returns the data
+// with the updated parent. Arrays are replaced in their parents, since adding or removing elements changes them.
+func updatePointerParent(data interface{}, path []string, update func(interface{}, string) (interface{}, error)) (interface{}, error) {
+
+	if len(path) == 1 {
+		return update(data, path[0])
+	}
+	token := path[0]
+	switch v := data.(type) {
+	case map[string]interface{}:
+		child, exists := v[token]
+		if !exists {
+			return data, fmt.Errorf("field %s not found", token)
+		}
+		updated, err := updatePointerParent(child, path[1:], update)
+		if err != nil {
+			return data, err
+		}
+		v[token] = updated
+		return v, nil
+	case []interface{}:
+		index, err := getPointerIndex(v, token, false)
+		if err != nil {
+			return data, err
+		}
+		updated, err := updatePointerParent(v[index], path[1:], update)
+		if err != nil {
+			return data, err
+		}
+		v[index] = updated
+		return v, nil
+	default:
+		return data, fmt.Errorf("cannot resolve %s in a value that is not an object or an array", token)
+	}
+}
+
+// Resolves an array index given as a number, as "-" for the end of the array when adding, or as [identifier=value].
+func getPointerIndex(array []interface{}, token string, allowEnd bool) (int, error) {
+
+	if strings.HasPrefix(token, "[") {
+		return GetArrayIndex(array, token)
+	}
+	if token == "-" && allowEnd {
+		return len(array), nil
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return -1, fmt.Errorf("invalid array index: %s", token)
+	}
+	if index > len(array) || (index == len(array) && !allowEnd) {
+		return -1, fmt.Errorf("array index %d is out of range", index)
+	}
+	return index, nil
+}
+
+func copyValue(value interface{}) (interface{}, error) {
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var copied interface{}
+	return copied, json.Unmarshal(encoded, &copied)
+}
+
+// Values are compared by their JSON encoding, so that numbers read from YAML and JSON files are equal.
+func jsonEqual(first, second interface{}) bool {
+
+	firstValue, firstErr := copyValue(first)
+	secondValue, secondErr := copyValue(second)
+	return firstErr == nil && secondErr == nil && reflect.DeepEqual(firstValue, secondValue)
+}
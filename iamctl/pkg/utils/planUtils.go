@@ -0,0 +1,276 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type PlanAction string
+
+const (
+	PLAN_CREATE PlanAction = "create"
+	PLAN_UPDATE PlanAction = "update"
+	PLAN_DELETE PlanAction = "delete"
+	PLAN_NO_OP  PlanAction = "no-op"
+)
+
+type PlanEntry struct {
+	ResourceType ResourceType `json:"resourceType"`
+	ResourceName string       `json:"resourceName"`
+	Action       PlanAction   `json:"action"`
+	Reason       string       `json:"reason,omitempty"`
+	// Type and name of the resource that is exported with the planned resource, such as the template type
+	// of an email template. Same as the resource type and name for resources exported on their own.
+	ExportResourceType ResourceType `json:"exportResourceType,omitempty"`
+	ExportResourceName string       `json:"exportResourceName,omitempty"`
+}
+
+// DRY_RUN makes the import flow read only. Resources are resolved and compared against the
+// deployed resources, but only the planned action is recorded instead of sending the request.
+var DRY_RUN bool
+
+var ImportPlan []PlanEntry
+var planLock sync.Mutex
+
+// Reason recorded for planned updates of resources that have the same content as the deployed resources.
+const PLAN_REASON_UNCHANGED = "Same as the deployed resource"
+
+type planResource struct {
+	resourceType ResourceType
+	resourceName string
+}
+
+// Resources whose local files have the same content as the deployed resources. Planned updates of these
+// resources are recorded as no-op.
+var unchangedResources map[planResource]bool
+
+func GetImportAction(exists bool) PlanAction {
+
+	if exists {
+		return PLAN_UPDATE
+	}
+	return PLAN_CREATE
+}
+
+// AddToPlan records the action planned for a resource. Does nothing unless running in dry run mode.
+func AddToPlan(resourceType ResourceType, resourceName string, action PlanAction, reason string) {
+
+	AddSubResourceToPlan(resourceType, resourceName, resourceName, action, reason)
+}
+
+// AddSubResourceToPlan records the action planned for a part of a resource that is exported along with the
+// resource, such as a template of a template type. Does nothing unless running in dry run mode.
+func AddSubResourceToPlan(resourceType ResourceType, exportResourceName, resourceName string, action PlanAction, reason string) {
+
+	if !DRY_RUN {
+		return
+	}
+	entry := PlanEntry{
+		ResourceType:       resourceType,
+		ResourceName:       resourceName,
+		Action:             action,
+		Reason:             reason,
+		ExportResourceType: resourceType,
+		ExportResourceName: exportResourceName,
+	}
+	exportResource := planResource{resourceType, exportResourceName}
+
+	planLock.Lock()
+	if (action == PLAN_CREATE || action == PLAN_DELETE) && unchangedResources[exportResource] {
+		// A part of the resource is created or deleted, even though its local files are the same as the deployed content.
+		delete(unchangedResources, exportResource)
+		for i, planned := range ImportPlan {
+			if planned.exportResource() == exportResource && planned.Reason == PLAN_REASON_UNCHANGED {
+				ImportPlan[i].Action = PLAN_UPDATE
+				ImportPlan[i].Reason = ""
+			}
+		}
+	} else if action == PLAN_UPDATE && unchangedResources[exportResource] {
+		entry.Action = PLAN_NO_OP
+		entry.Reason = PLAN_REASON_UNCHANGED
+	}
+	ImportPlan = append(ImportPlan, entry)
+	planLock.Unlock()
+	PrintLog(LogLevelInfo, resourceType, resourceName, fmt.Sprintf("Dry run. Planned action: %s", entry.Action))
+}
+
+// Returns the resource exported with the planned resource. Plans recorded before the export resource was added to
+// the plan entries are exported by the resource type and name.
+func (e PlanEntry) exportResource() planResource {
+
+	if e.ExportResourceName == "" {
+		return planResource{e.ResourceType, e.ResourceName}
+	}
+	return planResource{e.ExportResourceType, e.ExportResourceName}
+}
+
+// FindUnchangedResources compares the local files of the given resource types, with the keywords replaced and the
+// overlays applied as in an import, with the deployed resources exported with StartDeployedExport. Planned updates of
+// the resources whose local files are all equal to the deployed content are recorded as no-op. Resources with masked
+// secrets are always planned as updates, as the deployed secrets cannot be compared.
+func (s *Session) FindUnchangedResources(resourceTypes []ResourceType, inputDirPath string) {
+
+	unchanged := make(map[planResource]bool)
+	s.processLocalResourceFiles(resourceTypes, inputDirPath, func(file localResourceFile) {
+		resource := planResource{file.resourceType, file.resourceName}
+		isUnchanged, err := s.isFileUnchanged(file)
+		if err != nil {
+			PrintLog(LogLevelDebug, file.resourceType, file.resourceName, fmt.Sprintf("Unable to compare %s with the deployed resource: %s", file.relPath, err))
+		}
+		if previous, exists := unchanged[resource]; exists {
+			isUnchanged = isUnchanged && previous
+		}
+		unchanged[resource] = isUnchanged
+	})
+	planLock.Lock()
+	unchangedResources = unchanged
+	planLock.Unlock()
+}
+
+// Returns true if the resolved content of the local file is equal to the deployed content exported to the same path.
+// Files in a format other than the export format are compared with the exported file of the same name.
+func (s *Session) isFileUnchanged(file localResourceFile) (bool, error) {
+
+	exportedPath := file.filePath
+	exportedFile, exists := ExportedFiles[exportedPath]
+	if !exists {
+		exportedPath = strings.TrimSuffix(file.filePath, filepath.Ext(file.filePath)) + FormatYAML.Extension()
+		exportedFile, exists = ExportedFiles[exportedPath]
+	}
+	if !exists || bytes.Contains(exportedFile.Content, []byte(SENSITIVE_FIELD_MASK_WITHOUT_QUOTES)) {
+		return false, nil
+	}
+	localContent, err := s.resolveFile(file)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := FormatFromExtension(filepath.Ext(file.filePath)); err != nil {
+		return exportedPath == file.filePath && localContent == string(exportedFile.Content), nil
+	}
+	localData, err := deserializeFileContent(file.filePath, []byte(localContent), exportedFile.ResourceType)
+	if err != nil {
+		return false, err
+	}
+	exportedData, err := deserializeFileContent(exportedPath, exportedFile.Content, exportedFile.ResourceType)
+	if err != nil {
+		return false, err
+	}
+	return len(s.DiffData(localData, exportedData, exportedFile.ResourceType)) == 0, nil
+}
+
+// ResetUnchangedResources clears the resources found by FindUnchangedResources.
+func ResetUnchangedResources() {
+
+	planLock.Lock()
+	unchangedResources = nil
+	planLock.Unlock()
+}
+
+// CountPlanActions returns the number of planned entries for each action.
+func CountPlanActions(plan []PlanEntry) map[PlanAction]int {
+
+	counts := make(map[PlanAction]int)
+	for _, entry := range plan {
+		counts[entry.Action]++
+	}
+	return counts
+}
+
+func PrintPlan() {
+
+	fmt.Println("========================================")
+	fmt.Println("Import Plan (dry run)")
+	fmt.Println("========================================")
+	if len(ImportPlan) == 0 {
+		fmt.Println("No changes planned.")
+		fmt.Println("========================================")
+		return
+	}
+
+	var typeOrder []ResourceType
+	entriesByType := make(map[ResourceType][]PlanEntry)
+	for _, entry := range ImportPlan {
+		if _, exists := entriesByType[entry.ResourceType]; !exists {
+			typeOrder = append(typeOrder, entry.ResourceType)
+		}
+		entriesByType[entry.ResourceType] = append(entriesByType[entry.ResourceType], entry)
+	}
+	for _, resourceType := range typeOrder {
+		fmt.Println("----------------------------------------")
+		fmt.Printf("%s\n", resourceType)
+		fmt.Println("----------------------------------------")
+		for _, entry := range entriesByType[resourceType] {
+			printPlanEntry(entry)
+		}
+	}
+
+	counts := CountPlanActions(ImportPlan)
+	if counts[PLAN_DELETE] > 0 {
+		fmt.Println("========================================")
+		fmt.Println("Resources to be deleted (ALLOW_DELETE is enabled)")
+		fmt.Println("========================================")
+		for _, entry := range ImportPlan {
+			if entry.Action == PLAN_DELETE {
+				fmt.Printf("%s: %s\n", entry.ResourceType, entry.ResourceName)
+			}
+		}
+	}
+
+	fmt.Println("========================================")
+	fmt.Printf("Plan: %d to create, %d to update, %d to delete, %d unchanged.\n",
+		counts[PLAN_CREATE], counts[PLAN_UPDATE], counts[PLAN_DELETE], counts[PLAN_NO_OP])
+	fmt.Println("========================================")
+}
+
+func printPlanEntry(entry PlanEntry) {
+
+	symbol := " "
+	switch entry.Action {
+	case PLAN_CREATE:
+		symbol = "+"
+	case PLAN_UPDATE:
+		symbol = "~"
+	case PLAN_DELETE:
+		symbol = "-"
+	case PLAN_NO_OP:
+		symbol = "="
+	}
+	if entry.Reason != "" {
+		fmt.Printf("%s %-6s %s (%s)\n", symbol, entry.Action, entry.ResourceName, entry.Reason)
+	} else {
+		fmt.Printf("%s %-6s %s\n", symbol, entry.Action, entry.ResourceName)
+	}
+}
+
+// checkDryRun guards every request that modifies the server. Planned actions are recorded by the
+// resource packages, so a mutating request reaching here in dry run mode is rejected.
+func checkDryRun(method, reqUrl string) error {
+
+	if DRY_RUN && method != http.MethodGet {
+		return fmt.Errorf("dry run: %s request to %s was not sent", method, reqUrl)
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AUTH_SECRET_PROPERTIES lists the secret properties of each endpoint authentication type.
+var AUTH_SECRET_PROPERTIES = map[string][]string{
+	"NONE":                {},
+	"BASIC":               {"password"},
+	"BEARER":              {"accessToken"},
+	"API_KEY":             {"value"},
+	"CLIENT_CREDENTIAL":   {"clientSecret"},
+	"PASSWORD_CREDENTIAL": {"clientSecret", "password"},
+}
+
+// Paths of the secrets in the request and response payloads of each resource type. A path segment can be a field name,
+// an array element identifier such as [key=password], * to match any field or array element, or ** to match any depth.
+var secretPaths = map[ResourceType][]string{
+	APPLICATIONS: {
+		"inboundProtocolConfiguration.oidc.clientSecret",
+		"**.oauthConsumerSecret",
+	},
+	IDENTITY_PROVIDERS: append([]string{
+		"**.properties.[key=ClientSecret].value",
+	}, authSecretPaths("**.endpoint.authentication.properties")...),
+	ACTIONS: authSecretPaths("endpoint.authentication.properties"),
+	USERSTORES: {
+		"properties.[name=ConnectionPassword].value",
+		"properties.[name=password].value",
+	},
+	EMAIL_PROVIDERS: {
+		"password",
+		"properties.[key=password].value",
+		"properties.[key=clientSecret].value",
+		"properties.[key=accessToken].value",
+		"properties.[key=apiKeyValue].value",
+	},
+	SMS_PROVIDERS: append([]string{"secret"}, authSecretPaths("authentication.properties")...),
+}
+
+var secretPathsLock sync.RWMutex
+
+func authSecretPaths(propertiesPath string) []string {
+
+	paths := []string{}
+	for _, properties := range AUTH_SECRET_PROPERTIES {
+		for _, property := range properties {
+			path := propertiesPath + "." + property
+			if !Contains(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
+// AddSecretPaths adds paths of secrets found at runtime, such as the confidential properties of an authenticator,
+// to be redacted from the logged payloads of the resource type.
+func AddSecretPaths(resourceType ResourceType, paths ...string) {
+
+	secretPathsLock.Lock()
+	defer secretPathsLock.Unlock()
+	for _, path := range paths {
+		if !Contains(secretPaths[resourceType], path) {
+			secretPaths[resourceType] = append(secretPaths[resourceType], path)
+		}
+	}
+}
+
+// RedactPayload masks the secrets in a request or response payload before it is logged. The known secret paths of
+// the resource type and the paths given in the REDACT_PATHS log config are masked, along with any secret resolved
+// for a keyword. The secret paths of all resource types are used for payloads that do not belong to a known resource type.
+func (s *Session) RedactPayload(resourceType ResourceType, payload []byte) string {
+
+	paths := append(getSecretPaths(resourceType), s.ToolConfigs.Logs.RedactPaths...)
+	redacted := RedactPayload(payload, paths)
+	for _, secret := range s.getResolvedSecrets() {
+		redacted = strings.ReplaceAll(redacted, secret, SENSITIVE_FIELD_MASK_WITHOUT_QUOTES)
+	}
+	return redacted
+}
+
+// RedactPayload masks the values at the given paths in a JSON or YAML payload. Payloads in other formats are
+// redacted by the last field name of each path.
+func RedactPayload(payload []byte, paths []string) string {
+
+	if len(payload) == 0 || len(paths) == 0 {
+		return string(payload)
+	}
+	var data interface{}
+	if err := json.Unmarshal(payload, &data); err == nil {
+		for _, path := range paths {
+			data = redactPath(data, GetPathKeys(path))
+		}
+		if redacted, err := json.Marshal(data); err == nil {
+			return string(redacted)
+		}
+	}
+	data = nil
+	if err := yaml.Unmarshal(payload, &data); err == nil {
+		if _, ok := data.(map[string]interface{}); ok {
+			for _, path := range paths {
+				data = redactPath(data, GetPathKeys(path))
+			}
+			if redacted, err := yaml.Marshal(data); err == nil {
+				return string(redacted)
+			}
+		}
+	}
+	return redactFieldNames(string(payload), paths)
+}
+
+func getSecretPaths(resourceType ResourceType) []string {
+
+	secretPathsLock.RLock()
+	defer secretPathsLock.RUnlock()
+	if paths, ok := secretPaths[resourceType]; ok {
+		return append([]string{}, paths...)
+	}
+	allPaths := []string{}
+	for _, paths := range secretPaths {
+		for _, path := range paths {
+			if !Contains(allPaths, path) {
+				allPaths = append(allPaths, path)
+			}
+		}
+	}
+	return allPaths
+}
+
+// Masks the non-null values at the given path. Returns the data with the value replaced if the path ends at it.
+func redactPath(data interface{}, path []string) interface{} {
+
+	if len(path) == 0 {
+		if data == nil {
+			return nil
+		}
+		return SENSITIVE_FIELD_MASK_WITHOUT_QUOTES
+	}
+	key, rest := path[0], path[1:]
+	if key == "**" {
+		data = redactPath(data, rest)
+		switch v := data.(type) {
+		case map[string]interface{}:
+			for k, child := range v {
+				v[k] = redactPath(child, path)
+			}
+		case []interface{}:
+			for i, child := range v {
+				v[i] = redactPath(child, path)
+			}
+		}
+		return data
+	}
+
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if key == "*" {
+			for k, child := range v {
+				v[k] = redactPath(child, rest)
+			}
+		} else if child, exists := v[key]; exists {
+			v[key] = redactPath(child, rest)
+		}
+	case []interface{}:
+		for i, element := range v {
+			if key == "*" || matchesElementIdentifier(element, key) {
+				v[i] = redactPath(element, rest)
+			}
+		}
+	}
+	return data
+}
+
+// Checks whether an array element matches an identifier in the [field=value] format.
+func matchesElementIdentifier(element interface{}, identifier string) bool {
+
+	if !strings.HasPrefix(identifier, "[") || !strings.HasSuffix(identifier, "]") {
+		return false
+	}
+	parts := strings.SplitN(identifier[1:len(identifier)-1], "=", 2)
+	if len(parts) != 2 {
+		return false
+	}
+	elementMap, ok := element.(map[string]interface{})
+	return ok && GetValue(elementMap, parts[0]) == parts[1]
+}
+
+// Masks the values of the fields named by the last segment of each path in a YAML or XML payload. Paths ending in an
+// array element value, such as properties.[key=password].value, are skipped as the field name alone is too broad.
+func redactFieldNames(payload string, paths []string) string {
+
+	for _, path := range paths {
+		keys := GetPathKeys(path)
+		field := keys[len(keys)-1]
+		if field == "*" || field == "**" || strings.HasPrefix(field, "[") ||
+			(len(keys) > 1 && strings.HasPrefix(keys[len(keys)-2], "[")) {
+			continue
+		}
+		quotedField := regexp.QuoteMeta(field)
+		yamlPattern := regexp.MustCompile(`(?m)(^\s*` + quotedField + `:[ \t]*)[^\s#][^\n]*$`)
+		payload = yamlPattern.ReplaceAllString(payload, "${1}"+SENSITIVE_FIELD_MASK_WITHOUT_QUOTES)
+		xmlPattern := regexp.MustCompile(`(<` + quotedField + `>)[^<]*(</` + quotedField + `>)`)
+		payload = xmlPattern.ReplaceAllString(payload, "${1}"+SENSITIVE_FIELD_MASK_WITHOUT_QUOTES+"${2}")
+	}
+	return payload
+}
@@ -0,0 +1,202 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local resource file with the keyword mapping used for it during an import.
+type localResourceFile struct {
+	resourceType   ResourceType
+	keywordType    ResourceType
+	resourceName   string
+	filePath       string
+	relPath        string
+	keywordMapping map[string]interface{}
+}
+
+// RenderResources writes the local resource files of the given resource types to the output directory, with the
+// keywords replaced and the overlays applied as they would be during an import to the environment of the session. The server is not
+// contacted, so the resource types and resources are filtered only with the configs of the environment.
+// Resources with unresolved keywords are reported as failed and are not written.
+func (s *Session) RenderResources(resourceTypes []ResourceType, inputDirPath, outputDirPath string) {
+
+	s.processLocalResourceFiles(resourceTypes, inputDirPath, func(file localResourceFile) {
+		if err := s.renderFile(file, filepath.Join(outputDirPath, file.relPath)); err != nil {
+			UpdateFailureSummaryAndLog(file.resourceType, file.resourceName, RENDER, err, fmt.Sprintf("Error rendering %s: %s", file.relPath, err))
+			return
+		}
+		UpdateSuccessSummaryAndLog(file.resourceType, file.resourceName, RENDER, fmt.Sprintf("Rendered %s", file.relPath))
+	})
+}
+
+// CheckResourceKeywords returns an error for each local resource file of the given resource types that would be
+// imported with unresolved keywords to the environment of the session.
+func (s *Session) CheckResourceKeywords(resourceTypes []ResourceType, inputDirPath string) []error {
+
+	var errs []error
+	s.processLocalResourceFiles(resourceTypes, inputDirPath, func(file localResourceFile) {
+		fileBytes, err := ioutil.ReadFile(file.filePath)
+		if err != nil {
+			return
+		}
+		fileContent := s.ReplaceKeywords(string(fileBytes), file.keywordMapping)
+		if err := CheckUnresolvedKeywords(file.resourceType, file.relPath, fileContent, file.keywordMapping); err != nil {
+			errs = append(errs, err)
+		}
+	})
+	return errs
+}
+
+// Calls the given function for each local file of the given resource types that would be imported to the
+// environment of the session.
+func (s *Session) processLocalResourceFiles(resourceTypes []ResourceType, inputDirPath string, process func(file localResourceFile)) {
+
+	for _, resourceType := range SortResourceTypes(resourceTypes) {
+		if resourceType == BRANDING {
+			s.processLocalResourceTypeFiles(BRANDING_PREFERENCES, inputDirPath, process)
+			s.processLocalResourceTypeFiles(CUSTOM_TEXTS, inputDirPath, process)
+			continue
+		}
+		s.processLocalResourceTypeFiles(resourceType, inputDirPath, process)
+	}
+}
+
+func (s *Session) processLocalResourceTypeFiles(resourceType ResourceType, inputDirPath string, process func(file localResourceFile)) {
+
+	typeDir := GetResourceTypeDir(inputDirPath, resourceType)
+	if s.ShouldSkip(resourceType) {
+		return
+	}
+	if _, err := os.Stat(typeDir); os.IsNotExist(err) {
+		PrintLog(LogLevelDebug, resourceType, "", "No local resources found.")
+		return
+	}
+
+	err := filepath.Walk(typeDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(inputDirPath, filePath)
+		if err != nil {
+			return err
+		}
+		keywordType, resourceName := getRenderedResource(resourceType, typeDir, filePath)
+		if configs := s.ToolConfigs.resourceConfigs(resourceType); configs != nil && IsResourceExcluded(resourceName, *configs) {
+			return nil
+		}
+		keywordMapping := s.GetKeywordMapping(keywordType, resourceName)
+		if !s.MatchesFileFilter(resourceType, filePath, keywordMapping, RENDER) {
+			return nil
+		}
+		process(localResourceFile{
+			resourceType:   resourceType,
+			keywordType:    keywordType,
+			resourceName:   resourceName,
+			filePath:       filePath,
+			relPath:        relPath,
+			keywordMapping: keywordMapping,
+		})
+		return nil
+	})
+	if err != nil {
+		PrintLog(LogLevelError, resourceType, "", fmt.Sprintf("Error reading the resources directory: %s", err))
+		MarkResTypeFailure(resourceType)
+	}
+}
+
+// Returns the resource type and the name used to resolve the keyword mapping of a resource file. Resources are
+// stored either as a file or as a directory under the resource type directory, and are named after it. Claim
+// dialects are named by their dialect URI, and authorized APIs of applications by the application name.
+func getRenderedResource(resourceType ResourceType, typeDir, filePath string) (ResourceType, string) {
+
+	relPath, _ := filepath.Rel(typeDir, filePath)
+	parts := strings.Split(relPath, string(filepath.Separator))
+	if resourceType == APPLICATIONS && len(parts) > 1 && parts[0] == APPLICATION_AUTHORIZED_APIS.String() {
+		return APPLICATION_AUTHORIZED_APIS, GetFileInfo(filePath).ResourceName
+	}
+	if len(parts) > 1 {
+		return resourceType, parts[0]
+	}
+	if resourceType == CLAIMS {
+		if dialectUri, ok := readDialectUri(filePath); ok {
+			return resourceType, dialectUri
+		}
+	}
+	return resourceType, GetFileInfo(filePath).ResourceName
+}
+
+func readDialectUri(filePath string) (string, bool) {
+
+	format, err := FormatFromExtension(filepath.Ext(filePath))
+	if err != nil {
+		return "", false
+	}
+	fileBytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return "", false
+	}
+	var dialect struct {
+		URI string `yaml:"dialectURI" json:"dialectURI"`
+	}
+	if _, err := Deserialize(fileBytes, format, CLAIMS, &dialect); err != nil {
+		return "", false
+	}
+	return dialect.URI, dialect.URI != ""
+}
+
+// Writes the file with the keywords replaced and the overlays applied. The file may contain resolved secrets,
+// so it is only readable by the current user.
+func (s *Session) renderFile(file localResourceFile, outputFilePath string) error {
+
+	fileContent, err := s.resolveFile(file)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(outputFilePath), 0700); err != nil {
+		return fmt.Errorf("error creating the output directory: %w", err)
+	}
+	return ioutil.WriteFile(outputFilePath, []byte(fileContent), 0600)
+}
+
+// Returns the content of the file with the keywords replaced and the overlays applied. Secret masks of
+// applications are removed as in the import.
+func (s *Session) resolveFile(file localResourceFile) (string, error) {
+
+	fileBytes, err := ioutil.ReadFile(file.filePath)
+	if err != nil {
+		return "", fmt.Errorf("error reading the file: %w", err)
+	}
+	fileContent := s.ReplaceKeywords(string(fileBytes), file.keywordMapping)
+	if err := CheckUnresolvedKeywords(file.resourceType, file.relPath, fileContent, file.keywordMapping); err != nil {
+		return "", err
+	}
+	if file.keywordType == APPLICATIONS {
+		fileContent = RemoveSecretMasks(fileContent)
+	}
+	return s.ApplyOverlay(file.keywordType, file.filePath, fileContent)
+}
@@ -0,0 +1,260 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ReportFormat string
+
+const (
+	ReportFormatJSON  ReportFormat = "json"
+	ReportFormatJUnit ReportFormat = "junit"
+)
+
+const (
+	REPORT_STATUS_SUCCESS = "success"
+	REPORT_STATUS_FAILED  = "failed"
+	REPORT_STATUS_SKIPPED = "skipped"
+)
+
+// RunReport is the machine readable summary of an export or import run.
+type RunReport struct {
+	Operation     string               `json:"operation"`
+	RunId         string               `json:"runId"`
+	StartTime     time.Time            `json:"startTime"`
+	DurationMs    int64                `json:"durationMs"`
+	Summary       ReportSummary        `json:"summary"`
+	ResourceTypes []ResourceTypeReport `json:"resourceTypes"`
+	Warnings      []string             `json:"warnings,omitempty"`
+}
+
+type ReportSummary struct {
+	TotalOperations      int `json:"totalOperations"`
+	SuccessfulOperations int `json:"successfulOperations"`
+	FailedOperations     int `json:"failedOperations"`
+	RetriedRequests      int `json:"retriedRequests"`
+}
+
+type ResourceTypeReport struct {
+	ResourceType                ResourceType     `json:"resourceType"`
+	Status                      string           `json:"status"`
+	SkipReason                  string           `json:"skipReason,omitempty"`
+	DurationMs                  int64            `json:"durationMs"`
+	SuccessfulExports           int              `json:"successfulExports"`
+	SuccessfulImports           int              `json:"successfulImports"`
+	SuccessfulUpdates           int              `json:"successfulUpdates"`
+	Deleted                     int              `json:"deleted"`
+	Failed                      int              `json:"failed"`
+	SecretGeneratedApplications []string         `json:"secretGeneratedApplications,omitempty"`
+	Resources                   []ResourceReport `json:"resources"`
+}
+
+type ResourceReport struct {
+	Name       string `json:"name"`
+	Operation  string `json:"operation"`
+	Status     string `json:"status"`
+	DurationMs int64  `json:"durationMs,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ParseReportFormat returns the report format for the given name.
+func ParseReportFormat(format string) (ReportFormat, error) {
+
+	switch ReportFormat(strings.ToLower(format)) {
+	case ReportFormatJSON:
+		return ReportFormatJSON, nil
+	case ReportFormatJUnit:
+		return ReportFormatJUnit, nil
+	default:
+		return "", fmt.Errorf("unsupported report format: %s. Supported formats are json and junit", format)
+	}
+}
+
+// BuildReport creates the report of the run from the summaries collected so far.
+func BuildReport(operation string) RunReport {
+
+	summaryLock.Lock()
+	defer summaryLock.Unlock()
+
+	report := RunReport{
+		Operation: operation,
+		RunId:     RunId,
+		StartTime: StartTime,
+		Summary: ReportSummary{
+			TotalOperations:      AggregatedSummary.TotalRequests,
+			SuccessfulOperations: AggregatedSummary.SuccessfulOperations,
+			FailedOperations:     AggregatedSummary.FailedOperations,
+			RetriedRequests:      AggregatedSummary.RetriedRequests,
+		},
+		ResourceTypes: []ResourceTypeReport{},
+		Warnings:      append([]string(nil), Warnings...),
+	}
+	if !StartTime.IsZero() {
+		report.DurationMs = time.Since(StartTime).Milliseconds()
+	}
+
+	for _, summary := range ResTypeSummaryMap {
+		typeReport := ResourceTypeReport{
+			ResourceType:                summary.ResourceType,
+			Status:                      REPORT_STATUS_SUCCESS,
+			SkipReason:                  summary.SkipReason,
+			DurationMs:                  summary.Duration.Milliseconds(),
+			SuccessfulExports:           summary.SuccessfulExport,
+			SuccessfulImports:           summary.SuccessfulImport,
+			SuccessfulUpdates:           summary.SuccessfulUpdate,
+			Deleted:                     summary.DeletedCount,
+			Failed:                      summary.FailedCount,
+			SecretGeneratedApplications: summary.SecretGeneratedApplications,
+			Resources:                   []ResourceReport{},
+		}
+		if summary.Skipped {
+			typeReport.Status = REPORT_STATUS_SKIPPED
+		} else if summary.Failed || summary.FailedCount > 0 {
+			typeReport.Status = REPORT_STATUS_FAILED
+		}
+		for _, result := range summary.Resources {
+			resourceReport := ResourceReport{
+				Name:       result.ResourceName,
+				Operation:  result.Operation,
+				Status:     REPORT_STATUS_SUCCESS,
+				DurationMs: result.Duration.Milliseconds(),
+				Error:      result.Error,
+			}
+			if result.Error != "" {
+				resourceReport.Status = REPORT_STATUS_FAILED
+			}
+			typeReport.Resources = append(typeReport.Resources, resourceReport)
+		}
+		report.ResourceTypes = append(report.ResourceTypes, typeReport)
+	}
+	sort.Slice(report.ResourceTypes, func(i, j int) bool {
+		return report.ResourceTypes[i].ResourceType < report.ResourceTypes[j].ResourceType
+	})
+	return report
+}
+
+// WriteReport writes the report of the run to the given file in the given format.
+func WriteReport(filePath string, format ReportFormat, operation string) error {
+
+	report := BuildReport(operation)
+	var data []byte
+	var err error
+	if format == ReportFormatJUnit {
+		data, err = report.toJUnit()
+	} else {
+		data, err = json.MarshalIndent(report, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("error serializing report: %w", err)
+	}
+	if err := ioutil.WriteFile(filePath, data, 0600); err != nil {
+		return fmt.Errorf("error writing report: %w", err)
+	}
+	return nil
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+	SystemOut string          `xml:"system-out,omitempty"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// Converts the report to JUnit XML. Each resource type is a test suite and each resource operation is a test case.
+// Skipped resource types and resource types that failed before processing any resource are reported as a single test case.
+func (r RunReport) toJUnit() ([]byte, error) {
+
+	suites := junitTestSuites{Name: "iamctl " + r.Operation, Time: junitTime(r.DurationMs)}
+	for _, typeReport := range r.ResourceTypes {
+		suite := junitTestSuite{Name: typeReport.ResourceType.String(), Time: junitTime(typeReport.DurationMs)}
+		for _, resource := range typeReport.Resources {
+			testCase := junitTestCase{
+				ClassName: suite.Name,
+				Name:      fmt.Sprintf("%s %s", resource.Operation, resource.Name),
+				Time:      junitTime(resource.DurationMs),
+			}
+			if resource.Status == REPORT_STATUS_FAILED {
+				testCase.Failure = &junitMessage{Message: resource.Error}
+				suite.Failures++
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		if typeReport.Status == REPORT_STATUS_SKIPPED {
+			suite.TestCases = append(suite.TestCases, junitTestCase{ClassName: suite.Name, Name: suite.Name,
+				Time: junitTime(0), Skipped: &junitMessage{Message: typeReport.SkipReason}})
+			suite.Skipped++
+		} else if typeReport.Status == REPORT_STATUS_FAILED && suite.Failures == 0 {
+			suite.TestCases = append(suite.TestCases, junitTestCase{ClassName: suite.Name, Name: suite.Name,
+				Time: junitTime(typeReport.DurationMs), Failure: &junitMessage{Message: "Failed to process the resource type"}})
+			suite.Failures++
+		}
+		if len(typeReport.SecretGeneratedApplications) > 0 {
+			suite.SystemOut = "New client secrets generated for: " + strings.Join(typeReport.SecretGeneratedApplications, ", ")
+		}
+		suite.Tests = len(suite.TestCases)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+func junitTime(durationMs int64) string {
+
+	return fmt.Sprintf("%.3f", float64(durationMs)/1000)
+}
@@ -18,32 +18,232 @@
 
 package utils
 
-/*
-* ResourceOrder defines the sequence in which resources should be processed during
-* export and import operations.
-*
-* IMPORTANT: This order is critical because some resources may have dependencies on others.
- */
-var ResourceOrder = []ResourceType{
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ResourceTypes lists the supported resource types. Resource types that do not depend on each other
+// are processed in this order.
+var ResourceTypes = []ResourceType{
 	USERSTORES,
-	CLAIMS,             // Dependency: User Stores
-	IDENTITY_PROVIDERS, // Dependency: Claims, User Stores
+	CLAIMS,
+	IDENTITY_PROVIDERS,
 	API_RESOURCES,
-	APPLICATIONS, // Dependency: Claims, User Stores, Identity Providers, API Resources
+	APPLICATIONS,
 	OIDC_SCOPES,
-	ROLES, // Dependency: Applications
+	ROLES,
 	CHALLENGE_QUESTIONS,
 	EMAIL_TEMPLATES,
 	SMS_TEMPLATES,
 	EMAIL_PROVIDERS,
 	SMS_PROVIDERS,
 	SCRIPT_LIBRARIES,
-	GOVERNANCE_CONNECTORS, // Dependency: Roles
+	GOVERNANCE_CONNECTORS,
 	CERTIFICATES,
-	WORKFLOWS, // Dependency: Roles
+	WORKFLOWS,
 	VALIDATION_RULES,
-	ACTIONS, // Dependency: Applications, Claims
+	ACTIONS,
 	ORGANIZATIONS,
 	BRANDING,
-	FLOWS, // Dependency: Claims, Identity Providers, Governance Connectors
+	FLOWS,
+}
+
+// ResourceDependencies defines the resource types that must be processed before each resource type during
+// export and import operations. References declared in RESOURCE_REFERENCE_METADATA are added as dependencies as well.
+var ResourceDependencies = map[ResourceType][]ResourceType{
+	CLAIMS:                {USERSTORES},
+	IDENTITY_PROVIDERS:    {CLAIMS, USERSTORES},
+	APPLICATIONS:          {CLAIMS, USERSTORES, IDENTITY_PROVIDERS, API_RESOURCES},
+	ROLES:                 {APPLICATIONS},
+	EMAIL_TEMPLATES:       {APPLICATIONS}, // Application specific templates
+	SMS_TEMPLATES:         {APPLICATIONS}, // Application specific templates
+	GOVERNANCE_CONNECTORS: {ROLES},
+	WORKFLOWS:             {ROLES},
+	ACTIONS:               {APPLICATIONS, CLAIMS},
+	FLOWS:                 {CLAIMS, IDENTITY_PROVIDERS, GOVERNANCE_CONNECTORS},
+}
+
+// GetResourceDependencies returns the resource types the given resource type depends on.
+func GetResourceDependencies(resourceType ResourceType) []ResourceType {
+
+	dependencies := append([]ResourceType{}, ResourceDependencies[resourceType]...)
+	for _, reference := range RESOURCE_REFERENCE_METADATA[resourceType] {
+		if !containsResourceType(dependencies, reference.ReferencedResourceType) {
+			dependencies = append(dependencies, reference.ReferencedResourceType)
+		}
+	}
+	return dependencies
+}
+
+// SortResourceTypes orders the given resource types so that each resource type comes after the resource types
+// it depends on. Dependencies that are not in the given list are ignored. Resource types in a dependency cycle
+// are added at the end in their original order.
+func SortResourceTypes(resourceTypes []ResourceType) []ResourceType {
+
+	sorted := make([]ResourceType, 0, len(resourceTypes))
+	added := make(map[ResourceType]bool, len(resourceTypes))
+	for len(sorted) < len(resourceTypes) {
+		progressed := false
+		for _, resourceType := range resourceTypes {
+			if added[resourceType] || !areDependenciesAdded(resourceType, resourceTypes, added) {
+				continue
+			}
+			sorted = append(sorted, resourceType)
+			added[resourceType] = true
+			progressed = true
+			break
+		}
+		if !progressed {
+			var cycle []string
+			for _, resourceType := range resourceTypes {
+				if !added[resourceType] {
+					sorted = append(sorted, resourceType)
+					cycle = append(cycle, resourceType.String())
+				}
+			}
+			PrintLog(LogLevelWarn, UtilsResourceWrapper, "", fmt.Sprintf("Dependency cycle detected. Processing %s in the default order.",
+				strings.Join(cycle, ", ")))
+		}
+	}
+	return sorted
+}
+
+// ErrSessionInUse is returned when resource types are processed while another run is in progress, as the runs
+// would share the state of the package.
+var ErrSessionInUse = errors.New("resource types are already being processed. Sessions cannot be used concurrently")
+
+var processingResourceTypes bool
+var processingLock sync.Mutex
+
+// ProcessResourceTypes calls process for each of the given resource types after the resource types it depends on
+// have been processed. Resource types that do not depend on each other are processed in parallel, up to the
+// configured resource type concurrency. The resources of each resource type are processed in parallel separately,
+// so the concurrency of the resource types is kept apart from the concurrency of the resources.
+// Returns ErrSessionInUse without processing any resource type if another run is in progress.
+func (s *Session) ProcessResourceTypes(resourceTypes []ResourceType, process func(resourceType ResourceType)) error {
+
+	processingLock.Lock()
+	if processingResourceTypes {
+		processingLock.Unlock()
+		return ErrSessionInUse
+	}
+	processingResourceTypes = true
+	processingLock.Unlock()
+	defer func() {
+		processingLock.Lock()
+		processingResourceTypes = false
+		processingLock.Unlock()
+	}()
+
+	sorted := SortResourceTypes(resourceTypes)
+	if s.ToolConfigs.ResourceTypeConcurrency <= 1 {
+		for _, resourceType := range sorted {
+			process(resourceType)
+		}
+		return nil
+	}
+
+	// Each resource type waits only for the dependencies sorted before it, so that a cycle cannot block the run.
+	done := make(map[ResourceType]chan struct{}, len(sorted))
+	for _, resourceType := range sorted {
+		done[resourceType] = make(chan struct{})
+	}
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, s.ToolConfigs.ResourceTypeConcurrency)
+	for i, resourceType := range sorted {
+		var waitFor []chan struct{}
+		for _, dependency := range GetResourceDependencies(resourceType) {
+			if containsResourceType(sorted[:i], dependency) {
+				waitFor = append(waitFor, done[dependency])
+			}
+		}
+		wg.Add(1)
+		go func(resourceType ResourceType, waitFor []chan struct{}) {
+			defer wg.Done()
+			defer close(done[resourceType])
+			for _, dependencyDone := range waitFor {
+				<-dependencyDone
+			}
+			workers <- struct{}{}
+			defer func() { <-workers }()
+			process(resourceType)
+		}(resourceType, waitFor)
+	}
+	wg.Wait()
+	return nil
+}
+
+// WarnExcludedDependencies logs a warning for each of the given resource types that is included while a
+// resource type it depends on is excluded with the INCLUDE_ONLY or EXCLUDE configs.
+func (s *Session) WarnExcludedDependencies(resourceTypes []ResourceType) {
+
+	for _, resourceType := range resourceTypes {
+		if s.isResourceTypeExcluded(resourceType) {
+			continue
+		}
+		for _, dependency := range GetResourceDependencies(resourceType) {
+			if s.isResourceTypeExcluded(dependency) {
+				PrintLog(LogLevelWarn, resourceType, "", fmt.Sprintf("%s included but %s excluded. References to %s may not be resolved.",
+					getDisplayName(resourceType), getDisplayName(dependency), getDisplayName(dependency)))
+			}
+		}
+	}
+}
+
+func areDependenciesAdded(resourceType ResourceType, resourceTypes []ResourceType, added map[ResourceType]bool) bool {
+
+	for _, dependency := range GetResourceDependencies(resourceType) {
+		if containsResourceType(resourceTypes, dependency) && !added[dependency] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsResourceType(resourceTypes []ResourceType, resourceType ResourceType) bool {
+
+	for _, rt := range resourceTypes {
+		if rt == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+// Splits the resource type into words. Ex: GovernanceConnectors -> Governance Connectors
+func getDisplayName(resourceType ResourceType) string {
+
+	var name strings.Builder
+	for i, r := range resourceType.String() {
+		if i > 0 && unicode.IsUpper(r) {
+			name.WriteRune(' ')
+		}
+		name.WriteRune(r)
+	}
+	return name.String()
+}
+
+// ResolveResourceType returns the resource type with the given name, and the resource types it includes.
+// Branding sub types are resolved to the branding resource type, including only the given sub type.
+func ResolveResourceType(name string) (ResourceType, []ResourceType, error) {
+
+	for _, resourceType := range []ResourceType{BRANDING_PREFERENCES, CUSTOM_TEXTS} {
+		if strings.EqualFold(name, resourceType.String()) {
+			return BRANDING, []ResourceType{resourceType}, nil
+		}
+	}
+	for _, resourceType := range ResourceTypes {
+		if !strings.EqualFold(name, resourceType.String()) {
+			continue
+		}
+		if resourceType == BRANDING {
+			return BRANDING, []ResourceType{BRANDING_PREFERENCES, CUSTOM_TEXTS}, nil
+		}
+		return resourceType, []ResourceType{resourceType}, nil
+	}
+	return "", nil, fmt.Errorf("unsupported resource type: %s", name)
 }
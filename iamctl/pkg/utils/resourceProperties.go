@@ -23,7 +23,9 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 )
 
 func (rt ResourceType) String() string {
@@ -32,47 +34,164 @@ func (rt ResourceType) String() string {
 
 func IsResourceExcluded(resourceName string, resourceConfigs map[string]interface{}) bool {
 
+	// Resources excluded from the command line take precedence over the INCLUDE_ONLY and EXCLUDE configs.
+	if excludeOverrides, ok := resourceConfigs[EXCLUDE_OVERRIDES_CONFIG].([]interface{}); ok {
+		if rule, matched := matchResourceRule(resourceName, excludeOverrides); matched {
+			PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Resource %s matches --exclude-resource rule: %s", resourceName, rule))
+			PrintLog(LogLevelInfo, UtilsResourceWrapper, "", fmt.Sprintf("Excluded resource: %s", resourceName))
+			return true
+		}
+	}
+
 	// Include only the resources added to INCLUDE_ONLY config. Note: INCLUDE_ONLY config overrides the EXCLUDE config.
 	includeOnlyResources, ok := resourceConfigs[INCLUDE_ONLY_CONFIG].([]interface{})
 	if ok {
-		for _, resource := range includeOnlyResources {
-			if resource.(string) == resourceName {
-				return false
-			}
+		if _, matched := matchResourceRule(resourceName, includeOnlyResources); matched {
+			return false
 		}
+		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Resource %s does not match any INCLUDE_ONLY rule", resourceName))
 		PrintLog(LogLevelInfo, UtilsResourceWrapper, "", fmt.Sprintf("Excluded resource: %s", resourceName))
 		return true
 	} else {
 		// Exclude resources added to EXCLUDE config.
 		resourcesToExclude, ok := resourceConfigs[EXCLUDE_CONFIG].([]interface{})
 		if ok {
-			for _, resource := range resourcesToExclude {
-				if resource.(string) == resourceName {
-					PrintLog(LogLevelInfo, UtilsResourceWrapper, "", fmt.Sprintf("Excluded resource: %s", resourceName))
-					return true
-				}
+			if rule, matched := matchResourceRule(resourceName, resourcesToExclude); matched {
+				PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("Resource %s matches EXCLUDE rule: %s", resourceName, rule))
+				PrintLog(LogLevelInfo, UtilsResourceWrapper, "", fmt.Sprintf("Excluded resource: %s", resourceName))
+				return true
 			}
 		}
 		return false
 	}
 }
 
-func IsResourceTypeExcluded(resourceType ResourceType) bool {
+// Compiled patterns of the INCLUDE_ONLY and EXCLUDE rules. Rules that are not patterns are stored as nil.
+var rulePatterns sync.Map
+
+// Returns the first rule matching the resource name. A rule can be a resource name, a glob pattern prefixed with
+// "glob:" where * matches any sequence of characters and ? matches a single character, or a regular expression
+// prefixed with "re:". Rules without a prefix are matched as exact names, even if they contain glob characters.
+func matchResourceRule(resourceName string, rules []interface{}) (string, bool) {
+
+	for _, r := range rules {
+		rule, ok := r.(string)
+		if !ok {
+			continue
+		}
+		if rule == resourceName {
+			return rule, true
+		}
+		if pattern := getRulePattern(rule); pattern != nil && pattern.MatchString(resourceName) {
+			return rule, true
+		}
+	}
+	return "", false
+}
+
+func getRulePattern(rule string) *regexp.Regexp {
+
+	if pattern, ok := rulePatterns.Load(rule); ok {
+		return pattern.(*regexp.Regexp)
+	}
+	pattern, _ := compileRulePattern(rule)
+	rulePatterns.Store(rule, pattern)
+	return pattern
+}
+
+// Compiles the glob pattern or regular expression of a rule. Returns nil if the rule is a resource name.
+func compileRulePattern(rule string) (*regexp.Regexp, error) {
+
+	var expression string
+	if strings.HasPrefix(rule, REGEX_RULE_PREFIX) {
+		expression = strings.TrimPrefix(rule, REGEX_RULE_PREFIX)
+	} else if strings.HasPrefix(rule, GLOB_RULE_PREFIX) {
+		expression = regexp.QuoteMeta(strings.TrimPrefix(rule, GLOB_RULE_PREFIX))
+		expression = strings.ReplaceAll(expression, `\*`, ".*")
+		expression = strings.ReplaceAll(expression, `\?`, ".")
+		expression = "^" + expression + "$"
+	}
+	if expression == "" {
+		return nil, nil
+	}
+	return regexp.Compile(expression)
+}
+
+// ExactNameRule returns an INCLUDE_ONLY or EXCLUDE rule that matches only the given resource name.
+func ExactNameRule(resourceName string) string {
+
+	return REGEX_RULE_PREFIX + "^" + regexp.QuoteMeta(resourceName) + "$"
+}
+
+// Validate checks the configs used to select resources, so that an invalid config does not silently include or
+// exclude resources.
+func (t ToolConfigs) Validate() error {
+
+	if err := t.validateResourceRules(); err != nil {
+		return err
+	}
+	return t.validateFilters()
+}
+
+// Checks that the INCLUDE_ONLY and EXCLUDE rules of all resource types are valid.
+func (t ToolConfigs) validateResourceRules() error {
+
+	for _, resourceType := range ResourceTypes {
+		configs := t.resourceConfigs(resourceType)
+		if configs == nil {
+			continue
+		}
+		for _, configName := range []string{INCLUDE_ONLY_CONFIG, EXCLUDE_CONFIG, EXCLUDE_OVERRIDES_CONFIG} {
+			value, exists := (*configs)[configName]
+			if !exists {
+				continue
+			}
+			rules, ok := value.([]interface{})
+			if !ok {
+				return fmt.Errorf("%s config of %s must be a list of resource names or patterns", configName, resourceType)
+			}
+			for _, r := range rules {
+				rule, ok := r.(string)
+				if !ok {
+					return fmt.Errorf("%s config of %s must be a list of resource names or patterns", configName, resourceType)
+				}
+				if _, err := compileRulePattern(rule); err != nil {
+					return fmt.Errorf("invalid %s rule %q of %s: %w", configName, rule, resourceType, err)
+				}
+				if !strings.HasPrefix(rule, REGEX_RULE_PREFIX) && !strings.HasPrefix(rule, GLOB_RULE_PREFIX) &&
+					strings.ContainsAny(rule, "*?[") {
+					PrintLog(LogLevelWarn, resourceType, "", fmt.Sprintf("%s rule %q is matched as an exact resource name. "+
+						"Prefix it with %q to match it as a glob pattern", configName, rule, GLOB_RULE_PREFIX))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Session) IsResourceTypeExcluded(resourceType ResourceType) bool {
+
+	if s.isResourceTypeExcluded(resourceType) {
+		PrintLog(LogLevelInfo, resourceType, "", "Skipping excluded resource type")
+		return true
+	}
+	return false
+}
+
+func (s *Session) isResourceTypeExcluded(resourceType ResourceType) bool {
 
 	// Include only the resource types added to INCLUDE_ONLY config. Note: INCLUDE_ONLY config overrides the EXCLUDE config.
-	if len(TOOL_CONFIGS.IncludeOnly) > 0 {
-		for _, resource := range TOOL_CONFIGS.IncludeOnly {
+	if len(s.ToolConfigs.IncludeOnly) > 0 {
+		for _, resource := range s.ToolConfigs.IncludeOnly {
 			if resource == resourceType.String() {
 				return false
 			}
 		}
-		PrintLog(LogLevelInfo, resourceType, "", "Skipping excluded resource type")
 		return true
-	} else if len(TOOL_CONFIGS.Exclude) > 0 {
+	} else if len(s.ToolConfigs.Exclude) > 0 {
 		// Exclude resource types added to EXCLUDE config.
-		for _, resource := range TOOL_CONFIGS.Exclude {
+		for _, resource := range s.ToolConfigs.Exclude {
 			if resource == resourceType.String() {
-				PrintLog(LogLevelInfo, resourceType, "", "Skipping excluded resource type")
 				return true
 			}
 		}
@@ -80,9 +199,9 @@ func IsResourceTypeExcluded(resourceType ResourceType) bool {
 	return false
 }
 
-func IsEntitySupportedInOrg(resourceType ResourceType) bool {
+func (s *Session) IsEntitySupportedInOrg(resourceType ResourceType) bool {
 
-	if !IsSubOrganization() {
+	if !s.IsSubOrganization() {
 		return true
 	}
 	if entitySupportedInSubOrg[resourceType] {
@@ -92,26 +211,26 @@ func IsEntitySupportedInOrg(resourceType ResourceType) bool {
 	return false
 }
 
-func ShouldSkip(resourceType ResourceType) bool {
+func (s *Session) ShouldSkip(resourceType ResourceType) bool {
 
-	if !IsEntitySupportedInVersion(resourceType) {
-		UpdateSkipSummary(resourceType, "Not supported in server version")
+	if !s.IsEntitySupportedInVersion(resourceType) {
+		UpdateSkipSummary(resourceType, SKIP_REASON_UNSUPPORTED_VERSION)
 		return true
 	}
-	if !IsEntitySupportedInOrg(resourceType) {
-		UpdateSkipSummary(resourceType, "Not supported in sub-organizations")
+	if !s.IsEntitySupportedInOrg(resourceType) {
+		UpdateSkipSummary(resourceType, SKIP_REASON_SUB_ORGANIZATION)
 		return true
 	}
-	if IsResourceTypeExcluded(resourceType) {
-		UpdateSkipSummary(resourceType, "Excluded via tool configs")
+	if s.IsResourceTypeExcluded(resourceType) {
+		UpdateSkipSummary(resourceType, SKIP_REASON_EXCLUDED)
 		return true
 	}
 	return false
 }
 
-func ResolveAdvancedKeywordMapping(resourceName string, resourceConfigs map[string]interface{}) map[string]interface{} {
+func (s *Session) ResolveAdvancedKeywordMapping(resourceName string, resourceConfigs map[string]interface{}) map[string]interface{} {
 
-	defaultKeywordMapping := KEYWORD_CONFIGS.KeywordMappings
+	defaultKeywordMapping := s.KeywordConfigs.KeywordMappings
 
 	// Check if resource specific configs exist for the given resource and if not return the default keyword mappings.
 	if resourceSpecificConfigs, ok := resourceConfigs[resourceName]; ok {
@@ -120,11 +239,11 @@ func ResolveAdvancedKeywordMapping(resourceName string, resourceConfigs map[stri
 
 			mergedKeywordMap := make(map[string]interface{})
 			for key, value := range defaultKeywordMapping {
-				mergedKeywordMap[key] = value.(string)
+				mergedKeywordMap[key] = value
 			}
 			// Override the default keyword mappings with the resource specific keyword mappings.
 			for key, value := range resourceKeywordMap {
-				mergedKeywordMap[key] = value.(string)
+				mergedKeywordMap[key] = value
 			}
 			return mergedKeywordMap
 		}
@@ -132,7 +251,7 @@ func ResolveAdvancedKeywordMapping(resourceName string, resourceConfigs map[stri
 	return defaultKeywordMapping
 }
 
-func AreSecretsExcluded(resourceConfigs map[string]interface{}) bool {
+func (s *Session) AreSecretsExcluded(resourceConfigs map[string]interface{}) bool {
 
 	// Check if secrets are excluded for the given resource type.
 	if secretsExcluded, ok := resourceConfigs[EXCLUDE_SECRETS_CONFIG].(bool); ok {
@@ -140,7 +259,7 @@ func AreSecretsExcluded(resourceConfigs map[string]interface{}) bool {
 	}
 
 	// Check if secrets are excluded for all resources. Note: global config will be overridden by resource level config.
-	return TOOL_CONFIGS.ExcludeSecrets
+	return s.ToolConfigs.ExcludeSecrets
 }
 
 func RemoveDeletedLocalDirectories(parentDir string, deployedDirNames []string) {
@@ -162,6 +281,10 @@ func RemoveDeletedLocalDirectories(parentDir string, deployedDirNames []string)
 		}
 		if _, exists := deployedNames[entry.Name()]; !exists {
 			dirPath := filepath.Join(parentDir, entry.Name())
+			if inMemoryExport {
+				addLocalOnlyFiles(dirPath)
+				continue
+			}
 			if err := os.RemoveAll(dirPath); err != nil {
 				PrintLog(LogLevelError, UtilsResourceWrapper, "", fmt.Sprintf("Error when removing the directory %s: %s", entry.Name(), err))
 			} else {
@@ -186,6 +309,10 @@ func RemoveDeletedLocalResources(filePath string, deployedResourceNames []string
 		}
 		fileName := file.Name()
 		if !Contains(deployedResourceNames, GetFileInfo(fileName).ResourceName) {
+			if inMemoryExport {
+				addLocalOnlyFile(filepath.Join(filePath, fileName))
+				continue
+			}
 			err := os.Remove(filepath.Join(filePath, fileName))
 			if err != nil {
 				PrintLog(LogLevelError, UtilsResourceWrapper, "", fmt.Sprintf("Error when removing the file: %s %s", fileName, err))
@@ -196,6 +323,16 @@ func RemoveDeletedLocalResources(filePath string, deployedResourceNames []string
 	}
 }
 
+// RemoveLocalDirectory removes a local resource directory that no longer has any deployed resources.
+func RemoveLocalDirectory(dirPath string) error {
+
+	if inMemoryExport {
+		addLocalOnlyFiles(dirPath)
+		return nil
+	}
+	return os.RemoveAll(dirPath)
+}
+
 func RemoveSecretMasks(modifiedFileData string) string {
 
 	modifiedFileData = strings.ReplaceAll(modifiedFileData, SENSITIVE_FIELD_MASK, "null")
@@ -21,11 +21,13 @@ package utils
 import (
 	"fmt"
 	"strings"
+	"sync"
 )
 
 type ResourceIdentifierMap map[ResourceType]map[string]string
 
 var resourceIdentifierMap = make(ResourceIdentifierMap)
+var identifierMapLock sync.RWMutex
 
 func ExtractAndRegisterIdentifier(resourceType ResourceType, resourceData interface{}, operation string) {
 
@@ -48,6 +50,8 @@ func ExtractAndRegisterIdentifier(resourceType ResourceType, resourceData interf
 
 func AddToIdentifierMap(resourceType ResourceType, idValue, uniqueValue, operation string) {
 
+	identifierMapLock.Lock()
+	defer identifierMapLock.Unlock()
 	if resourceIdentifierMap[resourceType] == nil {
 		resourceIdentifierMap[resourceType] = make(map[string]string)
 	}
@@ -69,7 +73,7 @@ func ReplaceReferences(resourceType ResourceType, resourceData interface{}) (int
 
 	for _, refData := range references {
 		referencedType := refData.ReferencedResourceType
-		identifierMap := resourceIdentifierMap[referencedType]
+		identifierMap := GetResourceIdentifierMap(referencedType)
 
 		for _, refPath := range refData.ReferencePaths {
 			err := replaceReferenceValue(resourceData, refPath, identifierMap)
@@ -225,10 +229,24 @@ func containsAllItemsWildcard(pathString string) bool {
 
 func ResetResourceIdentifierMap() {
 
+	identifierMapLock.Lock()
+	defer identifierMapLock.Unlock()
 	resourceIdentifierMap = make(ResourceIdentifierMap)
 }
 
+// GetResourceIdentifierMap returns a copy of the identifiers registered for the given resource type,
+// so that it can be read while other resources are being registered.
 func GetResourceIdentifierMap(resourceType ResourceType) map[string]string {
 
-	return resourceIdentifierMap[resourceType]
+	identifierMapLock.RLock()
+	defer identifierMapLock.RUnlock()
+	identifierMap, exists := resourceIdentifierMap[resourceType]
+	if !exists {
+		return nil
+	}
+	identifiers := make(map[string]string, len(identifierMap))
+	for key, value := range identifierMap {
+		identifiers[key] = value
+	}
+	return identifiers
 }
@@ -0,0 +1,166 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+type RetryConfig struct {
+	MaxAttempts int `json:"MAX_ATTEMPTS"`
+	BaseDelayMs int `json:"BASE_DELAY_MS"`
+}
+
+var retryableStatusCodes = map[int]bool{
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+	http.StatusTooManyRequests:    true,
+}
+
+// POST and PATCH requests may already have been applied by the server when the connection drops or a gateway
+// times out, so they are retried only when the server is known not to have processed the request.
+var nonIdempotentRetryableStatusCodes = map[int]bool{
+	http.StatusServiceUnavailable: true,
+	http.StatusTooManyRequests:    true,
+}
+
+// Sends the request and records the time taken for the resource type, retrying transport errors and transient error responses with exponential backoff.
+// POST and PATCH requests are retried only if the server did not process them. Requests with a body that cannot be read again are sent only once.
+func (s *Session) sendWithRetry(req *http.Request, resourceType ResourceType) (*http.Response, error) {
+
+	maxAttempts := s.ToolConfigs.Retry.MaxAttempts
+	for attempt := 1; ; attempt++ {
+		startTime := time.Now()
+		resp, err := s.HttpClient.Do(req)
+		duration := time.Since(startTime)
+		recordRequestTiming(resourceType, duration)
+		if err == nil {
+			PrintHttpLog(resourceType, req, resp.StatusCode, duration)
+		}
+		if attempt >= maxAttempts || !isRetryable(req.Method, resp, err) {
+			return resp, err
+		}
+		nextReq, ok := cloneRequest(req)
+		if !ok {
+			return resp, err
+		}
+
+		var reason string
+		delay := s.retryDelay(attempt)
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = fmt.Sprintf("status code %d", resp.StatusCode)
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+			}
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		PrintLog(LogLevelInfo, UtilsResourceWrapper, "", fmt.Sprintf("%s %s failed with %s. Retrying in %s (attempt %d of %d).",
+			req.Method, req.URL.Path, reason, delay.Round(time.Millisecond), attempt+1, maxAttempts))
+		UpdateRetrySummary()
+		time.Sleep(delay)
+		req = nextReq
+	}
+}
+
+func isRetryable(method string, resp *http.Response, err error) bool {
+
+	if method == http.MethodPost || method == http.MethodPatch {
+		if err == nil {
+			return nonIdempotentRetryableStatusCodes[resp.StatusCode]
+		}
+		return errors.Is(err, syscall.ECONNREFUSED)
+	}
+	if err == nil {
+		return retryableStatusCodes[resp.StatusCode]
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// Returns the exponential backoff delay for the given attempt with a random jitter of up to half the delay.
+func (s *Session) retryDelay(attempt int) time.Duration {
+
+	baseDelay := time.Duration(s.ToolConfigs.Retry.BaseDelayMs) * time.Millisecond
+	if baseDelay <= 0 {
+		return 0
+	}
+	delay := MAX_RETRY_DELAY
+	if attempt <= 16 && baseDelay<<uint(attempt-1) < MAX_RETRY_DELAY {
+		delay = baseDelay << uint(attempt-1)
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// Parses the Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+
+	if value == "" {
+		return 0, false
+	}
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = time.Until(date)
+	} else {
+		return 0, false
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > MAX_RETRY_AFTER {
+		delay = MAX_RETRY_AFTER
+	}
+	return delay, true
+}
+
+// Returns a copy of the request that can be sent again.
+func cloneRequest(req *http.Request) (*http.Request, bool) {
+
+	clone := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return clone, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	clone.Body = body
+	return clone, true
+}
@@ -0,0 +1,469 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SecretResolver returns the secret for a reference, such as the name of an environment variable or a file path.
+type SecretResolver func(reference string) (string, error)
+
+// Matches secret references in keyword values, such as {{secret:env:APP_SECRET}} or {{secret:file:/run/secrets/x}}.
+var secretReferencePattern = regexp.MustCompile(`\{\{secret:([A-Za-z0-9_-]+):([^}]+)\}\}`)
+
+var secretResolvers = map[string]SecretResolver{
+	"env":  resolveEnvSecret,
+	"file": resolveFileSecret,
+}
+var secretResolversLock sync.RWMutex
+
+// RegisterSecretResolver adds a secret provider that can be used in keyword values as {{secret:<provider>:<reference>}}.
+func RegisterSecretResolver(provider string, resolver SecretResolver) {
+
+	secretResolversLock.Lock()
+	defer secretResolversLock.Unlock()
+	secretResolvers[provider] = resolver
+}
+
+// ResolveSecrets replaces the secret references in a keyword value with the secrets from their providers.
+func (s *Session) ResolveSecrets(value string) (string, error) {
+
+	var resolveErr error
+	resolved := secretReferencePattern.ReplaceAllStringFunc(value, func(reference string) string {
+		if resolveErr != nil {
+			return reference
+		}
+		parts := secretReferencePattern.FindStringSubmatch(reference)
+		secret, err := s.resolveSecret(parts[1], strings.TrimSpace(parts[2]))
+		if err != nil {
+			resolveErr = err
+			return reference
+		}
+		return secret
+	})
+	if resolveErr != nil {
+		return value, resolveErr
+	}
+	return resolved, nil
+}
+
+// Resolved secrets are cached in the session, keyed by the secret reference, so that each secret is only
+// read once in a run.
+func (s *Session) resolveSecret(provider, reference string) (string, error) {
+
+	s.secretLock.Lock()
+	defer s.secretLock.Unlock()
+	key := provider + ":" + reference
+	if secret, ok := s.resolvedSecrets[key]; ok {
+		return secret, nil
+	}
+	resolver, ok := s.getSecretResolver(provider)
+	if !ok {
+		return "", fmt.Errorf("unknown secret provider: %s", provider)
+	}
+	secret, err := resolver(reference)
+	if err != nil {
+		return "", fmt.Errorf("error resolving secret %s from %s: %w", reference, provider, err)
+	}
+	if s.resolvedSecrets == nil {
+		s.resolvedSecrets = make(map[string]string)
+	}
+	s.resolvedSecrets[key] = secret
+	return secret, nil
+}
+
+// The exec provider runs the command configured for the session, while the other providers are shared by all sessions.
+func (s *Session) getSecretResolver(provider string) (SecretResolver, bool) {
+
+	if provider == "exec" {
+		return s.resolveExecSecret, true
+	}
+	secretResolversLock.RLock()
+	defer secretResolversLock.RUnlock()
+	resolver, ok := secretResolvers[provider]
+	return resolver, ok
+}
+
+// Returns the secrets resolved in the session, so that they can be masked wherever they appear in the logs.
+func (s *Session) getResolvedSecrets() []string {
+
+	s.secretLock.Lock()
+	defer s.secretLock.Unlock()
+	secrets := make([]string, 0, len(s.resolvedSecrets))
+	for _, secret := range s.resolvedSecrets {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets
+}
+
+func resolveEnvSecret(name string) (string, error) {
+
+	secret, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return secret, nil
+}
+
+func resolveFileSecret(path string) (string, error) {
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// Runs the command configured in the keyword configs of the session, with the secret reference appended as the
+// last argument, and returns its output. Called with the secret lock held.
+func (s *Session) resolveExecSecret(reference string) (string, error) {
+
+	secretExecCommand := s.KeywordConfigs.SecretExecCommand
+	if len(secretExecCommand) == 0 {
+		return "", fmt.Errorf("SECRET_EXEC_COMMAND is not configured")
+	}
+	args := append(append([]string{}, secretExecCommand[1:]...), reference)
+	cmd := exec.Command(secretExecCommand[0], args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("command %s failed: %v %s", secretExecCommand[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimRight(string(output), "\r\n"), nil
+}
+
+// Written in place of a resolved secret until the secret is substituted into the parsed file content.
+const secretTokenFormat = "__IAMCTL_SECRET_%d__"
+
+// Substitutes the secrets for their tokens in the string values of the JSON or YAML content, so that the secrets
+// are escaped as required by the format of the file. Only the values with secrets are changed, and the rest of the
+// content is kept as it is written. Content that is not a JSON or YAML object or list, such as a single value, is
+// substituted as text. Content with unresolved keywords is also substituted as text, so that the unresolved
+// placeholders are reported as they are written in the file.
+func substituteSecrets(fileContent string, secrets map[string]string) string {
+
+	if hasKeywordPlaceholders(fileContent) {
+		return replaceSecretTokens(fileContent, secrets)
+	}
+	var substituted string
+	trimmedContent := strings.TrimSpace(fileContent)
+	if (strings.HasPrefix(trimmedContent, "{") || strings.HasPrefix(trimmedContent, "[")) && json.Valid([]byte(trimmedContent)) {
+		substituted = substituteJSONSecrets(fileContent, secrets)
+	} else {
+		substituted = substituteYAMLSecrets(fileContent, secrets)
+	}
+	if substituted == "" || containsSecretToken(substituted, secrets) {
+		substituted = replaceSecretTokens(fileContent, secrets)
+	}
+	return substituted
+}
+
+// Tokens can only be in the strings of valid JSON content, so each token is replaced with its secret escaped as a
+// JSON string.
+func substituteJSONSecrets(fileContent string, secrets map[string]string) string {
+
+	escapedSecrets := make(map[string]string, len(secrets))
+	for token, secret := range secrets {
+		literal, err := marshalJSONString(secret)
+		if err != nil {
+			return ""
+		}
+		escapedSecrets[token] = literal[1 : len(literal)-1]
+	}
+	substituted := replaceSecretTokens(fileContent, escapedSecrets)
+	if !json.Valid([]byte(substituted)) {
+		return ""
+	}
+	return substituted
+}
+
+func marshalJSONString(value string) (string, error) {
+
+	var output bytes.Buffer
+	encoder := json.NewEncoder(&output)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(output.String(), "\n"), nil
+}
+
+func substituteYAMLSecrets(fileContent string, secrets map[string]string) string {
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(fileContent), &document); err != nil || len(document.Content) == 0 {
+		return ""
+	}
+	if kind := document.Content[0].Kind; kind != yaml.MappingNode && kind != yaml.SequenceNode {
+		return ""
+	}
+	if substituted, ok := substituteYAMLSecretsInPlace(fileContent, &document, secrets); ok {
+		return substituted
+	}
+
+	// The values with secrets could not be changed in place, so the whole document is written again.
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind == yaml.ScalarNode {
+			node.Value = replaceSecretTokens(node.Value, secrets)
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(&document)
+
+	output, err := yaml.Marshal(&document)
+	if err != nil {
+		return ""
+	}
+	return string(output)
+}
+
+// A change to the file content, replacing the text from start to end.
+type textEdit struct {
+	start, end  int
+	replacement string
+}
+
+// Characters of a secret that can be written in a plain YAML value as they are.
+var plainYAMLSecretPattern = regexp.MustCompile(`^[A-Za-z0-9_./+=~-]*$`)
+
+// Replaces the tokens in the YAML content with their secrets, escaped as required by the style of the value they are
+// in. Plain values that cannot hold a secret as it is are written as double quoted values. Returns false if a value
+// cannot be changed in place, or if the changed content does not have the expected values.
+func substituteYAMLSecretsInPlace(fileContent string, document *yaml.Node, secrets map[string]string) (string, bool) {
+
+	tokens := make([]string, 0, len(secrets))
+	for token := range secrets {
+		tokens = append(tokens, regexp.QuoteMeta(token))
+	}
+	occurrences := regexp.MustCompile(strings.Join(tokens, "|")).FindAllStringIndex(fileContent, -1)
+
+	// Scalars are visited in the order they are written, so the tokens found in the content belong to the scalars
+	// with tokens in the same order. Tokens outside the values, such as in comments, are not supported.
+	var edits []textEdit
+	next := 0
+	ok := true
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if !ok {
+			return
+		}
+		if node.Kind == yaml.ScalarNode {
+			count := countSecretTokens(node.Value, secrets)
+			if count == 0 {
+				return
+			}
+			if next+count > len(occurrences) {
+				ok = false
+				return
+			}
+			var scalarEdits []textEdit
+			scalarEdits, ok = getYAMLScalarEdits(fileContent, node, occurrences[next:next+count], secrets)
+			edits = append(edits, scalarEdits...)
+			next += count
+			return
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(document)
+	if !ok || next != len(occurrences) {
+		return "", false
+	}
+
+	substituted := fileContent
+	for i := len(edits) - 1; i >= 0; i-- {
+		substituted = substituted[:edits[i].start] + edits[i].replacement + substituted[edits[i].end:]
+	}
+	var substitutedDocument yaml.Node
+	if err := yaml.Unmarshal([]byte(substituted), &substitutedDocument); err != nil {
+		return "", false
+	}
+	return substituted, hasSubstitutedValues(document, &substitutedDocument, secrets)
+}
+
+// Returns the changes to replace the given token occurrences of a scalar with their secrets.
+func getYAMLScalarEdits(fileContent string, node *yaml.Node, occurrences [][]int, secrets map[string]string) ([]textEdit, bool) {
+
+	value := replaceSecretTokens(node.Value, secrets)
+	var escape func(secret string, start int) (string, bool)
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		escape = func(secret string, start int) (string, bool) {
+			literal, err := marshalJSONString(secret)
+			return strings.TrimSuffix(strings.TrimPrefix(literal, `"`), `"`), err == nil
+		}
+	case yaml.SingleQuotedStyle:
+		if strings.ContainsAny(value, "\r\n") {
+			return requoteYAMLScalar(fileContent, node, "'"+strings.ReplaceAll(node.Value, "'", "''")+"'", value)
+		}
+		escape = func(secret string, start int) (string, bool) {
+			return strings.ReplaceAll(secret, "'", "''"), true
+		}
+	case yaml.LiteralStyle:
+		escape = func(secret string, start int) (string, bool) {
+			linePrefix := fileContent[strings.LastIndex(fileContent[:start], "\n")+1 : start]
+			indent := linePrefix[:len(linePrefix)-len(strings.TrimLeft(linePrefix, " "))]
+			return strings.ReplaceAll(secret, "\n", "\n"+indent), !strings.Contains(secret, "\r")
+		}
+	case yaml.FoldedStyle:
+		escape = func(secret string, start int) (string, bool) {
+			return secret, !strings.ContainsAny(secret, "\r\n")
+		}
+	default:
+		if !isPlainYAMLValue(node.Value, value, secrets) {
+			return requoteYAMLScalar(fileContent, node, node.Value, value)
+		}
+		escape = func(secret string, start int) (string, bool) {
+			return secret, true
+		}
+	}
+
+	edits := make([]textEdit, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		replacement, ok := escape(secrets[fileContent[occurrence[0]:occurrence[1]]], occurrence[0])
+		if !ok {
+			return nil, false
+		}
+		edits = append(edits, textEdit{start: occurrence[0], end: occurrence[1], replacement: replacement})
+	}
+	return edits, true
+}
+
+// Returns true if the secrets of the tokens in the value can be written in a plain YAML value, and the value with
+// the secrets is still read as the same string.
+func isPlainYAMLValue(tokenValue, value string, secrets map[string]string) bool {
+
+	for token, secret := range secrets {
+		if strings.Contains(tokenValue, token) && !plainYAMLSecretPattern.MatchString(secret) {
+			return false
+		}
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &node); err != nil || len(node.Content) == 0 {
+		return false
+	}
+	scalar := node.Content[0]
+	return scalar.Kind == yaml.ScalarNode && scalar.Style == 0 && scalar.Value == value && scalar.ShortTag() == "!!str"
+}
+
+// Returns the change to write a single line scalar as a double quoted value, so that the secrets in it are escaped.
+func requoteYAMLScalar(fileContent string, node *yaml.Node, rawValue, value string) ([]textEdit, bool) {
+
+	lineStart := 0
+	for line := 1; line < node.Line; line++ {
+		next := strings.Index(fileContent[lineStart:], "\n")
+		if next < 0 {
+			return nil, false
+		}
+		lineStart += next + 1
+	}
+	lineEnd := strings.Index(fileContent[lineStart:], "\n")
+	if lineEnd < 0 {
+		lineEnd = len(fileContent) - lineStart
+	}
+	line := fileContent[lineStart : lineStart+lineEnd]
+	if node.Column-1 > len([]rune(line)) {
+		return nil, false
+	}
+	column := len(string([]rune(line)[:node.Column-1]))
+	start := strings.Index(line[column:], rawValue)
+	if start < 0 {
+		return nil, false
+	}
+	literal, err := marshalJSONString(value)
+	if err != nil {
+		return nil, false
+	}
+	start += lineStart + column
+	return []textEdit{{start: start, end: start + len(rawValue), replacement: literal}}, true
+}
+
+// Returns true if the substituted document has the same structure as the document, with the secrets in place of
+// their tokens.
+func hasSubstitutedValues(node, substituted *yaml.Node, secrets map[string]string) bool {
+
+	if node.Kind != substituted.Kind || len(node.Content) != len(substituted.Content) {
+		return false
+	}
+	if node.Kind == yaml.ScalarNode &&
+		(substituted.Value != replaceSecretTokens(node.Value, secrets) || substituted.ShortTag() != node.ShortTag()) {
+		return false
+	}
+	for i := range node.Content {
+		if !hasSubstitutedValues(node.Content[i], substituted.Content[i], secrets) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasKeywordPlaceholders(fileContent string) bool {
+
+	for _, match := range keywordPlaceholderPattern.FindAllStringSubmatch(fileContent, -1) {
+		if keywordNamePattern.MatchString(match[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+func replaceSecretTokens(value string, secrets map[string]string) string {
+
+	for token, secret := range secrets {
+		value = strings.ReplaceAll(value, token, secret)
+	}
+	return value
+}
+
+func countSecretTokens(value string, secrets map[string]string) int {
+
+	count := 0
+	for token := range secrets {
+		count += strings.Count(value, token)
+	}
+	return count
+}
+
+func containsSecretToken(value string, secrets map[string]string) bool {
+
+	for token := range secrets {
+		if strings.Contains(value, token) {
+			return true
+		}
+	}
+	return false
+}
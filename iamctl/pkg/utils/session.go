@@ -0,0 +1,280 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Session holds the configs, the access token and the HTTP client used to manage a single target environment.
+//
+// The state of a run is not part of the session yet. The logger, the run summary, the dry run mode and plan, the in
+// memory export and the identifiers of the processed resources are kept in package state shared by all sessions.
+// So sessions can be used one after the other, such as to compare two environments, but two environments cannot be
+// processed at the same time. ProcessResourceTypes returns ErrSessionInUse if resource types are already being
+// processed. The logging configs of a session are only applied by LoadSession, LoadOfflineSession and
+// ApplyLogConfigs.
+type Session struct {
+	Name              string
+	BaseDir           string
+	KeywordConfigPath string
+	OverlayDir        string
+	ServerConfigs     ServerConfigs
+	ToolConfigs       ToolConfigs
+	KeywordConfigs    KeywordConfigs
+	HttpClient        *http.Client
+
+	// Flags to indicate the presence of resource-specific APIs
+	RolesV2ApiExists               bool
+	NotificationTemplatesApiExists bool
+
+	tokenLock        sync.Mutex
+	tokenRefreshTime time.Time
+
+	secretLock      sync.Mutex
+	resolvedSecrets map[string]string
+}
+
+func NewSession(serverConfigs ServerConfigs, toolConfigs ToolConfigs, keywordConfigs KeywordConfigs) (*Session, error) {
+
+	httpClient, err := newHttpClient(serverConfigs)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{
+		Name:           serverConfigs.ServerUrl,
+		ServerConfigs:  serverConfigs,
+		ToolConfigs:    toolConfigs,
+		KeywordConfigs: keywordConfigs,
+		HttpClient:     httpClient,
+	}, nil
+}
+
+// LoadSession loads the configs of an environment from the given config folder, or from the environment
+// variables if the path is empty, applies its logging configs and gets an access token for it.
+func LoadSession(envConfigPath string) *Session {
+
+	session := LoadOfflineSession(envConfigPath)
+	session.loadAccessToken()
+	return session
+}
+
+// LoadSessionKeepingLogs loads a session like LoadSession, but keeps the logging configs applied before, such as
+// those of another session loaded for the same run.
+func LoadSessionKeepingLogs(envConfigPath string) *Session {
+
+	session := loadOfflineSession(envConfigPath)
+	session.loadAccessToken()
+	return session
+}
+
+// LoadOfflineSession loads the configs of an environment like LoadSession, without contacting the server.
+// The session cannot be used to send requests.
+func LoadOfflineSession(envConfigPath string) *Session {
+
+	session := loadOfflineSession(envConfigPath)
+	if err := session.ApplyLogConfigs(); err != nil {
+		ExitWithCode(EXIT_CODE_CONFIG_ERROR, "ERROR: Utils -", err)
+	}
+	return session
+}
+
+// ApplyLogConfigs sets the log level, the log format and the log file of the process to the logging configs of
+// the session. The logging configs are shared by all sessions, so they are replaced for the other sessions too.
+func (s *Session) ApplyLogConfigs() error {
+
+	if err := ConfigureLogs(s.ToolConfigs.Logs); err != nil {
+		return err
+	}
+	CURRENT_LOG_LEVEL = resolveLogLevel(s.ToolConfigs.Logs.LogLevel)
+	return nil
+}
+
+func (s *Session) loadAccessToken() {
+
+	if err := s.authenticate(); err != nil {
+		ExitWithCode(EXIT_CODE_AUTH_FAILURE, "ERROR: Utils -", err)
+	}
+	PrintLog(LogLevelInfo, UtilsResourceWrapper, "", "Access Token received successfully.")
+}
+
+func loadOfflineSession(envConfigPath string) *Session {
+
+	baseDir, serverConfigs, toolConfigPath, keywordConfigPath := loadServerConfigs(envConfigPath)
+	toolConfigs := loadToolConfigsFromFile(toolConfigPath)
+	keywordConfigs := loadKeywordConfigsFromFile(keywordConfigPath)
+
+	session, err := NewSession(serverConfigs, toolConfigs, keywordConfigs)
+	if err != nil {
+		ExitWithCode(EXIT_CODE_CONFIG_ERROR, "ERROR: Utils -", err)
+	}
+	session.BaseDir = baseDir
+	session.KeywordConfigPath = keywordConfigPath
+	if envConfigPath != "" {
+		session.Name = filepath.Base(envConfigPath)
+		session.OverlayDir = filepath.Join(envConfigPath, OVERLAYS_DIR)
+	} else if toolConfigPath != "" {
+		session.OverlayDir = filepath.Join(filepath.Dir(toolConfigPath), OVERLAYS_DIR)
+	}
+	return session
+}
+
+// Gets a new access token and records when it should be refreshed. The refresh time is not set when the
+// token response does not specify an expiry.
+func (s *Session) authenticate() error {
+
+	response, err := s.getAccessToken()
+	if err != nil {
+		return err
+	}
+	s.ServerConfigs.Token = response.AccessToken
+	s.tokenRefreshTime = time.Time{}
+	if response.Expires > 0 {
+		lifetime := time.Duration(response.Expires) * time.Second
+		margin := TOKEN_REFRESH_MARGIN
+		if margin > lifetime/2 {
+			margin = lifetime / 2
+		}
+		s.tokenRefreshTime = time.Now().Add(lifetime - margin)
+	}
+	return nil
+}
+
+// Returns the current access token, refreshing it first if it is about to expire.
+func (s *Session) getToken() string {
+
+	s.tokenLock.Lock()
+	defer s.tokenLock.Unlock()
+
+	if !s.tokenRefreshTime.IsZero() && time.Now().After(s.tokenRefreshTime) {
+		PrintLog(LogLevelInfo, UtilsResourceWrapper, "", "Access token is about to expire. Refreshing the access token.")
+		if err := s.authenticate(); err != nil {
+			PrintLog(LogLevelError, UtilsResourceWrapper, "", fmt.Sprintf("Error refreshing the access token: %s", err))
+		}
+	}
+	return s.ServerConfigs.Token
+}
+
+// Refreshes the access token after it was rejected by the server. If another request has already
+// replaced the rejected token, the new token is returned without authenticating again.
+func (s *Session) refreshRejectedToken(rejectedToken string) (string, error) {
+
+	s.tokenLock.Lock()
+	defer s.tokenLock.Unlock()
+
+	if s.ServerConfigs.Token != rejectedToken {
+		return s.ServerConfigs.Token, nil
+	}
+	if err := s.authenticate(); err != nil {
+		return "", err
+	}
+	return s.ServerConfigs.Token, nil
+}
+
+// Sends the request with the access token of the session and a new correlation ID. If the token is rejected
+// with a 401 response, the request is sent once more after re-authenticating.
+func (s *Session) doRequest(req *http.Request, resourceType ResourceType) (*http.Response, error) {
+
+	correlationId := NewCorrelationId()
+	req.Header.Set(ACTIVITY_ID_HEADER, correlationId)
+	req.Header.Set(CORRELATION_ID_HEADER, correlationId)
+	token := s.getToken()
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := s.sendWithRetry(req, resourceType)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	retryReq, ok := cloneRequest(req)
+	if !ok {
+		// The request body cannot be sent again.
+		return resp, nil
+	}
+
+	PrintLog(LogLevelInfo, UtilsResourceWrapper, "", "Access token was rejected. Re-authenticating and retrying the request.")
+	newToken, err := s.refreshRejectedToken(token)
+	if err != nil {
+		PrintLog(LogLevelError, UtilsResourceWrapper, "", fmt.Sprintf("Error refreshing the access token: %s", err))
+		return resp, nil
+	}
+	retryReq.Header.Set("Authorization", "Bearer "+newToken)
+
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	return s.sendWithRetry(retryReq, resourceType)
+}
+
+func newHttpClient(serverConfigs ServerConfigs) (*http.Client, error) {
+
+	tlsConfig, err := newTLSConfig(serverConfigs)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}, nil
+}
+
+// Builds the TLS config of the server connection. Server certificates are verified against the system CAs
+// and the configured CA bundle unless verification is explicitly disabled for local development.
+func newTLSConfig(serverConfigs ServerConfigs) (*tls.Config, error) {
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if serverConfigs.InsecureSkipVerify {
+		PrintLog(LogLevelWarn, UtilsResourceWrapper, "", "TLS certificate verification is disabled. Do not use INSECURE_SKIP_VERIFY outside local development.")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if serverConfigs.CaCertPath != "" {
+		caCert, err := ioutil.ReadFile(serverConfigs.CaCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the CA certificate file: %w", err)
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no valid PEM certificates found in the CA certificate file: %s", serverConfigs.CaCertPath)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if serverConfigs.ClientCertPath != "" || serverConfigs.ClientKeyPath != "" {
+		if serverConfigs.ClientCertPath == "" || serverConfigs.ClientKeyPath == "" {
+			return nil, fmt.Errorf("both %s and %s are required for mutual TLS", CLIENT_CERT_PATH_CONFIG, CLIENT_KEY_PATH_CONFIG)
+		}
+		clientCert, err := tls.LoadX509KeyPair(serverConfigs.ClientCertPath, serverConfigs.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("error loading the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsConfig, nil
+}
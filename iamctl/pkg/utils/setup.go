@@ -20,21 +20,25 @@ package utils
 
 import (
 	"bytes"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
 type LogsConfig struct {
-	LogLevel           string `json:"LOG_LEVEL"`
-	LogRequestPayloads bool   `json:"LOG_REQUEST_PAYLOADS"`
+	LogLevel           string   `json:"LOG_LEVEL"`
+	LogRequestPayloads bool     `json:"LOG_REQUEST_PAYLOADS"`
+	RedactPaths        []string `json:"REDACT_PATHS"`
+	Format             string   `json:"FORMAT"`
+	File               string   `json:"FILE"`
+	MaxFileSizeMB      int      `json:"MAX_FILE_SIZE_MB"`
+	MaxBackups         int      `json:"MAX_BACKUPS"`
 }
 
 type oAuthResponse struct {
@@ -53,6 +57,11 @@ type ServerConfigs struct {
 	Organization  string `json:"ORGANIZATION"`
 	Token         string `json:"TOKEN"`
 	ServerVersion string `json:"SERVER_VERSION"`
+
+	InsecureSkipVerify bool   `json:"INSECURE_SKIP_VERIFY"`
+	CaCertPath         string `json:"CA_CERT_PATH"`
+	ClientCertPath     string `json:"CLIENT_CERT_PATH"`
+	ClientKeyPath      string `json:"CLIENT_KEY_PATH"`
 }
 
 type ToolConfigs struct {
@@ -83,6 +92,172 @@ type ToolConfigs struct {
 	CustomTextConfigs          map[string]interface{} `json:"CUSTOM_TEXTS"`
 	FlowConfigs                map[string]interface{} `json:"FLOWS"`
 	Logs                       LogsConfig             `json:"LOGS"`
+	Retry                      RetryConfig            `json:"RETRY"`
+	Concurrency                int                    `json:"CONCURRENCY"`
+	ResourceTypeConcurrency    int                    `json:"RESOURCE_TYPE_CONCURRENCY"`
+}
+
+// Returns the field holding the configs of the given resource type.
+func (t *ToolConfigs) resourceConfigs(resourceType ResourceType) *map[string]interface{} {
+
+	switch resourceType {
+	case APPLICATIONS:
+		return &t.ApplicationConfigs
+	case IDENTITY_PROVIDERS:
+		return &t.IdpConfigs
+	case CLAIMS:
+		return &t.ClaimConfigs
+	case USERSTORES:
+		return &t.UserStoreConfigs
+	case OIDC_SCOPES:
+		return &t.OidcScopeConfigs
+	case ROLES:
+		return &t.RoleConfigs
+	case CHALLENGE_QUESTIONS:
+		return &t.ChallengeQuestionConfigs
+	case EMAIL_TEMPLATES:
+		return &t.EmailTemplateConfigs
+	case SCRIPT_LIBRARIES:
+		return &t.ScriptLibraryConfigs
+	case GOVERNANCE_CONNECTORS:
+		return &t.GovernanceConnectorConfigs
+	case CERTIFICATES:
+		return &t.CertificateConfigs
+	case WORKFLOWS:
+		return &t.WorkflowConfigs
+	case API_RESOURCES:
+		return &t.ApiResourceConfigs
+	case VALIDATION_RULES:
+		return &t.ValidationRuleConfigs
+	case EMAIL_PROVIDERS:
+		return &t.EmailProviderConfigs
+	case SMS_PROVIDERS:
+		return &t.SmsProviderConfigs
+	case SMS_TEMPLATES:
+		return &t.SmsTemplateConfigs
+	case ACTIONS:
+		return &t.ActionConfigs
+	case ORGANIZATIONS:
+		return &t.OrganizationConfigs
+	case BRANDING_PREFERENCES:
+		return &t.BrandingPreferenceConfigs
+	case CUSTOM_TEXTS:
+		return &t.CustomTextConfigs
+	case FLOWS:
+		return &t.FlowConfigs
+	}
+	return nil
+}
+
+// Returns the keyword configs of the given resource type.
+func (k KeywordConfigs) resourceConfigs(resourceType ResourceType) map[string]interface{} {
+
+	switch resourceType {
+	case APPLICATIONS, APPLICATION_AUTHORIZED_APIS:
+		return k.ApplicationConfigs
+	case IDENTITY_PROVIDERS:
+		return k.IdpConfigs
+	case CLAIMS:
+		return k.ClaimConfigs
+	case USERSTORES:
+		return k.UserStoreConfigs
+	case OIDC_SCOPES:
+		return k.OidcScopeConfigs
+	case ROLES:
+		return k.RoleConfigs
+	case CHALLENGE_QUESTIONS:
+		return k.ChallengeQuestionConfigs
+	case EMAIL_TEMPLATES:
+		return k.EmailTemplateConfigs
+	case SCRIPT_LIBRARIES:
+		return k.ScriptLibraryConfigs
+	case GOVERNANCE_CONNECTORS:
+		return k.GovernanceConnectorConfigs
+	case CERTIFICATES:
+		return k.CertificateConfigs
+	case WORKFLOWS:
+		return k.WorkflowConfigs
+	case API_RESOURCES:
+		return k.ApiResourceConfigs
+	case VALIDATION_RULES:
+		return k.ValidationRuleConfigs
+	case EMAIL_PROVIDERS:
+		return k.EmailProviderConfigs
+	case SMS_PROVIDERS:
+		return k.SmsProviderConfigs
+	case SMS_TEMPLATES:
+		return k.SmsTemplateConfigs
+	case ACTIONS:
+		return k.ActionConfigs
+	case ORGANIZATIONS:
+		return k.OrganizationConfigs
+	case BRANDING_PREFERENCES:
+		return k.BrandingPreferenceConfigs
+	case CUSTOM_TEXTS:
+		return k.CustomTextConfigs
+	case FLOWS:
+		return k.FlowConfigs
+	}
+	return nil
+}
+
+// GetKeywordMapping returns the keyword mapping used for the given resource, with the resource specific
+// keyword mappings applied.
+func (s *Session) GetKeywordMapping(resourceType ResourceType, resourceName string) map[string]interface{} {
+
+	if resourceConfigs := s.KeywordConfigs.resourceConfigs(resourceType); resourceConfigs != nil {
+		return s.ResolveAdvancedKeywordMapping(resourceName, resourceConfigs)
+	}
+	return s.KeywordConfigs.KeywordMappings
+}
+
+// IncludeOnlyResources returns a copy of the tool configs that includes only the given resource types. If resource
+// names are given, only the resources with those names are included and the other resources are not deleted.
+func (t ToolConfigs) IncludeOnlyResources(resourceTypes []ResourceType, resourceNames []string) ToolConfigs {
+
+	t.IncludeOnly = []string{}
+	t.Exclude = nil
+	for _, resourceType := range resourceTypes {
+		t.IncludeOnly = append(t.IncludeOnly, resourceType.String())
+	}
+	if len(resourceNames) == 0 {
+		return t
+	}
+
+	for _, resourceType := range resourceTypes {
+		t.includeOnlyResourceNames(resourceType, toInterfaces(resourceNames))
+	}
+	t.AllowDelete = false
+	return t
+}
+
+// Replaces the resource type configs with a copy that includes only the resources with the given names,
+// regardless of the EXCLUDE and FILTER configs.
+func (t *ToolConfigs) includeOnlyResourceNames(resourceType ResourceType, names []interface{}) {
+
+	configs := t.copyResourceConfigs(resourceType)
+	if configs == nil {
+		return
+	}
+	delete(configs, EXCLUDE_CONFIG)
+	delete(configs, EXCLUDE_OVERRIDES_CONFIG)
+	delete(configs, FILTER_CONFIG)
+	configs[INCLUDE_ONLY_CONFIG] = names
+}
+
+// Replaces the resource type configs with a copy, so that they can be modified without changing the loaded configs.
+func (t *ToolConfigs) copyResourceConfigs(resourceType ResourceType) map[string]interface{} {
+
+	configs := t.resourceConfigs(resourceType)
+	if configs == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(*configs)+1)
+	for key, value := range *configs {
+		copied[key] = value
+	}
+	*configs = copied
+	return copied
 }
 
 type KeywordConfigs struct {
@@ -109,26 +284,14 @@ type KeywordConfigs struct {
 	BrandingPreferenceConfigs  map[string]interface{} `json:"BRANDING_PREFERENCES"`
 	CustomTextConfigs          map[string]interface{} `json:"CUSTOM_TEXTS"`
 	FlowConfigs                map[string]interface{} `json:"FLOWS"`
+	SecretExecCommand          []string               `json:"SECRET_EXEC_COMMAND"`
 }
 
-var SERVER_CONFIGS ServerConfigs
-var TOOL_CONFIGS ToolConfigs
-var KEYWORD_CONFIGS KeywordConfigs
-
-func LoadConfigs(envConfigPath string) (baseDir string) {
-
-	baseDir, toolConfigFile, keywordConfigPath := loadServerConfigs(envConfigPath)
-	TOOL_CONFIGS = loadToolConfigsFromFile(toolConfigFile)
-	CURRENT_LOG_LEVEL = resolveLogLevel(TOOL_CONFIGS.Logs.LogLevel)
-	KEYWORD_CONFIGS = loadKeywordConfigsFromFile(keywordConfigPath)
-	return baseDir
-}
-
-func loadServerConfigs(envConfigPath string) (baseDir string, toolConfigPath string, keywordConfigPath string) {
+func loadServerConfigs(envConfigPath string) (baseDir string, serverConfigs ServerConfigs, toolConfigPath string, keywordConfigPath string) {
 
 	if envConfigPath == "" {
 		PrintLog(LogLevelInfo, UtilsResourceWrapper, "", "Loading configs from environment variables.")
-		toolConfigPath, keywordConfigPath = loadConfigsFromEnvVar()
+		serverConfigs, toolConfigPath, keywordConfigPath = loadConfigsFromEnvVar()
 		baseDir = filepath.Dir(filepath.Dir(filepath.Dir(toolConfigPath)))
 	} else {
 		PrintLog(LogLevelInfo, UtilsResourceWrapper, "", "Loading configs from config files.")
@@ -137,51 +300,52 @@ func loadServerConfigs(envConfigPath string) (baseDir string, toolConfigPath str
 		toolConfigPath = filepath.Join(envConfigPath, TOOL_CONFIG_FILE)
 		keywordConfigPath = filepath.Join(envConfigPath, KEYWORD_CONFIG_FILE)
 
-		SERVER_CONFIGS = loadServerConfigsFromFile(serverConfigFile)
+		serverConfigs = loadServerConfigsFromFile(serverConfigFile)
+		resolveCertPaths(&serverConfigs, envConfigPath)
 	}
-	sanitizeServerConfigs()
+	sanitizeServerConfigs(&serverConfigs)
 
 	// Validate server version format
-	if SERVER_CONFIGS.ServerVersion != "" {
-		_, err := ParseVersion(SERVER_CONFIGS.ServerVersion)
+	if serverConfigs.ServerVersion != "" {
+		_, err := ParseVersion(serverConfigs.ServerVersion)
 		if err != nil {
-			PrintLog(LogLevelError, UtilsResourceWrapper, "", fmt.Sprintf("Error parsing server version: %s. Error: %s", SERVER_CONFIGS.ServerVersion, err))
-			log.Fatalln("ERROR: Utils - Unexpected format for Server Version.")
+			PrintLog(LogLevelError, UtilsResourceWrapper, "", fmt.Sprintf("Error parsing server version: %s. Error: %s", serverConfigs.ServerVersion, err))
+			ExitWithCode(EXIT_CODE_CONFIG_ERROR, "ERROR: Utils - Unexpected format for Server Version.")
 		}
 	}
 
-	// Get access token.
-	SERVER_CONFIGS.Token = getAccessToken(SERVER_CONFIGS)
-	PrintLog(LogLevelInfo, UtilsResourceWrapper, "", "Access Token received successfully.")
-
-	return baseDir, toolConfigPath, keywordConfigPath
+	return baseDir, serverConfigs, toolConfigPath, keywordConfigPath
 }
 
-func loadConfigsFromEnvVar() (toolConfigPath string, keywordConfigPath string) {
+func loadConfigsFromEnvVar() (serverConfigs ServerConfigs, toolConfigPath string, keywordConfigPath string) {
 
 	// Load server configs from environment variables.
-	SERVER_CONFIGS.ServerUrl = os.Getenv(SERVER_URL_CONFIG)
-	SERVER_CONFIGS.ClientId = os.Getenv(CLIENT_ID_CONFIG)
-	SERVER_CONFIGS.ClientSecret = os.Getenv(CLIENT_SECRET_CONFIG)
-	SERVER_CONFIGS.TenantDomain = os.Getenv(TENANT_DOMAIN_CONFIG)
-	SERVER_CONFIGS.Organization = os.Getenv(ORGANIZATION_CONFIG)
+	serverConfigs.ServerUrl = os.Getenv(SERVER_URL_CONFIG)
+	serverConfigs.ClientId = os.Getenv(CLIENT_ID_CONFIG)
+	serverConfigs.ClientSecret = os.Getenv(CLIENT_SECRET_CONFIG)
+	serverConfigs.TenantDomain = os.Getenv(TENANT_DOMAIN_CONFIG)
+	serverConfigs.Organization = os.Getenv(ORGANIZATION_CONFIG)
 	serverVersion, exists := os.LookupEnv(SERVER_VERSION_CONFIG)
 	if !exists {
-		log.Fatalln("ERROR: Utils - Server Version environment variable is not set.")
+		ExitWithCode(EXIT_CODE_CONFIG_ERROR, "ERROR: Utils - Server Version environment variable is not set.")
 	}
-	SERVER_CONFIGS.ServerVersion = serverVersion
+	serverConfigs.ServerVersion = serverVersion
+	serverConfigs.InsecureSkipVerify, _ = strconv.ParseBool(os.Getenv(INSECURE_SKIP_VERIFY_CONFIG))
+	serverConfigs.CaCertPath = os.Getenv(CA_CERT_PATH_CONFIG)
+	serverConfigs.ClientCertPath = os.Getenv(CLIENT_CERT_PATH_CONFIG)
+	serverConfigs.ClientKeyPath = os.Getenv(CLIENT_KEY_PATH_CONFIG)
 
 	// Load tool config file path from environment variables.
 	toolConfigPath = os.Getenv(TOOL_CONFIG_PATH)
 	keywordConfigPath = os.Getenv(KEYWORD_CONFIG_PATH)
-	return toolConfigPath, keywordConfigPath
+	return serverConfigs, toolConfigPath, keywordConfigPath
 }
 
 func loadServerConfigsFromFile(configFilePath string) (serverConfigs ServerConfigs) {
 
 	configFile, err := ioutil.ReadFile(configFilePath)
 	if err != nil {
-		log.Fatalln("ERROR: Utils -", err.Error())
+		ExitWithCode(EXIT_CODE_CONFIG_ERROR, "ERROR: Utils -", err.Error())
 	}
 
 	// Replace placeholder keys with environment variable values
@@ -189,17 +353,17 @@ func loadServerConfigsFromFile(configFilePath string) (serverConfigs ServerConfi
 
 	var rawMap map[string]json.RawMessage
 	if err = json.Unmarshal(configFile, &rawMap); err != nil {
-		log.Fatalln("ERROR: Utils -", err)
+		ExitWithCode(EXIT_CODE_CONFIG_ERROR, "ERROR: Utils -", err)
 	}
 	if _, exists := rawMap[SERVER_VERSION_CONFIG]; !exists {
-		log.Fatalln("ERROR: Utils - Server Version is missing from the server config file.")
+		ExitWithCode(EXIT_CODE_CONFIG_ERROR, "ERROR: Utils - Server Version is missing from the server config file.")
 	}
 
 	reader := bytes.NewReader(configFile)
 	jsonParser := json.NewDecoder(reader)
 	err = jsonParser.Decode(&serverConfigs)
 	if err != nil {
-		log.Fatalln("ERROR: Utils -", err)
+		ExitWithCode(EXIT_CODE_CONFIG_ERROR, "ERROR: Utils -", err)
 	}
 	PrintLog(LogLevelInfo, UtilsResourceWrapper, "", "Server configs loaded successfully from the config file.")
 	return serverConfigs
@@ -209,17 +373,24 @@ func loadToolConfigsFromFile(configFilePath string) (toolConfigs ToolConfigs) {
 
 	configFile, err := ioutil.ReadFile(configFilePath)
 	if err != nil {
-		log.Fatalln("ERROR: Utils -", "Error when reading the tool config file.", err.Error())
+		ExitWithCode(EXIT_CODE_CONFIG_ERROR, "ERROR: Utils -", "Error when reading the tool config file.", err.Error())
 	}
 
 	toolConfigs.ExcludeSecrets = true
+	toolConfigs.Retry = RetryConfig{MaxAttempts: DEFAULT_RETRY_MAX_ATTEMPTS, BaseDelayMs: DEFAULT_RETRY_BASE_DELAY_MS}
+	toolConfigs.Concurrency = DEFAULT_CONCURRENCY
+	toolConfigs.ResourceTypeConcurrency = DEFAULT_RESOURCE_TYPE_CONCURRENCY
+	toolConfigs.Logs = LogsConfig{MaxFileSizeMB: DEFAULT_LOG_MAX_FILE_SIZE_MB, MaxBackups: DEFAULT_LOG_MAX_BACKUPS}
 	if len(configFile) == 0 {
 		return toolConfigs
 	}
 
 	err = json.Unmarshal(configFile, &toolConfigs)
 	if err != nil {
-		log.Fatalln("ERROR: Utils - Tool configs are not in the correct format. Please check the config file.", err)
+		ExitWithCode(EXIT_CODE_CONFIG_ERROR, "ERROR: Utils - Tool configs are not in the correct format. Please check the config file.", err)
+	}
+	if err := toolConfigs.Validate(); err != nil {
+		ExitWithCode(EXIT_CODE_CONFIG_ERROR, "ERROR: Utils - Invalid tool configs. Please check the config file.", err)
 	}
 
 	PrintLog(LogLevelInfo, UtilsResourceWrapper, "", "Tool configs loaded successfully from the config file.")
@@ -230,7 +401,7 @@ func loadKeywordConfigsFromFile(configFilePath string) (keywordConfigs KeywordCo
 
 	configFile, err := ioutil.ReadFile(configFilePath)
 	if err != nil {
-		log.Fatalln("ERROR: Utils - Error when reading the keyword config file.", err.Error())
+		ExitWithCode(EXIT_CODE_CONFIG_ERROR, "ERROR: Utils - Error when reading the keyword config file.", err.Error())
 	}
 
 	if len(configFile) == 0 {
@@ -242,130 +413,107 @@ func loadKeywordConfigsFromFile(configFilePath string) (keywordConfigs KeywordCo
 
 	err = json.Unmarshal(configFile, &keywordConfigs)
 	if err != nil {
-		log.Fatalln("ERROR: Utils - Keyword configs are not in the correct format. Please check the config file.", err)
+		ExitWithCode(EXIT_CODE_CONFIG_ERROR, "ERROR: Utils - Keyword configs are not in the correct format. Please check the config file.", err)
 	}
 
 	PrintLog(LogLevelInfo, UtilsResourceWrapper, "", "Keyword configs loaded successfully from the config file.")
 	return keywordConfigs
 }
 
-func getAccessToken(config ServerConfigs) string {
-
-	var err error
-	var response oAuthResponse
+func (s *Session) getAccessToken() (oAuthResponse, error) {
 
+	config := s.ServerConfigs
 	if config.ServerUrl == "" {
-		log.Fatalln("ERROR: Utils -", "Server URL is not defined in the config file.")
+		return oAuthResponse{}, fmt.Errorf("server URL is not defined in the config file")
 	}
-	authUrl := config.ServerUrl + "/t/" + config.TenantDomain + "/oauth2/token"
 
 	body := url.Values{}
 	body.Set("grant_type", "client_credentials")
 	body.Set("scope", SCOPE)
 
-	req, err := http.NewRequest("POST", authUrl, strings.NewReader(body.Encode()))
+	response, err := s.sendTokenRequest(body)
 	if err != nil {
-		log.Fatalln("ERROR: Utils -", err)
+		return response, fmt.Errorf("error in getting access token: %w", err)
 	}
-	req.SetBasicAuth(config.ClientId, config.ClientSecret)
-	req.Header.Set("Content-Type", MEDIA_TYPE_FORM)
-	defer req.Body.Close()
-
-	httpClient := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		log.Fatalln("ERROR: Utils -", err)
+	if config.Organization != "" {
+		PrintLog(LogLevelInfo, UtilsResourceWrapper, "", "Getting access token for Organization: "+config.Organization)
+		return s.switchAccessToken(response.AccessToken)
 	}
-	defer resp.Body.Close()
+	return response, nil
+}
 
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		log.Fatalln("ERROR: Utils -", err)
-	}
+func (s *Session) switchAccessToken(accessToken string) (oAuthResponse, error) {
 
-	if resp.StatusCode != 200 {
-		log.Fatalln("ERROR: Utils -", "Error in getting access token, response: "+string(respBody))
-	}
+	body := url.Values{}
+	body.Set("grant_type", "organization_switch")
+	body.Set("scope", SCOPE)
+	body.Set("token", accessToken)
+	body.Set("switching_organization", s.ServerConfigs.Organization)
 
-	err2 := json.Unmarshal(respBody, &response)
-	if err2 != nil {
-		log.Fatalln("ERROR: Utils -", err2)
-	}
-	if IsSubOrganization() {
-		PrintLog(LogLevelInfo, UtilsResourceWrapper, "", "Getting access token for Organization: "+SERVER_CONFIGS.Organization)
-		return switchAccessToken(config, response.AccessToken)
+	response, err := s.sendTokenRequest(body)
+	if err != nil {
+		return response, fmt.Errorf("error in switching access token: %w", err)
 	}
-	return response.AccessToken
+	return response, nil
 }
 
-func switchAccessToken(config ServerConfigs, accessToken string) string {
+func (s *Session) sendTokenRequest(body url.Values) (oAuthResponse, error) {
 
-	var err error
 	var response oAuthResponse
-
+	config := s.ServerConfigs
 	authUrl := config.ServerUrl + "/t/" + config.TenantDomain + "/oauth2/token"
 
-	body := url.Values{}
-	body.Set("grant_type", "organization_switch")
-	body.Set("scope", SCOPE)
-	body.Set("token", accessToken)
-	body.Set("switching_organization", config.Organization)
-
 	req, err := http.NewRequest("POST", authUrl, strings.NewReader(body.Encode()))
 	if err != nil {
-		log.Fatalln("ERROR: Utils -", err)
+		return response, err
 	}
 	req.SetBasicAuth(config.ClientId, config.ClientSecret)
 	req.Header.Set("Content-Type", MEDIA_TYPE_FORM)
 	defer req.Body.Close()
 
-	httpClient := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
-	resp, err := httpClient.Do(req)
+	resp, err := s.HttpClient.Do(req)
 	if err != nil {
-		log.Fatalln("ERROR: Utils -", err)
+		return response, err
 	}
 	defer resp.Body.Close()
 
 	respBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		log.Fatalln("ERROR: Utils -", err)
+		return response, err
 	}
 
 	if resp.StatusCode != 200 {
-		log.Fatalln("ERROR: Utils -", "Error in switching access token, response: "+string(respBody))
+		return response, fmt.Errorf("response: %s", string(respBody))
 	}
 
-	err2 := json.Unmarshal(respBody, &response)
-	if err2 != nil {
-		log.Fatalln("ERROR: Utils -", err2)
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return response, err
 	}
-	return response.AccessToken
+	return response, nil
 }
 
-func sanitizeServerConfigs() {
+func sanitizeServerConfigs(serverConfigs *ServerConfigs) {
 
-	SERVER_CONFIGS.ServerUrl = strings.TrimSuffix(SERVER_CONFIGS.ServerUrl, "/")
+	serverConfigs.ServerUrl = strings.TrimSuffix(serverConfigs.ServerUrl, "/")
 
 	// Set tenant domain if not defined in the config file.
-	if SERVER_CONFIGS.TenantDomain == "" {
+	if serverConfigs.TenantDomain == "" {
 		PrintLog(LogLevelInfo, UtilsResourceWrapper, "", "Tenant domain not defined. Defaulting to: carbon.super")
-		SERVER_CONFIGS.TenantDomain = DEFAULT_TENANT_DOMAIN
+		serverConfigs.TenantDomain = DEFAULT_TENANT_DOMAIN
+	}
+}
+
+// Resolves certificate paths given relative to the environment config folder.
+func resolveCertPaths(serverConfigs *ServerConfigs, envConfigPath string) {
+
+	for _, certPath := range []*string{&serverConfigs.CaCertPath, &serverConfigs.ClientCertPath, &serverConfigs.ClientKeyPath} {
+		if *certPath != "" && !filepath.IsAbs(*certPath) {
+			*certPath = filepath.Join(envConfigPath, *certPath)
+		}
 	}
 }
 
-func IsSubOrganization() bool {
+func (s *Session) IsSubOrganization() bool {
 
-	return SERVER_CONFIGS.Organization != ""
+	return s.ServerConfigs.Organization != ""
 }
@@ -0,0 +1,186 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SnapshotManifest lists the resources that an import creates, updates or deletes. It is stored in the snapshot
+// directory along with the exported state of the updated and deleted resources before the import.
+// SecretsIncluded tells whether the secrets of the resources were exported, and so can be restored by a rollback.
+type SnapshotManifest struct {
+	ServerUrl       string      `json:"serverUrl"`
+	TenantDomain    string      `json:"tenantDomain"`
+	Organization    string      `json:"organization,omitempty"`
+	CreatedAt       time.Time   `json:"createdAt"`
+	SecretsIncluded bool        `json:"secretsIncluded"`
+	Resources       []PlanEntry `json:"resources"`
+}
+
+// NewSnapshotManifest creates the manifest from the import plan. Resources that are left unchanged are ignored.
+// Secrets are only exported to the snapshot if includeSecrets is true.
+func (s *Session) NewSnapshotManifest(plan []PlanEntry, includeSecrets bool) SnapshotManifest {
+
+	manifest := SnapshotManifest{
+		ServerUrl:       s.ServerConfigs.ServerUrl,
+		TenantDomain:    s.ServerConfigs.TenantDomain,
+		Organization:    s.ServerConfigs.Organization,
+		CreatedAt:       time.Now(),
+		SecretsIncluded: includeSecrets,
+	}
+	for _, entry := range plan {
+		if entry.Action != PLAN_NO_OP {
+			manifest.Resources = append(manifest.Resources, entry)
+		}
+	}
+	return manifest
+}
+
+// CheckEnvironment returns an error if the snapshot was not taken from the environment of the given session.
+func (m SnapshotManifest) CheckEnvironment(s *Session) error {
+
+	if m.ServerUrl != s.ServerConfigs.ServerUrl || m.TenantDomain != s.ServerConfigs.TenantDomain ||
+		m.Organization != s.ServerConfigs.Organization {
+		return fmt.Errorf("snapshot was taken from %s (tenant: %s) and cannot be restored to %s (tenant: %s)",
+			m.ServerUrl, m.TenantDomain, s.ServerConfigs.ServerUrl, s.ServerConfigs.TenantDomain)
+	}
+	return nil
+}
+
+// SnapshotToolConfigs returns the tool configs to export the resources that the import updates or deletes.
+// Secrets are masked, unless the manifest includes them, in which case they are exported where the APIs allow it.
+// Returns false if the import does not update or delete any resource.
+func (m SnapshotManifest) SnapshotToolConfigs(toolConfigs ToolConfigs) (ToolConfigs, bool) {
+
+	toolConfigs = m.includeOnly(toolConfigs, PLAN_UPDATE, PLAN_DELETE)
+	toolConfigs.AllowDelete = false
+	toolConfigs.ExcludeSecrets = !m.SecretsIncluded
+	for _, resourceType := range toolConfigs.IncludeOnly {
+		if configs := toolConfigs.resourceConfigs(ResourceType(resourceType)); configs != nil {
+			(*configs)[EXCLUDE_SECRETS_CONFIG] = !m.SecretsIncluded
+		}
+	}
+	return toolConfigs, len(toolConfigs.IncludeOnly) > 0
+}
+
+// RollbackToolConfigs returns the tool configs to import the snapshot. Resources created by the import are not
+// in the snapshot, so they are deleted by enabling the ALLOW_DELETE config for the resources in the manifest.
+// Returns false if there are no resources to roll back.
+func (m SnapshotManifest) RollbackToolConfigs(toolConfigs ToolConfigs) (ToolConfigs, bool) {
+
+	toolConfigs = m.includeOnly(toolConfigs, PLAN_CREATE, PLAN_UPDATE, PLAN_DELETE)
+	toolConfigs.AllowDelete = true
+	return toolConfigs, len(toolConfigs.IncludeOnly) > 0
+}
+
+// PrepareRollbackDir creates the directories of the resource types in the manifest that were not exported
+// to the snapshot, so that the resources created by the import are deleted during the rollback.
+func (m SnapshotManifest) PrepareRollbackDir(snapshotDir string) error {
+
+	for _, entry := range m.Resources {
+		if err := os.MkdirAll(GetResourceTypeDir(snapshotDir, entry.ResourceType), 0700); err != nil {
+			return fmt.Errorf("error creating directory for %s: %w", entry.ResourceType, err)
+		}
+	}
+	return nil
+}
+
+// Restricts the tool configs to the resources exported with the resources planned with the given actions.
+// Resource names are matched exactly, even if they contain glob characters.
+func (m SnapshotManifest) includeOnly(toolConfigs ToolConfigs, actions ...PlanAction) ToolConfigs {
+
+	resourceNames := make(map[ResourceType][]interface{})
+	included := make(map[planResource]bool)
+	toolConfigs.IncludeOnly = []string{}
+	toolConfigs.Exclude = nil
+	for _, entry := range m.Resources {
+		resource := entry.exportResource()
+		if !containsPlanAction(actions, entry.Action) || included[resource] {
+			continue
+		}
+		included[resource] = true
+		if _, exists := resourceNames[resource.resourceType]; !exists {
+			toolConfigs.IncludeOnly = append(toolConfigs.IncludeOnly, resource.resourceType.String())
+		}
+		resourceNames[resource.resourceType] = append(resourceNames[resource.resourceType], ExactNameRule(resource.resourceName))
+	}
+
+	for resourceType, names := range resourceNames {
+		toolConfigs.includeOnlyResourceNames(resourceType, names)
+	}
+	return toolConfigs
+}
+
+func containsPlanAction(actions []PlanAction, action PlanAction) bool {
+
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDefaultSnapshotsDir returns the directory to save the snapshots to when no directory is given. The snapshots
+// are kept in the user cache directory, outside the resource repository, so that they are not committed with it.
+func GetDefaultSnapshotsDir() (string, error) {
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding the user cache directory. Use the snapshotDir flag to give the snapshot directory: %w", err)
+	}
+	return filepath.Join(cacheDir, "iamctl", SNAPSHOTS_DIR), nil
+}
+
+// GetResourceTypeDir returns the directory of the given resource type in an export directory.
+func GetResourceTypeDir(baseDir string, resourceType ResourceType) string {
+
+	if resourceType == BRANDING_PREFERENCES || resourceType == CUSTOM_TEXTS {
+		return filepath.Join(baseDir, BRANDING.String(), resourceType.String())
+	}
+	return filepath.Join(baseDir, resourceType.String())
+}
+
+func WriteSnapshotManifest(snapshotDir string, manifest SnapshotManifest) error {
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing snapshot manifest: %w", err)
+	}
+	return ioutil.WriteFile(filepath.Join(snapshotDir, SNAPSHOT_MANIFEST_FILE), data, 0600)
+}
+
+func ReadSnapshotManifest(snapshotDir string) (SnapshotManifest, error) {
+
+	var manifest SnapshotManifest
+	data, err := ioutil.ReadFile(filepath.Join(snapshotDir, SNAPSHOT_MANIFEST_FILE))
+	if err != nil {
+		return manifest, fmt.Errorf("error reading snapshot manifest: %w", err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("error parsing snapshot manifest: %w", err)
+	}
+	return manifest, nil
+}
@@ -0,0 +1,192 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToolConfigOverrides holds the tool configs given as command line flags. Resources are given in the
+// <resource type>:<resource name> format.
+type ToolConfigOverrides struct {
+	Include          []string
+	Exclude          []string
+	IncludeResources []string
+	ExcludeResources []string
+	AllowDelete      bool
+	NoDelete         bool
+}
+
+// ApplyOverrides returns a copy of the tool configs with the overrides merged on top of them. Overrides take
+// precedence over the tool configs, and exclusions take precedence over inclusions among the overrides.
+func (t ToolConfigs) ApplyOverrides(overrides ToolConfigOverrides) (ToolConfigs, error) {
+
+	PrintLog(LogLevelDebug, UtilsResourceWrapper, "", "Tool config precedence (highest first): --no-delete/--allow-delete, "+
+		"--exclude-resource, --include-resource, --exclude, --include, TOOL_CONFIGS")
+
+	if overrides.AllowDelete && overrides.NoDelete {
+		return t, fmt.Errorf("--allow-delete and --no-delete cannot be used together")
+	}
+	include, err := resolveResourceTypeNames(overrides.Include)
+	if err != nil {
+		return t, err
+	}
+	exclude, err := resolveResourceTypeNames(overrides.Exclude)
+	if err != nil {
+		return t, err
+	}
+	includeResources, err := parseResourceNames(overrides.IncludeResources)
+	if err != nil {
+		return t, err
+	}
+	excludeResources, err := parseResourceNames(overrides.ExcludeResources)
+	if err != nil {
+		return t, err
+	}
+
+	if len(include) > 0 {
+		t.IncludeOnly = include
+		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("INCLUDE_ONLY set to %v by --include", t.IncludeOnly))
+	}
+	if len(exclude) > 0 {
+		if len(t.IncludeOnly) > 0 {
+			t.IncludeOnly = removeNames(t.IncludeOnly, exclude)
+			if len(t.IncludeOnly) == 0 {
+				return t, fmt.Errorf("all included resource types are excluded by --exclude")
+			}
+		}
+		t.Exclude = appendNames(append([]string{}, t.Exclude...), exclude)
+		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", fmt.Sprintf("EXCLUDE set to %v by --exclude", t.Exclude))
+	}
+
+	for _, entry := range includeResources {
+		configs := t.copyResourceConfigs(entry.resourceType)
+		configs[INCLUDE_ONLY_CONFIG] = toInterfaces(entry.names)
+		PrintLog(LogLevelDebug, entry.resourceType, "", fmt.Sprintf("INCLUDE_ONLY set to %v by --include-resource", entry.names))
+	}
+	// Excluded resources are kept separately from the EXCLUDE config, since EXCLUDE is not checked when INCLUDE_ONLY
+	// is set, and an INCLUDE_ONLY glob or regular expression would otherwise still include them.
+	for _, entry := range excludeResources {
+		configs := t.copyResourceConfigs(entry.resourceType)
+		excluded, _ := configs[EXCLUDE_OVERRIDES_CONFIG].([]interface{})
+		configs[EXCLUDE_OVERRIDES_CONFIG] = toInterfaces(appendNames(toStrings(excluded), entry.names))
+		PrintLog(LogLevelDebug, entry.resourceType, "", fmt.Sprintf("Resources %v excluded by --exclude-resource", configs[EXCLUDE_OVERRIDES_CONFIG]))
+	}
+
+	if overrides.AllowDelete {
+		t.AllowDelete = true
+		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", "ALLOW_DELETE set to true by --allow-delete")
+	} else if overrides.NoDelete {
+		t.AllowDelete = false
+		PrintLog(LogLevelDebug, UtilsResourceWrapper, "", "ALLOW_DELETE set to false by --no-delete")
+	}
+	return t, t.Validate()
+}
+
+type resourceNames struct {
+	resourceType ResourceType
+	names        []string
+}
+
+// Resolves the given resource type names to the names used in the tool configs.
+func resolveResourceTypeNames(names []string) ([]string, error) {
+
+	var resolved []string
+	for _, name := range names {
+		_, resourceTypes, err := ResolveResourceType(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		for _, resourceType := range resourceTypes {
+			resolved = appendNames(resolved, []string{resourceType.String()})
+		}
+	}
+	return resolved, nil
+}
+
+// Groups resources given in the <resource type>:<resource name> format by resource type, in the given order.
+func parseResourceNames(resources []string) ([]resourceNames, error) {
+
+	var grouped []resourceNames
+	for _, resource := range resources {
+		parts := strings.SplitN(resource, ":", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("invalid resource %q. Use the <resource type>:<resource name> format", resource)
+		}
+		_, resourceTypes, err := ResolveResourceType(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, err
+		}
+		for _, resourceType := range resourceTypes {
+			added := false
+			for i := range grouped {
+				if grouped[i].resourceType == resourceType {
+					grouped[i].names = appendNames(grouped[i].names, []string{parts[1]})
+					added = true
+				}
+			}
+			if !added {
+				grouped = append(grouped, resourceNames{resourceType: resourceType, names: []string{parts[1]}})
+			}
+		}
+	}
+	return grouped, nil
+}
+
+func appendNames(names []string, namesToAdd []string) []string {
+
+	for _, name := range namesToAdd {
+		if !Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func removeNames(names []string, namesToRemove []string) []string {
+
+	remaining := []string{}
+	for _, name := range names {
+		if !Contains(namesToRemove, name) {
+			remaining = append(remaining, name)
+		}
+	}
+	return remaining
+}
+
+func toStrings(values []interface{}) []string {
+
+	var names []string
+	for _, value := range values {
+		if name, ok := value.(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func toInterfaces(names []string) []interface{} {
+
+	values := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		values = append(values, name)
+	}
+	return values
+}
@@ -0,0 +1,123 @@
+/**
+* Copyright (c) 2026, WSO2 LLC. (https://www.wso2.com).
+*
+* WSO2 LLC. licenses this file to you under the Apache License,
+* Version 2.0 (the "License"); you may not use this file except
+* in compliance with the License.
+* You may obtain a copy of the License at
+*
+* http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied. See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const ACTIVITY_ID_HEADER = "activityid"
+const CORRELATION_ID_HEADER = "X-Correlation-ID"
+
+// TRACE_REQUESTS logs every request sent to the server at the INFO level, and prints the time taken by the
+// requests of each resource type at the end of the summary.
+var TRACE_REQUESTS bool
+
+// RunId identifies the requests sent by a single run of the tool in the server logs.
+var RunId = newRunId()
+
+var requestCount uint64
+
+// RequestTiming is the time taken by the requests sent for a resource type.
+type RequestTiming struct {
+	ResourceType  ResourceType
+	Requests      int
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+}
+
+var requestTimings = make(map[ResourceType]RequestTiming)
+var requestTimingLock sync.Mutex
+
+func newRunId() string {
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(id)
+}
+
+// NewCorrelationId returns the ID of a new request, made of the run ID and the sequence number of the request.
+func NewCorrelationId() string {
+
+	return fmt.Sprintf("%s-%d", RunId, atomic.AddUint64(&requestCount, 1))
+}
+
+func recordRequestTiming(resourceType ResourceType, duration time.Duration) {
+
+	requestTimingLock.Lock()
+	defer requestTimingLock.Unlock()
+	timing := requestTimings[resourceType]
+	timing.ResourceType = resourceType
+	timing.Requests++
+	timing.TotalDuration += duration
+	if duration > timing.MaxDuration {
+		timing.MaxDuration = duration
+	}
+	requestTimings[resourceType] = timing
+}
+
+// GetRequestTimings returns the request timings of each resource type, sorted by the total time taken.
+func GetRequestTimings() []RequestTiming {
+
+	requestTimingLock.Lock()
+	defer requestTimingLock.Unlock()
+	timings := make([]RequestTiming, 0, len(requestTimings))
+	for _, timing := range requestTimings {
+		timings = append(timings, timing)
+	}
+	sort.Slice(timings, func(i, j int) bool {
+		if timings[i].TotalDuration != timings[j].TotalDuration {
+			return timings[i].TotalDuration > timings[j].TotalDuration
+		}
+		return timings[i].ResourceType < timings[j].ResourceType
+	})
+	return timings
+}
+
+func resetRequestTimings() {
+
+	requestTimingLock.Lock()
+	defer requestTimingLock.Unlock()
+	requestTimings = make(map[ResourceType]RequestTiming)
+}
+
+func printRequestTimings() {
+
+	timings := GetRequestTimings()
+	if len(timings) == 0 {
+		return
+	}
+	fmt.Println("========================================")
+	fmt.Println("Request Timing Breakdown")
+	fmt.Println("========================================")
+	fmt.Printf("%-28s %8s %12s %10s %10s\n", "Resource Type", "Requests", "Total", "Average", "Max")
+	for _, timing := range timings {
+		average := timing.TotalDuration / time.Duration(timing.Requests)
+		fmt.Printf("%-28s %8d %12s %10s %10s\n", timing.ResourceType, timing.Requests,
+			timing.TotalDuration.Round(time.Millisecond), average.Round(time.Millisecond), timing.MaxDuration.Round(time.Millisecond))
+	}
+}
@@ -24,25 +24,21 @@ import (
 	"strings"
 )
 
-// Flags to indicate the presence of resource-specific APIs
-var RolesV2ApiExists bool
-var NotificationTemplatesApiExists bool
-
 // Checks if a resource type is supported in the configured WSO2 IS version.
 // Returns true if:
 //   - minimum required version <= Configured version <= maximum supported version for the resource type
 //   - No version requirement is defined for the resource type
-func IsEntitySupportedInVersion(resourceType ResourceType) bool {
+func (s *Session) IsEntitySupportedInVersion(resourceType ResourceType) bool {
 
 	minVersion, hasMin := EntityMinVersionRequirements[resourceType]
 	maxVersion, hasMax := EntityMaxSupportedVersion[resourceType]
 
 	if hasMax {
 		// When server version is "", consider it as the latest version
-		if SERVER_CONFIGS.ServerVersion == "" {
+		if s.ServerConfigs.ServerVersion == "" {
 			return false
 		}
-		comparison, _ := CompareVersions(SERVER_CONFIGS.ServerVersion, maxVersion)
+		comparison, _ := CompareVersions(s.ServerConfigs.ServerVersion, maxVersion)
 		if comparison > 0 {
 			PrintLog(LogLevelInfo, resourceType, "", fmt.Sprintf("Skipping: Supported up to IS version %s", maxVersion))
 			return false
@@ -50,10 +46,10 @@ func IsEntitySupportedInVersion(resourceType ResourceType) bool {
 	}
 
 	if hasMin {
-		if SERVER_CONFIGS.ServerVersion == "" {
+		if s.ServerConfigs.ServerVersion == "" {
 			return true
 		}
-		comparison, _ := CompareVersions(SERVER_CONFIGS.ServerVersion, minVersion)
+		comparison, _ := CompareVersions(s.ServerConfigs.ServerVersion, minVersion)
 		if comparison < 0 {
 			PrintLog(LogLevelInfo, resourceType, "", fmt.Sprintf("Skipping: Supported from IS version %s or higher", minVersion))
 			return false
@@ -115,13 +111,13 @@ func ParseVersion(version string) ([3]int, error) {
 	return parts, nil
 }
 
-func ExportAPIExists(resourceType ResourceType) bool {
+func (s *Session) ExportAPIExists(resourceType ResourceType) bool {
 
 	minVersion, exists := ExportAPIMinVersionRequirements[resourceType]
 	if !exists {
 		return false
 	}
-	res, err := CompareVersions(SERVER_CONFIGS.ServerVersion, minVersion)
+	res, err := CompareVersions(s.ServerConfigs.ServerVersion, minVersion)
 	if err != nil {
 		// Use the export API when the server version is not properly configured for backward compatibility
 		return true
@@ -20,42 +20,40 @@ package validationRules
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.VALIDATION_RULES, "", "Exporting validation rules...")
 	exportFilePath = filepath.Join(exportFilePath, utils.VALIDATION_RULES.String())
 
-	if utils.ShouldSkip(utils.VALIDATION_RULES) {
+	if session.ShouldSkip(utils.VALIDATION_RULES) {
 		return
 	}
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.VALIDATION_RULES, "", fmt.Sprintf("Error creating validation rules directory: %s", err))
 			utils.MarkResTypeFailure(utils.VALIDATION_RULES)
 			return
 		}
 	}
 
-	err := exportValidationRules(exportFilePath, format)
+	utils.MarkResourceStart(utils.VALIDATION_RULES, resourceFileName)
+	err := exportValidationRules(session, exportFilePath, format)
 	if err != nil {
-		utils.UpdateFailureSummary(utils.VALIDATION_RULES, resourceFileName)
-		utils.PrintLog(utils.LogLevelError, utils.VALIDATION_RULES, "", fmt.Sprintf("Error while exporting validation rules: %s", err))
+		utils.UpdateFailureSummaryAndLog(utils.VALIDATION_RULES, resourceFileName, utils.EXPORT, err, fmt.Sprintf("Error while exporting validation rules: %s", err))
 	} else {
-		utils.UpdateSuccessSummary(utils.VALIDATION_RULES, utils.EXPORT)
-		utils.PrintLog(utils.LogLevelInfo, utils.VALIDATION_RULES, "", "Exported successfully")
+		utils.UpdateSuccessSummaryAndLog(utils.VALIDATION_RULES, resourceFileName, utils.EXPORT, "Exported successfully")
 	}
 }
 
-func exportValidationRules(outputDirPath string, formatString string) error {
+func exportValidationRules(session *utils.Session, outputDirPath string, formatString string) error {
 
-	rules, err := utils.GetResourceData(utils.VALIDATION_RULES, "")
+	rules, err := session.GetResourceData(utils.VALIDATION_RULES, "")
 	if err != nil {
 		return fmt.Errorf("error while getting validation rules: %w", err)
 	}
@@ -63,8 +61,8 @@ func exportValidationRules(outputDirPath string, formatString string) error {
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, resourceFileName, format)
 
-	keywordMapping := getValidationRuleKeywordMapping()
-	modifiedRules, err := utils.ProcessExportedData(rules, exportedFileName, format, keywordMapping, utils.VALIDATION_RULES)
+	keywordMapping := getValidationRuleKeywordMapping(session)
+	modifiedRules, err := session.ProcessExportedData(rules, exportedFileName, format, keywordMapping, utils.VALIDATION_RULES)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -74,7 +72,7 @@ func exportValidationRules(outputDirPath string, formatString string) error {
 		return fmt.Errorf("error while serializing validation rules: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.VALIDATION_RULES)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
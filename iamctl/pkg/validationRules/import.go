@@ -27,30 +27,30 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.VALIDATION_RULES, "", "Importing validation rules...")
 	importFilePath := filepath.Join(inputDirPath, utils.VALIDATION_RULES.String())
 
-	if utils.ShouldSkip(utils.VALIDATION_RULES) {
+	if session.ShouldSkip(utils.VALIDATION_RULES) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
 		utils.PrintLog(utils.LogLevelInfo, utils.VALIDATION_RULES, "", "No validation rules to import.")
 		return
 	}
+	utils.MarkResourceStart(utils.VALIDATION_RULES, resourceFileName)
 	filePath, err := getValidationRulesFilePath(importFilePath)
 
 	if err == nil {
-		err = importValidationRules(filePath)
+		err = importValidationRules(session, filePath)
 	}
 	if err != nil {
-		utils.UpdateFailureSummary(utils.VALIDATION_RULES, resourceFileName)
-		utils.PrintLog(utils.LogLevelError, utils.VALIDATION_RULES, "", fmt.Sprintf("Error importing validation rules: %s", err))
+		utils.UpdateFailureSummaryAndLog(utils.VALIDATION_RULES, resourceFileName, utils.IMPORT, err, fmt.Sprintf("Error importing validation rules: %s", err))
 	}
 }
 
-func importValidationRules(filePath string) error {
+func importValidationRules(session *utils.Session, filePath string) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(filePath))
 	if err != nil {
@@ -61,13 +61,25 @@ func importValidationRules(filePath string) error {
 		return fmt.Errorf("error reading validation rules file: %w", err)
 	}
 
-	keywordMapping := getValidationRuleKeywordMapping()
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	keywordMapping := getValidationRuleKeywordMapping(session)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.VALIDATION_RULES, filePath, modifiedFileData, keywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.VALIDATION_RULES, filePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
+
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.VALIDATION_RULES, resourceFileName, utils.PLAN_UPDATE, "")
+		return nil
+	}
 
-	return updateValidationRules([]byte(modifiedFileData), format)
+	return updateValidationRules(session, []byte(modifiedFileData), format)
 }
 
-func updateValidationRules(requestBody []byte, format utils.Format) error {
+func updateValidationRules(session *utils.Session, requestBody []byte, format utils.Format) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.VALIDATION_RULES, "", "Updating validation rules")
 
@@ -76,13 +88,12 @@ func updateValidationRules(requestBody []byte, format utils.Format) error {
 		return fmt.Errorf("error preparing update request body: %w", err)
 	}
 
-	resp, err := utils.SendPutRequest(utils.VALIDATION_RULES, "", jsonBody)
+	resp, err := session.SendPutRequest(utils.VALIDATION_RULES, "", jsonBody)
 	if err != nil {
 		return fmt.Errorf("error when updating validation rules: %w", err)
 	}
 	defer resp.Body.Close()
 
-	utils.UpdateSuccessSummary(utils.VALIDATION_RULES, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.VALIDATION_RULES, "", "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.VALIDATION_RULES, resourceFileName, utils.UPDATE, "Updated successfully")
 	return nil
 }
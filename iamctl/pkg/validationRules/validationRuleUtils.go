@@ -27,12 +27,12 @@ import (
 
 const resourceFileName = "validationRules"
 
-func getValidationRuleKeywordMapping() map[string]interface{} {
+func getValidationRuleKeywordMapping(session *utils.Session) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.ValidationRuleConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(resourceFileName, utils.KEYWORD_CONFIGS.ValidationRuleConfigs)
+	if session.KeywordConfigs.ValidationRuleConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(resourceFileName, session.KeywordConfigs.ValidationRuleConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func getValidationRulesFilePath(importDirPath string) (string, error) {
@@ -19,24 +19,25 @@
 package workflows
 
 import (
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ExportAll(exportFilePath string, format string) {
+func ExportAll(session *utils.Session, exportFilePath string, format string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.WORKFLOWS, "", "Exporting workflows...")
 	exportFilePath = filepath.Join(exportFilePath, utils.WORKFLOWS.String())
-	setWorkflowVersionConfigs()
+	setWorkflowVersionConfigs(session)
 
-	if utils.ShouldSkip(utils.WORKFLOWS) {
+	if session.ShouldSkip(utils.WORKFLOWS) {
 		return
 	}
-	workflows, err := getWorkflowList()
+	workflows, err := getWorkflowList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.WORKFLOWS, "", fmt.Sprintf("Error when retrieving workflow list: %s", err))
 		utils.MarkResTypeFailure(utils.WORKFLOWS)
@@ -44,45 +45,46 @@ func ExportAll(exportFilePath string, format string) {
 	}
 
 	if _, err := os.Stat(exportFilePath); os.IsNotExist(err) {
-		if err := os.MkdirAll(exportFilePath, 0700); err != nil {
+		if err := utils.CreateExportDir(exportFilePath); err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.WORKFLOWS, "", fmt.Sprintf("Error creating workflows directory: %s", err))
 			utils.MarkResTypeFailure(utils.WORKFLOWS)
 			return
 		}
 	} else {
-		if utils.TOOL_CONFIGS.AllowDelete {
+		if session.ToolConfigs.AllowDelete {
 			deployedWorkflowNames := getDeployedWorkflowNames(workflows)
 			utils.RemoveDeletedLocalResources(exportFilePath, deployedWorkflowNames)
 		}
 	}
 
 	exportedAssociationNames = []string{}
-	successCount := 0
+	var exportedWorkflowNames []string
+	var exportedLock sync.Mutex
 
-	for _, wf := range workflows {
-		if !utils.IsResourceExcluded(wf.Name, utils.TOOL_CONFIGS.WorkflowConfigs) {
+	session.ProcessConcurrently(len(workflows), func(i int) {
+		wf := workflows[i]
+		if !utils.IsResourceExcluded(wf.Name, session.ToolConfigs.WorkflowConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.WORKFLOWS, wf.Name, "Exporting")
-			err := exportWorkflow(wf.ID, wf.Name, exportFilePath, format)
+			utils.MarkResourceStart(utils.WORKFLOWS, wf.Name)
+			err := exportWorkflow(session, wf.ID, wf.Name, exportFilePath, format)
+			if errors.Is(err, utils.ErrFilteredOut) {
+				return
+			}
 			if err != nil {
-				utils.UpdateFailureSummary(utils.WORKFLOWS, wf.Name)
-				utils.PrintLog(utils.LogLevelError, utils.WORKFLOWS, wf.Name, fmt.Sprintf("Error while exporting: %s", err))
+				utils.UpdateFailureSummaryAndLog(utils.WORKFLOWS, wf.Name, utils.EXPORT, err, fmt.Sprintf("Error while exporting: %s", err))
+			} else if assocSharingSupported {
+				utils.UpdateSuccessSummaryAndLog(utils.WORKFLOWS, wf.Name, utils.EXPORT, "Exported successfully")
 			} else {
-				if assocSharingSupported {
-					utils.UpdateSuccessSummary(utils.WORKFLOWS, utils.EXPORT)
-				} else {
-					successCount++
-				}
-				utils.PrintLog(utils.LogLevelInfo, utils.WORKFLOWS, wf.Name, "Exported successfully")
+				exportedLock.Lock()
+				exportedWorkflowNames = append(exportedWorkflowNames, wf.Name)
+				exportedLock.Unlock()
 			}
 		}
-	}
+	})
 
 	if !assocSharingSupported {
 		err = writeWorkflowAssociationsList(exportFilePath, format)
-		updateWorkflowExportSummary(err == nil, successCount)
-		if err != nil {
-			utils.PrintLog(utils.LogLevelError, utils.WORKFLOWS, "", fmt.Sprintf("Error writing workflow associations list: %s", err))
-		}
+		updateWorkflowExportSummary(err, exportedWorkflowNames)
 	}
 
 	utils.PrintLog(utils.LogLevelWarn, utils.WORKFLOWS, "", "Users associated with workflow steps are not exported")
@@ -91,9 +93,9 @@ func ExportAll(exportFilePath string, format string) {
 	}
 }
 
-func exportWorkflow(workflowId string, workflowName string, outputDirPath string, formatString string) error {
+func exportWorkflow(session *utils.Session, workflowId string, workflowName string, outputDirPath string, formatString string) error {
 
-	wf, err := getWorkflowData(workflowId)
+	wf, err := getWorkflowData(session, workflowId)
 	if err != nil {
 		return fmt.Errorf("error while getting workflow: %w", err)
 	}
@@ -101,8 +103,8 @@ func exportWorkflow(workflowId string, workflowName string, outputDirPath string
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, workflowName, format)
 
-	keywordMapping := getWorkflowKeywordMapping(workflowName)
-	modifiedWf, err := utils.ProcessExportedData(wf, exportedFileName, format, keywordMapping, utils.WORKFLOWS)
+	keywordMapping := getWorkflowKeywordMapping(session, workflowName)
+	modifiedWf, err := session.ProcessExportedData(wf, exportedFileName, format, keywordMapping, utils.WORKFLOWS)
 	if err != nil {
 		return fmt.Errorf("error while processing exported content: %w", err)
 	}
@@ -112,7 +114,7 @@ func exportWorkflow(workflowId string, workflowName string, outputDirPath string
 		return fmt.Errorf("error while serializing workflow: %w", err)
 	}
 
-	err = ioutil.WriteFile(exportedFileName, modifiedFile, 0644)
+	err = utils.WriteExportedFile(exportedFileName, modifiedFile, utils.WORKFLOWS)
 	if err != nil {
 		return fmt.Errorf("error when writing exported content to file: %w", err)
 	}
@@ -120,9 +122,9 @@ func exportWorkflow(workflowId string, workflowName string, outputDirPath string
 	return nil
 }
 
-func getWorkflowData(workflowId string) (interface{}, error) {
+func getWorkflowData(session *utils.Session, workflowId string) (interface{}, error) {
 
-	wf, err := utils.GetResourceData(utils.WORKFLOWS, workflowId)
+	wf, err := session.GetResourceData(utils.WORKFLOWS, workflowId)
 	if err != nil {
 		return nil, fmt.Errorf("error while getting workflow: %w", err)
 	}
@@ -132,7 +134,7 @@ func getWorkflowData(workflowId string) (interface{}, error) {
 		return nil, fmt.Errorf("unexpected format for workflow data")
 	}
 
-	associations, _, err := getAssociationsOfWorkflow(workflowId)
+	associations, _, err := getAssociationsOfWorkflow(session, workflowId)
 	if err != nil {
 		return nil, fmt.Errorf("error while getting workflow associations: %w", err)
 	}
@@ -27,13 +27,13 @@ import (
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
 
-func ImportAll(inputDirPath string) {
+func ImportAll(session *utils.Session, inputDirPath string) {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.WORKFLOWS, "", "Importing workflows...")
 	importFilePath := filepath.Join(inputDirPath, utils.WORKFLOWS.String())
-	setWorkflowVersionConfigs()
+	setWorkflowVersionConfigs(session)
 
-	if utils.ShouldSkip(utils.WORKFLOWS) {
+	if session.ShouldSkip(utils.WORKFLOWS) {
 		return
 	}
 	if _, err := os.Stat(importFilePath); os.IsNotExist(err) {
@@ -41,7 +41,7 @@ func ImportAll(inputDirPath string) {
 		return
 	}
 
-	existingWorkflows, err := getWorkflowList()
+	existingWorkflows, err := getWorkflowList(session)
 	if err != nil {
 		utils.PrintLog(utils.LogLevelError, utils.WORKFLOWS, "", fmt.Sprintf("Error retrieving the deployed workflow list: %s", err))
 		utils.MarkResTypeFailure(utils.WORKFLOWS)
@@ -53,57 +53,57 @@ func ImportAll(inputDirPath string) {
 		utils.MarkResTypeFailure(utils.WORKFLOWS)
 		return
 	}
-	if utils.TOOL_CONFIGS.AllowDelete {
-		removeDeletedDeployedWorkflows(files, existingWorkflows)
+	if session.IsDeleteAllowed(utils.WORKFLOWS) {
+		removeDeletedDeployedWorkflows(session, files, existingWorkflows)
 	}
 
 	var existingAssoc []workflowAssociation
 	failedWorkflows := make(map[string]struct{})
 	if !assocSharingSupported {
-		existingAssoc, err = getWorkflowAssociationsList()
+		existingAssoc, err = getWorkflowAssociationsList(session)
 		if err != nil {
 			utils.PrintLog(utils.LogLevelError, utils.WORKFLOWS, "", fmt.Sprintf("Error retrieving the deployed workflow association list: %s", err))
-			utils.UpdateFailureSummary(utils.WORKFLOWS, utils.WORKFLOW_ASSOCIATIONS.String())
+			utils.UpdateFailureSummary(utils.WORKFLOWS, utils.WORKFLOW_ASSOCIATIONS.String(), utils.IMPORT, err)
 			return
 		}
 
-		if utils.TOOL_CONFIGS.AllowDelete {
+		if session.ToolConfigs.AllowDelete {
 			localAssoc, err := readLocalAssociationNames(importFilePath)
 			if err != nil {
 				utils.PrintLog(utils.LogLevelError, utils.WORKFLOWS, "", fmt.Sprintf("Error reading local workflow association list: %s", err))
-				utils.UpdateFailureSummary(utils.WORKFLOWS, utils.WORKFLOW_ASSOCIATIONS.String())
+				utils.UpdateFailureSummary(utils.WORKFLOWS, utils.WORKFLOW_ASSOCIATIONS.String(), utils.IMPORT, err)
 				return
 			}
-			failedWorkflows, _ = removeDeletedDeployedWfAssociations(localAssoc, existingAssoc)
+			failedWorkflows, _ = removeDeletedDeployedWfAssociations(session, localAssoc, existingAssoc)
 		}
 	}
 
-	for _, file := range files {
+	session.ProcessConcurrently(len(files), func(i int) {
+		file := files[i]
 		wfFilePath := filepath.Join(importFilePath, file.Name())
 		fileInfo := utils.GetFileInfo(wfFilePath)
 		workflowName := fileInfo.ResourceName
 
 		if workflowName == utils.WORKFLOW_ASSOCIATIONS.String() {
-			continue
+			return
 		}
 		if _, failed := failedWorkflows[workflowName]; failed {
-			utils.PrintLog(utils.LogLevelError, utils.WORKFLOWS, workflowName, "Skipping workflow: deleting stale workflow associations failed")
-			utils.UpdateFailureSummary(utils.WORKFLOWS, workflowName)
-			continue
+			utils.UpdateFailureSummaryAndLog(utils.WORKFLOWS, workflowName, utils.IMPORT, fmt.Errorf("deleting stale workflow associations failed"), "Skipping workflow: deleting stale workflow associations failed")
+			return
 		}
 
-		if !utils.IsResourceExcluded(workflowName, utils.TOOL_CONFIGS.WorkflowConfigs) {
+		if !utils.IsResourceExcluded(workflowName, session.ToolConfigs.WorkflowConfigs) && session.MatchesFileFilter(utils.WORKFLOWS, wfFilePath, getWorkflowKeywordMapping(session, workflowName), utils.IMPORT) {
 			workflowId := getWorkflowId(workflowName, existingWorkflows)
-			if err := importWorkflow(workflowName, workflowId, wfFilePath, existingAssoc); err != nil {
-				utils.PrintLog(utils.LogLevelError, utils.WORKFLOWS, workflowName, fmt.Sprintf("Error importing workflow: %s", err))
-				utils.UpdateFailureSummary(utils.WORKFLOWS, workflowName)
+			utils.MarkResourceStart(utils.WORKFLOWS, workflowName)
+			if err := importWorkflow(session, workflowName, workflowId, wfFilePath, existingAssoc); err != nil {
+				utils.UpdateFailureSummaryAndLog(utils.WORKFLOWS, workflowName, utils.IMPORT, err, fmt.Sprintf("Error importing workflow: %s", err))
 			}
 		}
-	}
+	})
 	utils.PrintLog(utils.LogLevelWarn, utils.WORKFLOWS, "", "Users associated with workflow steps are removed during import")
 }
 
-func importWorkflow(workflowName string, workflowId string, importFilePath string, existingAssoc []workflowAssociation) error {
+func importWorkflow(session *utils.Session, workflowName string, workflowId string, importFilePath string, existingAssoc []workflowAssociation) error {
 
 	format, err := utils.FormatFromExtension(filepath.Ext(importFilePath))
 	if err != nil {
@@ -114,8 +114,20 @@ func importWorkflow(workflowName string, workflowId string, importFilePath strin
 		return fmt.Errorf("error when reading the file for workflow: %w", err)
 	}
 
-	keywordMapping := getWorkflowKeywordMapping(workflowName)
-	modifiedFileData := utils.ReplaceKeywords(string(fileBytes), keywordMapping)
+	keywordMapping := getWorkflowKeywordMapping(session, workflowName)
+	modifiedFileData := session.ReplaceKeywords(string(fileBytes), keywordMapping)
+	if err := utils.CheckUnresolvedKeywords(utils.WORKFLOWS, importFilePath, modifiedFileData, keywordMapping); err != nil {
+		return err
+	}
+	modifiedFileData, err = session.ApplyOverlay(utils.WORKFLOWS, importFilePath, modifiedFileData)
+	if err != nil {
+		return err
+	}
+
+	if utils.DRY_RUN {
+		utils.AddToPlan(utils.WORKFLOWS, workflowName, utils.GetImportAction(workflowId != ""), "")
+		return nil
+	}
 
 	requestBody, associations, err := prepareWorkflowRequestBody([]byte(modifiedFileData), format)
 	if err != nil {
@@ -123,16 +135,16 @@ func importWorkflow(workflowName string, workflowId string, importFilePath strin
 	}
 
 	if workflowId == "" {
-		return createWorkflow(requestBody, workflowName, associations, existingAssoc)
+		return createWorkflow(session, requestBody, workflowName, associations, existingAssoc)
 	}
-	return updateWorkflow(workflowId, requestBody, workflowName, associations, existingAssoc)
+	return updateWorkflow(session, workflowId, requestBody, workflowName, associations, existingAssoc)
 }
 
-func createWorkflow(requestBody []byte, workflowName string, associations []map[string]interface{}, existingAssoc []workflowAssociation) error {
+func createWorkflow(session *utils.Session, requestBody []byte, workflowName string, associations []map[string]interface{}, existingAssoc []workflowAssociation) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.WORKFLOWS, workflowName, "Creating new workflow")
 
-	resp, err := utils.SendPostRequest(utils.WORKFLOWS, requestBody)
+	resp, err := session.SendPostRequest(utils.WORKFLOWS, requestBody)
 	if err != nil {
 		return fmt.Errorf("error when importing workflow: %w", err)
 	}
@@ -142,45 +154,43 @@ func createWorkflow(requestBody []byte, workflowName string, associations []map[
 	if _, err := utils.ParseResponseBody(resp, &created); err != nil {
 		return fmt.Errorf("error reading create workflow response: %w", err)
 	}
-	if err := syncWorkflowAssociations(created.ID, associations, existingAssoc); err != nil {
+	if err := syncWorkflowAssociations(session, created.ID, associations, existingAssoc); err != nil {
 		return fmt.Errorf("error syncing workflow associations: %w", err)
 	}
 
-	utils.UpdateSuccessSummary(utils.WORKFLOWS, utils.IMPORT)
-	utils.PrintLog(utils.LogLevelInfo, utils.WORKFLOWS, workflowName, "Imported successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.WORKFLOWS, workflowName, utils.IMPORT, "Imported successfully")
 	return nil
 }
 
-func updateWorkflow(workflowId string, requestBody []byte, workflowName string, associations []map[string]interface{}, existingAssoc []workflowAssociation) error {
+func updateWorkflow(session *utils.Session, workflowId string, requestBody []byte, workflowName string, associations []map[string]interface{}, existingAssoc []workflowAssociation) error {
 
 	utils.PrintLog(utils.LogLevelInfo, utils.WORKFLOWS, workflowName, "Updating workflow")
 
-	resp, err := utils.SendPutRequest(utils.WORKFLOWS, workflowId, requestBody)
+	resp, err := session.SendPutRequest(utils.WORKFLOWS, workflowId, requestBody)
 	if err != nil {
 		return fmt.Errorf("error when updating workflow: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if err := syncWorkflowAssociations(workflowId, associations, existingAssoc); err != nil {
+	if err := syncWorkflowAssociations(session, workflowId, associations, existingAssoc); err != nil {
 		return fmt.Errorf("error syncing workflow associations: %w", err)
 	}
 
-	utils.UpdateSuccessSummary(utils.WORKFLOWS, utils.UPDATE)
-	utils.PrintLog(utils.LogLevelInfo, utils.WORKFLOWS, workflowName, "Updated successfully")
+	utils.UpdateSuccessSummaryAndLog(utils.WORKFLOWS, workflowName, utils.UPDATE, "Updated successfully")
 	return nil
 }
 
-func syncWorkflowAssociations(workflowId string, associations []map[string]interface{}, deployedAssoc []workflowAssociation) error {
+func syncWorkflowAssociations(session *utils.Session, workflowId string, associations []map[string]interface{}, deployedAssoc []workflowAssociation) error {
 
 	if assocSharingSupported {
-		_, assocs, err := getAssociationsOfWorkflow(workflowId)
+		_, assocs, err := getAssociationsOfWorkflow(session, workflowId)
 		if err != nil {
 			return fmt.Errorf("error retrieving deployed workflow associations: %w", err)
 		}
 		deployedAssoc = assocs
 
-		if utils.TOOL_CONFIGS.AllowDelete {
-			if _, err := removeDeletedDeployedWfAssociations(getLocalWfAssocNames(associations), deployedAssoc); err != nil {
+		if session.ToolConfigs.AllowDelete {
+			if _, err := removeDeletedDeployedWfAssociations(session, getLocalWfAssocNames(associations), deployedAssoc); err != nil {
 				return fmt.Errorf("error removing deleted workflow associations: %w", err)
 			}
 		}
@@ -197,11 +207,11 @@ func syncWorkflowAssociations(workflowId string, associations []map[string]inter
 		}
 
 		if assocId := getWfAssocId(assocName, deployedAssoc); assocId != "" {
-			if err := updateAssociation(assocId, requestBody, assocName); err != nil {
+			if err := updateAssociation(session, assocId, requestBody, assocName); err != nil {
 				return err
 			}
 		} else {
-			if err := createAssociation(requestBody, assocName, isEnabled); err != nil {
+			if err := createAssociation(session, requestBody, assocName, isEnabled); err != nil {
 				return err
 			}
 		}
@@ -209,25 +219,25 @@ func syncWorkflowAssociations(workflowId string, associations []map[string]inter
 	return nil
 }
 
-func createAssociation(requestBody []byte, associationName string, isEnabled bool) error {
+func createAssociation(session *utils.Session, requestBody []byte, associationName string, isEnabled bool) error {
 
-	resp, err := utils.SendPostRequest(utils.WORKFLOW_ASSOCIATIONS, requestBody)
+	resp, err := session.SendPostRequest(utils.WORKFLOW_ASSOCIATIONS, requestBody)
 	if err != nil {
 		return fmt.Errorf("error when creating workflow association %s: %w", associationName, err)
 	}
 	defer resp.Body.Close()
 
 	if !isEnabled {
-		if err := disableAssociation(resp, requestBody); err != nil {
+		if err := disableAssociation(session, resp, requestBody); err != nil {
 			return fmt.Errorf("error when disabling workflow association %s: %w", associationName, err)
 		}
 	}
 	return nil
 }
 
-func updateAssociation(associationId string, requestBody []byte, associationName string) error {
+func updateAssociation(session *utils.Session, associationId string, requestBody []byte, associationName string) error {
 
-	resp, err := utils.SendPatchRequest(utils.WORKFLOW_ASSOCIATIONS, associationId, requestBody)
+	resp, err := session.SendPatchRequest(utils.WORKFLOW_ASSOCIATIONS, associationId, requestBody)
 	if err != nil {
 		return fmt.Errorf("error when updating workflow association %s: %w", associationName, err)
 	}
@@ -236,7 +246,7 @@ func updateAssociation(associationId string, requestBody []byte, associationName
 	return nil
 }
 
-func removeDeletedDeployedWorkflows(localFiles []os.FileInfo, deployedWorkflows []workflow) {
+func removeDeletedDeployedWorkflows(session *utils.Session, localFiles []os.FileInfo, deployedWorkflows []workflow) {
 
 	if len(deployedWorkflows) == 0 {
 		return
@@ -252,22 +262,25 @@ func removeDeletedDeployedWorkflows(localFiles []os.FileInfo, deployedWorkflows
 		if _, existsLocally := localResourceNames[wf.Name]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(wf.Name, utils.TOOL_CONFIGS.WorkflowConfigs) {
+		if utils.IsResourceExcluded(wf.Name, session.ToolConfigs.WorkflowConfigs) {
 			utils.PrintLog(utils.LogLevelInfo, utils.WORKFLOWS, wf.Name, "Excluded from deletion.")
 			continue
 		}
 
+		if utils.DRY_RUN {
+			utils.AddToPlan(utils.WORKFLOWS, wf.Name, utils.PLAN_DELETE, "Not found locally")
+			continue
+		}
 		utils.PrintLog(utils.LogLevelInfo, utils.WORKFLOWS, wf.Name, "Not found locally. Deleting workflow.")
-		if err := utils.SendDeleteRequest(wf.ID, utils.WORKFLOWS); err != nil {
-			utils.UpdateFailureSummary(utils.WORKFLOWS, wf.Name)
-			utils.PrintLog(utils.LogLevelError, utils.WORKFLOWS, wf.Name, fmt.Sprintf("Error deleting workflow: %s", err))
+		if err := session.SendDeleteRequest(wf.ID, utils.WORKFLOWS); err != nil {
+			utils.UpdateFailureSummaryAndLog(utils.WORKFLOWS, wf.Name, utils.DELETE, err, fmt.Sprintf("Error deleting workflow: %s", err))
 		} else {
-			utils.UpdateSuccessSummary(utils.WORKFLOWS, utils.DELETE)
+			utils.UpdateSuccessSummary(utils.WORKFLOWS, wf.Name, utils.DELETE)
 		}
 	}
 }
 
-func removeDeletedDeployedWfAssociations(localNames []string, deployedAssociations []workflowAssociation) (failedWorkflows map[string]struct{}, err error) {
+func removeDeletedDeployedWfAssociations(session *utils.Session, localNames []string, deployedAssociations []workflowAssociation) (failedWorkflows map[string]struct{}, err error) {
 
 	failedWorkflows = make(map[string]struct{})
 	if len(deployedAssociations) == 0 {
@@ -282,10 +295,14 @@ func removeDeletedDeployedWfAssociations(localNames []string, deployedAssociatio
 		if _, existsLocally := localSet[assoc.Name]; existsLocally {
 			continue
 		}
-		if utils.IsResourceExcluded(assoc.WorkflowName, utils.TOOL_CONFIGS.WorkflowConfigs) {
+		if utils.IsResourceExcluded(assoc.WorkflowName, session.ToolConfigs.WorkflowConfigs) {
+			continue
+		}
+		if utils.DRY_RUN {
+			utils.AddSubResourceToPlan(utils.WORKFLOWS, assoc.WorkflowName, assoc.Name, utils.PLAN_DELETE, "Workflow association not found locally")
 			continue
 		}
-		if err := utils.SendDeleteRequest(assoc.ID, utils.WORKFLOW_ASSOCIATIONS); err != nil {
+		if err := session.SendDeleteRequest(assoc.ID, utils.WORKFLOW_ASSOCIATIONS); err != nil {
 			if assocSharingSupported {
 				return nil, fmt.Errorf("error deleting workflow association: %s. %w", assoc.Name, err)
 			} else {
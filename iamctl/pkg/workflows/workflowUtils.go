@@ -24,6 +24,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"path/filepath"
+	"sort"
+	"sync"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
 )
@@ -50,10 +52,11 @@ type associationsOfWorkflowResponse struct {
 var assocSharingSupported bool
 var assocRulesSupported bool
 var exportedAssociationNames []string
+var exportedAssociationsLock sync.Mutex
 
-func getWorkflowList() ([]workflow, error) {
+func getWorkflowList(session *utils.Session) ([]workflow, error) {
 
-	data, err := utils.SendPaginatedGetListRequest(
+	data, err := session.SendPaginatedGetListRequest(
 		utils.WORKFLOWS,
 		"totalResults",
 		"count",
@@ -72,9 +75,9 @@ func getWorkflowList() ([]workflow, error) {
 	return workflows, nil
 }
 
-func getWorkflowAssociationsList() ([]workflowAssociation, error) {
+func getWorkflowAssociationsList(session *utils.Session) ([]workflowAssociation, error) {
 
-	data, err := utils.SendPaginatedGetListRequest(
+	data, err := session.SendPaginatedGetListRequest(
 		utils.WORKFLOW_ASSOCIATIONS,
 		"totalResults",
 		"count",
@@ -113,12 +116,12 @@ func getLocalWfAssocNames(associations []map[string]interface{}) []string {
 	return names
 }
 
-func getWorkflowKeywordMapping(workflowName string) map[string]interface{} {
+func getWorkflowKeywordMapping(session *utils.Session, workflowName string) map[string]interface{} {
 
-	if utils.KEYWORD_CONFIGS.WorkflowConfigs != nil {
-		return utils.ResolveAdvancedKeywordMapping(workflowName, utils.KEYWORD_CONFIGS.WorkflowConfigs)
+	if session.KeywordConfigs.WorkflowConfigs != nil {
+		return session.ResolveAdvancedKeywordMapping(workflowName, session.KeywordConfigs.WorkflowConfigs)
 	}
-	return utils.KEYWORD_CONFIGS.KeywordMappings
+	return session.KeywordConfigs.KeywordMappings
 }
 
 func getWorkflowId(name string, list []workflow) string {
@@ -141,9 +144,9 @@ func getWfAssocId(name string, list []workflowAssociation) string {
 	return ""
 }
 
-func getAssociationsOfWorkflow(workflowId string) ([]interface{}, []workflowAssociation, error) {
+func getAssociationsOfWorkflow(session *utils.Session, workflowId string) ([]interface{}, []workflowAssociation, error) {
 
-	body, err := utils.SendGetListRequest(utils.WORKFLOW_ASSOCIATIONS,
+	body, err := session.SendGetListRequest(utils.WORKFLOW_ASSOCIATIONS,
 		utils.WithQueryParams(map[string]string{"filter": "workflowId eq " + workflowId}))
 	if err != nil {
 		return nil, nil, fmt.Errorf("error while retrieving associations: %w", err)
@@ -176,7 +179,9 @@ func processWorkflowAssociation(association interface{}) error {
 	if !ok {
 		return fmt.Errorf("unexpected format for associationName in association")
 	}
+	exportedAssociationsLock.Lock()
 	exportedAssociationNames = append(exportedAssociationNames, name)
+	exportedAssociationsLock.Unlock()
 	delete(assocMap, "workflowName")
 	delete(assocMap, "rule")
 	return nil
@@ -282,14 +287,14 @@ func prepareAssociationRequestBody(assocMap map[string]interface{}, workflowId s
 	return body, isEnabled, nil
 }
 
-func disableAssociation(resp *http.Response, requestBody []byte) error {
+func disableAssociation(session *utils.Session, resp *http.Response, requestBody []byte) error {
 
 	var created workflowAssociation
 	if _, err := utils.ParseResponseBody(resp, &created); err != nil {
 		return fmt.Errorf("error reading create association response: %w", err)
 	}
 
-	patchResp, err := utils.SendPatchRequest(utils.WORKFLOW_ASSOCIATIONS, created.ID, requestBody)
+	patchResp, err := session.SendPatchRequest(utils.WORKFLOW_ASSOCIATIONS, created.ID, requestBody)
 	if err != nil {
 		return fmt.Errorf("error updating association: %w", err)
 	}
@@ -330,31 +335,34 @@ func writeWorkflowAssociationsList(outputDirPath string, formatString string) er
 	format := utils.FormatFromString(formatString)
 	exportedFileName := utils.GetExportedFilePath(outputDirPath, utils.WORKFLOW_ASSOCIATIONS.String(), format)
 
+	// Associations are exported concurrently, so the names are sorted to keep the file content stable.
+	sort.Strings(exportedAssociationNames)
 	data, err := utils.Serialize(exportedAssociationNames, format, utils.WORKFLOW_ASSOCIATIONS)
 	if err != nil {
 		return fmt.Errorf("error serializing workflow associations list: %w", err)
 	}
 
-	if err := ioutil.WriteFile(exportedFileName, data, 0644); err != nil {
+	if err := utils.WriteExportedFile(exportedFileName, data, utils.WORKFLOW_ASSOCIATIONS); err != nil {
 		return fmt.Errorf("error writing workflow associations list: %w", err)
 	}
 	return nil
 }
 
-func updateWorkflowExportSummary(success bool, successCount int) {
+func updateWorkflowExportSummary(associationsErr error, exportedWorkflowNames []string) {
 
-	if !success {
-		utils.UpdateFailureSummary(utils.WORKFLOWS, utils.WORKFLOW_ASSOCIATIONS.String())
+	if associationsErr != nil {
+		utils.UpdateFailureSummaryAndLog(utils.WORKFLOWS, utils.WORKFLOW_ASSOCIATIONS.String(), utils.EXPORT, associationsErr,
+			fmt.Sprintf("Error writing workflow associations list: %s", associationsErr))
 		return
 	}
-	for i := 0; i < successCount; i++ {
-		utils.UpdateSuccessSummary(utils.WORKFLOWS, utils.EXPORT)
+	for _, workflowName := range exportedWorkflowNames {
+		utils.UpdateSuccessSummaryAndLog(utils.WORKFLOWS, workflowName, utils.EXPORT, "Exported successfully")
 	}
 }
 
-func setWorkflowVersionConfigs() {
+func setWorkflowVersionConfigs(session *utils.Session) {
 
-	res, err := utils.CompareVersions(utils.SERVER_CONFIGS.ServerVersion, utils.MIN_VERSION_ASSOCIATION_SHARING_ACROSS_WORKFLOWS)
+	res, err := utils.CompareVersions(session.ServerConfigs.ServerVersion, utils.MIN_VERSION_ASSOCIATION_SHARING_ACROSS_WORKFLOWS)
 
 	// Assume association sharing is supported if the server version is ""
 	if err != nil || res >= 0 {
@@ -363,7 +371,7 @@ func setWorkflowVersionConfigs() {
 		assocSharingSupported = false
 	}
 
-	res, err = utils.CompareVersions(utils.SERVER_CONFIGS.ServerVersion, utils.MIN_VERSION_WORKFLOW_ASSOCIATION_RULES)
+	res, err = utils.CompareVersions(session.ServerConfigs.ServerVersion, utils.MIN_VERSION_WORKFLOW_ASSOCIATION_RULES)
 
 	// Assume association rules are supported if the server version is ""
 	if err != nil || res >= 0 {
@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestProcessConcurrently(t *testing.T) {
+	tests := []struct {
+		name                string
+		concurrency         int
+		count               int
+		expectedMaxParallel int
+	}{
+		{
+			name:                "Resources are processed sequentially by default",
+			concurrency:         0,
+			count:               5,
+			expectedMaxParallel: 1,
+		},
+		{
+			name:                "Concurrency of one processes resources sequentially",
+			concurrency:         1,
+			count:               5,
+			expectedMaxParallel: 1,
+		},
+		{
+			name:                "Resources are processed in parallel up to the concurrency",
+			concurrency:         3,
+			count:               9,
+			expectedMaxParallel: 3,
+		},
+		{
+			name:                "Concurrency larger than the resource count",
+			concurrency:         10,
+			count:               2,
+			expectedMaxParallel: 2,
+		},
+		{
+			name:                "No resources to process",
+			concurrency:         3,
+			count:               0,
+			expectedMaxParallel: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			session := &utils.Session{ToolConfigs: utils.ToolConfigs{Concurrency: tc.concurrency}}
+
+			var lock sync.Mutex
+			processed := make([]int, tc.count)
+			running, maxRunning := 0, 0
+			session.ProcessConcurrently(tc.count, func(index int) {
+				lock.Lock()
+				processed[index]++
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				lock.Unlock()
+
+				time.Sleep(50 * time.Millisecond)
+
+				lock.Lock()
+				running--
+				lock.Unlock()
+			})
+
+			for i, count := range processed {
+				if count != 1 {
+					t.Errorf("Expected index %d to be processed once, processed %d times", i, count)
+				}
+			}
+			if maxRunning != tc.expectedMaxParallel {
+				t.Errorf("Expected at most %d resources processed in parallel, got %d", tc.expectedMaxParallel, maxRunning)
+			}
+		})
+	}
+}
+
+func TestConcurrentSummaryUpdates(t *testing.T) {
+	utils.ResTypeSummaryMap = nil
+	utils.AggregatedSummary = utils.Summary{}
+	utils.ResetResourceIdentifierMap()
+	defer func() {
+		utils.ResTypeSummaryMap = nil
+		utils.AggregatedSummary = utils.Summary{}
+		utils.ResetResourceIdentifierMap()
+	}()
+
+	session := &utils.Session{ToolConfigs: utils.ToolConfigs{Concurrency: 8}}
+	session.ProcessConcurrently(100, func(index int) {
+		name := fmt.Sprintf("role-%d", index)
+		utils.MarkResourceStart(utils.ROLES, name)
+		if index%4 == 0 {
+			utils.UpdateFailureSummary(utils.ROLES, name, utils.EXPORT, fmt.Errorf("export failed"))
+			return
+		}
+		utils.AddToIdentifierMap(utils.ROLES, "id-"+name, name, utils.EXPORT)
+		utils.UpdateSuccessSummary(utils.ROLES, name, utils.EXPORT)
+	})
+
+	summary := utils.ResTypeSummaryMap[utils.ROLES]
+	if summary.SuccessfulExport != 75 {
+		t.Errorf("Expected 75 successful exports, got %d", summary.SuccessfulExport)
+	}
+	if summary.FailedCount != 25 || len(summary.FailedResources) != 25 {
+		t.Errorf("Expected 25 failed resources, got %d (%d names)", summary.FailedCount, len(summary.FailedResources))
+	}
+	if len(summary.Resources) != 100 {
+		t.Errorf("Expected 100 resource results, got %d", len(summary.Resources))
+	}
+	if utils.AggregatedSummary.TotalRequests != 100 {
+		t.Errorf("Expected 100 total operations, got %d", utils.AggregatedSummary.TotalRequests)
+	}
+	if identifiers := utils.GetResourceIdentifierMap(utils.ROLES); len(identifiers) != 75 {
+		t.Errorf("Expected 75 registered identifiers, got %d", len(identifiers))
+	}
+}
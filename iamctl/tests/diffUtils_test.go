@@ -0,0 +1,242 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestDiffFileContent(t *testing.T) {
+	tests := []struct {
+		name          string
+		fileName      string
+		sourceContent string
+		targetContent string
+		expected      []utils.FieldDiff
+	}{
+		{
+			name:     "Reordered keyed array elements are not reported",
+			fileName: "role.yml",
+			sourceContent: `displayName: role1
+permissions:
+- value: internal_login
+  display: Login
+- value: internal_user_mgt_view
+  display: View Users
+`,
+			targetContent: `displayName: role1
+permissions:
+- value: internal_user_mgt_view
+  display: View Users
+- value: internal_login
+  display: Login
+`,
+			expected: nil,
+		},
+		{
+			name:     "Reordered scalar array elements are not reported",
+			fileName: "role.json",
+			sourceContent: `{"displayName": "role1", "audience": {"type": "organization"},
+				"schemas": ["urn:a", "urn:b"]}`,
+			targetContent: `{"displayName": "role1", "audience": {"type": "organization"},
+				"schemas": ["urn:b", "urn:a"]}`,
+			expected: nil,
+		},
+		{
+			name:     "Changes in keyed array elements are reported with identifier paths",
+			fileName: "role.yml",
+			sourceContent: `displayName: role1
+permissions:
+- value: internal_login
+  display: Login
+- value: internal_user_mgt_view
+  display: View Users
+`,
+			targetContent: `displayName: role1
+permissions:
+- value: internal_login
+  display: Sign in
+- value: internal_user_mgt_list
+  display: List Users
+`,
+			expected: []utils.FieldDiff{
+				{Path: "permissions.[value=internal_login].display", Status: utils.DIFF_MODIFIED, Source: "Login", Target: "Sign in"},
+				{Path: "permissions.[value=internal_user_mgt_list]", Status: utils.DIFF_ONLY_TARGET,
+					Target: map[string]interface{}{"value": "internal_user_mgt_list", "display": "List Users"}},
+				{Path: "permissions.[value=internal_user_mgt_view]", Status: utils.DIFF_ONLY_SOURCE,
+					Source: map[string]interface{}{"value": "internal_user_mgt_view", "display": "View Users"}},
+			},
+		},
+		{
+			name:          "Fields present on one side only are reported",
+			fileName:      "role.yml",
+			sourceContent: "displayName: role1\naudience:\n  type: organization\n",
+			targetContent: "displayName: role1\nusers:\n- admin\n",
+			expected: []utils.FieldDiff{
+				{Path: "audience", Status: utils.DIFF_ONLY_SOURCE, Source: map[string]interface{}{"type": "organization"}},
+				{Path: "users", Status: utils.DIFF_ONLY_TARGET, Target: []interface{}{"admin"}},
+			},
+		},
+		{
+			name:          "Unchanged keywords are not reported",
+			fileName:      "role.yml",
+			sourceContent: "displayName: role1\ndescription: Role for {{ENV}}\n",
+			targetContent: "displayName: role1\ndescription: Role for {{ENV}}\n",
+			expected:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &utils.Session{}
+			diffs, err := session.DiffFileContent(tt.fileName, []byte(tt.sourceContent), []byte(tt.targetContent), utils.ROLES)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(diffs, tt.expected) {
+				t.Errorf("Expected %v but got %v", tt.expected, diffs)
+			}
+		})
+	}
+}
+
+func TestReplaceValuesWithKeywords(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           interface{}
+		keywordMapping map[string]interface{}
+		expected       interface{}
+	}{
+		{
+			name: "Keyword values are replaced in nested fields",
+			data: map[string]interface{}{
+				"callbackUrl": "https://dev.example.com/callback",
+				"urls":        []interface{}{"https://dev.example.com", "https://other.com"},
+			},
+			keywordMapping: map[string]interface{}{"ENV_HOST": "dev.example.com"},
+			expected: map[string]interface{}{
+				"callbackUrl": "https://{{ENV_HOST}}/callback",
+				"urls":        []interface{}{"https://{{ENV_HOST}}", "https://other.com"},
+			},
+		},
+		{
+			name:           "Longer values are replaced first",
+			data:           map[string]interface{}{"url": "https://api.dev.example.com"},
+			keywordMapping: map[string]interface{}{"HOST": "dev.example.com", "API_HOST": "api.dev.example.com"},
+			expected:       map[string]interface{}{"url": "https://{{API_HOST}}"},
+		},
+		{
+			name:           "Empty and non string values are ignored",
+			data:           map[string]interface{}{"enabled": true, "name": "app"},
+			keywordMapping: map[string]interface{}{"EMPTY": "", "FLAG": true},
+			expected:       map[string]interface{}{"enabled": true, "name": "app"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.ReplaceValuesWithKeywords(tt.data, tt.keywordMapping)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDiffExports(t *testing.T) {
+	sourceFiles := map[string]utils.ExportedFile{
+		"Roles/role1.yml": {ResourceType: utils.ROLES, Content: []byte("displayName: role1\n")},
+		"Roles/role2.yml": {ResourceType: utils.ROLES, Content: []byte("displayName: role2\n")},
+		"Roles/role3.yml": {ResourceType: utils.ROLES, Content: []byte("displayName: role3\ndescription: old\n")},
+	}
+	targetFiles := map[string]utils.ExportedFile{
+		"Roles/role1.yml": {ResourceType: utils.ROLES, Content: []byte("displayName: role1\n")},
+		"Roles/role3.yml": {ResourceType: utils.ROLES, Content: []byte("displayName: role3\ndescription: new\n")},
+		"Roles/role4.yml": {ResourceType: utils.ROLES, Content: []byte("displayName: role4\n")},
+	}
+	expected := []utils.ResourceDiff{
+		{ResourceType: "Roles", ResourceName: "role2", FilePath: "Roles/role2.yml", Status: utils.DIFF_ONLY_SOURCE},
+		{ResourceType: "Roles", ResourceName: "role3", FilePath: "Roles/role3.yml", Status: utils.DIFF_MODIFIED,
+			Fields: []utils.FieldDiff{{Path: "description", Status: utils.DIFF_MODIFIED, Source: "old", Target: "new"}}},
+		{ResourceType: "Roles", ResourceName: "role4", FilePath: "Roles/role4.yml", Status: utils.DIFF_ONLY_TARGET},
+	}
+
+	session := &utils.Session{}
+	diffs := session.DiffExports(sourceFiles, targetFiles)
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Errorf("Expected %v but got %v", expected, diffs)
+	}
+}
+
+func TestRemoveDeletedLocalResourcesInMemory(t *testing.T) {
+	baseDir, err := ioutil.TempDir("", "local-only")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(baseDir)
+
+	var expected []string
+	var resourceDirs []string
+	for i := 0; i < 10; i++ {
+		resourceDir := filepath.Join(baseDir, fmt.Sprintf("type%d", i))
+		if err := os.MkdirAll(resourceDir, 0700); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, name := range []string{"deployed.yml", "local.yml"} {
+			if err := ioutil.WriteFile(filepath.Join(resourceDir, name), []byte("name: value\n"), 0600); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		resourceDirs = append(resourceDirs, resourceDir)
+		expected = append(expected, filepath.Join(resourceDir, "local.yml"))
+	}
+
+	utils.StartInMemoryExport()
+	defer utils.StopInMemoryExport()
+	var wg sync.WaitGroup
+	for _, resourceDir := range resourceDirs {
+		wg.Add(1)
+		go func(resourceDir string) {
+			defer wg.Done()
+			utils.RemoveDeletedLocalResources(resourceDir, []string{"deployed"})
+		}(resourceDir)
+	}
+	wg.Wait()
+
+	localOnlyFiles := append([]string{}, utils.LocalOnlyFiles...)
+	sort.Strings(localOnlyFiles)
+	sort.Strings(expected)
+	if !reflect.DeepEqual(localOnlyFiles, expected) {
+		t.Errorf("Expected local only files %v but got %v", expected, localOnlyFiles)
+	}
+	for _, filePath := range expected {
+		if _, err := os.Stat(filePath); err != nil {
+			t.Errorf("Expected local file %s to be kept: %v", filePath, err)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestGetExitCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		setup        func()
+		strict       bool
+		expectedCode int
+	}{
+		{
+			name: "All operations successful",
+			setup: func() {
+				utils.UpdateSuccessSummary(utils.APPLICATIONS, "app1", utils.IMPORT)
+				utils.UpdateSkipSummary(utils.WORKFLOWS, utils.SKIP_REASON_UNSUPPORTED_VERSION)
+			},
+			expectedCode: utils.EXIT_CODE_SUCCESS,
+		},
+		{
+			name: "Some operations failed",
+			setup: func() {
+				utils.UpdateSuccessSummary(utils.APPLICATIONS, "app1", utils.IMPORT)
+				utils.UpdateFailureSummary(utils.APPLICATIONS, "app2", utils.IMPORT, fmt.Errorf("bad request"))
+			},
+			expectedCode: utils.EXIT_CODE_PARTIAL_FAILURE,
+		},
+		{
+			name: "Resource type failed while others succeeded",
+			setup: func() {
+				utils.UpdateSuccessSummary(utils.APPLICATIONS, "app1", utils.EXPORT)
+				utils.MarkResTypeFailure(utils.ROLES)
+			},
+			expectedCode: utils.EXIT_CODE_PARTIAL_FAILURE,
+		},
+		{
+			name: "All operations failed",
+			setup: func() {
+				utils.UpdateFailureSummary(utils.APPLICATIONS, "app1", utils.IMPORT, fmt.Errorf("bad request"))
+				utils.MarkResTypeFailure(utils.ROLES)
+			},
+			expectedCode: utils.EXIT_CODE_TOTAL_FAILURE,
+		},
+		{
+			name: "Unsupported resource type skipped in strict mode",
+			setup: func() {
+				utils.UpdateSuccessSummary(utils.APPLICATIONS, "app1", utils.IMPORT)
+				utils.UpdateSkipSummary(utils.WORKFLOWS, utils.SKIP_REASON_UNSUPPORTED_VERSION)
+			},
+			strict:       true,
+			expectedCode: utils.EXIT_CODE_UNSUPPORTED,
+		},
+		{
+			name: "Excluded resource type skipped in strict mode",
+			setup: func() {
+				utils.UpdateSuccessSummary(utils.APPLICATIONS, "app1", utils.IMPORT)
+				utils.UpdateSkipSummary(utils.WORKFLOWS, utils.SKIP_REASON_EXCLUDED)
+			},
+			strict:       true,
+			expectedCode: utils.EXIT_CODE_SUCCESS,
+		},
+		{
+			name: "Failures take precedence over unsupported skips in strict mode",
+			setup: func() {
+				utils.UpdateFailureSummary(utils.APPLICATIONS, "app1", utils.IMPORT, fmt.Errorf("bad request"))
+				utils.UpdateSkipSummary(utils.WORKFLOWS, utils.SKIP_REASON_UNSUPPORTED_VERSION)
+			},
+			strict:       true,
+			expectedCode: utils.EXIT_CODE_TOTAL_FAILURE,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.ResetSummary()
+			defer utils.ResetSummary()
+			tt.setup()
+
+			if code := utils.GetExitCode(tt.strict); code != tt.expectedCode {
+				t.Errorf("Expected exit code %d, got %d", tt.expectedCode, code)
+			}
+		})
+	}
+}
+
+func TestGetRunExitCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		setup        func()
+		reportErr    error
+		expectedCode int
+	}{
+		{
+			name: "Successful run with the report written",
+			setup: func() {
+				utils.UpdateSuccessSummary(utils.APPLICATIONS, "app1", utils.IMPORT)
+			},
+			expectedCode: utils.EXIT_CODE_SUCCESS,
+		},
+		{
+			name: "Successful run with the report not written",
+			setup: func() {
+				utils.UpdateSuccessSummary(utils.APPLICATIONS, "app1", utils.IMPORT)
+			},
+			reportErr:    fmt.Errorf("error writing report"),
+			expectedCode: utils.EXIT_CODE_CONFIG_ERROR,
+		},
+		{
+			name: "Partially failed run with the report not written",
+			setup: func() {
+				utils.UpdateSuccessSummary(utils.APPLICATIONS, "app1", utils.IMPORT)
+				utils.UpdateFailureSummary(utils.APPLICATIONS, "app2", utils.IMPORT, fmt.Errorf("bad request"))
+			},
+			reportErr:    fmt.Errorf("error writing report"),
+			expectedCode: utils.EXIT_CODE_PARTIAL_FAILURE,
+		},
+		{
+			name: "Failed run with the report not written",
+			setup: func() {
+				utils.UpdateFailureSummary(utils.APPLICATIONS, "app1", utils.IMPORT, fmt.Errorf("bad request"))
+			},
+			reportErr:    fmt.Errorf("error writing report"),
+			expectedCode: utils.EXIT_CODE_TOTAL_FAILURE,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.ResetSummary()
+			defer utils.ResetSummary()
+			tt.setup()
+
+			if code := utils.GetRunExitCode(false, tt.reportErr); code != tt.expectedCode {
+				t.Errorf("Expected exit code %d, got %d", tt.expectedCode, code)
+			}
+		})
+	}
+}
+
+func TestGetWriteExitCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		written      bool
+		expectedCode int
+	}{
+		{name: "No files written before the failure", written: false, expectedCode: utils.EXIT_CODE_TOTAL_FAILURE},
+		{name: "Some files written before the failure", written: true, expectedCode: utils.EXIT_CODE_PARTIAL_FAILURE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := utils.GetWriteExitCode(tt.written); code != tt.expectedCode {
+				t.Errorf("Expected exit code %d, got %d", tt.expectedCode, code)
+			}
+		})
+	}
+}
+
+func TestValidateCommandFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		validate    func() error
+		expectError bool
+	}{
+		{name: "Text output format", validate: func() error { return utils.ValidateOutputFormat("text") }},
+		{name: "JSON output format", validate: func() error { return utils.ValidateOutputFormat("json") }},
+		{name: "Unsupported output format", validate: func() error { return utils.ValidateOutputFormat("xml") }, expectError: true},
+		{
+			name:     "Diff of local resources",
+			validate: func() error { return utils.ValidateDiffSources("configs/dev", "exported", "", "") },
+		},
+		{
+			name:     "Diff of two environments",
+			validate: func() error { return utils.ValidateDiffSources("", "", "configs/dev", "configs/prod") },
+		},
+		{
+			name:        "Diff of two environments without the target",
+			validate:    func() error { return utils.ValidateDiffSources("", "", "configs/dev", "") },
+			expectError: true,
+		},
+		{
+			name:        "Diff of two environments with a local directory",
+			validate:    func() error { return utils.ValidateDiffSources("", "exported", "configs/dev", "configs/prod") },
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate()
+			if tt.expectError && err == nil {
+				t.Errorf("Expected an error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
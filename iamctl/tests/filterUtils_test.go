@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+var filterTestResource = map[string]interface{}{
+	"name": "payments",
+	"audience": map[string]interface{}{
+		"type": "APPLICATION",
+	},
+	"inboundProtocolConfiguration": map[string]interface{}{
+		"saml": map[string]interface{}{
+			"issuer": "payments-sp",
+		},
+	},
+	"claims": []interface{}{
+		map[string]interface{}{"uri": "http://wso2.org/claims/email", "mandatory": true},
+		map[string]interface{}{"uri": "http://wso2.org/claims/username", "mandatory": false},
+	},
+	"tags": map[interface{}]interface{}{
+		"team": "Payments Team",
+	},
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name          string
+		expression    string
+		expectedMatch bool
+		expectError   bool
+	}{
+		{
+			name:          "Value equals",
+			expression:    "audience.type == APPLICATION",
+			expectedMatch: true,
+		},
+		{
+			name:          "Value not equals",
+			expression:    "audience.type != APPLICATION",
+			expectedMatch: false,
+		},
+		{
+			name:          "Path exists",
+			expression:    "inboundProtocolConfiguration.saml != null",
+			expectedMatch: true,
+		},
+		{
+			name:          "Path does not exist",
+			expression:    "inboundProtocolConfiguration.oidc == null",
+			expectedMatch: true,
+		},
+		{
+			name:          "Quoted value with spaces",
+			expression:    "tags.team == 'Payments Team'",
+			expectedMatch: true,
+		},
+		{
+			name:          "Array element selector",
+			expression:    "claims.[uri=http://wso2.org/claims/email].mandatory == true",
+			expectedMatch: true,
+		},
+		{
+			name:          "All items selector matches any item",
+			expression:    "claims.[uri=all_items].mandatory == false",
+			expectedMatch: true,
+		},
+		{
+			name:          "All items selector with no matching item",
+			expression:    "claims.[uri=all_items].displayName != null",
+			expectedMatch: false,
+		},
+		{
+			name:          "And binds tighter than or",
+			expression:    "name == other || audience.type == APPLICATION && inboundProtocolConfiguration.saml != null",
+			expectedMatch: true,
+		},
+		{
+			name:          "Parentheses",
+			expression:    "(name == other || audience.type == APPLICATION) && inboundProtocolConfiguration.saml == null",
+			expectedMatch: false,
+		},
+		{
+			name:        "Missing operator",
+			expression:  "inboundProtocolConfiguration.saml",
+			expectError: true,
+		},
+		{
+			name:        "Missing value",
+			expression:  "audience.type ==",
+			expectError: true,
+		},
+		{
+			name:        "Unterminated quote",
+			expression:  "tags.team == 'Payments",
+			expectError: true,
+		},
+		{
+			name:        "Missing closing parenthesis",
+			expression:  "(name == payments",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := utils.ParseFilter(tt.expression)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error for %s", tt.expression)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if match := filter.Matches(filterTestResource); match != tt.expectedMatch {
+				t.Errorf("Expected match %v for %s, got %v", tt.expectedMatch, tt.expression, match)
+			}
+		})
+	}
+}
+
+func TestResourceFilterConfigs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filter")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	roleFile := filepath.Join(dir, "payments.yml")
+	content := "displayName: payments\naudience:\n  type: \"{{AUDIENCE_TYPE}}\"\n"
+	if err := ioutil.WriteFile(roleFile, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name                string
+		roleConfigs         map[string]interface{}
+		keywordMapping      map[string]interface{}
+		expectedMatch       bool
+		expectedFileMatch   bool
+		expectedAllowDelete bool
+	}{
+		{
+			name:                "No filter",
+			roleConfigs:         map[string]interface{}{},
+			expectedMatch:       true,
+			expectedFileMatch:   true,
+			expectedAllowDelete: true,
+		},
+		{
+			name:              "Matching filter",
+			roleConfigs:       map[string]interface{}{"FILTER": "audience.type == APPLICATION"},
+			keywordMapping:    map[string]interface{}{"AUDIENCE_TYPE": "APPLICATION"},
+			expectedMatch:     true,
+			expectedFileMatch: true,
+		},
+		{
+			name:              "Filter matched after replacing keywords",
+			roleConfigs:       map[string]interface{}{"FILTER": "audience.type == ORGANIZATION"},
+			keywordMapping:    map[string]interface{}{"AUDIENCE_TYPE": "ORGANIZATION"},
+			expectedMatch:     false,
+			expectedFileMatch: true,
+		},
+		{
+			name:              "Invalid filter matches no resource",
+			roleConfigs:       map[string]interface{}{"FILTER": "audience.type"},
+			expectedMatch:     false,
+			expectedFileMatch: false,
+		},
+		{
+			name:              "File that cannot be matched against the filter",
+			roleConfigs:       map[string]interface{}{"FILTER": "audience.type == APPLICATION"},
+			keywordMapping:    map[string]interface{}{"AUDIENCE_TYPE": "\"APPLICATION"},
+			expectedMatch:     true,
+			expectedFileMatch: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &utils.Session{ToolConfigs: utils.ToolConfigs{AllowDelete: true, RoleConfigs: tt.roleConfigs}}
+
+			if match := session.MatchesFilter(utils.ROLES, "payments", filterTestResource); match != tt.expectedMatch {
+				t.Errorf("Expected match %v, got %v", tt.expectedMatch, match)
+			}
+			if match := session.MatchesFileFilter(utils.ROLES, roleFile, tt.keywordMapping, utils.IMPORT); match != tt.expectedFileMatch {
+				t.Errorf("Expected file match %v, got %v", tt.expectedFileMatch, match)
+			}
+			if allowed := session.IsDeleteAllowed(utils.ROLES); allowed != tt.expectedAllowDelete {
+				t.Errorf("Expected delete allowed %v, got %v", tt.expectedAllowDelete, allowed)
+			}
+		})
+	}
+}
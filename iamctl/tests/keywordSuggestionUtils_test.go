@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+type keywordSuggestionResult struct {
+	Keyword   string
+	Source    interface{}
+	Target    interface{}
+	Locations []string
+}
+
+func TestSuggestKeywords(t *testing.T) {
+	tests := []struct {
+		name             string
+		sourceFiles      map[string]string
+		targetFiles      map[string]string
+		reservedKeywords []string
+		expected         []keywordSuggestionResult
+	}{
+		{
+			name:        "Whole differing value of a field",
+			sourceFiles: map[string]string{"Roles/role1.yml": "displayName: role1\ndescription: Dev role\n"},
+			targetFiles: map[string]string{"Roles/role1.yml": "displayName: role1\ndescription: Stage role\n"},
+			expected: []keywordSuggestionResult{
+				{Keyword: "ROLE1_DESCRIPTION", Source: "Dev role", Target: "Stage role",
+					Locations: []string{"Roles/role1.yml description => {{ROLE1_DESCRIPTION}}"}},
+			},
+		},
+		{
+			name: "Host of a URL shared by resources",
+			sourceFiles: map[string]string{
+				"Roles/role1.yml": "displayName: role1\nurl: https://dev.example.com/role1\n",
+				"Roles/role2.yml": "displayName: role2\nurl: https://dev.example.com/role2\n",
+			},
+			targetFiles: map[string]string{
+				"Roles/role1.yml": "displayName: role1\nurl: https://stage.example.com/role1\n",
+				"Roles/role2.yml": "displayName: role2\nurl: https://stage.example.com/role2\n",
+			},
+			expected: []keywordSuggestionResult{
+				{Keyword: "URL_HOST", Source: "dev.example.com", Target: "stage.example.com", Locations: []string{
+					"Roles/role1.yml url => https://{{URL_HOST}}/role1",
+					"Roles/role2.yml url => https://{{URL_HOST}}/role2",
+				}},
+			},
+		},
+		{
+			name:        "Keyed array elements and typed values",
+			sourceFiles: map[string]string{"Roles/role1.yml": "displayName: role1\nusers:\n- name: alice\n  enabled: true\n  maxAge: 10\n"},
+			targetFiles: map[string]string{"Roles/role1.yml": "displayName: role1\nusers:\n- name: alice\n  enabled: false\n  maxAge: 10\n"},
+			expected: []keywordSuggestionResult{
+				{Keyword: "ROLE1_ENABLED", Source: true, Target: false,
+					Locations: []string{"Roles/role1.yml users.[name=alice].enabled => {{ROLE1_ENABLED}}"}},
+			},
+		},
+		{
+			name:             "Reserved and repeated keyword names",
+			sourceFiles:      map[string]string{"Roles/role1.yml": "displayName: role1\nsite:\n  apiUrl: a\nportal:\n  apiUrl: b\n"},
+			targetFiles:      map[string]string{"Roles/role1.yml": "displayName: role1\nsite:\n  apiUrl: c\nportal:\n  apiUrl: d\n"},
+			reservedKeywords: []string{"ROLE1_API_URL"},
+			expected: []keywordSuggestionResult{
+				{Keyword: "ROLE1_API_URL_2", Source: "b", Target: "d", Locations: []string{"Roles/role1.yml portal.apiUrl => {{ROLE1_API_URL_2}}"}},
+				{Keyword: "ROLE1_API_URL_3", Source: "a", Target: "c", Locations: []string{"Roles/role1.yml site.apiUrl => {{ROLE1_API_URL_3}}"}},
+			},
+		},
+		{
+			name:        "Values with keywords, lists and removed fields are skipped",
+			sourceFiles: map[string]string{"Roles/role1.yml": "displayName: role1\nhost: '{{ENV_HOST}}'\nscopes: [a, b]\nold: value\n"},
+			targetFiles: map[string]string{"Roles/role1.yml": "displayName: role1\nhost: stage.example.com\nscopes: [a, c]\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &utils.Session{}
+			suggestions := session.SuggestKeywords(toExportedRoles(tt.sourceFiles), toExportedRoles(tt.targetFiles), tt.reservedKeywords)
+
+			var results []keywordSuggestionResult
+			for _, suggestion := range suggestions {
+				result := keywordSuggestionResult{Keyword: suggestion.Keyword, Source: suggestion.Source, Target: suggestion.Target}
+				for _, location := range suggestion.Locations {
+					result.Locations = append(result.Locations, location.FilePath+" "+location.Path+" => "+location.Value.(string))
+				}
+				results = append(results, result)
+			}
+			if !reflect.DeepEqual(results, tt.expected) {
+				t.Errorf("Expected %v but got %v", tt.expected, results)
+			}
+		})
+	}
+}
+
+func TestWriteKeywordPlaceholders(t *testing.T) {
+	localDir, err := ioutil.TempDir("", "keyword-suggestions")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(localDir)
+	if err := os.MkdirAll(filepath.Join(localDir, "Roles"), 0700); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	localFile := filepath.Join(localDir, "Roles", "role1.yml")
+	if err := ioutil.WriteFile(localFile, []byte("displayName: role1\nurl: https://dev.example.com/role1\ndescription: Local role\n"), 0644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sourceFiles := toExportedRoles(map[string]string{
+		"Roles/role1.yml": "displayName: role1\nurl: https://dev.example.com/role1\ndescription: Dev role\n",
+		"Roles/role2.yml": "displayName: role2\ndescription: Dev role\n",
+	})
+	targetFiles := toExportedRoles(map[string]string{
+		"Roles/role1.yml": "displayName: role1\nurl: https://stage.example.com/role1\ndescription: Stage role\n",
+		"Roles/role2.yml": "displayName: role2\ndescription: Stage role\n",
+	})
+	session := &utils.Session{}
+	suggestions := session.SuggestKeywords(sourceFiles, targetFiles, nil)
+
+	updatedFiles, err := utils.WriteKeywordPlaceholders(localDir, suggestions)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(updatedFiles, []string{localFile}) {
+		t.Errorf("Expected updated files %v but got %v", []string{localFile}, updatedFiles)
+	}
+	content, err := ioutil.ReadFile(localFile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(string(content), "url: https://{{ROLE1_URL_HOST}}/role1") {
+		t.Errorf("Expected the keyword placeholder in the local file but got %s", content)
+	}
+	if !strings.Contains(string(content), "description: Local role") {
+		t.Errorf("Expected the modified local value to be kept but got %s", content)
+	}
+}
+
+func TestAddKeywordMappings(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected map[string]interface{}
+	}{
+		{
+			name:    "Existing mappings and configs are kept",
+			content: `{"KEYWORD_MAPPINGS": {"ENV_HOST": "dev.example.com"}, "APPLICATIONS": {"app1": {}}}`,
+			expected: map[string]interface{}{
+				"KEYWORD_MAPPINGS": map[string]interface{}{"ENV_HOST": "dev.example.com", "ROLE1_ENABLED": true},
+				"APPLICATIONS":     map[string]interface{}{"app1": map[string]interface{}{}},
+			},
+		},
+		{
+			name:    "Empty config file",
+			content: "",
+			expected: map[string]interface{}{
+				"KEYWORD_MAPPINGS": map[string]interface{}{"ENV_HOST": "stage.example.com", "ROLE1_ENABLED": true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile, err := ioutil.TempFile("", "keywordConfig")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			defer os.Remove(configFile.Name())
+			configFile.WriteString(tt.content)
+			configFile.Close()
+
+			mappings := map[string]interface{}{"ENV_HOST": "stage.example.com", "ROLE1_ENABLED": true}
+			if err := utils.AddKeywordMappings(configFile.Name(), mappings); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			content, err := ioutil.ReadFile(configFile.Name())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var keywordConfigs map[string]interface{}
+			if err := json.Unmarshal(content, &keywordConfigs); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(keywordConfigs, tt.expected) {
+				t.Errorf("Expected %v but got %v", tt.expected, keywordConfigs)
+			}
+		})
+	}
+}
+
+func toExportedRoles(files map[string]string) map[string]utils.ExportedFile {
+	exportedFiles := make(map[string]utils.ExportedFile)
+	for fileName, content := range files {
+		exportedFiles[fileName] = utils.ExportedFile{ResourceType: utils.ROLES, Content: []byte(content)}
+	}
+	return exportedFiles
+}
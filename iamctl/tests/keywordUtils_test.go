@@ -2,9 +2,13 @@ package tests
 
 import (
 	"fmt"
+	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
@@ -44,11 +48,71 @@ func TestReplaceKeywords(t *testing.T) {
 			},
 			expectedResult: "description: This is a sample application in the {{ENV}} environment.",
 		},
+		{
+			description: "Use the default value of a keyword without a mapping",
+			fileContent: "callbackUrl: https://{{HOST:-localhost:9443}}/callback",
+			keywordMapping: map[string]interface{}{
+				"APP": "application",
+			},
+			expectedResult: "callbackUrl: https://localhost:9443/callback",
+		},
+		{
+			description: "Use the value of a keyword with a default value",
+			fileContent: "callbackUrl: https://{{HOST:-localhost}}/callback",
+			keywordMapping: map[string]interface{}{
+				"HOST": "prod.example.com",
+			},
+			expectedResult: "callbackUrl: https://prod.example.com/callback",
+		},
+		{
+			description: "Use the default value of a keyword with an empty value",
+			fileContent: "description: {{DESCRIPTION:-Default description}}",
+			keywordMapping: map[string]interface{}{
+				"DESCRIPTION": "",
+			},
+			expectedResult: "description: Default description",
+		},
+		{
+			description: "Replace quoted and unquoted placeholders with typed values",
+			fileContent: "enabled: '{{ENABLED}}'\nport: {{PORT}}\ntimeout: \"{{TIMEOUT:-30}}\"\nurl: https://localhost:{{PORT}}",
+			keywordMapping: map[string]interface{}{
+				"ENABLED": true,
+				"PORT":    float64(9443),
+				"TIMEOUT": 60.5,
+			},
+			expectedResult: "enabled: true\nport: 9443\ntimeout: 60.5\nurl: https://localhost:9443",
+		},
+		{
+			description: "Replace typed values in JSON",
+			fileContent: `{"enabled": "{{ENABLED}}", "allowedOrigins": "{{ORIGINS}}"}`,
+			keywordMapping: map[string]interface{}{
+				"ENABLED": false,
+				"ORIGINS": []interface{}{"https://a.example.com", "https://b.example.com"},
+			},
+			expectedResult: `{"enabled": false, "allowedOrigins": ["https://a.example.com","https://b.example.com"]}`,
+		},
+		{
+			description: "Expand list values into arrays and list elements",
+			fileContent: "allowedOrigins: '{{ORIGINS}}'\ncallbackURLs:\n  - https://static.example.com\n  - '{{ORIGINS}}'\ndescription: Origins {{ORIGINS}}",
+			keywordMapping: map[string]interface{}{
+				"ORIGINS": []interface{}{"https://a.example.com", "https://b.example.com"},
+			},
+			expectedResult: "allowedOrigins: [\"https://a.example.com\",\"https://b.example.com\"]\ncallbackURLs:\n  - https://static.example.com\n  - \"https://a.example.com\"\n  - \"https://b.example.com\"\ndescription: Origins {{ORIGINS}}",
+		},
+		{
+			description: "Compose keywords in list elements",
+			fileContent: "callbackURLs:\n  - {{BASE_URL}}/callback\n  - {{BASE_URL}}/logout",
+			keywordMapping: map[string]interface{}{
+				"BASE_URL": "https://prod.example.com",
+			},
+			expectedResult: "callbackURLs:\n  - https://prod.example.com/callback\n  - https://prod.example.com/logout",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.description, func(t *testing.T) {
-			result := utils.ReplaceKeywords(tc.fileContent, tc.keywordMapping)
+			session := &utils.Session{}
+			result := session.ReplaceKeywords(tc.fileContent, tc.keywordMapping)
 
 			if result != tc.expectedResult {
 				t.Errorf("Unexpected result for %s: expected %v, but got %v", tc.description, tc.expectedResult, result)
@@ -57,6 +121,149 @@ func TestReplaceKeywords(t *testing.T) {
 	}
 }
 
+func TestFindUnresolvedKeywords(t *testing.T) {
+
+	tests := []struct {
+		description    string
+		fileName       string
+		fileContent    string
+		keywordMapping map[string]interface{}
+		expectedResult []utils.UnresolvedKeyword
+	}{
+		{
+			description:    "No unresolved keywords",
+			fileName:       "app.yml",
+			fileContent:    "callbackUrl: https://dev.example.com/cb\n",
+			expectedResult: nil,
+		},
+		{
+			description: "Keyword without a mapping",
+			fileName:    "app.yml",
+			fileContent: "inboundProtocols:\n  oidc:\n    callbackURLs:\n    - https://{{APP_HOST}}/cb\n",
+			expectedResult: []utils.UnresolvedKeyword{
+				{Keyword: "APP_HOST", Path: "inboundProtocols.oidc.callbackURLs[0]", Reason: "no keyword mapping found"},
+			},
+		},
+		{
+			description: "Keyword with a list value inside a string",
+			fileName:    "app.yml",
+			fileContent: "description: Allowed origins are {{ORIGINS}}\n",
+			keywordMapping: map[string]interface{}{
+				"ORIGINS": []interface{}{"https://a.example.com"},
+			},
+			expectedResult: []utils.UnresolvedKeyword{
+				{Keyword: "ORIGINS", Path: "description", Reason: "list and object keyword values can only be the whole value of a field"},
+			},
+		},
+		{
+			description: "Keyword with a secret that could not be resolved",
+			fileName:    "app.json",
+			fileContent: `{"clientSecret": "{{CLIENT_SECRET}}"}`,
+			keywordMapping: map[string]interface{}{
+				"CLIENT_SECRET": "{{secret:env:IAMCTL_TEST_UNSET_SECRET}}",
+			},
+			expectedResult: []utils.UnresolvedKeyword{
+				{Keyword: "CLIENT_SECRET", Path: "clientSecret", Reason: "keyword value could not be resolved"},
+			},
+		},
+		{
+			description:    "Server placeholders are not keywords",
+			fileName:       "en_US.yml",
+			fileContent:    "body: Hi {{user-name}}, your code is {{confirmation-code}}\n",
+			expectedResult: nil,
+		},
+		{
+			description: "Line number is used for files that cannot be parsed",
+			fileName:    "library.js",
+			fileContent: "var host = 'dev';\nvar url = 'https://{{API_HOST}}';\n",
+			expectedResult: []utils.UnresolvedKeyword{
+				{Keyword: "API_HOST", Path: "line 2", Reason: "no keyword mapping found"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			result := utils.FindUnresolvedKeywords(tc.fileName, tc.fileContent, tc.keywordMapping)
+
+			if !reflect.DeepEqual(result, tc.expectedResult) {
+				t.Errorf("Unexpected result for %s: expected %v, but got %v", tc.description, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestCheckUnresolvedKeywords(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "logs")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	defer utils.ConfigureLogs(utils.LogsConfig{})
+	defer utils.ResetSummary()
+
+	tests := []struct {
+		description     string
+		resourceType    utils.ResourceType
+		fileName        string
+		fileContent     string
+		expectedError   bool
+		expectedWarning string
+	}{
+		{
+			description:     "Unmapped placeholder in snake case",
+			resourceType:    utils.APPLICATIONS,
+			fileName:        "app.yml",
+			fileContent:     "callbackUrl: https://{{app_host}}/cb\n",
+			expectedWarning: "No keyword mapping found for {{app_host}} at callbackUrl",
+		},
+		{
+			description:     "Unmapped placeholder in camel case",
+			resourceType:    utils.IDENTITY_PROVIDERS,
+			fileName:        "idp.yml",
+			fileContent:     "homeRealmIdentifier: '{{AppHost}}'\n",
+			expectedWarning: "No keyword mapping found for {{AppHost}} at homeRealmIdentifier",
+		},
+		{
+			description:  "Server placeholders in templates",
+			resourceType: utils.EMAIL_TEMPLATES,
+			fileName:     "en_US.yml",
+			fileContent:  "body: Hi {{user-name}}\n",
+		},
+		{
+			description:   "Unmapped keyword",
+			resourceType:  utils.APPLICATIONS,
+			fileName:      "app.yml",
+			fileContent:   "callbackUrl: https://{{APP_HOST}}/cb\n",
+			expectedError: true,
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			logPath := filepath.Join(tempDir, fmt.Sprintf("iamctl%d.log", i))
+			if err := utils.ConfigureLogs(utils.LogsConfig{File: logPath}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			err := utils.CheckUnresolvedKeywords(tc.resourceType, tc.fileName, tc.fileContent, nil)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("Expected error: %v, got %v", tc.expectedError, err)
+			}
+
+			logs, err := ioutil.ReadFile(logPath)
+			if err != nil {
+				t.Fatalf("Failed to read log file: %v", err)
+			}
+			if tc.expectedWarning == "" && strings.Contains(string(logs), "No keyword mapping found") {
+				t.Errorf("Expected no warning, got %s", logs)
+			}
+			if tc.expectedWarning != "" && !strings.Contains(string(logs), tc.expectedWarning) {
+				t.Errorf("Expected warning %q, got %s", tc.expectedWarning, logs)
+			}
+		})
+	}
+}
+
 func TestResolveAdvancedKeywordMapping(t *testing.T) {
 
 	testCases := []struct {
@@ -107,8 +314,8 @@ func TestResolveAdvancedKeywordMapping(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			utils.KEYWORD_CONFIGS = tc.keywordConfig
-			result := utils.ResolveAdvancedKeywordMapping(tc.resourceName, tc.keywordConfig.ApplicationConfigs)
+			session := &utils.Session{KeywordConfigs: tc.keywordConfig}
+			result := session.ResolveAdvancedKeywordMapping(tc.resourceName, tc.keywordConfig.ApplicationConfigs)
 			if !reflect.DeepEqual(result, tc.expectedResult) {
 				t.Errorf("Unexpected result for %s: expected %v, but got %v", tc.description, tc.expectedResult, result)
 			}
@@ -148,6 +355,14 @@ func TestContainsKeywords(t *testing.T) {
 			},
 			expectedResult: false,
 		},
+		{
+			description: "Test with a keyword with a default value, but without a mapping",
+			data:        "This is a sample application in the {{ENV:-dev}} environment.",
+			keywordMapping: map[string]interface{}{
+				"APP": "Application",
+			},
+			expectedResult: true,
+		},
 		{
 			description: "Test with an empty string",
 			data:        "",
@@ -228,7 +443,8 @@ func TestGetKeywordLocations(t *testing.T) {
 		"claimMappings.[localClaim.uri=http://wso2.org/claims/username].applicationClaim",
 	}
 
-	result := utils.GetKeywordLocations(fileData, []string{}, keywordMapping, utils.APPLICATIONS)
+	session := &utils.Session{}
+	result := session.GetKeywordLocations(fileData, []string{}, keywordMapping, utils.APPLICATIONS)
 
 	sort.Strings(result)
 	sort.Strings(expectedResult)
@@ -597,7 +813,8 @@ func TestAddKeywords(t *testing.T) {
 		"ENV":     "dev",
 		"KEYWORD": "keyword value",
 	}
-	result, err := utils.AddKeywords(exportedFileData, localFileContent, keywordMapping, utils.APPLICATIONS)
+	session := &utils.Session{}
+	result, err := session.AddKeywords(exportedFileData, localFileContent, keywordMapping, utils.APPLICATIONS)
 	if err != nil {
 		log.Println("Error when adding keywords: ", err)
 	}
@@ -663,7 +880,40 @@ func TestModifyFieldsWithKeywords(t *testing.T) {
 		},
 	}
 
-	result := utils.ModifyFieldsWithKeywords(exportedFileData, localFileData, keywordLocations, keywordMap)
+	session := &utils.Session{}
+	result := session.ModifyFieldsWithKeywords(exportedFileData, localFileData, keywordLocations, keywordMap)
+
+	if !reflect.DeepEqual(result, expectedExportedFileData) {
+		t.Errorf("Expected %+v, but got %+v", expectedExportedFileData, result)
+	}
+}
+
+func TestModifyFieldsWithTypedKeywords(t *testing.T) {
+
+	keywordLocations := []string{"enabled", "allowedOrigins", "port"}
+	keywordMap := map[string]interface{}{
+		"ENABLED": true,
+		"ORIGINS": []interface{}{"https://a.example.com", "https://b.example.com"},
+		"PORT":    float64(9443),
+	}
+	localFileData := map[string]interface{}{
+		"enabled":        "{{ENABLED}}",
+		"allowedOrigins": "{{ORIGINS}}",
+		"port":           "{{PORT}}",
+	}
+	exportedFileData := map[string]interface{}{
+		"enabled":        true,
+		"allowedOrigins": []interface{}{"https://a.example.com", "https://b.example.com"},
+		"port":           8443,
+	}
+	expectedExportedFileData := map[string]interface{}{
+		"enabled":        "{{ENABLED}}",
+		"allowedOrigins": "{{ORIGINS}}",
+		"port":           8443,
+	}
+
+	session := &utils.Session{}
+	result := session.ModifyFieldsWithKeywords(exportedFileData, localFileData, keywordLocations, keywordMap)
 
 	if !reflect.DeepEqual(result, expectedExportedFileData) {
 		t.Errorf("Expected %+v, but got %+v", expectedExportedFileData, result)
@@ -0,0 +1,231 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestParseLogFormat(t *testing.T) {
+	tests := []struct {
+		name           string
+		format         string
+		expectedFormat utils.LogFormat
+		expectError    bool
+	}{
+		{name: "Default format", format: "", expectedFormat: utils.LogFormatText},
+		{name: "JSON in upper case", format: "JSON", expectedFormat: utils.LogFormatJSON},
+		{name: "Unsupported format", format: "xml", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := utils.ParseLogFormat(tt.format)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error for format %s", tt.format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if format != tt.expectedFormat {
+				t.Errorf("Expected format %s, got %s", tt.expectedFormat, format)
+			}
+		})
+	}
+}
+
+func readLogLines(t *testing.T, path string) []string {
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestJSONLogFile(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "logs")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	defer utils.ConfigureLogs(utils.LogsConfig{})
+
+	logPath := filepath.Join(tempDir, "iamctl.log")
+	if err := utils.ConfigureLogs(utils.LogsConfig{Format: "json", File: logPath}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, "app1", "Exported successfully")
+	utils.UpdateFailureSummary(utils.ROLES, "role1", utils.IMPORT, fmt.Errorf("bad request"))
+	defer utils.ResetSummary()
+
+	lines := readLogLines(t, logPath)
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log events, got %d: %v", len(lines), lines)
+	}
+	expected := []utils.LogEvent{
+		{Level: "INFO", ResourceType: "Applications", ResourceName: "app1", Message: "Exported successfully"},
+		{Level: "ERROR", ResourceType: "Roles", ResourceName: "role1", Operation: utils.IMPORT, Message: "import failed: bad request"},
+	}
+	for i, line := range lines {
+		var event utils.LogEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("Log line is not valid JSON: %s", line)
+		}
+		if event.Timestamp == "" {
+			t.Errorf("Expected a timestamp in %s", line)
+		}
+		event.Timestamp = ""
+		if event != expected[i] {
+			t.Errorf("Expected event %+v, got %+v", expected[i], event)
+		}
+	}
+}
+
+func TestResultLogEvents(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "logs")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	defer utils.ConfigureLogs(utils.LogsConfig{})
+	defer utils.ResetSummary()
+
+	tests := []struct {
+		name   string
+		format string
+		log    func()
+		level  string
+		text   string
+	}{
+		{
+			name:   "Success in JSON format",
+			format: "json",
+			log: func() {
+				utils.UpdateSuccessSummaryAndLog(utils.APPLICATIONS, "app1", utils.EXPORT, "Exported successfully")
+			},
+			level: "INFO",
+			text:  "Exported successfully",
+		},
+		{
+			name:   "Failure in JSON format",
+			format: "json",
+			log: func() {
+				utils.UpdateFailureSummaryAndLog(utils.ROLES, "role1", utils.IMPORT, fmt.Errorf("bad request"), "Error importing: bad request")
+			},
+			level: "ERROR",
+			text:  "Error importing: bad request",
+		},
+		{
+			name:   "Success in text format",
+			format: "text",
+			log: func() {
+				utils.UpdateSuccessSummaryAndLog(utils.APPLICATIONS, "app1", utils.EXPORT, "Exported successfully")
+			},
+			text: "Applications - app1 - Exported successfully",
+		},
+		{
+			name:   "Failure without a message in text format",
+			format: "text",
+			log: func() {
+				utils.UpdateFailureSummary(utils.ROLES, "role1", utils.IMPORT, fmt.Errorf("bad request"))
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPath := filepath.Join(tempDir, fmt.Sprintf("iamctl%d.log", i))
+			if err := utils.ConfigureLogs(utils.LogsConfig{Format: tt.format, File: logPath}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			tt.log()
+
+			data, err := ioutil.ReadFile(logPath)
+			if err != nil {
+				t.Fatalf("Failed to read log file: %v", err)
+			}
+			if tt.text == "" {
+				if len(data) != 0 {
+					t.Errorf("Expected no log events, got %s", data)
+				}
+				return
+			}
+			lines := readLogLines(t, logPath)
+			if len(lines) != 1 {
+				t.Fatalf("Expected 1 log event, got %d: %v", len(lines), lines)
+			}
+			if tt.format == "text" {
+				if !strings.Contains(lines[0], tt.text) {
+					t.Errorf("Expected %q in %s", tt.text, lines[0])
+				}
+				return
+			}
+			var event utils.LogEvent
+			if err := json.Unmarshal([]byte(lines[0]), &event); err != nil {
+				t.Fatalf("Log line is not valid JSON: %s", lines[0])
+			}
+			if event.Level != tt.level || event.Operation == "" || event.Message != tt.text {
+				t.Errorf("Expected a %s event with the operation and message %q, got %+v", tt.level, tt.text, event)
+			}
+		})
+	}
+}
+
+func TestLogFileRotation(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "logs")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	defer utils.ConfigureLogs(utils.LogsConfig{})
+
+	logPath := filepath.Join(tempDir, "iamctl.log")
+	if err := utils.ConfigureLogs(utils.LogsConfig{File: logPath, MaxFileSizeMB: 1, MaxBackups: 2}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	message := strings.Repeat("x", 1024)
+	for i := 0; i < 3*1024; i++ {
+		utils.PrintLog(utils.LogLevelInfo, utils.APPLICATIONS, "", message)
+	}
+
+	for _, path := range []string{logPath, logPath + ".1", logPath + ".2"} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Expected log file %s to exist: %v", path, err)
+		}
+		if info.Size() > 1024*1024 {
+			t.Errorf("Expected log file %s to be rotated at 1 MB, got %d bytes", path, info.Size())
+		}
+	}
+	if _, err := os.Stat(logPath + ".3"); !os.IsNotExist(err) {
+		t.Errorf("Expected only 2 backups to be kept")
+	}
+}
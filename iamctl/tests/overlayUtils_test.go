@@ -0,0 +1,235 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	identifiers := map[string]string{"authenticationSteps": "stepOrder", "federatedIdentityProviders": "identityProviderName"}
+	tests := []struct {
+		name     string
+		data     string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "Objects are merged and null fields are removed",
+			data:     "name: app1\nclaimConfiguration:\n  alwaysSendMappedLocalSubjectId: false\n  role: admin\ndescription: App\n",
+			patch:    "claimConfiguration:\n  alwaysSendMappedLocalSubjectId: true\ndescription: null\n",
+			expected: "name: app1\nclaimConfiguration:\n  alwaysSendMappedLocalSubjectId: true\n  role: admin\n",
+		},
+		{
+			name: "Keyed array elements are merged, added and deleted",
+			data: `authenticationSteps:
+- stepOrder: 1
+  subjectStep: true
+- stepOrder: 2
+  subjectStep: false
+federatedIdentityProviders:
+- identityProviderName: Google
+- identityProviderName: GitHub
+`,
+			patch: `authenticationSteps:
+- stepOrder: 2
+  subjectStep: true
+- stepOrder: 3
+  subjectStep: false
+federatedIdentityProviders:
+- identityProviderName: GitHub
+  $patch: delete
+`,
+			expected: `authenticationSteps:
+- stepOrder: 1
+  subjectStep: true
+- stepOrder: 2
+  subjectStep: true
+- stepOrder: 3
+  subjectStep: false
+federatedIdentityProviders:
+- identityProviderName: Google
+`,
+		},
+		{
+			name:     "Scalar and unkeyed arrays are replaced",
+			data:     "scopes: [openid, profile]\nurls:\n- value: a\n",
+			patch:    "scopes: [openid, email]\nurls:\n- value: b\n",
+			expected: "scopes: [openid, email]\nurls:\n- value: b\n",
+		},
+		{
+			name:     "Keyed arrays are cleared with an empty array",
+			data:     "federatedIdentityProviders:\n- identityProviderName: Google\n",
+			patch:    "federatedIdentityProviders: []\n",
+			expected: "federatedIdentityProviders: []\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.ApplyMergePatch(parseYAML(t, tt.data), parseYAML(t, tt.patch), "Applications", identifiers)
+			expected := parseYAML(t, tt.expected)
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("Expected %v but got %v", expected, result)
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	data := `name: app1
+authenticationSteps:
+- stepOrder: 1
+  options:
+  - idp: LOCAL
+    authenticator: BasicAuthenticator
+urls: [a, b]
+`
+	tests := []struct {
+		name     string
+		patch    string
+		expected string
+		err      string
+	}{
+		{
+			name: "Add, replace and remove by index",
+			patch: `- {op: add, path: /urls/1, value: c}
+- {op: replace, path: /name, value: app2}
+- {op: remove, path: /urls/0}
+`,
+			expected: strings.Replace(strings.Replace(data, "urls: [a, b]", "urls: [c, b]", 1), "name: app1", "name: app2", 1),
+		},
+		{
+			name: "Append to arrays selected by identifiers",
+			patch: `- op: add
+  path: /authenticationSteps/[stepOrder=1]/options/-
+  value: {idp: Google, authenticator: GoogleOIDCAuthenticator}
+`,
+			expected: strings.Replace(data, "urls:", "  - idp: Google\n    authenticator: GoogleOIDCAuthenticator\nurls:", 1),
+		},
+		{
+			name: "Move, copy and test",
+			patch: `- {op: test, path: /name, value: app1}
+- {op: copy, from: /name, path: /description}
+- {op: move, from: /urls, path: /callbackUrls}
+`,
+			expected: strings.Replace(data, "urls: [a, b]", "description: app1\ncallbackUrls: [a, b]", 1),
+		},
+		{
+			name:  "Failed test",
+			patch: "- {op: test, path: /name, value: app2}\n",
+			err:   "operation 0 (test /name): test failed",
+		},
+		{
+			name:  "Missing field",
+			patch: "- {op: replace, path: /description, value: App}\n",
+			err:   "operation 0 (replace /description): field description not found",
+		},
+		{
+			name:  "Index out of range",
+			patch: "- {op: add, path: /urls/5, value: c}\n",
+			err:   "operation 0 (add /urls/5): array index 5 is out of range",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operations := parseYAML(t, tt.patch).([]interface{})
+			result, err := utils.ApplyJSONPatch(parseYAML(t, data), operations)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("Expected error %q but got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			expected := parseYAML(t, tt.expected)
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("Expected %v but got %v", expected, result)
+			}
+		})
+	}
+}
+
+func TestApplyOverlay(t *testing.T) {
+	configDir, err := ioutil.TempDir("", "overlays")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(configDir)
+	overlayDir := filepath.Join(configDir, utils.OVERLAYS_DIR)
+	if err := os.MkdirAll(filepath.Join(overlayDir, "Roles"), 0700); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	overlay := "permissions:\n- value: internal_user_mgt_delete\n"
+	if err := ioutil.WriteFile(filepath.Join(overlayDir, "Roles", "role1.yaml"), []byte(overlay), 0644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		content  string
+		expected string
+	}{
+		{
+			name:     "Overlay of the resource is applied",
+			filePath: filepath.Join("resources", "Roles", "role1.yml"),
+			content:  "displayName: role1\npermissions:\n- value: internal_user_mgt_view\n",
+			expected: "displayName: role1\npermissions:\n- value: internal_user_mgt_view\n- value: internal_user_mgt_delete\n",
+		},
+		{
+			name:     "Resource without an overlay is not changed",
+			filePath: filepath.Join("resources", "Roles", "role2.yml"),
+			content:  "displayName: role2\n",
+			expected: "displayName: role2\n",
+		},
+	}
+
+	session := &utils.Session{OverlayDir: overlayDir}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.ApplyOverlay(utils.ROLES, tt.filePath, tt.content)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(parseYAML(t, result), parseYAML(t, tt.expected)) {
+				t.Errorf("Expected %s but got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func parseYAML(t *testing.T, content string) interface{} {
+	var data interface{}
+	if err := yaml.Unmarshal([]byte(content), &data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return utils.ConvertToStringKeyMap(data)
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestAddToPlan(t *testing.T) {
+	tests := []struct {
+		name          string
+		dryRun        bool
+		entries       []utils.PlanEntry
+		expectedCount map[utils.PlanAction]int
+	}{
+		{
+			name:   "Dry run records all actions",
+			dryRun: true,
+			entries: []utils.PlanEntry{
+				{ResourceType: utils.APPLICATIONS, ResourceName: "app1", Action: utils.GetImportAction(false)},
+				{ResourceType: utils.APPLICATIONS, ResourceName: "app2", Action: utils.GetImportAction(true)},
+				{ResourceType: utils.APPLICATIONS, ResourceName: "app3", Action: utils.PLAN_DELETE},
+				{ResourceType: utils.ROLES, ResourceName: "admin", Action: utils.PLAN_NO_OP, Reason: "System role"},
+				{ResourceType: utils.ROLES, ResourceName: "role1", Action: utils.GetImportAction(true)},
+			},
+			expectedCount: map[utils.PlanAction]int{
+				utils.PLAN_CREATE: 1,
+				utils.PLAN_UPDATE: 2,
+				utils.PLAN_DELETE: 1,
+				utils.PLAN_NO_OP:  1,
+			},
+		},
+		{
+			name:   "Plan is not recorded outside dry run",
+			dryRun: false,
+			entries: []utils.PlanEntry{
+				{ResourceType: utils.APPLICATIONS, ResourceName: "app1", Action: utils.PLAN_CREATE},
+			},
+			expectedCount: map[utils.PlanAction]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.DRY_RUN = tt.dryRun
+			utils.ImportPlan = nil
+			defer func() {
+				utils.DRY_RUN = false
+				utils.ImportPlan = nil
+			}()
+
+			for _, entry := range tt.entries {
+				utils.AddToPlan(entry.ResourceType, entry.ResourceName, entry.Action, entry.Reason)
+			}
+			counts := utils.CountPlanActions(utils.ImportPlan)
+			for _, action := range []utils.PlanAction{utils.PLAN_CREATE, utils.PLAN_UPDATE, utils.PLAN_DELETE, utils.PLAN_NO_OP} {
+				if counts[action] != tt.expectedCount[action] {
+					t.Errorf("Expected %d %s actions but got %d", tt.expectedCount[action], action, counts[action])
+				}
+			}
+		})
+	}
+}
+
+func TestFindUnchangedResources(t *testing.T) {
+	inputDir, err := ioutil.TempDir("", "plan-input")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(inputDir)
+
+	localFiles := map[string]string{
+		"Roles/role1.yml":       "displayName: role1\naudience: https://{{HOST}}\n",
+		"Roles/role2.yml":       "displayName: role2\naudience: https://{{HOST}}/v2\n",
+		"Roles/role3.json":      `{"displayName": "role3", "audience": "https://{{HOST}}"}`,
+		"Roles/role4.yml":       "displayName: role4\n",
+		"Applications/app1.yml": "name: app1\n",
+		"Applications/ApplicationAuthorizedApis/app1.yml": "identifier: https://{{HOST}}/api\n",
+		"Applications/app2.yml":                           "name: app2\nclientSecret: '********'\n",
+	}
+	for name, content := range localFiles {
+		filePath := filepath.Join(inputDir, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := ioutil.WriteFile(filePath, []byte(content), 0600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	utils.StartDeployedExport()
+	defer utils.StopInMemoryExport()
+	deployedFiles := []struct {
+		name         string
+		resourceType utils.ResourceType
+		content      string
+	}{
+		{name: "Roles/role1.yml", resourceType: utils.ROLES, content: "displayName: role1\naudience: https://prod.example.com\n"},
+		{name: "Roles/role2.yml", resourceType: utils.ROLES, content: "displayName: role2\naudience: https://prod.example.com/v1\n"},
+		{name: "Roles/role3.yml", resourceType: utils.ROLES, content: "displayName: role3\naudience: https://prod.example.com\n"},
+		{name: "Applications/app1.yml", resourceType: utils.APPLICATIONS, content: "name: app1\n"},
+		{name: "Applications/ApplicationAuthorizedApis/app1.yml", resourceType: utils.APPLICATION_AUTHORIZED_APIS, content: "identifier: https://test.example.com/api\n"},
+		{name: "Applications/app2.yml", resourceType: utils.APPLICATIONS, content: "name: app2\nclientSecret: '********'\n"},
+	}
+	for _, file := range deployedFiles {
+		if err := utils.WriteExportedFile(filepath.Join(inputDir, file.name), []byte(file.content), file.resourceType); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	session := &utils.Session{
+		ServerConfigs:  utils.ServerConfigs{ServerVersion: "7.1.0"},
+		KeywordConfigs: utils.KeywordConfigs{KeywordMappings: map[string]interface{}{"HOST": "prod.example.com"}},
+	}
+	session.FindUnchangedResources([]utils.ResourceType{utils.APPLICATIONS, utils.ROLES}, inputDir)
+	defer utils.ResetUnchangedResources()
+
+	tests := []struct {
+		name           string
+		resourceType   utils.ResourceType
+		resourceName   string
+		expectedAction utils.PlanAction
+	}{
+		{name: "Deployed resource equal to the resolved local file", resourceType: utils.ROLES, resourceName: "role1", expectedAction: utils.PLAN_NO_OP},
+		{name: "Deployed resource different from the resolved local file", resourceType: utils.ROLES, resourceName: "role2", expectedAction: utils.PLAN_UPDATE},
+		{name: "Local file in a different format", resourceType: utils.ROLES, resourceName: "role3", expectedAction: utils.PLAN_NO_OP},
+		{name: "Resource that is not exported", resourceType: utils.ROLES, resourceName: "role4", expectedAction: utils.PLAN_UPDATE},
+		{name: "Resource with a changed sub resource file", resourceType: utils.APPLICATIONS, resourceName: "app1", expectedAction: utils.PLAN_UPDATE},
+		{name: "Resource with masked secrets", resourceType: utils.APPLICATIONS, resourceName: "app2", expectedAction: utils.PLAN_UPDATE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.DRY_RUN = true
+			utils.ImportPlan = nil
+			defer func() {
+				utils.DRY_RUN = false
+				utils.ImportPlan = nil
+			}()
+
+			utils.AddToPlan(tt.resourceType, tt.resourceName, utils.PLAN_UPDATE, "")
+			if len(utils.ImportPlan) != 1 || utils.ImportPlan[0].Action != tt.expectedAction {
+				t.Errorf("Expected planned action %s but got %v", tt.expectedAction, utils.ImportPlan)
+			}
+		})
+	}
+
+	t.Run("Resource with a deleted sub resource", func(t *testing.T) {
+		utils.DRY_RUN = true
+		utils.ImportPlan = nil
+		defer func() {
+			utils.DRY_RUN = false
+			utils.ImportPlan = nil
+		}()
+
+		utils.AddToPlan(utils.ROLES, "role3", utils.PLAN_UPDATE, "")
+		utils.AddSubResourceToPlan(utils.ROLES, "role3", "role3/permission", utils.PLAN_DELETE, "Not found locally")
+		utils.AddToPlan(utils.ROLES, "role3", utils.PLAN_UPDATE, "")
+		if len(utils.ImportPlan) != 3 || utils.ImportPlan[0].Action != utils.PLAN_UPDATE || utils.ImportPlan[2].Action != utils.PLAN_UPDATE {
+			t.Errorf("Expected planned action %s but got %v", utils.PLAN_UPDATE, utils.ImportPlan)
+		}
+	})
+}
+
+func TestMutatingRequestsBlockedInDryRun(t *testing.T) {
+	utils.DRY_RUN = true
+	defer func() { utils.DRY_RUN = false }()
+
+	session := &utils.Session{}
+	if err := session.SendDeleteRequest("app1", utils.APPLICATIONS); err == nil {
+		t.Errorf("Expected delete request to be rejected in dry run mode")
+	}
+	if _, err := session.SendPostRequest(utils.ROLES, []byte("{}")); err == nil {
+		t.Errorf("Expected POST request to be rejected in dry run mode")
+	}
+	if _, err := session.SendPatchRequest(utils.IDENTITY_PROVIDERS, "idp1", []byte("[]")); err == nil {
+		t.Errorf("Expected PATCH request to be rejected in dry run mode")
+	}
+}
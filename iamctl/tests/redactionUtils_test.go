@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestRedactPayload(t *testing.T) {
+	tests := []struct {
+		name          string
+		resourceType  utils.ResourceType
+		redactPaths   []string
+		payload       string
+		redacted      []string
+		notRedacted   []string
+		expectedExact string
+	}{
+		{
+			name:         "Action authentication secrets",
+			resourceType: utils.ACTIONS,
+			payload:      `{"name":"pre-issue","endpoint":{"uri":"https://hook.io","authentication":{"type":"BASIC","properties":{"username":"admin","password":"s3cret"}}}}`,
+			redacted:     []string{"s3cret"},
+			notRedacted:  []string{"admin", "https://hook.io"},
+		},
+		{
+			name:         "Userstore connection password in properties array",
+			resourceType: utils.USERSTORES,
+			payload:      `{"name":"LDAP","properties":[{"name":"ConnectionURL","value":"ldap://localhost"},{"name":"ConnectionPassword","value":"ldapPass"}]}`,
+			redacted:     []string{"ldapPass"},
+			notRedacted:  []string{"ldap://localhost"},
+		},
+		{
+			name:         "Email provider password and properties",
+			resourceType: utils.EMAIL_PROVIDERS,
+			payload:      `{"smtpServerHost":"smtp.io","userName":"mailer","password":"smtpPass","properties":[{"key":"clientSecret","value":"oauthSecret"},{"key":"scope","value":"mail"}]}`,
+			redacted:     []string{"smtpPass", "oauthSecret"},
+			notRedacted:  []string{"mailer", "mail"},
+		},
+		{
+			name:         "Application client secret in a YAML file",
+			resourceType: utils.APPLICATIONS,
+			payload:      "applicationName: app1\ninboundAuthenticationConfig:\n  inboundAuthenticationRequestConfigs:\n    - inboundAuthKey: client1\n      inboundConfigurationProtocol:\n        oauthConsumerSecret: appSecret\n",
+			redacted:     []string{"appSecret"},
+			notRedacted:  []string{"client1"},
+		},
+		{
+			name:         "Application client secret in a YAML file with type tags",
+			resourceType: utils.APPLICATIONS,
+			payload:      "applicationName: app1\ninboundConfigurationProtocol: !!org.wso2.carbon.identity.oauth.dto.OAuthConsumerAppDTO\n  oauthConsumerKey: client1\n  oauthConsumerSecret: appSecret\n",
+			redacted:     []string{"appSecret"},
+			notRedacted:  []string{"client1"},
+		},
+		{
+			name:         "Configured redact paths",
+			resourceType: utils.ROLES,
+			redactPaths:  []string{"customAttributes.*.token"},
+			payload:      `{"displayName":"role1","customAttributes":[{"name":"a","token":"roleToken"}]}`,
+			redacted:     []string{"roleToken"},
+			notRedacted:  []string{"role1"},
+		},
+		{
+			name:          "Null secrets are kept",
+			resourceType:  utils.SMS_PROVIDERS,
+			payload:       `{"provider":"Twilio","secret":null}`,
+			expectedExact: `{"provider":"Twilio","secret":null}`,
+		},
+		{
+			name:          "Plain text is unchanged",
+			resourceType:  utils.ROLES,
+			payload:       "Bad Request",
+			expectedExact: "Bad Request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &utils.Session{ToolConfigs: utils.ToolConfigs{Logs: utils.LogsConfig{RedactPaths: tt.redactPaths}}}
+			result := session.RedactPayload(tt.resourceType, []byte(tt.payload))
+
+			if tt.expectedExact != "" && result != tt.expectedExact {
+				t.Errorf("Expected %s, got %s", tt.expectedExact, result)
+			}
+			for _, secret := range tt.redacted {
+				if strings.Contains(result, secret) {
+					t.Errorf("Expected %s to be redacted in %s", secret, result)
+				}
+			}
+			for _, value := range tt.notRedacted {
+				if !strings.Contains(result, value) {
+					t.Errorf("Expected %s to be kept in %s", value, result)
+				}
+			}
+			if len(tt.redacted) > 0 && !strings.Contains(result, utils.SENSITIVE_FIELD_MASK_WITHOUT_QUOTES) {
+				t.Errorf("Expected the mask in %s", result)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestRenderResources(t *testing.T) {
+	inputDir, err := ioutil.TempDir("", "render-input")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(inputDir)
+	outputDir, err := ioutil.TempDir("", "render-output")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(outputDir)
+	defer utils.ResetSummary()
+
+	files := map[string]string{
+		"Applications/app1.yml":                           "callbackUrl: https://{{HOST}}/callback\nclientSecret: '********'\n",
+		"Applications/app2.yml":                           "callbackUrl: https://{{HOST}}/callback\n",
+		"Applications/ApplicationAuthorizedApis/app1.yml": "identifier: https://{{HOST}}/api\n",
+		"Claims/oidc.yml":                                 "dialectURI: http://wso2.org/oidc/claim\nclaimURI: {{HOST}}\n",
+		"EmailTemplates/AccountLock/en_US.yml":            "body: Contact {{HOST}}\n",
+		"Roles/role1.yml":                                 "audience: https://{{ROLE_HOST}}\n",
+	}
+	for name, content := range files {
+		filePath := filepath.Join(inputDir, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := ioutil.WriteFile(filePath, []byte(content), 0600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	session := &utils.Session{
+		ServerConfigs: utils.ServerConfigs{ServerVersion: "7.1.0"},
+		ToolConfigs: utils.ToolConfigs{
+			ApplicationConfigs: map[string]interface{}{
+				"EXCLUDE": []interface{}{"app2"},
+			},
+		},
+		KeywordConfigs: utils.KeywordConfigs{
+			KeywordMappings: map[string]interface{}{"HOST": "prod.example.com"},
+			ApplicationConfigs: map[string]interface{}{
+				"app1": map[string]interface{}{
+					"KEYWORD_MAPPINGS": map[string]interface{}{"HOST": "app1.example.com"},
+				},
+			},
+			ClaimConfigs: map[string]interface{}{
+				"http://wso2.org/oidc/claim": map[string]interface{}{
+					"KEYWORD_MAPPINGS": map[string]interface{}{"HOST": "claims.example.com"},
+				},
+			},
+		},
+	}
+	resourceTypes := []utils.ResourceType{utils.APPLICATIONS, utils.CLAIMS, utils.EMAIL_TEMPLATES, utils.ROLES}
+	session.RenderResources(resourceTypes, inputDir, outputDir)
+
+	tests := []struct {
+		name            string
+		file            string
+		expectedExists  bool
+		expectedContent string
+	}{
+		{
+			name:            "Resource specific keywords are replaced and secret masks are removed",
+			file:            "Applications/app1.yml",
+			expectedExists:  true,
+			expectedContent: "callbackUrl: https://app1.example.com/callback\nclientSecret: null\n",
+		},
+		{
+			name: "Excluded resource is not rendered",
+			file: "Applications/app2.yml",
+		},
+		{
+			name:            "Authorized APIs use the keywords of the application",
+			file:            "Applications/ApplicationAuthorizedApis/app1.yml",
+			expectedExists:  true,
+			expectedContent: "identifier: https://app1.example.com/api\n",
+		},
+		{
+			name:            "Claim dialect keywords are resolved by the dialect URI",
+			file:            "Claims/oidc.yml",
+			expectedExists:  true,
+			expectedContent: "dialectURI: http://wso2.org/oidc/claim\nclaimURI: claims.example.com\n",
+		},
+		{
+			name:            "Resources stored as directories use the default keywords",
+			file:            "EmailTemplates/AccountLock/en_US.yml",
+			expectedExists:  true,
+			expectedContent: "body: Contact prod.example.com\n",
+		},
+		{
+			name: "Resource with unresolved keywords is not rendered",
+			file: "Roles/role1.yml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := ioutil.ReadFile(filepath.Join(outputDir, tt.file))
+			if !tt.expectedExists {
+				if !os.IsNotExist(err) {
+					t.Errorf("Expected %s not to be rendered", tt.file)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(content) != tt.expectedContent {
+				t.Errorf("Expected content %q but got %q", tt.expectedContent, string(content))
+			}
+			if info, err := os.Stat(filepath.Join(outputDir, tt.file)); err != nil || info.Mode().Perm() != 0600 {
+				t.Errorf("Expected %s to be readable only by the owner", tt.file)
+			}
+		})
+	}
+
+	t.Run("Resources with unresolved keywords are reported", func(t *testing.T) {
+		errs := session.CheckResourceKeywords(resourceTypes, inputDir)
+		if len(errs) != 1 {
+			t.Fatalf("Expected 1 error but got %v", errs)
+		}
+		expected := "unresolved keywords in " + filepath.Join("Roles", "role1.yml") + ": {{ROLE_HOST}} at audience (no keyword mapping found)"
+		if errs[0].Error() != expected {
+			t.Errorf("Expected error %q but got %q", expected, errs[0].Error())
+		}
+	})
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestParseReportFormat(t *testing.T) {
+	tests := []struct {
+		name           string
+		format         string
+		expectedFormat utils.ReportFormat
+		expectError    bool
+	}{
+		{name: "JSON", format: "json", expectedFormat: utils.ReportFormatJSON},
+		{name: "JUnit in upper case", format: "JUNIT", expectedFormat: utils.ReportFormatJUnit},
+		{name: "Unsupported format", format: "xml", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := utils.ParseReportFormat(tt.format)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error for format %s", tt.format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if format != tt.expectedFormat {
+				t.Errorf("Expected format %s, got %s", tt.expectedFormat, format)
+			}
+		})
+	}
+}
+
+func setupReportSummary() {
+
+	utils.ResetSummary()
+	utils.MarkResourceStart(utils.APPLICATIONS, "app1")
+	utils.UpdateSuccessSummary(utils.APPLICATIONS, "app1", utils.IMPORT)
+	utils.AddNewSecretIndicatorToSummary("app1")
+	utils.UpdateFailureSummary(utils.APPLICATIONS, "app2", utils.UPDATE, fmt.Errorf("invalid inbound protocol"))
+	utils.UpdateSuccessSummary(utils.ROLES, "role1", utils.DELETE)
+	utils.UpdateSkipSummary(utils.WORKFLOWS, "Not supported in this server version")
+}
+
+func TestBuildReport(t *testing.T) {
+	setupReportSummary()
+	defer utils.ResetSummary()
+
+	report := utils.BuildReport(utils.IMPORT)
+	if report.Summary.TotalOperations != 3 || report.Summary.FailedOperations != 1 {
+		t.Errorf("Expected 3 operations with 1 failure, got %d with %d failures",
+			report.Summary.TotalOperations, report.Summary.FailedOperations)
+	}
+
+	statuses := make(map[utils.ResourceType]utils.ResourceTypeReport)
+	for _, typeReport := range report.ResourceTypes {
+		statuses[typeReport.ResourceType] = typeReport
+	}
+	tests := []struct {
+		resourceType      utils.ResourceType
+		expectedStatus    string
+		expectedResources []utils.ResourceReport
+	}{
+		{
+			resourceType:   utils.APPLICATIONS,
+			expectedStatus: utils.REPORT_STATUS_FAILED,
+			expectedResources: []utils.ResourceReport{
+				{Name: "app1", Operation: utils.IMPORT, Status: utils.REPORT_STATUS_SUCCESS},
+				{Name: "app2", Operation: utils.UPDATE, Status: utils.REPORT_STATUS_FAILED, Error: "invalid inbound protocol"},
+			},
+		},
+		{
+			resourceType:   utils.ROLES,
+			expectedStatus: utils.REPORT_STATUS_SUCCESS,
+			expectedResources: []utils.ResourceReport{
+				{Name: "role1", Operation: utils.DELETE, Status: utils.REPORT_STATUS_SUCCESS},
+			},
+		},
+		{
+			resourceType:      utils.WORKFLOWS,
+			expectedStatus:    utils.REPORT_STATUS_SKIPPED,
+			expectedResources: []utils.ResourceReport{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resourceType.String(), func(t *testing.T) {
+			typeReport, exists := statuses[tt.resourceType]
+			if !exists {
+				t.Fatalf("Expected %s in the report", tt.resourceType)
+			}
+			if typeReport.Status != tt.expectedStatus {
+				t.Errorf("Expected status %s, got %s", tt.expectedStatus, typeReport.Status)
+			}
+			if len(typeReport.Resources) != len(tt.expectedResources) {
+				t.Fatalf("Expected %d resources, got %d", len(tt.expectedResources), len(typeReport.Resources))
+			}
+			for i, expected := range tt.expectedResources {
+				actual := typeReport.Resources[i]
+				actual.DurationMs = 0
+				if actual != expected {
+					t.Errorf("Expected resource %+v, got %+v", expected, actual)
+				}
+			}
+		})
+	}
+	if apps := statuses[utils.APPLICATIONS].SecretGeneratedApplications; len(apps) != 1 || apps[0] != "app1" {
+		t.Errorf("Expected new secret generated for app1, got %v", apps)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	setupReportSummary()
+	defer utils.ResetSummary()
+
+	dir, err := ioutil.TempDir("", "report")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Run("JSON", func(t *testing.T) {
+		reportFile := filepath.Join(dir, "report.json")
+		if err := utils.WriteReport(reportFile, utils.ReportFormatJSON, utils.IMPORT); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		data, _ := ioutil.ReadFile(reportFile)
+		var report utils.RunReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			t.Fatalf("Failed to parse report: %v", err)
+		}
+		if report.Operation != utils.IMPORT || len(report.ResourceTypes) != 3 {
+			t.Errorf("Unexpected report: %s", data)
+		}
+	})
+
+	t.Run("JUnit", func(t *testing.T) {
+		reportFile := filepath.Join(dir, "report.xml")
+		if err := utils.WriteReport(reportFile, utils.ReportFormatJUnit, utils.IMPORT); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		data, _ := ioutil.ReadFile(reportFile)
+		var suites struct {
+			Tests    int `xml:"tests,attr"`
+			Failures int `xml:"failures,attr"`
+			Skipped  int `xml:"skipped,attr"`
+		}
+		if err := xml.Unmarshal(data, &suites); err != nil {
+			t.Fatalf("Failed to parse report: %v", err)
+		}
+		if suites.Tests != 4 || suites.Failures != 1 || suites.Skipped != 1 {
+			t.Errorf("Expected 4 tests with 1 failure and 1 skipped, got %d, %d and %d", suites.Tests, suites.Failures, suites.Skipped)
+		}
+		if !strings.Contains(string(data), "New client secrets generated for: app1") {
+			t.Errorf("Expected the applications with new secrets in the report: %s", data)
+		}
+	})
+}
@@ -0,0 +1,247 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestSortResourceTypes(t *testing.T) {
+	tests := []struct {
+		name          string
+		resourceTypes []utils.ResourceType
+		dependencies  map[utils.ResourceType][]utils.ResourceType
+		expected      []utils.ResourceType
+		expectWarning bool
+	}{
+		{
+			name:          "Dependencies are sorted before the dependent resource type",
+			resourceTypes: []utils.ResourceType{utils.FLOWS, utils.GOVERNANCE_CONNECTORS, utils.ROLES, utils.CLAIMS},
+			dependencies:  utils.ResourceDependencies,
+			expected:      []utils.ResourceType{utils.ROLES, utils.GOVERNANCE_CONNECTORS, utils.CLAIMS, utils.FLOWS},
+		},
+		{
+			name:          "Independent resource types keep the given order",
+			resourceTypes: []utils.ResourceType{utils.EMAIL_TEMPLATES, utils.CERTIFICATES, utils.SCRIPT_LIBRARIES},
+			dependencies:  utils.ResourceDependencies,
+			expected:      []utils.ResourceType{utils.EMAIL_TEMPLATES, utils.CERTIFICATES, utils.SCRIPT_LIBRARIES},
+		},
+		{
+			name:          "References in the resource reference metadata are dependencies",
+			resourceTypes: []utils.ResourceType{utils.WORKFLOWS, utils.ROLES},
+			dependencies:  map[utils.ResourceType][]utils.ResourceType{},
+			expected:      []utils.ResourceType{utils.ROLES, utils.WORKFLOWS},
+		},
+		{
+			name:          "Resource types in a cycle are added in the given order",
+			resourceTypes: []utils.ResourceType{utils.CERTIFICATES, utils.ACTIONS, utils.FLOWS},
+			dependencies: map[utils.ResourceType][]utils.ResourceType{
+				utils.ACTIONS: {utils.FLOWS},
+				utils.FLOWS:   {utils.ACTIONS},
+			},
+			expected:      []utils.ResourceType{utils.CERTIFICATES, utils.ACTIONS, utils.FLOWS},
+			expectWarning: true,
+		},
+	}
+
+	defaultDependencies := utils.ResourceDependencies
+	defer func() { utils.ResourceDependencies = defaultDependencies }()
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			utils.ResourceDependencies = tc.dependencies
+			utils.Warnings = nil
+
+			result := utils.SortResourceTypes(tc.resourceTypes)
+			if strings.Join(toStrings(result), ",") != strings.Join(toStrings(tc.expected), ",") {
+				t.Errorf("Expected order %v, got %v", tc.expected, result)
+			}
+			if hasWarning := len(utils.Warnings) > 0; hasWarning != tc.expectWarning {
+				t.Errorf("Expected warning: %v, got warnings: %v", tc.expectWarning, utils.Warnings)
+			}
+		})
+	}
+	utils.Warnings = nil
+}
+
+func TestDefaultResourceTypeOrder(t *testing.T) {
+	sorted := utils.SortResourceTypes(utils.ResourceTypes)
+	if len(sorted) != len(utils.ResourceTypes) {
+		t.Fatalf("Expected %d resource types, got %d", len(utils.ResourceTypes), len(sorted))
+	}
+	position := make(map[utils.ResourceType]int)
+	for i, resourceType := range sorted {
+		position[resourceType] = i
+	}
+	for _, resourceType := range utils.ResourceTypes {
+		for _, dependency := range utils.GetResourceDependencies(resourceType) {
+			if position[dependency] > position[resourceType] {
+				t.Errorf("Expected %s to be processed before %s", dependency, resourceType)
+			}
+		}
+	}
+}
+
+func TestProcessResourceTypes(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+	}{
+		{
+			name:        "Resource types are processed sequentially by default",
+			concurrency: 1,
+		},
+		{
+			name:        "Independent resource types are processed in parallel",
+			concurrency: 4,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			session := &utils.Session{ToolConfigs: utils.ToolConfigs{Concurrency: 4, ResourceTypeConcurrency: tc.concurrency}}
+
+			var lock sync.Mutex
+			completed := make(map[utils.ResourceType]bool)
+			running, maxRunning := 0, 0
+			err := session.ProcessResourceTypes(utils.ResourceTypes, func(resourceType utils.ResourceType) {
+				lock.Lock()
+				for _, dependency := range utils.GetResourceDependencies(resourceType) {
+					if !completed[dependency] {
+						t.Errorf("%s processed before its dependency %s", resourceType, dependency)
+					}
+				}
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				lock.Unlock()
+
+				time.Sleep(20 * time.Millisecond)
+
+				lock.Lock()
+				running--
+				completed[resourceType] = true
+				lock.Unlock()
+			})
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(completed) != len(utils.ResourceTypes) {
+				t.Errorf("Expected %d resource types to be processed, got %d", len(utils.ResourceTypes), len(completed))
+			}
+			if tc.concurrency <= 1 && maxRunning != 1 {
+				t.Errorf("Expected resource types to be processed one at a time, got %d in parallel", maxRunning)
+			}
+			if tc.concurrency > 1 && (maxRunning < 2 || maxRunning > tc.concurrency) {
+				t.Errorf("Expected between 2 and %d resource types in parallel, got %d", tc.concurrency, maxRunning)
+			}
+		})
+	}
+}
+
+func TestExcludedDependencyWarnings(t *testing.T) {
+	tests := []struct {
+		name             string
+		toolConfigs      utils.ToolConfigs
+		expectedWarnings []string
+	}{
+		{
+			name:             "Dependency missing from INCLUDE_ONLY",
+			toolConfigs:      utils.ToolConfigs{IncludeOnly: []string{"Flows", "Claims", "IdentityProviders", "UserStores"}},
+			expectedWarnings: []string{"Flows included but Governance Connectors excluded"},
+		},
+		{
+			name:             "Excluded dependency",
+			toolConfigs:      utils.ToolConfigs{Exclude: []string{"ApiResources"}},
+			expectedWarnings: []string{"Applications included but Api Resources excluded"},
+		},
+		{
+			name:        "All dependencies included",
+			toolConfigs: utils.ToolConfigs{IncludeOnly: []string{"Workflows", "Roles", "Applications", "Claims", "UserStores", "IdentityProviders", "ApiResources"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			utils.Warnings = nil
+			session := &utils.Session{ToolConfigs: tc.toolConfigs}
+			session.WarnExcludedDependencies(utils.ResourceTypes)
+
+			if len(utils.Warnings) != len(tc.expectedWarnings) {
+				t.Fatalf("Expected %d warnings, got %v", len(tc.expectedWarnings), utils.Warnings)
+			}
+			for i, expected := range tc.expectedWarnings {
+				if !strings.Contains(utils.Warnings[i], expected) {
+					t.Errorf("Expected warning to contain %q, got %q", expected, utils.Warnings[i])
+				}
+			}
+		})
+	}
+	utils.Warnings = nil
+}
+
+func toStrings(resourceTypes []utils.ResourceType) []string {
+	var result []string
+	for _, resourceType := range resourceTypes {
+		result = append(result, resourceType.String())
+	}
+	return result
+}
+
+func TestProcessResourceTypesWithConcurrentSessions(t *testing.T) {
+	devSession := &utils.Session{Name: "dev"}
+	prodSession := &utils.Session{Name: "prod"}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	devDone := make(chan error)
+	go func() {
+		devDone <- devSession.ProcessResourceTypes([]utils.ResourceType{utils.ROLES}, func(resourceType utils.ResourceType) {
+			close(started)
+			<-release
+		})
+	}()
+	<-started
+
+	processed := false
+	err := prodSession.ProcessResourceTypes([]utils.ResourceType{utils.ROLES}, func(resourceType utils.ResourceType) {
+		processed = true
+	})
+	if err != utils.ErrSessionInUse || processed {
+		t.Errorf("Expected %v without processing any resource type, got %v", utils.ErrSessionInUse, err)
+	}
+
+	close(release)
+	if err := <-devDone; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	err = prodSession.ProcessResourceTypes([]utils.ResourceType{utils.ROLES}, func(resourceType utils.ResourceType) {
+		processed = true
+	})
+	if err != nil || !processed {
+		t.Errorf("Expected the resource types to be processed once the other session completed, got %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestResolveResourceType(t *testing.T) {
+	tests := []struct {
+		name                  string
+		input                 string
+		expectedResourceType  utils.ResourceType
+		expectedIncludedTypes []utils.ResourceType
+		expectError           bool
+	}{
+		{
+			name:                  "Resource type folder name",
+			input:                 "Applications",
+			expectedResourceType:  utils.APPLICATIONS,
+			expectedIncludedTypes: []utils.ResourceType{utils.APPLICATIONS},
+		},
+		{
+			name:                  "Resource type name is not case sensitive",
+			input:                 "identityproviders",
+			expectedResourceType:  utils.IDENTITY_PROVIDERS,
+			expectedIncludedTypes: []utils.ResourceType{utils.IDENTITY_PROVIDERS},
+		},
+		{
+			name:                  "Branding includes both sub types",
+			input:                 "Branding",
+			expectedResourceType:  utils.BRANDING,
+			expectedIncludedTypes: []utils.ResourceType{utils.BRANDING_PREFERENCES, utils.CUSTOM_TEXTS},
+		},
+		{
+			name:                  "Branding sub type",
+			input:                 "CustomTexts",
+			expectedResourceType:  utils.BRANDING,
+			expectedIncludedTypes: []utils.ResourceType{utils.CUSTOM_TEXTS},
+		},
+		{
+			name:        "Unsupported resource type",
+			input:       "Users",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceType, includedTypes, err := utils.ResolveResourceType(tt.input)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error for %s", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if resourceType != tt.expectedResourceType {
+				t.Errorf("Expected resource type %s, got %s", tt.expectedResourceType, resourceType)
+			}
+			if !reflect.DeepEqual(includedTypes, tt.expectedIncludedTypes) {
+				t.Errorf("Expected included types %v, got %v", tt.expectedIncludedTypes, includedTypes)
+			}
+		})
+	}
+}
+
+func TestGetResourceFilesDir(t *testing.T) {
+	baseDir, err := ioutil.TempDir("", "resources")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(baseDir)
+
+	files := []string{
+		filepath.Join(baseDir, "Applications", "payments.yml"),
+		filepath.Join(baseDir, "Applications", "billing.yml"),
+		filepath.Join(baseDir, "Roles", "admin.yml"),
+		filepath.Join(baseDir, "Branding", "CustomTexts", "login.yml"),
+		filepath.Join(baseDir, "other", "Applications", "orders.yml"),
+	}
+	for _, file := range files {
+		if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := ioutil.WriteFile(file, []byte{}, 0600); err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(baseDir, "EmailTemplates", "AccountLock"), 0700); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		resourceTypes []utils.ResourceType
+		files         []string
+		expectedNames []string
+		expectError   bool
+	}{
+		{
+			name:          "Resource files in the resource type directory",
+			resourceTypes: []utils.ResourceType{utils.APPLICATIONS},
+			files:         files[:2],
+			expectedNames: []string{"payments", "billing"},
+		},
+		{
+			name:          "Branding sub type directory",
+			resourceTypes: []utils.ResourceType{utils.BRANDING_PREFERENCES, utils.CUSTOM_TEXTS},
+			files:         []string{files[3]},
+			expectedNames: []string{"login"},
+		},
+		{
+			name:          "Resource folder",
+			resourceTypes: []utils.ResourceType{utils.EMAIL_TEMPLATES},
+			files:         []string{filepath.Join(baseDir, "EmailTemplates", "AccountLock") + string(filepath.Separator)},
+			expectedNames: []string{"AccountLock"},
+		},
+		{
+			name:          "Resource file of another resource type",
+			resourceTypes: []utils.ResourceType{utils.APPLICATIONS},
+			files:         []string{files[2]},
+			expectError:   true,
+		},
+		{
+			name:          "Resource files in different directories",
+			resourceTypes: []utils.ResourceType{utils.APPLICATIONS},
+			files:         []string{files[0], files[4]},
+			expectError:   true,
+		},
+		{
+			name:          "Missing resource file",
+			resourceTypes: []utils.ResourceType{utils.APPLICATIONS},
+			files:         []string{filepath.Join(baseDir, "Applications", "missing.yml")},
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputDir, names, err := utils.GetResourceFilesDir(tt.resourceTypes, tt.files)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error for %v", tt.files)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if inputDir != baseDir {
+				t.Errorf("Expected input directory %s, got %s", baseDir, inputDir)
+			}
+			if !reflect.DeepEqual(names, tt.expectedNames) {
+				t.Errorf("Expected resource names %v, got %v", tt.expectedNames, names)
+			}
+		})
+	}
+}
+
+func TestIncludeOnlyResources(t *testing.T) {
+	toolConfigs := utils.ToolConfigs{
+		Exclude:     []string{"Applications"},
+		AllowDelete: true,
+		ApplicationConfigs: map[string]interface{}{
+			"EXCLUDE":         []interface{}{"payments"},
+			"EXCLUDE_SECRETS": true,
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		resourceNames        []string
+		expectedApplications map[string]interface{}
+		expectedAllowDelete  bool
+	}{
+		{
+			name:                 "All resources of the resource type",
+			expectedApplications: toolConfigs.ApplicationConfigs,
+			expectedAllowDelete:  true,
+		},
+		{
+			name:          "Resources with the given names",
+			resourceNames: []string{"payments", "billing"},
+			expectedApplications: map[string]interface{}{
+				"INCLUDE_ONLY":    []interface{}{"payments", "billing"},
+				"EXCLUDE_SECRETS": true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restricted := toolConfigs.IncludeOnlyResources([]utils.ResourceType{utils.APPLICATIONS}, tt.resourceNames)
+			session := &utils.Session{ToolConfigs: restricted}
+
+			if session.IsResourceTypeExcluded(utils.APPLICATIONS) || !session.IsResourceTypeExcluded(utils.ROLES) {
+				t.Errorf("Expected only %s to be included, got %v", utils.APPLICATIONS, restricted.IncludeOnly)
+			}
+			if !reflect.DeepEqual(restricted.ApplicationConfigs, tt.expectedApplications) {
+				t.Errorf("Expected application configs %v, got %v", tt.expectedApplications, restricted.ApplicationConfigs)
+			}
+			if restricted.AllowDelete != tt.expectedAllowDelete {
+				t.Errorf("Expected AllowDelete %v, got %v", tt.expectedAllowDelete, restricted.AllowDelete)
+			}
+			if _, exists := toolConfigs.ApplicationConfigs["INCLUDE_ONLY"]; exists {
+				t.Errorf("Expected the original tool configs to be unchanged")
+			}
+		})
+	}
+}
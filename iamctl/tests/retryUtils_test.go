@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestRequestRetries(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		maxAttempts      int
+		responses        []int // A status code of 0 closes the connection without a response
+		responseDelay    time.Duration
+		clientTimeout    time.Duration
+		retryAfter       string
+		expectedRequests int
+		expectedRetries  int
+		expectErr        bool
+		minDuration      time.Duration
+	}{
+		{
+			name:             "Transient gateway errors are retried",
+			method:           http.MethodPut,
+			maxAttempts:      3,
+			responses:        []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			expectedRequests: 3,
+			expectedRetries:  2,
+		},
+		{
+			name:             "Connection errors are retried",
+			method:           http.MethodPut,
+			maxAttempts:      3,
+			responses:        []int{0, http.StatusOK},
+			expectedRequests: 2,
+			expectedRetries:  1,
+		},
+		{
+			name:             "Retry-After header is honoured for rate limited requests",
+			method:           http.MethodPost,
+			maxAttempts:      3,
+			responses:        []int{http.StatusTooManyRequests, http.StatusCreated},
+			retryAfter:       "1",
+			expectedRequests: 2,
+			expectedRetries:  1,
+			minDuration:      time.Second,
+		},
+		{
+			name:             "Request fails after the maximum attempts",
+			method:           http.MethodPut,
+			maxAttempts:      2,
+			responses:        []int{http.StatusGatewayTimeout, http.StatusGatewayTimeout, http.StatusCreated},
+			expectedRequests: 2,
+			expectedRetries:  1,
+			expectErr:        true,
+		},
+		{
+			name:             "Other server errors are not retried",
+			method:           http.MethodPut,
+			maxAttempts:      3,
+			responses:        []int{http.StatusInternalServerError, http.StatusCreated},
+			expectedRequests: 1,
+			expectErr:        true,
+		},
+		{
+			name:             "Requests are not retried when retries are disabled",
+			method:           http.MethodPut,
+			maxAttempts:      1,
+			responses:        []int{http.StatusServiceUnavailable, http.StatusCreated},
+			expectedRequests: 1,
+			expectErr:        true,
+		},
+		{
+			name:             "Unavailable service is retried for creates",
+			method:           http.MethodPost,
+			maxAttempts:      3,
+			responses:        []int{http.StatusServiceUnavailable, http.StatusCreated},
+			expectedRequests: 2,
+			expectedRetries:  1,
+		},
+		{
+			name:             "Gateway errors are not retried for creates",
+			method:           http.MethodPost,
+			maxAttempts:      3,
+			responses:        []int{http.StatusBadGateway, http.StatusCreated},
+			expectedRequests: 1,
+			expectErr:        true,
+		},
+		{
+			name:             "Closed connections are not retried for creates",
+			method:           http.MethodPost,
+			maxAttempts:      3,
+			responses:        []int{0, http.StatusCreated},
+			expectedRequests: 1,
+			expectErr:        true,
+		},
+		{
+			name:             "Creates received by the server are not retried after a timeout",
+			method:           http.MethodPost,
+			maxAttempts:      3,
+			responses:        []int{http.StatusCreated, http.StatusCreated},
+			responseDelay:    300 * time.Millisecond,
+			clientTimeout:    50 * time.Millisecond,
+			expectedRequests: 1,
+			expectErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestBodies []string
+			var lock sync.Mutex
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				lock.Lock()
+				requestBodies = append(requestBodies, string(body))
+				status := tt.responses[len(requestBodies)-1]
+				lock.Unlock()
+				time.Sleep(tt.responseDelay)
+				if status == 0 {
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				}
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			session, err := utils.NewSession(utils.ServerConfigs{ServerUrl: server.URL, TenantDomain: "carbon.super"},
+				utils.ToolConfigs{Retry: utils.RetryConfig{MaxAttempts: tt.maxAttempts, BaseDelayMs: 1}}, utils.KeywordConfigs{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.clientTimeout > 0 {
+				session.HttpClient.Timeout = tt.clientTimeout
+			}
+			retriesBefore := utils.AggregatedSummary.RetriedRequests
+			start := time.Now()
+
+			requestBody := []byte(`{"displayName":"role1"}`)
+			if tt.method == http.MethodPut {
+				_, err = session.SendPutRequest(utils.ROLES, "role1", requestBody)
+			} else {
+				_, err = session.SendPostRequest(utils.ROLES, requestBody)
+			}
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Expected error: %v but got: %v", tt.expectErr, err)
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if len(requestBodies) != tt.expectedRequests {
+				t.Errorf("Expected %d requests but got %d", tt.expectedRequests, len(requestBodies))
+			}
+			for _, body := range requestBodies {
+				if body != `{"displayName":"role1"}` {
+					t.Errorf("Expected the request body to be sent with every attempt but got %q", body)
+				}
+			}
+			if retries := utils.AggregatedSummary.RetriedRequests - retriesBefore; retries != tt.expectedRetries {
+				t.Errorf("Expected %d retries but got %d", tt.expectedRetries, retries)
+			}
+			if elapsed := time.Since(start); elapsed < tt.minDuration {
+				t.Errorf("Expected the retry to wait at least %s but it took %s", tt.minDuration, elapsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestResolveSecrets(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "secrets")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	secretFile := filepath.Join(tempDir, "app_secret")
+	if err := ioutil.WriteFile(secretFile, []byte("fileSecret\n"), 0600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+	os.Setenv("IAMCTL_TEST_ENV_SECRET", "envSecret")
+	defer os.Unsetenv("IAMCTL_TEST_ENV_SECRET")
+	session := &utils.Session{KeywordConfigs: utils.KeywordConfigs{SecretExecCommand: []string{"echo", "vault"}}}
+	utils.RegisterSecretResolver("static", func(reference string) (string, error) {
+		return strings.ToUpper(reference), nil
+	})
+
+	tests := []struct {
+		name        string
+		value       string
+		expected    string
+		expectError bool
+	}{
+		{name: "Plain value", value: "https://dev.io/callback", expected: "https://dev.io/callback"},
+		{name: "Environment variable", value: "{{secret:env:IAMCTL_TEST_ENV_SECRET}}", expected: "envSecret"},
+		{name: "File without the trailing new line", value: "{{secret:file:" + secretFile + "}}", expected: "fileSecret"},
+		{name: "Exec command with the reference as the last argument", value: "{{secret:exec:secret/app1}}", expected: "vault secret/app1"},
+		{name: "Registered provider", value: "{{secret:static:abc}}", expected: "ABC"},
+		{name: "Reference within a value", value: "jdbc:mysql://db?password={{secret:env:IAMCTL_TEST_ENV_SECRET}}", expected: "jdbc:mysql://db?password=envSecret"},
+		{name: "Missing environment variable", value: "{{secret:env:IAMCTL_TEST_MISSING_SECRET}}", expectError: true},
+		{name: "Missing file", value: "{{secret:file:" + filepath.Join(tempDir, "missing") + "}}", expectError: true},
+		{name: "Unknown provider", value: "{{secret:vault:secret/app1}}", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.ResolveSecrets(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error for %s", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestReplaceKeywordsWithSecrets(t *testing.T) {
+	os.Setenv("IAMCTL_TEST_CLIENT_SECRET", "clientSecret123")
+	defer os.Unsetenv("IAMCTL_TEST_CLIENT_SECRET")
+
+	keywordMapping := map[string]interface{}{
+		"APP_SECRET":    "{{secret:env:IAMCTL_TEST_CLIENT_SECRET}}",
+		"UNUSED_SECRET": "{{secret:env:IAMCTL_TEST_UNUSED_SECRET}}",
+		"MISSING":       "{{secret:env:IAMCTL_TEST_MISSING_SECRET}}",
+	}
+	session := &utils.Session{}
+	result := session.ReplaceKeywords("oauthConsumerSecret: {{APP_SECRET}}\npassword: {{MISSING}}", keywordMapping)
+	expected := "oauthConsumerSecret: clientSecret123\npassword: {{MISSING}}"
+	if result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+
+	logged := session.RedactPayload(utils.ROLES, []byte(`{"description":"clientSecret123"}`))
+	if strings.Contains(logged, "clientSecret123") {
+		t.Errorf("Expected the resolved secret to be masked in %s", logged)
+	}
+}
+
+func TestSecretsResolvedPerSession(t *testing.T) {
+	devSession := &utils.Session{KeywordConfigs: utils.KeywordConfigs{SecretExecCommand: []string{"echo", "dev"}}}
+	prodSession := &utils.Session{KeywordConfigs: utils.KeywordConfigs{SecretExecCommand: []string{"echo", "prod"}}}
+
+	var wg sync.WaitGroup
+	results := make([]string, 2)
+	for i, session := range []*utils.Session{devSession, prodSession} {
+		wg.Add(1)
+		go func(i int, session *utils.Session) {
+			defer wg.Done()
+			results[i], _ = session.ResolveSecrets("{{secret:exec:app1}}")
+		}(i, session)
+	}
+	wg.Wait()
+
+	if results[0] != "dev app1" || results[1] != "prod app1" {
+		t.Errorf("Expected each session to run its own command, got %q and %q", results[0], results[1])
+	}
+	logged := prodSession.RedactPayload(utils.ROLES, []byte(`{"description":"dev app1"}`))
+	if !strings.Contains(logged, "dev app1") {
+		t.Errorf("Expected only the secrets resolved in the session to be masked in %s", logged)
+	}
+}
+
+func TestReplaceKeywordsEscapesSecrets(t *testing.T) {
+	secret := "p@ss: \"word\" it's #1\nnext line\\"
+	os.Setenv("IAMCTL_TEST_SPECIAL_SECRET", secret)
+	defer os.Unsetenv("IAMCTL_TEST_SPECIAL_SECRET")
+	keywordMapping := map[string]interface{}{
+		"APP_SECRET": "{{secret:env:IAMCTL_TEST_SPECIAL_SECRET}}",
+		"HOST":       "prod.example.com",
+	}
+
+	tests := []struct {
+		name        string
+		fileContent string
+		format      utils.Format
+	}{
+		{
+			name:        "YAML plain value",
+			fileContent: "name: app1\nhost: {{HOST}}\noauthConsumerSecret: {{APP_SECRET}}\n",
+			format:      utils.FormatYAML,
+		},
+		{
+			name:        "YAML single quoted value",
+			fileContent: "name: app1\nhost: {{HOST}}\noauthConsumerSecret: '{{APP_SECRET}}'\n",
+			format:      utils.FormatYAML,
+		},
+		{
+			name:        "YAML double quoted value",
+			fileContent: "name: app1\nhost: {{HOST}}\noauthConsumerSecret: \"{{APP_SECRET}}\"\n",
+			format:      utils.FormatYAML,
+		},
+		{
+			name:        "JSON value",
+			fileContent: "{\n  \"name\": \"app1\",\n  \"host\": \"{{HOST}}\",\n  \"oauthConsumerSecret\": \"{{APP_SECRET}}\"\n}",
+			format:      utils.FormatJSON,
+		},
+	}
+
+	session := &utils.Session{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := session.ReplaceKeywords(tt.fileContent, keywordMapping)
+			data, err := utils.Deserialize([]byte(result), tt.format, utils.APPLICATIONS)
+			if err != nil {
+				t.Fatalf("Expected valid content but got %v:\n%s", err, result)
+			}
+			values, ok := data.(map[string]interface{})
+			if !ok {
+				t.Fatalf("Expected an object but got %T", data)
+			}
+			if values["oauthConsumerSecret"] != secret {
+				t.Errorf("Expected secret %q, got %q", secret, values["oauthConsumerSecret"])
+			}
+			if values["host"] != "prod.example.com" || values["name"] != "app1" {
+				t.Errorf("Expected the other values to be kept, got %v", values)
+			}
+		})
+	}
+}
+
+func TestReplaceKeywordsKeepsLayoutWithSecrets(t *testing.T) {
+	os.Setenv("IAMCTL_TEST_SPECIAL_SECRET", "p@ss: \"word\" it's #1\nnext line\\")
+	os.Setenv("IAMCTL_TEST_PLAIN_SECRET", "s3cr3t-value")
+	defer os.Unsetenv("IAMCTL_TEST_SPECIAL_SECRET")
+	defer os.Unsetenv("IAMCTL_TEST_PLAIN_SECRET")
+	keywordMapping := map[string]interface{}{
+		"APP_SECRET":   "{{secret:env:IAMCTL_TEST_SPECIAL_SECRET}}",
+		"PLAIN_SECRET": "{{secret:env:IAMCTL_TEST_PLAIN_SECRET}}",
+		"HOST":         "prod.example.com",
+	}
+
+	tests := []struct {
+		name           string
+		fileContent    string
+		expectedResult string
+	}{
+		{
+			name: "YAML plain values",
+			fileContent: "# Application\nname: app1\noauthConsumerSecret: {{APP_SECRET}} # secret\n" +
+				"password: {{PLAIN_SECRET}}\nhosts: [{{HOST}}, other]\ndefaults: &defaults\n  host:   {{HOST}}\ncopy: *defaults\n",
+			expectedResult: "# Application\nname: app1\noauthConsumerSecret: \"p@ss: \\\"word\\\" it's #1\\nnext line\\\\\" # secret\n" +
+				"password: s3cr3t-value\nhosts: [prod.example.com, other]\ndefaults: &defaults\n  host:   prod.example.com\ncopy: *defaults\n",
+		},
+		{
+			name:           "YAML quoted values",
+			fileContent:    "z: 'id {{PLAIN_SECRET}}'\na: \"{{APP_SECRET}}\"\nb: 'x {{APP_SECRET}}'\n",
+			expectedResult: "z: 'id s3cr3t-value'\na: \"p@ss: \\\"word\\\" it's #1\\nnext line\\\\\"\nb: \"x p@ss: \\\"word\\\" it's #1\\nnext line\\\\\"\n",
+		},
+		{
+			name:           "YAML literal block",
+			fileContent:    "name: app1\ncertificate: |\n  {{APP_SECRET}}\nhost: {{HOST}}\n",
+			expectedResult: "name: app1\ncertificate: |\n  p@ss: \"word\" it's #1\n  next line\\\nhost: prod.example.com\n",
+		},
+		{
+			name:           "JSON values",
+			fileContent:    "{\"z\": \"{{APP_SECRET}}\",   \"a\": 1,\n \"b\": \"<{{PLAIN_SECRET}}>\"}",
+			expectedResult: "{\"z\": \"p@ss: \\\"word\\\" it's #1\\nnext line\\\\\",   \"a\": 1,\n \"b\": \"<s3cr3t-value>\"}",
+		},
+	}
+
+	session := &utils.Session{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := session.ReplaceKeywords(tt.fileContent, keywordMapping)
+			if result != tt.expectedResult {
+				t.Errorf("Expected:\n%s\nbut got:\n%s", tt.expectedResult, result)
+			}
+		})
+	}
+}
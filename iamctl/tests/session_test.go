@@ -0,0 +1,326 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestSessionRequestsUseSessionConfigs(t *testing.T) {
+	tests := []struct {
+		name         string
+		token        string
+		tenantDomain string
+		organization string
+		expectedPath string
+	}{
+		{
+			name:         "Root organization session",
+			token:        "token1",
+			tenantDomain: "carbon.super",
+			expectedPath: "/t/carbon.super/api/server/v1/applications/app1",
+		},
+		{
+			name:         "Sub organization session",
+			token:        "token2",
+			tenantDomain: "tenant1",
+			organization: "org1",
+			expectedPath: "/t/tenant1/o/api/server/v1/applications/app1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedPath, receivedAuth string
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				receivedPath = r.URL.Path
+				receivedAuth = r.Header.Get("Authorization")
+				w.Write([]byte("{}"))
+			}))
+			defer server.Close()
+
+			session, err := utils.NewSession(utils.ServerConfigs{
+				ServerUrl:          server.URL,
+				TenantDomain:       tt.tenantDomain,
+				Organization:       tt.organization,
+				Token:              tt.token,
+				InsecureSkipVerify: true,
+			}, utils.ToolConfigs{}, utils.KeywordConfigs{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if _, err := session.SendGetRequest(utils.APPLICATIONS, "app1"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if receivedPath != tt.expectedPath {
+				t.Errorf("Expected request path %s but got %s", tt.expectedPath, receivedPath)
+			}
+			if receivedAuth != "Bearer "+tt.token {
+				t.Errorf("Expected authorization header with token %s but got %s", tt.token, receivedAuth)
+			}
+		})
+	}
+}
+
+func TestSessionTLSVerification(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	// The test server certificate is used both as the CA bundle and as the client certificate.
+	certDir, err := ioutil.TempDir("", "certs")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(certDir)
+	serverCert := server.TLS.Certificates[0]
+	certPath := filepath.Join(certDir, "cert.pem")
+	keyPath := filepath.Join(certDir, "key.pem")
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(serverCert.PrivateKey)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: serverCert.Certificate[0]}), 0600)
+	ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}), 0600)
+
+	tests := []struct {
+		name             string
+		serverConfigs    utils.ServerConfigs
+		expectConfigErr  bool
+		expectRequestErr bool
+	}{
+		{
+			name:             "Untrusted server certificate is rejected by default",
+			serverConfigs:    utils.ServerConfigs{ClientCertPath: certPath, ClientKeyPath: keyPath},
+			expectRequestErr: true,
+		},
+		{
+			name:          "Server certificate is trusted with the CA bundle",
+			serverConfigs: utils.ServerConfigs{CaCertPath: certPath, ClientCertPath: certPath, ClientKeyPath: keyPath},
+		},
+		{
+			name:          "Verification is skipped when disabled",
+			serverConfigs: utils.ServerConfigs{InsecureSkipVerify: true, ClientCertPath: certPath, ClientKeyPath: keyPath},
+		},
+		{
+			name:             "Request fails without the client certificate",
+			serverConfigs:    utils.ServerConfigs{CaCertPath: certPath},
+			expectRequestErr: true,
+		},
+		{
+			name:            "Client certificate without a key is rejected",
+			serverConfigs:   utils.ServerConfigs{ClientCertPath: certPath},
+			expectConfigErr: true,
+		},
+		{
+			name:            "Invalid CA bundle is rejected",
+			serverConfigs:   utils.ServerConfigs{CaCertPath: keyPath},
+			expectConfigErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.serverConfigs.ServerUrl = server.URL
+			tt.serverConfigs.TenantDomain = "carbon.super"
+			session, err := utils.NewSession(tt.serverConfigs, utils.ToolConfigs{}, utils.KeywordConfigs{})
+			if (err != nil) != tt.expectConfigErr {
+				t.Fatalf("Expected config error: %v but got: %v", tt.expectConfigErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			_, err = session.SendGetRequest(utils.APPLICATIONS, "app1")
+			if (err != nil) != tt.expectRequestErr {
+				t.Errorf("Expected request error: %v but got: %v", tt.expectRequestErr, err)
+			}
+		})
+	}
+}
+
+// tokenServer issues a new token for every token request and accepts only the latest token for other requests.
+type tokenServer struct {
+	sync.Mutex
+	expiresIn    int
+	grantTypes   []string
+	currentToken string
+	requestBody  []string
+}
+
+func (ts *tokenServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	ts.Lock()
+	defer ts.Unlock()
+	if strings.HasSuffix(r.URL.Path, "/oauth2/token") {
+		r.ParseForm()
+		ts.grantTypes = append(ts.grantTypes, r.PostForm.Get("grant_type"))
+		ts.currentToken = fmt.Sprintf("token%d", len(ts.grantTypes))
+		json.NewEncoder(w).Encode(map[string]interface{}{"access_token": ts.currentToken, "expires_in": ts.expiresIn})
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+ts.currentToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	ts.requestBody = append(ts.requestBody, string(body))
+	w.Write([]byte("{}"))
+}
+
+func loadTestSession(t *testing.T, serverUrl, organization string) *utils.Session {
+
+	configDir := writeTestConfigs(t, serverUrl, organization, "{}")
+	defer os.RemoveAll(configDir)
+	return utils.LoadSession(configDir)
+}
+
+func writeTestConfigs(t *testing.T, serverUrl, organization, toolConfig string) string {
+
+	configDir, err := ioutil.TempDir("", "configs")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	serverConfig, _ := json.Marshal(map[string]interface{}{
+		"SERVER_URL":           serverUrl,
+		"CLIENT_ID":            "client",
+		"CLIENT_SECRET":        "secret",
+		"ORGANIZATION":         organization,
+		"SERVER_VERSION":       "7.1.0",
+		"INSECURE_SKIP_VERIFY": true,
+	})
+	ioutil.WriteFile(filepath.Join(configDir, utils.SERVER_CONFIG_FILE), serverConfig, 0600)
+	ioutil.WriteFile(filepath.Join(configDir, utils.TOOL_CONFIG_FILE), []byte(toolConfig), 0600)
+	ioutil.WriteFile(filepath.Join(configDir, utils.KEYWORD_CONFIG_FILE), []byte("{}"), 0600)
+	return configDir
+}
+
+func TestLoadSessionKeepingLogs(t *testing.T) {
+	server := httptest.NewTLSServer(&tokenServer{expiresIn: 3600})
+	defer server.Close()
+	defer utils.ConfigureLogs(utils.LogsConfig{})
+	defer func() { utils.CURRENT_LOG_LEVEL = utils.LogLevelInfo }()
+
+	fromDir := writeTestConfigs(t, server.URL, "", `{"LOGS": {"LOG_LEVEL": "ERROR"}}`)
+	defer os.RemoveAll(fromDir)
+	toDir := writeTestConfigs(t, server.URL, "", `{"LOGS": {"LOG_LEVEL": "DEBUG"}}`)
+	defer os.RemoveAll(toDir)
+
+	utils.LoadSession(fromDir)
+	toSession := utils.LoadSessionKeepingLogs(toDir)
+	if utils.CURRENT_LOG_LEVEL != utils.LogLevelError {
+		t.Errorf("Expected the log level of the first session to be kept, got %v", utils.CURRENT_LOG_LEVEL)
+	}
+	if toSession.ServerConfigs.Token == "" {
+		t.Errorf("Expected an access token for the second session")
+	}
+
+	if err := toSession.ApplyLogConfigs(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if utils.CURRENT_LOG_LEVEL != utils.LogLevelDebug {
+		t.Errorf("Expected the log level of the second session to be applied, got %v", utils.CURRENT_LOG_LEVEL)
+	}
+}
+
+func TestSessionTokenRefresh(t *testing.T) {
+	tests := []struct {
+		name               string
+		organization       string
+		expiresIn          int
+		waitBeforeRequest  time.Duration
+		rejectCurrentToken bool
+		expectedGrantTypes []string
+	}{
+		{
+			name:               "Valid token is reused",
+			expiresIn:          3600,
+			expectedGrantTypes: []string{"client_credentials"},
+		},
+		{
+			name:               "Token is refreshed before it expires",
+			expiresIn:          1,
+			waitBeforeRequest:  600 * time.Millisecond,
+			expectedGrantTypes: []string{"client_credentials", "client_credentials"},
+		},
+		{
+			name:               "Request is retried once after a 401 response",
+			expiresIn:          3600,
+			rejectCurrentToken: true,
+			expectedGrantTypes: []string{"client_credentials", "client_credentials"},
+		},
+		{
+			name:               "Organization switch token is refreshed after a 401 response",
+			organization:       "org1",
+			expiresIn:          3600,
+			rejectCurrentToken: true,
+			expectedGrantTypes: []string{"client_credentials", "organization_switch", "client_credentials", "organization_switch"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &tokenServer{expiresIn: tt.expiresIn}
+			server := httptest.NewTLSServer(ts)
+			defer server.Close()
+
+			session := loadTestSession(t, server.URL, tt.organization)
+			time.Sleep(tt.waitBeforeRequest)
+			if tt.rejectCurrentToken {
+				ts.Lock()
+				ts.currentToken = "revoked"
+				ts.Unlock()
+			}
+
+			if _, err := session.SendPostRequest(utils.ROLES, []byte(`{"displayName":"role1"}`)); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(ts.requestBody) != 1 || ts.requestBody[0] != `{"displayName":"role1"}` {
+				t.Errorf("Expected the request body to be received once but got %v", ts.requestBody)
+			}
+			if strings.Join(ts.grantTypes, ",") != strings.Join(tt.expectedGrantTypes, ",") {
+				t.Errorf("Expected token requests %v but got %v", tt.expectedGrantTypes, ts.grantTypes)
+			}
+		})
+	}
+}
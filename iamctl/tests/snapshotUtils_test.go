@@ -0,0 +1,248 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+var snapshotPlan = []utils.PlanEntry{
+	{ResourceType: utils.APPLICATIONS, ResourceName: "newApp", Action: utils.PLAN_CREATE},
+	{ResourceType: utils.APPLICATIONS, ResourceName: "app1", Action: utils.PLAN_UPDATE},
+	{ResourceType: utils.APPLICATIONS, ResourceName: "Console", Action: utils.PLAN_NO_OP, Reason: "System application"},
+	{ResourceType: utils.ROLES, ResourceName: "role1", Action: utils.PLAN_DELETE},
+	{ResourceType: utils.CERTIFICATES, ResourceName: "cert1", Action: utils.PLAN_CREATE},
+	{ResourceType: utils.CUSTOM_TEXTS, ResourceName: "login", Action: utils.PLAN_UPDATE},
+}
+
+func newSnapshotSession() *utils.Session {
+	return &utils.Session{
+		ServerConfigs: utils.ServerConfigs{ServerUrl: "https://localhost:9443", TenantDomain: "carbon.super"},
+		ToolConfigs: utils.ToolConfigs{
+			Exclude:        []string{"Roles"},
+			ExcludeSecrets: true,
+			ApplicationConfigs: map[string]interface{}{
+				"EXCLUDE":         []interface{}{"app1"},
+				"EXCLUDE_SECRETS": true,
+			},
+		},
+	}
+}
+
+func TestSnapshotResourceNames(t *testing.T) {
+	plan := []utils.PlanEntry{
+		{ResourceType: utils.APPLICATIONS, ResourceName: "team-*", Action: utils.PLAN_UPDATE},
+		{ResourceType: utils.EMAIL_TEMPLATES, ResourceName: "en_US", Action: utils.PLAN_DELETE,
+			ExportResourceType: utils.EMAIL_TEMPLATES, ExportResourceName: "AccountLock"},
+	}
+	session := newSnapshotSession()
+	toolConfigs, exists := session.NewSnapshotManifest(plan, false).SnapshotToolConfigs(session.ToolConfigs)
+	if !exists {
+		t.Fatalf("Expected resources to be snapshotted")
+	}
+
+	tests := []struct {
+		name            string
+		resourceName    string
+		resourceConfigs map[string]interface{}
+		expectedExclude bool
+	}{
+		{name: "Resource name with glob characters", resourceName: "team-*", resourceConfigs: toolConfigs.ApplicationConfigs},
+		{name: "Resource matching the glob characters", resourceName: "team-a", resourceConfigs: toolConfigs.ApplicationConfigs, expectedExclude: true},
+		{name: "Template type of a deleted template", resourceName: "AccountLock", resourceConfigs: toolConfigs.EmailTemplateConfigs},
+		{name: "Locale of a deleted template", resourceName: "en_US", resourceConfigs: toolConfigs.EmailTemplateConfigs, expectedExclude: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if excluded := utils.IsResourceExcluded(tt.resourceName, tt.resourceConfigs); excluded != tt.expectedExclude {
+				t.Errorf("Expected excluded: %v for %s but got: %v", tt.expectedExclude, tt.resourceName, excluded)
+			}
+		})
+	}
+}
+
+func TestSnapshotToolConfigs(t *testing.T) {
+	tests := []struct {
+		name                  string
+		plan                  []utils.PlanEntry
+		includeSecrets        bool
+		rollback              bool
+		expectedExists        bool
+		expectedIncludeOnly   []string
+		expectedApplications  []interface{}
+		expectedCertificates  []interface{}
+		expectedAllowDelete   bool
+		expectedSecretsExport bool
+	}{
+		{
+			name:                 "Snapshot includes only updated and deleted resources",
+			plan:                 snapshotPlan,
+			expectedExists:       true,
+			expectedIncludeOnly:  []string{"Applications", "Roles", "CustomTexts"},
+			expectedApplications: []interface{}{"re:^app1$"},
+		},
+		{
+			name:                  "Snapshot includes secrets when enabled",
+			plan:                  snapshotPlan,
+			includeSecrets:        true,
+			expectedExists:        true,
+			expectedIncludeOnly:   []string{"Applications", "Roles", "CustomTexts"},
+			expectedApplications:  []interface{}{"re:^app1$"},
+			expectedSecretsExport: true,
+		},
+		{
+			name:                 "Rollback includes created, updated and deleted resources",
+			plan:                 snapshotPlan,
+			rollback:             true,
+			expectedExists:       true,
+			expectedIncludeOnly:  []string{"Applications", "Roles", "Certificates", "CustomTexts"},
+			expectedApplications: []interface{}{"re:^newApp$", "re:^app1$"},
+			expectedCertificates: []interface{}{"re:^cert1$"},
+			expectedAllowDelete:  true,
+		},
+		{
+			name: "No snapshot needed when resources are only created",
+			plan: []utils.PlanEntry{
+				{ResourceType: utils.APPLICATIONS, ResourceName: "newApp", Action: utils.PLAN_CREATE},
+			},
+			expectedExists: false,
+		},
+		{
+			name: "Nothing to roll back when resources are unchanged",
+			plan: []utils.PlanEntry{
+				{ResourceType: utils.ROLES, ResourceName: "admin", Action: utils.PLAN_NO_OP},
+			},
+			rollback:       true,
+			expectedExists: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			session := newSnapshotSession()
+			manifest := session.NewSnapshotManifest(tc.plan, tc.includeSecrets)
+
+			var toolConfigs utils.ToolConfigs
+			var exists bool
+			if tc.rollback {
+				toolConfigs, exists = manifest.RollbackToolConfigs(session.ToolConfigs)
+			} else {
+				toolConfigs, exists = manifest.SnapshotToolConfigs(session.ToolConfigs)
+			}
+			if exists != tc.expectedExists {
+				t.Fatalf("Expected exists to be %v, got %v", tc.expectedExists, exists)
+			}
+			if !exists {
+				return
+			}
+
+			if !reflect.DeepEqual(toolConfigs.IncludeOnly, tc.expectedIncludeOnly) {
+				t.Errorf("Expected included resource types %v, got %v", tc.expectedIncludeOnly, toolConfigs.IncludeOnly)
+			}
+			if len(toolConfigs.Exclude) != 0 {
+				t.Errorf("Expected no excluded resource types, got %v", toolConfigs.Exclude)
+			}
+			if !reflect.DeepEqual(toolConfigs.ApplicationConfigs["INCLUDE_ONLY"], tc.expectedApplications) {
+				t.Errorf("Expected included applications %v, got %v", tc.expectedApplications, toolConfigs.ApplicationConfigs["INCLUDE_ONLY"])
+			}
+			if _, exists := toolConfigs.ApplicationConfigs["EXCLUDE"]; exists {
+				t.Errorf("Expected the application EXCLUDE config to be removed")
+			}
+			if tc.expectedCertificates != nil && !reflect.DeepEqual(toolConfigs.CertificateConfigs["INCLUDE_ONLY"], tc.expectedCertificates) {
+				t.Errorf("Expected included certificates %v, got %v", tc.expectedCertificates, toolConfigs.CertificateConfigs["INCLUDE_ONLY"])
+			}
+			if toolConfigs.AllowDelete != tc.expectedAllowDelete {
+				t.Errorf("Expected ALLOW_DELETE to be %v", tc.expectedAllowDelete)
+			}
+			snapshotSession := &utils.Session{ToolConfigs: toolConfigs}
+			if snapshotSession.AreSecretsExcluded(toolConfigs.ApplicationConfigs) == tc.expectedSecretsExport {
+				t.Errorf("Expected secrets to be exported: %v", tc.expectedSecretsExport)
+			}
+
+			// The configs of the session must be left unchanged.
+			if session.ToolConfigs.ApplicationConfigs["EXCLUDE_SECRETS"] != true || len(session.ToolConfigs.Exclude) != 1 {
+				t.Errorf("Expected the session tool configs to be unchanged, got %v", session.ToolConfigs)
+			}
+		})
+	}
+}
+
+func TestSnapshotManifest(t *testing.T) {
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(snapshotDir)
+
+	session := newSnapshotSession()
+	manifest := session.NewSnapshotManifest(snapshotPlan, true)
+	if len(manifest.Resources) != len(snapshotPlan)-1 {
+		t.Errorf("Expected unchanged resources to be left out of the manifest, got %v", manifest.Resources)
+	}
+	if err := utils.WriteSnapshotManifest(snapshotDir, manifest); err != nil {
+		t.Fatalf("Error writing manifest: %s", err)
+	}
+	read, err := utils.ReadSnapshotManifest(snapshotDir)
+	if err != nil {
+		t.Fatalf("Error reading manifest: %s", err)
+	}
+	if !reflect.DeepEqual(read.Resources, manifest.Resources) || !read.CreatedAt.Equal(manifest.CreatedAt) || !read.SecretsIncluded {
+		t.Errorf("Expected manifest %v, got %v", manifest, read)
+	}
+
+	if err := read.CheckEnvironment(session); err != nil {
+		t.Errorf("Expected the snapshot to match the environment: %s", err)
+	}
+	otherSession := newSnapshotSession()
+	otherSession.ServerConfigs.ServerUrl = "https://prod.example.com"
+	if err := read.CheckEnvironment(otherSession); err == nil {
+		t.Errorf("Expected an error when restoring the snapshot to another environment")
+	}
+
+	if err := read.PrepareRollbackDir(snapshotDir); err != nil {
+		t.Fatalf("Error preparing rollback directory: %s", err)
+	}
+	for _, dir := range []string{"Applications", "Roles", "Certificates", filepath.Join("Branding", "CustomTexts")} {
+		if info, err := os.Stat(filepath.Join(snapshotDir, dir)); err != nil || !info.IsDir() {
+			t.Errorf("Expected directory %s to be created", dir)
+		}
+	}
+}
+
+func TestGetDefaultSnapshotsDir(t *testing.T) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		t.Skipf("No user cache directory: %s", err)
+	}
+	snapshotsDir, err := utils.GetDefaultSnapshotsDir()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !strings.HasPrefix(snapshotsDir, cacheDir) {
+		t.Errorf("Expected the snapshots directory to be in the user cache directory %s, got %s", cacheDir, snapshotsDir)
+	}
+}
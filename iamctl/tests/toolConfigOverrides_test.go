@@ -0,0 +1,248 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func newOverrideToolConfigs() utils.ToolConfigs {
+	return utils.ToolConfigs{
+		AllowDelete: true,
+		IncludeOnly: []string{"Applications", "Roles", "Claims"},
+		ApplicationConfigs: map[string]interface{}{
+			"EXCLUDE":         []interface{}{"My Account"},
+			"EXCLUDE_SECRETS": true,
+		},
+		RoleConfigs: map[string]interface{}{
+			"INCLUDE_ONLY": []interface{}{"admin", "viewer"},
+		},
+	}
+}
+
+func TestApplyToolConfigOverrides(t *testing.T) {
+	tests := []struct {
+		name                 string
+		overrides            utils.ToolConfigOverrides
+		expectedIncludeOnly  []string
+		expectedExclude      []string
+		expectedApplications map[string]interface{}
+		expectedRoles        map[string]interface{}
+		expectedAllowDelete  bool
+		expectError          bool
+	}{
+		{
+			name:                "No overrides",
+			expectedIncludeOnly: []string{"Applications", "Roles", "Claims"},
+			expectedApplications: map[string]interface{}{
+				"EXCLUDE":         []interface{}{"My Account"},
+				"EXCLUDE_SECRETS": true,
+			},
+			expectedRoles:       map[string]interface{}{"INCLUDE_ONLY": []interface{}{"admin", "viewer"}},
+			expectedAllowDelete: true,
+		},
+		{
+			name: "Include replaces the included resource types and exclude removes them",
+			overrides: utils.ToolConfigOverrides{
+				Include: []string{"Applications", "roles", "Branding"},
+				Exclude: []string{"Roles", "CustomTexts"},
+			},
+			expectedIncludeOnly: []string{"Applications", "BrandingPreferences"},
+			expectedExclude:     []string{"Roles", "CustomTexts"},
+			expectedApplications: map[string]interface{}{
+				"EXCLUDE":         []interface{}{"My Account"},
+				"EXCLUDE_SECRETS": true,
+			},
+			expectedRoles:       map[string]interface{}{"INCLUDE_ONLY": []interface{}{"admin", "viewer"}},
+			expectedAllowDelete: true,
+		},
+		{
+			name: "Resource overrides are merged with the resource type configs",
+			overrides: utils.ToolConfigOverrides{
+				IncludeResources: []string{"Roles:admin", "Roles:editor"},
+				ExcludeResources: []string{"Applications:Console", "Roles:admin"},
+				NoDelete:         true,
+			},
+			expectedIncludeOnly: []string{"Applications", "Roles", "Claims"},
+			expectedApplications: map[string]interface{}{
+				"EXCLUDE":           []interface{}{"My Account"},
+				"EXCLUDE_OVERRIDES": []interface{}{"Console"},
+				"EXCLUDE_SECRETS":   true,
+			},
+			expectedRoles: map[string]interface{}{
+				"INCLUDE_ONLY":      []interface{}{"admin", "editor"},
+				"EXCLUDE_OVERRIDES": []interface{}{"admin"},
+			},
+		},
+		{
+			name:        "Allow delete and no delete together",
+			overrides:   utils.ToolConfigOverrides{AllowDelete: true, NoDelete: true},
+			expectError: true,
+		},
+		{
+			name:        "Unsupported resource type",
+			overrides:   utils.ToolConfigOverrides{Include: []string{"Users"}},
+			expectError: true,
+		},
+		{
+			name:        "Resource without a resource type",
+			overrides:   utils.ToolConfigOverrides{ExcludeResources: []string{"Console"}},
+			expectError: true,
+		},
+		{
+			name:        "Invalid regular expression rule",
+			overrides:   utils.ToolConfigOverrides{ExcludeResources: []string{"Roles:re:^tmp_("}},
+			expectError: true,
+		},
+		{
+			name:        "All included resource types excluded",
+			overrides:   utils.ToolConfigOverrides{Include: []string{"Roles"}, Exclude: []string{"Roles"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toolConfigs := newOverrideToolConfigs()
+			merged, err := toolConfigs.ApplyOverrides(tt.overrides)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error for %+v", tt.overrides)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(merged.IncludeOnly, tt.expectedIncludeOnly) {
+				t.Errorf("Expected INCLUDE_ONLY %v, got %v", tt.expectedIncludeOnly, merged.IncludeOnly)
+			}
+			if !reflect.DeepEqual(merged.Exclude, tt.expectedExclude) {
+				t.Errorf("Expected EXCLUDE %v, got %v", tt.expectedExclude, merged.Exclude)
+			}
+			if !reflect.DeepEqual(merged.ApplicationConfigs, tt.expectedApplications) {
+				t.Errorf("Expected application configs %v, got %v", tt.expectedApplications, merged.ApplicationConfigs)
+			}
+			if !reflect.DeepEqual(merged.RoleConfigs, tt.expectedRoles) {
+				t.Errorf("Expected role configs %v, got %v", tt.expectedRoles, merged.RoleConfigs)
+			}
+			if merged.AllowDelete != tt.expectedAllowDelete {
+				t.Errorf("Expected ALLOW_DELETE %v, got %v", tt.expectedAllowDelete, merged.AllowDelete)
+			}
+			if !reflect.DeepEqual(toolConfigs, newOverrideToolConfigs()) {
+				t.Errorf("Expected the loaded tool configs to be unchanged")
+			}
+		})
+	}
+}
+
+func TestValidateToolConfigs(t *testing.T) {
+	tests := []struct {
+		name        string
+		toolConfigs utils.ToolConfigs
+		expectError bool
+	}{
+		{
+			name: "Valid names, globs and regular expressions",
+			toolConfigs: utils.ToolConfigs{
+				ApplicationConfigs: map[string]interface{}{"INCLUDE_ONLY": []interface{}{"Console", "glob:team-*", "re:^tmp_.*$"}},
+				RoleConfigs:        map[string]interface{}{"EXCLUDE": []interface{}{"glob:admin?"}},
+			},
+		},
+		{
+			name: "Name with glob characters",
+			toolConfigs: utils.ToolConfigs{
+				ApplicationConfigs: map[string]interface{}{"EXCLUDE": []interface{}{"team-*"}},
+			},
+		},
+		{
+			name: "Invalid regular expression in EXCLUDE",
+			toolConfigs: utils.ToolConfigs{
+				ApplicationConfigs: map[string]interface{}{"EXCLUDE": []interface{}{"re:^tmp_("}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid regular expression in INCLUDE_ONLY",
+			toolConfigs: utils.ToolConfigs{
+				ClaimConfigs: map[string]interface{}{"INCLUDE_ONLY": []interface{}{"re:[a-"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Valid FILTER expression",
+			toolConfigs: utils.ToolConfigs{
+				RoleConfigs: map[string]interface{}{"FILTER": "audience.type == APPLICATION || audience.type == null"},
+			},
+		},
+		{
+			name: "Invalid FILTER expression",
+			toolConfigs: utils.ToolConfigs{
+				RoleConfigs: map[string]interface{}{"FILTER": "audience.type = APPLICATION"},
+			},
+			expectError: true,
+		},
+		{
+			name: "Rules that are not a list",
+			toolConfigs: utils.ToolConfigs{
+				RoleConfigs: map[string]interface{}{"EXCLUDE": "admin"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.toolConfigs.Validate()
+			if (err != nil) != tt.expectError {
+				t.Errorf("Expected error: %v but got: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestExcludeResourceOverridesIncludeOnlyRules(t *testing.T) {
+	toolConfigs := utils.ToolConfigs{
+		ApplicationConfigs: map[string]interface{}{"INCLUDE_ONLY": []interface{}{"glob:team-*"}},
+	}
+	merged, err := toolConfigs.ApplyOverrides(utils.ToolConfigOverrides{ExcludeResources: []string{"Applications:team-a"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		resourceName string
+		expected     bool
+	}{
+		{resourceName: "team-a", expected: true},
+		{resourceName: "team-b", expected: false},
+		{resourceName: "Console", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resourceName, func(t *testing.T) {
+			if excluded := utils.IsResourceExcluded(tt.resourceName, merged.ApplicationConfigs); excluded != tt.expected {
+				t.Errorf("Expected excluded: %v for %s but got: %v", tt.expected, tt.resourceName, excluded)
+			}
+		})
+	}
+}
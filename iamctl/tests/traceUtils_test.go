@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2026, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wso2-extensions/identity-tools-cli/iamctl/pkg/utils"
+)
+
+func TestRequestCorrelationIds(t *testing.T) {
+	var activityIds, correlationIds []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		activityIds = append(activityIds, r.Header.Get(utils.ACTIVITY_ID_HEADER))
+		correlationIds = append(correlationIds, r.Header.Get(utils.CORRELATION_ID_HEADER))
+		if len(activityIds) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	session, err := utils.NewSession(utils.ServerConfigs{ServerUrl: server.URL, TenantDomain: "carbon.super"},
+		utils.ToolConfigs{Retry: utils.RetryConfig{MaxAttempts: 2, BaseDelayMs: 1}}, utils.KeywordConfigs{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	utils.ResetSummary()
+	defer utils.ResetSummary()
+
+	if _, err := session.SendGetRequest(utils.ROLES, "role1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := session.SendGetRequest(utils.CLAIMS, "claim1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(activityIds) != 3 {
+		t.Fatalf("Expected 3 requests but got %d", len(activityIds))
+	}
+	for i := range activityIds {
+		if !strings.HasPrefix(activityIds[i], utils.RunId+"-") {
+			t.Errorf("Expected the correlation ID %q to start with the run ID %s", activityIds[i], utils.RunId)
+		}
+		if activityIds[i] != correlationIds[i] {
+			t.Errorf("Expected the activityid and X-Correlation-ID headers to match but got %q and %q", activityIds[i], correlationIds[i])
+		}
+	}
+	if activityIds[0] != activityIds[1] {
+		t.Errorf("Expected a retried request to keep its correlation ID but got %q and %q", activityIds[0], activityIds[1])
+	}
+	if activityIds[1] == activityIds[2] {
+		t.Errorf("Expected a new correlation ID for each request but got %q twice", activityIds[1])
+	}
+
+	requests := map[utils.ResourceType]int{}
+	for _, timing := range utils.GetRequestTimings() {
+		requests[timing.ResourceType] = timing.Requests
+		if timing.MaxDuration > timing.TotalDuration {
+			t.Errorf("Expected the maximum duration of %s to be within the total duration", timing.ResourceType)
+		}
+	}
+	if requests[utils.ROLES] != 2 || requests[utils.CLAIMS] != 1 {
+		t.Errorf("Expected 2 timed requests for roles and 1 for claims but got %v", requests)
+	}
+}
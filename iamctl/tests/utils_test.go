@@ -77,6 +77,87 @@ func TestIsResourceExcluded(t *testing.T) {
 			},
 			expectedResult: false,
 		},
+		{
+			name:         "ExcludeConfig: Resource excluded by glob",
+			resourceName: "test-payments",
+			resourceConfigs: map[string]interface{}{
+				"EXCLUDE": []interface{}{
+					"glob:test-*",
+				},
+			},
+			expectedResult: true,
+		},
+		{
+			name:         "ExcludeConfig: Glob matches the whole name",
+			resourceName: "team-test-payments",
+			resourceConfigs: map[string]interface{}{
+				"EXCLUDE": []interface{}{
+					"glob:test-*",
+					"glob:team-?",
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			name:         "ExcludeConfig: Glob matches names with special characters",
+			resourceName: "http://wso2.org/oidc/claim",
+			resourceConfigs: map[string]interface{}{
+				"EXCLUDE": []interface{}{
+					"glob:http://wso2.org/*",
+				},
+			},
+			expectedResult: true,
+		},
+		{
+			name:         "ExcludeConfig: Name with glob characters matched exactly",
+			resourceName: "test-payments",
+			resourceConfigs: map[string]interface{}{
+				"EXCLUDE": []interface{}{
+					"test-*",
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			name:         "ExcludeConfig: Resource excluded by regex",
+			resourceName: "tmp_app",
+			resourceConfigs: map[string]interface{}{
+				"EXCLUDE": []interface{}{
+					"re:^tmp_.*$",
+				},
+			},
+			expectedResult: true,
+		},
+		{
+			name:         "ExcludeConfig: Invalid regex does not match",
+			resourceName: "tmp_app",
+			resourceConfigs: map[string]interface{}{
+				"EXCLUDE": []interface{}{
+					"re:^tmp_(",
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			name:         "IncludeOnlyConfig: Resource included by glob",
+			resourceName: "team-payments-api",
+			resourceConfigs: map[string]interface{}{
+				"INCLUDE_ONLY": []interface{}{
+					"glob:team-payments-*",
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			name:         "IncludeOnlyConfig: Resource not matching regex excluded",
+			resourceName: "Console",
+			resourceConfigs: map[string]interface{}{
+				"INCLUDE_ONLY": []interface{}{
+					"re:^team-",
+				},
+			},
+			expectedResult: true,
+		},
 	}
 
 	for _, tc := range testCases {
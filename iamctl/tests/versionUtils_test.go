@@ -124,11 +124,9 @@ func TestParseVersion(t *testing.T) {
 }
 
 func TestIsEntitySupportedInVersion(t *testing.T) {
-	originalVersion := utils.SERVER_CONFIGS.ServerVersion
 	originalMinVersions := utils.EntityMinVersionRequirements
 	originalMaxVersions := utils.EntityMaxSupportedVersion
 	defer func() {
-		utils.SERVER_CONFIGS.ServerVersion = originalVersion
 		utils.EntityMinVersionRequirements = originalMinVersions
 		utils.EntityMaxSupportedVersion = originalMaxVersions
 	}()
@@ -170,7 +168,7 @@ func TestIsEntitySupportedInVersion(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			utils.SERVER_CONFIGS.ServerVersion = tt.version
+			session := &utils.Session{ServerConfigs: utils.ServerConfigs{ServerVersion: tt.version}}
 
 			if tt.minVersion != "" {
 				utils.EntityMinVersionRequirements = map[utils.ResourceType]string{testResource: tt.minVersion}
@@ -184,7 +182,7 @@ func TestIsEntitySupportedInVersion(t *testing.T) {
 				utils.EntityMaxSupportedVersion = map[utils.ResourceType]string{}
 			}
 
-			result := utils.IsEntitySupportedInVersion(testResource)
+			result := session.IsEntitySupportedInVersion(testResource)
 
 			if result != tt.expected {
 				t.Errorf("IsEntitySupportedInVersion(%s) with version=%s min=%s max=%s = %v; expected %v",